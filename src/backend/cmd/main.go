@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
@@ -12,8 +13,13 @@ import (
 	_ "onetaste-family/backend/docs/swagger" // 导入生成的 Swagger 文档
 	"onetaste-family/backend/internal/config"
 	"onetaste-family/backend/internal/handlers"
+	"onetaste-family/backend/internal/jobs"
+	"onetaste-family/backend/pkg/aiclient"
+	"onetaste-family/backend/pkg/aiclient/aistub"
 	"onetaste-family/backend/pkg/cache"
 	"onetaste-family/backend/pkg/database"
+	"onetaste-family/backend/pkg/payment"
+	"onetaste-family/backend/pkg/sms"
 	"onetaste-family/backend/pkg/storage"
 )
 
@@ -96,6 +102,65 @@ func main() {
 	defer cache.CloseRedis()
 	log.Println("Redis connected successfully")
 
+	// 初始化短信发送
+	smsCfg := sms.Config{
+		Provider: config.AppConfig.SMS.Provider,
+		LogFile:  config.AppConfig.SMS.LogFile,
+		URL:      config.AppConfig.SMS.URL,
+		APIKey:   config.AppConfig.SMS.APIKey,
+	}
+
+	if err := sms.InitSMS(smsCfg); err != nil {
+		log.Fatalf("Failed to initialize SMS sender: %v", err)
+	}
+
+	// 初始化支付渠道
+	paymentCfg := payment.Config{
+		Provider:   config.AppConfig.Payment.Provider,
+		MockSecret: config.AppConfig.Payment.MockSecret,
+	}
+
+	if err := payment.InitPayment(paymentCfg); err != nil {
+		log.Fatalf("Failed to initialize payment gateway: %v", err)
+	}
+
+	// 初始化 AI 服务客户端
+	aiCfg := aiclient.Config{
+		BaseURL:          config.AppConfig.AIService.URL,
+		APIKey:           config.AppConfig.AIService.APIKey,
+		Timeout:          config.AppConfig.AIService.Timeout,
+		MaxRetries:       config.AppConfig.AIService.MaxRetries,
+		BreakerThreshold: config.AppConfig.AIService.BreakerThreshold,
+		BreakerCooldown:  config.AppConfig.AIService.BreakerCooldown,
+	}
+
+	if config.AppConfig.AIService.Stub {
+		stubURL, stopStub, err := aistub.Start()
+		if err != nil {
+			log.Fatalf("Failed to start AI service stub: %v", err)
+		}
+		defer stopStub()
+		aiCfg.BaseURL = stubURL
+		log.Printf("AI service stub started on %s", stubURL)
+	}
+	aiclient.InitAIClient(aiCfg)
+
+	// 启动已解散家庭的数据清理任务
+	stopFamilyPurge := jobs.StartFamilyPurge(time.Hour)
+	defer stopFamilyPurge()
+
+	// 启动回收站菜式清理任务
+	stopDishPurge := jobs.StartDishPurge(time.Hour)
+	defer stopDishPurge()
+
+	// 启动会员到期处理任务，到期后重新计算家庭权益上限
+	stopMembershipExpiry := jobs.StartMembershipExpiry(10 * time.Minute)
+	defer stopMembershipExpiry()
+
+	// 启动超时订单关闭与退款重试任务
+	stopPaymentOrderExpiry := jobs.StartPaymentOrderExpiry(5 * time.Minute)
+	defer stopPaymentOrderExpiry()
+
 	// 设置Gin模式
 	if config.AppConfig.Server.Mode == "release" {
 		gin.SetMode(gin.ReleaseMode)
@@ -104,6 +169,11 @@ func main() {
 	// 创建Gin引擎
 	r := gin.Default()
 
+	// 仅信任配置的反向代理转发的客户端地址，避免伪造 X-Forwarded-For 绕过按IP的限制
+	if err := r.SetTrustedProxies(config.AppConfig.Server.TrustedProxies); err != nil {
+		log.Fatalf("Invalid trusted proxies: %v", err)
+	}
+
 	// 添加CORS中间件
 	r.Use(corsMiddleware())
 
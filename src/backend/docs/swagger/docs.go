@@ -78,7 +78,7 @@ const docTemplate = `{
         },
         "/auth/login": {
             "post": {
-                "description": "用户登录接口，支持手机号和密码登录。登录成功后返回用户ID、JWT Token和过期时间。",
+                "description": "用户登录接口，支持手机号和密码登录。登录成功后返回用户ID、短期访问令牌、刷新令牌及各自过期时间，每次登录创建一个独立会话。\n同一手机号或IP多次登录失败后需要携带图形验证码（code=40001），继续失败需等待后重试（code=42901），失败次数达到上限后临时锁定（code=42301）。失败时 data 返回登录防护状态，retry_after 为需等待的秒数。",
                 "consumes": [
                     "application/json"
                 ],
@@ -120,7 +120,95 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "手机号或密码错误",
+                        "description": "手机号或密码错误、需要验证码或验证码错误",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.LoginGuardStatus"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "423": {
+                        "description": "账号或IP已被临时锁定",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.LoginGuardStatus"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "429": {
+                        "description": "登录失败过多，需等待后重试",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.LoginGuardStatus"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/logout": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "吊销当前会话，当前访问令牌与刷新令牌立即失效。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户认证"
+                ],
+                "summary": "退出登录",
+                "responses": {
+                    "200": {
+                        "description": "退出成功",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权或Token无效",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
@@ -134,9 +222,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/register": {
+        "/auth/password/reset": {
             "post": {
-                "description": "用户注册接口，支持手机号、密码、验证码、昵称注册。注册成功后返回用户ID和JWT Token。",
+                "description": "使用短信验证码重置密码。验证码只能使用一次，连续输错5次后作废；重置成功后该账号全部设备需重新登录。",
                 "consumes": [
                     "application/json"
                 ],
@@ -146,39 +234,27 @@ const docTemplate = `{
                 "tags": [
                     "用户认证"
                 ],
-                "summary": "用户注册",
+                "summary": "重置密码",
                 "parameters": [
                     {
-                        "description": "注册请求参数",
+                        "description": "重置密码请求参数",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.RegisterRequest"
+                            "$ref": "#/definitions/models.ResetPasswordRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "注册成功",
+                        "description": "重置成功",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/utils.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/models.RegisterResponse"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/utils.Response"
                         }
                     },
                     "400": {
-                        "description": "请求参数错误、验证码错误或手机号已注册",
+                        "description": "请求参数错误或短信验证码错误",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
@@ -192,14 +268,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/dishes": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "按照家庭返回菜式列表，支持按分类和名称关键字筛选。需要Bearer Token认证。",
+        "/auth/password/reset-code": {
+            "post": {
+                "description": "校验图形验证码后向手机号发送6位短信验证码，验证码10分钟内有效，同一手机号60秒内只能发送一次。手机号未注册时同样返回成功。",
                 "consumes": [
                     "application/json"
                 ],
@@ -207,38 +278,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "菜式"
+                    "用户认证"
                 ],
-                "summary": "获取菜式列表",
+                "summary": "发送重置密码验证码",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "页码（默认1）",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "每页数量（默认20，最大100）",
-                        "name": "page_size",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "菜式分类",
-                        "name": "category",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "名称关键字",
-                        "name": "keyword",
-                        "in": "query"
+                        "description": "发送验证码请求参数",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SendResetCodeRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "获取成功",
+                        "description": "发送成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -248,21 +304,21 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.DishListResponse"
+                                            "$ref": "#/definitions/models.SendResetCodeResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "401": {
-                        "description": "未授权",
+                    "400": {
+                        "description": "请求参数错误或图形验证码错误",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
                     },
-                    "404": {
-                        "description": "尚未加入家庭",
+                    "429": {
+                        "description": "发送过于频繁",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
@@ -274,14 +330,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/refresh": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "向当前家庭食谱库添加菜式，至少包含一个食材和一个烹饪步骤。需要Bearer Token认证。",
+                "description": "使用刷新令牌换取新的访问令牌与刷新令牌。刷新令牌只能使用一次，旧令牌被重复使用时整个会话将被吊销。",
                 "consumes": [
                     "application/json"
                 ],
@@ -289,23 +342,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "菜式"
+                    "用户认证"
                 ],
-                "summary": "创建菜式",
+                "summary": "刷新令牌",
                 "parameters": [
                     {
-                        "description": "创建菜式请求",
+                        "description": "刷新令牌请求参数",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CreateDishRequest"
+                            "$ref": "#/definitions/models.RefreshTokenRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "创建成功",
+                        "description": "刷新成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -315,7 +368,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.DishCreateResponse"
+                                            "$ref": "#/definitions/models.LoginResponse"
                                         }
                                     }
                                 }
@@ -323,19 +376,13 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "参数错误或业务限制",
+                        "description": "请求参数错误",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
                     },
                     "401": {
-                        "description": "未授权",
-                        "schema": {
-                            "$ref": "#/definitions/utils.Response"
-                        }
-                    },
-                    "404": {
-                        "description": "尚未加入家庭",
+                        "description": "刷新令牌无效或已过期",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
@@ -349,14 +396,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/dishes/{id}": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "返回菜式的食材和烹饪步骤信息。需要Bearer Token认证。",
+        "/auth/register": {
+            "post": {
+                "description": "用户注册接口，支持手机号、密码、验证码、昵称注册。注册成功后返回用户ID、访问令牌与刷新令牌。",
                 "consumes": [
                     "application/json"
                 ],
@@ -364,21 +406,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "菜式"
+                    "用户认证"
                 ],
-                "summary": "获取菜式详情",
+                "summary": "用户注册",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "菜式ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "注册请求参数",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RegisterRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "获取成功",
+                        "description": "注册成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -388,21 +432,15 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.DishDetailResponse"
+                                            "$ref": "#/definitions/models.RegisterResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "401": {
-                        "description": "未授权",
-                        "schema": {
-                            "$ref": "#/definitions/utils.Response"
-                        }
-                    },
-                    "404": {
-                        "description": "菜式或家庭不存在",
+                    "400": {
+                        "description": "请求参数错误、验证码错误或手机号已注册",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
@@ -414,14 +452,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/auth/sessions": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "仅允许菜式创建者或家庭管理员编辑菜式。需要Bearer Token认证。",
+                "description": "获取当前用户在各设备上的有效登录会话，current 标记当前请求所用会话。需要Bearer Token认证。",
                 "consumes": [
                     "application/json"
                 ],
@@ -429,30 +469,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "菜式"
-                ],
-                "summary": "更新菜式",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "菜式ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "更新菜式请求",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UpdateDishRequest"
-                        }
-                    }
+                    "用户认证"
                 ],
+                "summary": "获取登录会话列表",
                 "responses": {
                     "200": {
-                        "description": "更新成功",
+                        "description": "获取成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -462,33 +484,18 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.DishDetailResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.SessionInfo"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "参数错误或业务限制",
-                        "schema": {
-                            "$ref": "#/definitions/utils.Response"
-                        }
-                    },
                     "401": {
-                        "description": "未授权",
-                        "schema": {
-                            "$ref": "#/definitions/utils.Response"
-                        }
-                    },
-                    "403": {
-                        "description": "无权限",
-                        "schema": {
-                            "$ref": "#/definitions/utils.Response"
-                        }
-                    },
-                    "404": {
-                        "description": "菜式或家庭不存在",
+                        "description": "未授权或Token无效",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
@@ -500,14 +507,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/auth/sessions/logout-others": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "仅允许菜式创建者或家庭管理员删除菜式。需要Bearer Token认证。",
+                "description": "吊销除当前会话以外的全部会话。需要Bearer Token认证。",
                 "consumes": [
                     "application/json"
                 ],
@@ -515,39 +524,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "菜式"
-                ],
-                "summary": "删除菜式",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "菜式ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "用户认证"
                 ],
+                "summary": "退出其他设备",
                 "responses": {
                     "200": {
-                        "description": "删除成功",
+                        "description": "退出成功",
                         "schema": {
-                            "$ref": "#/definitions/utils.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.LogoutOthersResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "401": {
-                        "description": "未授权",
-                        "schema": {
-                            "$ref": "#/definitions/utils.Response"
-                        }
-                    },
-                    "403": {
-                        "description": "无权限",
-                        "schema": {
-                            "$ref": "#/definitions/utils.Response"
-                        }
-                    },
-                    "404": {
-                        "description": "菜式或家庭不存在",
+                        "description": "未授权或Token无效",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
@@ -561,14 +561,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/family/create": {
-            "post": {
+        "/auth/sessions/{id}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "创建一个新的家庭，创建者自动成为owner并加入家庭。需要Bearer Token认证。",
+                "description": "吊销当前用户的指定会话，该设备需重新登录。需要Bearer Token认证。",
                 "consumes": [
                     "application/json"
                 ],
@@ -576,41 +576,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "家庭"
+                    "用户认证"
                 ],
-                "summary": "创建家庭",
+                "summary": "退出指定设备",
                 "parameters": [
                     {
-                        "description": "创建家庭请求参数",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.CreateFamilyRequest"
-                        }
+                        "type": "string",
+                        "description": "会话ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "创建成功",
+                        "description": "退出成功",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/utils.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/models.FamilyResponse"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/utils.Response"
                         }
                     },
                     "400": {
-                        "description": "请求参数错误或已加入家庭",
+                        "description": "请求参数错误",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
@@ -621,6 +607,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.Response"
                         }
                     },
+                    "404": {
+                        "description": "会话不存在或已失效",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
                     "500": {
                         "description": "服务器内部错误",
                         "schema": {
@@ -630,14 +622,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/family/info": {
+        "/dishes": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "获取当前用户所属家庭信息，包括成员数量与菜式数量。需要Bearer Token认证。",
+                "description": "按照家庭返回菜式列表及其标签，支持按任意标签组合（需同时满足）、难度和名称关键字筛选。需要Bearer Token认证。",
                 "consumes": [
                     "application/json"
                 ],
@@ -645,9 +637,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "家庭"
+                    "菜式"
+                ],
+                "summary": "获取菜式列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "页码（默认1）",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量（默认20，最大100）",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "标签ID，可重复传入，返回同时带有全部标签的菜式",
+                        "name": "tag_ids",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "easy",
+                            "medium",
+                            "hard"
+                        ],
+                        "type": "string",
+                        "description": "难度",
+                        "name": "difficulty",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "名称关键字",
+                        "name": "keyword",
+                        "in": "query"
+                    }
                 ],
-                "summary": "获取家庭信息",
                 "responses": {
                     "200": {
                         "description": "获取成功",
@@ -660,7 +693,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.FamilyInfoResponse"
+                                            "$ref": "#/definitions/models.DishListResponse"
                                         }
                                     }
                                 }
@@ -668,13 +701,13 @@ const docTemplate = `{
                         }
                     },
                     "401": {
-                        "description": "未授权或Token无效",
+                        "description": "未授权",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
                     },
                     "404": {
-                        "description": "尚未创建或加入家庭",
+                        "description": "尚未加入家庭",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
@@ -686,16 +719,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/family/member/invite": {
+            },
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "用户在前端点击“同意”后调用该接口，加入邀请人所在家庭。需要Bearer Token认证。",
+                "description": "向当前家庭食谱库添加菜式，至少包含一个食材和一个烹饪步骤。填写分类时自动关联同名标签，不存在则创建为自定义标签。需要Bearer Token认证。",
                 "consumes": [
                     "application/json"
                 ],
@@ -703,23 +734,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "家庭"
+                    "菜式"
                 ],
-                "summary": "扫码加入家庭",
+                "summary": "创建菜式",
                 "parameters": [
                     {
-                        "description": "扫码加入家庭请求参数",
+                        "description": "创建菜式请求",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.FamilyInviteRequest"
+                            "$ref": "#/definitions/models.CreateDishRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "加入成功",
+                        "description": "创建成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -729,7 +760,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.FamilyJoinResponse"
+                                            "$ref": "#/definitions/models.DishCreateResponse"
                                         }
                                     }
                                 }
@@ -743,13 +774,13 @@ const docTemplate = `{
                         }
                     },
                     "401": {
-                        "description": "未授权或Token无效",
+                        "description": "未授权",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
                     },
                     "404": {
-                        "description": "家庭不存在或邀请无效",
+                        "description": "尚未加入家庭",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
@@ -763,14 +794,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/family/members": {
+        "/dishes/export": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "返回当前用户所属家庭的成员列表。需要Bearer Token认证。",
+                "description": "导出单个或全部菜式，包含基础食材ID与名称、步骤与图片。format=json 为本应用格式，可原样导入；format=jsonld 为 schema.org Recipe JSON-LD（@graph），可导入其他菜谱应用。需要Bearer Token认证。",
                 "consumes": [
                     "application/json"
                 ],
@@ -778,12 +809,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "家庭"
+                    "菜式"
+                ],
+                "summary": "导出菜式",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "json",
+                        "description": "导出格式：json、jsonld",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "菜式ID，不传则导出全部菜式",
+                        "name": "dish_id",
+                        "in": "query"
+                    }
                 ],
-                "summary": "获取家庭成员列表",
                 "responses": {
                     "200": {
-                        "description": "获取成功",
+                        "description": "导出成功（format=jsonld 时 data 为 models.RecipeGraph）",
                         "schema": {
                             "allOf": [
                                 {
@@ -793,24 +839,27 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.FamilyMemberInfo"
-                                            }
+                                            "$ref": "#/definitions/models.DishTransferBundle"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
                     "401": {
-                        "description": "未授权或Token无效",
+                        "description": "未授权",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
                     },
                     "404": {
-                        "description": "尚未创建或加入家庭",
+                        "description": "菜式或家庭不存在",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
@@ -824,40 +873,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/health": {
-            "get": {
-                "description": "检查服务是否正常运行",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "系统"
-                ],
-                "summary": "健康检查",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    }
-                }
-            }
-        },
-        "/ingredients/by-category": {
-            "get": {
+        "/dishes/import": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "用户根据分类浏览基础食材",
+                "description": "导入本应用导出的菜式包（format=json）或 schema.org Recipe JSON-LD（format=jsonld，支持单个对象、数组与 @graph）。食材按ID或名称模糊匹配食材库，未匹配的食材与标签不导入并在结果中列出；同名、缺少食材或步骤的菜式跳过。可导入的菜式在同一事务内创建，导入后超过菜式数量上限时整体拒绝。dry_run=true 时只返回匹配结果。单次最多导入100道菜式。需要Bearer Token认证。",
                 "consumes": [
                     "application/json"
                 ],
@@ -865,39 +888,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "食材"
+                    "菜式"
                 ],
-                "summary": "按分类分页查询食材",
+                "summary": "导入菜式",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "食材分类",
-                        "name": "category",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "页码",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "每页数量",
-                        "name": "page_size",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "分类内模糊搜索",
-                        "name": "keyword",
-                        "in": "query"
+                        "description": "导入数据",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.DishImportRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "查询成功",
+                        "description": "导入成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -907,7 +914,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.IngredientCategoryListResponse"
+                                            "$ref": "#/definitions/models.DishImportResponse"
                                         }
                                     }
                                 }
@@ -915,7 +922,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "参数错误",
+                        "description": "导入数据无效或菜式数量超过上限",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
@@ -926,8 +933,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.Response"
                         }
                     },
+                    "404": {
+                        "description": "家庭不存在",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
                     "500": {
-                        "description": "服务器错误",
+                        "description": "服务器内部错误",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
@@ -935,14 +948,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/ingredients/search": {
+        "/dishes/tags": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "根据关键字返回最多10条可用基础食材",
+                "description": "按主料、口味、自定义分组返回可用的菜式标签，前两组为系统标签，自定义组为本家庭创建的标签。需要Bearer Token认证。",
                 "consumes": [
                     "application/json"
                 ],
@@ -950,27 +963,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "食材"
-                ],
-                "summary": "食材模糊搜索",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "关键字",
-                        "name": "keyword",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "返回条数 (1-20, 默认10)",
-                        "name": "limit",
-                        "in": "query"
-                    }
+                    "菜式"
                 ],
+                "summary": "获取菜式标签",
                 "responses": {
                     "200": {
-                        "description": "搜索成功",
+                        "description": "获取成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -980,72 +978,64 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object",
-                                            "additionalProperties": true
+                                            "$ref": "#/definitions/models.DishTagListResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "参数错误",
+                    "401": {
+                        "description": "未授权",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
                     },
-                    "401": {
-                        "description": "未授权",
+                    "404": {
+                        "description": "尚未加入家庭",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
                     },
                     "500": {
-                        "description": "服务器错误",
+                        "description": "服务器内部错误",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
                     }
                 }
-            }
-        },
-        "/media/upload": {
+            },
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "上传图片等媒体文件，并返回可访问地址。需要 Bearer Token。",
+                "description": "为当前家庭新增自定义菜式标签，名称不能与系统标签或已有标签重复，每个家庭最多50个。需要Bearer Token认证。",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "媒体"
+                    "菜式"
                 ],
-                "summary": "上传媒体文件",
+                "summary": "新增自定义标签",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "待上传文件",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "文件目录前缀，例如 /user/head/{userId}、/family/{familyId}/dishes/{dishId}",
-                        "name": "path",
-                        "in": "formData",
-                        "required": true
+                        "description": "新增标签请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateDishTagRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "创建成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -1055,7 +1045,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.MediaUploadResponse"
+                                            "$ref": "#/definitions/models.DishTag"
                                         }
                                     }
                                 }
@@ -1063,19 +1053,25 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "参数错误、名称重复或数量已达上限",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "尚未加入家庭",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "服务器内部错误",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
@@ -1083,14 +1079,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/menus": {
-            "post": {
+        "/dishes/tags/{id}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "为某一天某一餐创建菜单，支持日期、餐次（早餐/午餐/晚餐）、菜式列表输入。同一日期同一餐次只能有一个菜单，可以覆盖已有菜单。需要Bearer Token认证。",
+                "description": "删除本家庭的自定义标签，菜式上的该标签会一并移除。系统标签不能删除，仅标签创建者或家庭管理员可删除。需要Bearer Token认证。",
                 "consumes": [
                     "application/json"
                 ],
@@ -1098,53 +1094,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "菜单"
+                    "菜式"
                 ],
-                "summary": "创建菜单",
+                "summary": "删除自定义标签",
                 "parameters": [
                     {
-                        "description": "创建菜单请求",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.CreateMenuRequest"
-                        }
+                        "type": "string",
+                        "description": "标签ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "创建成功",
+                        "description": "删除成功",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/utils.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/models.MenuCreateResponse"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/utils.Response"
                         }
                     },
-                    "400": {
-                        "description": "参数错误或业务限制",
+                    "401": {
+                        "description": "未授权",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
                     },
-                    "401": {
-                        "description": "未授权",
+                    "403": {
+                        "description": "无权限",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
                     },
                     "404": {
-                        "description": "尚未加入家庭或菜式不存在",
+                        "description": "标签或家庭不存在",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
@@ -1158,14 +1140,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/menus/daily": {
+        "/dishes/trash": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "获取某一天的三餐菜单（早餐、午餐、晚餐）。需要Bearer Token认证。",
+                "description": "分页返回本家庭已删除的菜式，按删除时间倒序。菜式删除30天后彻底删除，purge_after 为彻底删除时间。需要Bearer Token认证。",
                 "consumes": [
                     "application/json"
                 ],
@@ -1173,16 +1155,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "菜单"
+                    "菜式"
                 ],
-                "summary": "获取每日菜单",
+                "summary": "获取回收站菜式",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "日期，格式：YYYY-MM-DD",
-                        "name": "date",
-                        "in": "query",
-                        "required": true
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "每页数量",
+                        "name": "page_size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1197,7 +1186,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.DailyMenuResponse"
+                                            "$ref": "#/definitions/models.DishTrashListResponse"
                                         }
                                     }
                                 }
@@ -1205,7 +1194,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "参数错误",
+                        "description": "请求参数错误",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
@@ -1217,7 +1206,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "尚未加入家庭",
+                        "description": "家庭不存在",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
@@ -1231,36 +1220,36 @@ const docTemplate = `{
                 }
             }
         },
-        "/menus/weekly": {
-            "get": {
+        "/dishes/voice-draft": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "获取从开始日期起一周的菜单列表。需要Bearer Token认证。",
+                "description": "上传一段描述菜式做法的语音，转写并整理为菜式草稿。识别出的食材会匹配食材库，未匹配的食材在 ingredients 中标出并给出候选。语音仅用于本次识别，处理完成后即删除。草稿不会保存，确认后请使用创建菜式接口提交。每人每天可使用10次，仅付费版可用，识别失败不扣次数。需要Bearer Token认证。",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "菜单"
+                    "菜式"
                 ],
-                "summary": "获取每周菜单",
+                "summary": "语音录入菜式",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "开始日期，格式：YYYY-MM-DD",
-                        "name": "start_date",
-                        "in": "query",
+                        "type": "file",
+                        "description": "语音文件，支持 MP3/WAV/M4A/AAC/WebM/OGG/AMR/FLAC，最大10MB",
+                        "name": "file",
+                        "in": "formData",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "获取成功",
+                        "description": "识别成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -1270,7 +1259,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.WeeklyMenuResponse"
+                                            "$ref": "#/definitions/models.VoiceDishDraftResponse"
                                         }
                                     }
                                 }
@@ -1278,7 +1267,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "参数错误",
+                        "description": "文件为空、过大或格式不支持",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
@@ -1289,29 +1278,65 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.Response"
                         }
                     },
+                    "403": {
+                        "description": "AI功能仅付费会员可用",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
                     "404": {
                         "description": "尚未加入家庭",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
                     },
+                    "429": {
+                        "description": "今日次数已用完",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.AIQuotaUsage"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
                     "500": {
                         "description": "服务器内部错误",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
+                    },
+                    "502": {
+                        "description": "AI返回的菜式无效",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "AI服务暂不可用",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
                     }
                 }
             }
         },
-        "/menus/{id}": {
-            "put": {
+        "/dishes/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "更新已有菜单，支持添加菜式、删除菜式、修改日期或餐次。需要Bearer Token认证。",
+                "description": "返回菜式的食材和烹饪步骤信息。传入 servings 时按该份数缩放食材用量，计数单位取整、勺按半勺取整。需要Bearer Token认证。",
                 "consumes": [
                     "application/json"
                 ],
@@ -1319,30 +1344,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "菜单"
+                    "菜式"
                 ],
-                "summary": "更新菜单",
+                "summary": "获取菜式详情",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "菜单ID",
+                        "description": "菜式ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "更新菜单请求",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UpdateMenuRequest"
-                        }
+                        "type": "integer",
+                        "description": "份数（1-50），不填按菜式默认份数",
+                        "name": "servings",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "更新成功",
+                        "description": "获取成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -1352,7 +1374,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.MenuUpdateResponse"
+                                            "$ref": "#/definitions/models.DishDetailResponse"
                                         }
                                     }
                                 }
@@ -1360,7 +1382,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "参数错误或业务限制",
+                        "description": "参数错误",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
@@ -1372,7 +1394,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "菜单或家庭不存在",
+                        "description": "菜式或家庭不存在",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
@@ -1384,16 +1406,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/user/info": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "获取当前登录用户的基本信息和会员状态。需要Bearer Token认证。",
+                "description": "仅允许菜式创建者或家庭管理员编辑菜式。未传 tag_ids、prep_minutes、cook_minutes、servings、difficulty 时保持原值；填写分类时自动关联同名标签。需要Bearer Token认证。",
                 "consumes": [
                     "application/json"
                 ],
@@ -1401,12 +1421,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户"
+                    "菜式"
+                ],
+                "summary": "更新菜式",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "菜式ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "更新菜式请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateDishRequest"
+                        }
+                    }
                 ],
-                "summary": "获取用户信息",
                 "responses": {
                     "200": {
-                        "description": "获取成功",
+                        "description": "更新成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -1416,21 +1454,33 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.UserInfoResponse"
+                                            "$ref": "#/definitions/models.DishDetailResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "400": {
+                        "description": "参数错误或业务限制",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
                     "401": {
-                        "description": "未授权或Token无效",
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
                     },
                     "404": {
-                        "description": "用户不存在",
+                        "description": "菜式或家庭不存在",
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
@@ -1442,213 +1492,7234 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "models.CaptchaResponse": {
-            "description": "图形验证码返回数据",
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "仅允许菜式创建者或家庭管理员删除菜式。删除的菜式进入回收站，30天内可恢复，期满后彻底删除。菜式会从引用它的菜单中移除，已被菜单引用时返回409及受影响的菜单，确认删除请传force=true。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "菜式"
+                ],
+                "summary": "删除菜式",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "菜式ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "菜式已被菜单引用时确认删除",
+                        "name": "force",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除成功",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "菜式或家庭不存在",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "菜式已被菜单引用，需确认删除",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.DishDeleteImpactResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/dishes/{id}/delete-impact": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "返回引用该菜式的菜单，按今天划分为未来菜单与历史菜单。删除后菜式会从这些菜单中移除，恢复菜式不会重新加入菜单。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "菜式"
+                ],
+                "summary": "删除菜式前检查影响",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "菜式ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.DishDeleteImpactResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "菜式或家庭不存在",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/dishes/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "恢复回收站中的菜式，仅创建者或家庭管理员可操作。恢复后占用菜式数量额度，名称不能与现有菜式重复，删除时被移出的菜单不会自动恢复。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "菜式"
+                ],
+                "summary": "从回收站恢复菜式",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "菜式ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "恢复成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.DishDetailResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "菜式数量达到上限、名称重复或内容已清除",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "菜式或家庭不存在",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/dishes/{id}/revisions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "分页返回菜式的修订记录（版本号、操作、操作人、时间），按版本号倒序。每次创建、编辑、恢复菜式都会生成一个新版本。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "菜式"
+                ],
+                "summary": "获取菜式修订记录",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "菜式ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "每页数量",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.DishRevisionListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "菜式或家庭不存在",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/dishes/{id}/revisions/diff": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "逐项比较两个版本：基本信息按字段列出新旧值；食材按基础食材与单位对应，列出新增、删除与修改的字段；步骤按序号对应；标签列出新增与移除。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "菜式"
+                ],
+                "summary": "比较菜式的两个版本",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "菜式ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "旧版本号",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "新版本号",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.DishRevisionDiffResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "菜式、版本或家庭不存在",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/dishes/{id}/revisions/{revision}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "返回菜式某一版本的完整内容，包括基本信息、食材、步骤与标签。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "菜式"
+                ],
+                "summary": "获取菜式某一版本",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "菜式ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "版本号",
+                        "name": "revision",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.DishRevision"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "菜式、版本或家庭不存在",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/dishes/{id}/revisions/{revision}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "将菜式内容恢复为指定版本，并生成一个新版本，原有版本不受影响。历史版本中已删除的标签会被忽略；食材已停用或名称与其他菜式重复时无法恢复。仅允许菜式创建者或家庭管理员操作。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "菜式"
+                ],
+                "summary": "恢复菜式历史版本",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "菜式ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "版本号",
+                        "name": "revision",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "恢复成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.DishDetailResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "名称重复或食材已停用",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "菜式、版本或家庭不存在",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/family/create": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "创建一个新的家庭，创建者自动成为owner并加入家庭。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "家庭"
+                ],
+                "summary": "创建家庭",
+                "parameters": [
+                    {
+                        "description": "创建家庭请求参数",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateFamilyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "创建成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.FamilyResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误或已加入家庭",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权或Token无效",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/family/dissolve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "仅owner可操作，需再次输入家庭名称确认。家庭标记为解散，全部成员退出后可加入或创建其他家庭；菜式与菜单软删除，30天内owner可导出数据，之后数据被清理。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "家庭"
+                ],
+                "summary": "解散家庭",
+                "parameters": [
+                    {
+                        "description": "解散家庭请求参数",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.DissolveFamilyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "解散成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.FamilyDissolveResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误或家庭名称不一致",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权或Token无效",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "仅owner可操作",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "尚未创建或加入家庭",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/family/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "仅owner可操作，导出家庭信息、成员、菜式、菜单与购物清单的JSON数据包。家庭解散后在数据清理前仍可通过family_id导出。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "家庭"
+                ],
+                "summary": "导出家庭数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "已解散家庭的ID，不传则导出当前家庭",
+                        "name": "family_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "导出成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.FamilyExportBundle"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权或Token无效",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "仅owner可操作",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "家庭不存在或数据已清理",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/family/info": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取当前用户所属家庭信息，包括成员数量与菜式数量。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "家庭"
+                ],
+                "summary": "获取家庭信息",
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.FamilyInfoResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未授权或Token无效",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "尚未创建或加入家庭",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/family/invitations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "返回当前家庭的全部邀请及其状态（active, expired, revoked, exhausted）。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "家庭"
+                ],
+                "summary": "获取家庭邀请列表",
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.FamilyInvitationResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未授权或Token无效",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "尚未创建或加入家庭",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "家庭成员生成带签名的邀请令牌，可设置有效时长、最大使用次数与指定手机号。令牌仅在创建时返回，用于生成二维码。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "家庭"
+                ],
+                "summary": "创建家庭邀请",
+                "parameters": [
+                    {
+                        "description": "创建邀请请求参数",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateFamilyInvitationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "创建成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.FamilyInvitationResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权或Token无效",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "尚未创建或加入家庭",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/family/invitations/preview": {
+            "get": {
+                "description": "邀请落地页根据二维码中的令牌获取家庭名称与邀请人信息，无需登录。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "家庭"
+                ],
+                "summary": "邀请令牌预览",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "邀请令牌",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.FamilyInvitationPreview"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "邀请无效、已过期、已撤销或次数已用完",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "家庭不存在或已解散",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/family/invitations/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "邀请人本人或owner可撤销邀请，撤销后令牌立即失效。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "家庭"
+                ],
+                "summary": "撤销家庭邀请",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "邀请ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "撤销成功",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "邀请已撤销",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权或Token无效",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权撤销",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "邀请或家庭不存在",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/family/leave": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "成员主动退出家庭；owner退出时必须通过 new_owner_id 指定新owner，家庭仅剩owner一人时无法退出。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "家庭"
+                ],
+                "summary": "退出家庭",
+                "parameters": [
+                    {
+                        "description": "退出家庭请求参数",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.LeaveFamilyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "退出成功",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误或需要先转让家庭",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权或Token无效",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "家庭或新owner不存在",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/family/member/invite": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "用户在前端点击“同意”后调用该接口，兑换二维码中的邀请令牌并加入邀请人所在家庭。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "家庭"
+                ],
+                "summary": "扫码加入家庭",
+                "parameters": [
+                    {
+                        "description": "扫码加入家庭请求参数",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.FamilyInviteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "加入成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.FamilyJoinResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误、邀请无效或业务限制",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权或Token无效",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "邀请仅限指定手机号",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "家庭不存在或已解散",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/family/members": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "返回当前用户所属家庭的成员列表。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "家庭"
+                ],
+                "summary": "获取家庭成员列表",
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.FamilyMemberInfo"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未授权或Token无效",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "尚未创建或加入家庭",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/family/members/{user_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "仅owner可操作，被移除成员的状态置为已退出。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "家庭"
+                ],
+                "summary": "移除家庭成员",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "成员用户ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "移除成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.FamilyMemberInfo"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "不能移除自己",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权或Token无效",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "仅owner可操作",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "家庭或成员不存在",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/family/members/{user_id}/role": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "仅owner可操作。将成员角色设为owner即转让家庭，原owner降为普通成员。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "家庭"
+                ],
+                "summary": "修改成员角色",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "成员用户ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "修改角色请求参数",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateFamilyMemberRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "修改成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.FamilyMemberInfo"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权或Token无效",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "仅owner可操作",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "家庭或成员不存在",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/family/transfer": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "仅owner可操作，将家庭转让给其他成员，原owner降为普通成员。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "家庭"
+                ],
+                "summary": "转让家庭",
+                "parameters": [
+                    {
+                        "description": "转让家庭请求参数",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TransferFamilyOwnershipRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "转让成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.FamilyMemberInfo"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权或Token无效",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "仅owner可操作",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "家庭或成员不存在",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "检查服务是否正常运行",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "系统"
+                ],
+                "summary": "健康检查",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/health-records": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "按记录时间倒序分页返回身体状况记录及AI分析结果。默认返回本人的记录；家庭管理员可通过user_id查看已开启公开的成员记录。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "身体状况"
+                ],
+                "summary": "获取身体状况记录列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "页码（默认1）",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量（默认20，最大100）",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "成员ID，为空表示本人",
+                        "name": "user_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.HealthRecordListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权查看该成员的记录",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "尚未加入家庭或成员不存在",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "记录本人当前的身体状况，包括疾病或不适、工作繁忙程度、压力水平与身体感受，至少填写一项。记录仅本人可见，开启公开后家庭管理员可查看。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "身体状况"
+                ],
+                "summary": "新增身体状况记录",
+                "parameters": [
+                    {
+                        "description": "身体状况记录",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateHealthRecordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "记录成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.HealthRecord"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误或内容为空",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "尚未加入家庭",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/health-records/sharing": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "返回本人是否允许家庭管理员查看自己的身体状况记录，默认不公开，退出家庭后重置。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "身体状况"
+                ],
+                "summary": "获取身体状况记录公开设置",
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.HealthSharingResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "尚未加入家庭",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "设置是否允许家庭管理员查看本人的全部身体状况记录，包括AI分析结果。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "身体状况"
+                ],
+                "summary": "设置身体状况记录公开",
+                "parameters": [
+                    {
+                        "description": "公开设置",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.HealthSharingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "设置成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.HealthSharingResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "尚未加入家庭",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/health-records/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取一条身体状况记录及其AI分析结果。仅本人或已获公开授权的家庭管理员可查看。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "身体状况"
+                ],
+                "summary": "获取身体状况记录详情",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "记录ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.HealthRecord"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "记录不存在或无权查看",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/health-records/{id}/analyze": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "由AI分析本人的一条身体状况记录并给出建议与饮食建议，分析结果保存到该记录，重复分析会覆盖上次结果。每人每天可分析2次，仅付费版可用，分析失败不扣次数。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "身体状况"
+                ],
+                "summary": "AI分析身体状况记录",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "记录ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "分析成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.HealthRecordAnalysisResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "AI功能仅付费会员可用或只能分析本人的记录",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "记录不存在",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "今日分析次数已用完",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.AIQuotaUsage"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "502": {
+                        "description": "AI返回的分析结果无效",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "AI服务暂不可用",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/ingredients/by-category": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "用户根据分类浏览基础食材",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "食材"
+                ],
+                "summary": "按分类分页查询食材",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "食材分类",
+                        "name": "category",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "分类内模糊搜索",
+                        "name": "keyword",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.IngredientCategoryListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/ingredients/convert": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "将数量换算为目标单位；提供食材ID时使用食材的密度与单个重量进行体积、计数与质量之间的换算，目标单位缺省为食材推荐单位",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "食材"
+                ],
+                "summary": "单位换算预览",
+                "parameters": [
+                    {
+                        "description": "换算请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UnitConvertRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "换算成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.UnitConvertResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误、单位无法识别或无法换算",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "食材不存在",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/ingredients/search": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "根据关键字返回最多10条可用基础食材",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "食材"
+                ],
+                "summary": "食材模糊搜索",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "关键字",
+                        "name": "keyword",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "返回条数 (1-20, 默认10)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "搜索成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "additionalProperties": true
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/ingredients/units": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "返回质量、体积、计数三类单位及其别名，系数为换算到克、毫升、个的倍数",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "食材"
+                ],
+                "summary": "获取支持换算的单位",
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.UnitListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/media/upload": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "上传图片等媒体文件，并返回可访问地址。需要 Bearer Token。",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "媒体"
+                ],
+                "summary": "上传媒体文件",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "待上传文件",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "文件目录前缀，例如 /user/head/{userId}、/family/{familyId}/dishes/{dishId}",
+                        "name": "path",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.MediaUploadResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/menus": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "为某一天某一餐创建菜单，支持日期、餐次（早餐/午餐/晚餐）、菜式列表输入。可设置用餐人数，也可为单个菜式单独设置，购物清单中的食材用量按此缩放。同一日期同一餐次只能有一个菜单，可以覆盖已有菜单。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "菜单"
+                ],
+                "summary": "创建菜单",
+                "parameters": [
+                    {
+                        "description": "创建菜单请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateMenuRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "创建成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.MenuCreateResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误或业务限制",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "尚未加入家庭或菜式不存在",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/menus/daily": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取某一天的三餐菜单（早餐、午餐、晚餐）。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "菜单"
+                ],
+                "summary": "获取每日菜单",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "日期，格式：YYYY-MM-DD",
+                        "name": "date",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.DailyMenuResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "尚未加入家庭",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/menus/generate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "根据家庭食谱库与成员的饮食偏好、最新身体状况（仅包含本人及已开启公开的成员），由AI生成一段时间（最多14天）的菜单草稿。草稿不会直接写入菜单，需调用确认接口后生效。每个家庭每周可生成5次，仅付费版可用，生成失败不扣次数。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "菜单"
+                ],
+                "summary": "AI生成菜单",
+                "parameters": [
+                    {
+                        "description": "生成菜单请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.GenerateMenuRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "生成成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.MenuGenerationResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误或食谱库为空",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "AI功能仅付费会员可用",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "尚未加入家庭或成员不存在",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "本周期生成次数已用完",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.AIQuotaUsage"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "502": {
+                        "description": "AI返回的菜单无效",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "AI服务暂不可用",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/menus/generations/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取AI生成的菜单草稿详情。草稿待确认时，每餐会标出已有菜单的ID与来源，来源为manual的餐次确认时需要覆盖确认。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "菜单"
+                ],
+                "summary": "获取AI菜单草稿",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "草稿ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.MenuGenerationResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "草稿或家庭不存在",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "放弃待确认的AI菜单草稿，已使用的生成次数不退回。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "菜单"
+                ],
+                "summary": "放弃AI菜单草稿",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "草稿ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "已放弃",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误或草稿已处理",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "草稿或家庭不存在",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/menus/generations/{id}/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "将AI菜单草稿写入菜单，来源记为ai。已有AI菜单的餐次直接替换；已有手动菜单的餐次需传 overwrite=true 才会覆盖，否则返回409及冲突的餐次列表。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "菜单"
+                ],
+                "summary": "确认AI菜单草稿",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "草稿ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "确认请求",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ConfirmMenuGenerationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "确认成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ConfirmMenuGenerationResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误或草稿已处理",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "草稿、菜式或家庭不存在",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "需确认覆盖手动菜单",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.MenuGenerationConflictResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/menus/plan": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "不使用AI，按规则为日期范围（最多14天）内没有菜单的餐次排菜：早餐1道、午餐和晚餐各2道；同一菜式在指定天数内不重复（默认3天）；同一天尽量安排不同分类；指定菜式优先安排，排除的菜式不参与。可选菜式不足时会放宽规则并在warnings中说明。结果仅为预览，需调用采纳接口写入菜单。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "菜单"
+                ],
+                "summary": "规则排菜预览",
+                "parameters": [
+                    {
+                        "description": "排菜请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PlanMenuRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.PlanMenuResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误或食谱库为空",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "尚未加入家庭或菜式不存在",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/menus/plan/accept": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "将排菜预览（可在预览基础上调整）写入菜单。只能写入空餐次，预览之后该餐次已有菜单时整体失败并返回409。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "菜单"
+                ],
+                "summary": "采纳排菜预览",
+                "parameters": [
+                    {
+                        "description": "采纳请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AcceptMenuPlanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "创建成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.AcceptMenuPlanResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "尚未加入家庭或菜式不存在",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "餐次已有菜单",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/menus/weekly": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取从开始日期起一周的菜单列表。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "菜单"
+                ],
+                "summary": "获取每周菜单",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "开始日期，格式：YYYY-MM-DD",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.WeeklyMenuResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "尚未加入家庭",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/menus/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "更新已有菜单，支持添加菜式、删除菜式、修改日期、餐次或用餐人数。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "菜单"
+                ],
+                "summary": "更新菜单",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "菜单ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "更新菜单请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateMenuRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.MenuUpdateResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误或业务限制",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "菜单或家庭不存在",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/menus/{id}/schedule": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "将菜单中多道菜的烹饪步骤合并为一条时间线：同一道菜的步骤按顺序进行；需要看管的步骤同一时间只做一件，炖煮、烤制等无需看管的步骤与其他步骤并行；炒锅、烤箱各1个、灶眼2个，占用同一厨具的步骤不会冲突。未设置时长的步骤按5分钟估算。返回总用时及相比逐道菜依次烹饪节省的时间。仅付费版可用。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "菜单"
+                ],
+                "summary": "获取一餐的烹饪排程",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "菜单ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.CookingScheduleResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "仅付费会员可用",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "菜单或家庭不存在",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment/membership": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取当前用户所在家庭的会员类型、到期时间及权益（最大菜式数量等）。会员权益按家庭生效，到期后自动恢复为免费版。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "会员"
+                ],
+                "summary": "获取会员信息",
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.MembershipResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未授权或Token无效",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment/mock/pay": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "仅在使用 mock 支付渠道时可用：为当前用户的订单生成一条已签名的支付回调并按真实回调流程处理，用于本地联调会员开通流程。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "会员"
+                ],
+                "summary": "模拟支付",
+                "parameters": [
+                    {
+                        "description": "模拟支付请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MockPayRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "处理成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.PaymentOrderResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误或订单已退款",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权或Token无效",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "未启用模拟支付",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "订单不存在",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment/notify/{provider}": {
+            "post": {
+                "description": "供支付渠道异步通知支付结果，回调需通过渠道签名校验。同一订单的重复回调只处理一次。无需认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "会员"
+                ],
+                "summary": "支付结果回调",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "支付渠道，如 mock",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "处理成功",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "签名错误、渠道不匹配或金额不一致",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "订单不存在",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment/orders": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "分页返回当前用户的支付订单，按创建时间倒序。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "会员"
+                ],
+                "summary": "获取订单列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "页码（默认1）",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量（默认20，最大50）",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.PaymentOrderListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未授权或Token无效",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment/orders/{order_no}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "查询当前用户的订单状态，客户端支付完成后可轮询该接口确认结果。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "会员"
+                ],
+                "summary": "获取订单详情",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "订单号",
+                        "name": "order_no",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.PaymentOrderResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未授权或Token无效",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "订单不存在",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment/orders/{order_no}/refund": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "对支付后7天内的订单发起全额退款并取消对应的会员时长。家庭之后已续费时需先退款最近一笔订单。渠道退款未及时完成时订单返回 refunding 状态，由后台自动重试，也可再次调用立即重试。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "会员"
+                ],
+                "summary": "申请退款",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "订单号",
+                        "name": "order_no",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "已发起退款",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.PaymentOrderResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "订单状态不允许退款或已超过退款期限",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权或Token无效",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "订单不存在",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "该订单之后已有续费",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment/plans": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "返回可购买的会员套餐及价格（单位：分）。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "会员"
+                ],
+                "summary": "获取会员套餐",
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.MembershipPlan"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未授权或Token无效",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment/subscribe": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "为当前家庭创建会员订单并向支付渠道下单，返回客户端拉起支付所需参数。订单30分钟内未支付将自动关闭。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "会员"
+                ],
+                "summary": "购买会员",
+                "parameters": [
+                    {
+                        "description": "订阅会员请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SubscribeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "下单成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.PaymentOrderResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误或不支持的支付渠道",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权或Token无效",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "尚未加入家庭",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/shopping-lists": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "分页返回当前家庭的购物清单，按创建时间倒序。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "购物清单"
+                ],
+                "summary": "获取购物清单列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "页码（默认1）",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量（默认20，最大50）",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ShoppingListListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "尚未加入家庭",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/shopping-lists/generate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "根据日期范围（最多31天）或指定菜单ID列表汇总菜式食材，按 ingredient_id + unit 合并数量并按食材分类分组保存。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "购物清单"
+                ],
+                "summary": "生成购物清单",
+                "parameters": [
+                    {
+                        "description": "生成购物清单请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.GenerateShoppingListRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "生成成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ShoppingListDetail"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误或范围内没有菜单",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "尚未加入家庭或菜单不存在",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/shopping-lists/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "返回购物清单及按食材分类分组的清单项。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "购物清单"
+                ],
+                "summary": "获取购物清单详情",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "购物清单ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ShoppingListDetail"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "购物清单或家庭不存在",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/shopping-lists/{id}/items": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "向购物清单添加基础食材，若已存在同食材同单位的清单项则合并数量。手动添加的清单项在重新生成时保留。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "购物清单"
+                ],
+                "summary": "手动添加清单项",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "购物清单ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "添加清单项请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AddShoppingItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "添加成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ShoppingListDetail"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误或食材不存在",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "购物清单或家庭不存在",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/shopping-lists/{id}/items/{itemId}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "修改清单项的数量或单位，修改后的清单项在重新生成时保留；可换算的单位会统一为食材推荐单位，换算后与已有清单项重复时自动合并。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "购物清单"
+                ],
+                "summary": "修改清单项",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "购物清单ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "清单项ID",
+                        "name": "itemId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "修改清单项请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateShoppingItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "修改成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ShoppingListDetail"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "购物清单、清单项或家庭不存在",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "从购物清单中删除清单项。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "购物清单"
+                ],
+                "summary": "删除清单项",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "购物清单ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "清单项ID",
+                        "name": "itemId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ShoppingListDetail"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "购物清单、清单项或家庭不存在",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/shopping-lists/{id}/items/{itemId}/toggle": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "在待购买与已购买之间切换清单项状态，全部清单项已购买时购物清单自动标记为完成。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "购物清单"
+                ],
+                "summary": "切换清单项购买状态",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "购物清单ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "清单项ID",
+                        "name": "itemId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "操作成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ShoppingListDetail"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "购物清单、清单项或家庭不存在",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/shopping-lists/{id}/regenerate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "按清单原来的日期范围或菜单重新汇总食材，手动添加或修改过的清单项保留，同食材同单位的清单项保留已购买状态。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "购物清单"
+                ],
+                "summary": "重新生成购物清单",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "购物清单ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "重新生成成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ShoppingListDetail"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "购物清单或家庭不存在",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/avatar": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "上传图片到当前用户的头像目录并设为新头像，旧头像文件会被删除。需要Bearer Token认证。",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户"
+                ],
+                "summary": "上传头像",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "头像图片",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "上传成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.UserInfoResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "文件不合法",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权或Token无效",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "用户不存在",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/info": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取当前登录用户的基本信息和会员状态。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户"
+                ],
+                "summary": "获取用户信息",
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.UserInfoResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未授权或Token无效",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "用户不存在",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/password": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "校验当前密码后设置新密码，成功后其他设备的登录会话全部失效，当前会话保持登录。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户"
+                ],
+                "summary": "修改密码",
+                "parameters": [
+                    {
+                        "description": "修改密码请求参数",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ChangePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "修改成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ChangePasswordResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误或当前密码不正确",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权或Token无效",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "用户不存在",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/profile": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "更新昵称、头像与饮食偏好，未传的字段保持不变。头像需先通过媒体上传接口上传到 user/head/{userId} 目录，替换后旧头像文件会被删除。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户"
+                ],
+                "summary": "更新用户资料",
+                "parameters": [
+                    {
+                        "description": "更新资料请求参数",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.UserInfoResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误或头像不合法",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权或Token无效",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "用户不存在",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/quotas": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "返回各AI功能在当前周期的额度与剩余次数：语音输入每人每天10次，身体状况分析每人每天2次，菜单生成与菜单分析每个家庭每周5次，烹饪步骤优化不限次数。周期按北京时间计算，每周从周一开始。AI功能仅付费版可用，免费版剩余次数均为0。需要Bearer Token认证。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户"
+                ],
+                "summary": "获取AI功能剩余次数",
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.AIQuotaResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未授权或Token无效",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "models.AIQuotaResponse": {
+            "description": "当前用户各AI功能的剩余次数，AI功能仅付费版可用",
+            "type": "object",
+            "properties": {
+                "available": {
+                    "description": "是否可使用AI功能",
+                    "type": "boolean",
+                    "example": true
+                },
+                "membership_type": {
+                    "description": "会员类型：free-免费版，premium-付费版",
+                    "type": "string",
+                    "example": "premium"
+                },
+                "quotas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AIQuotaUsage"
+                    }
+                }
+            }
+        },
+        "models.AIQuotaUsage": {
+            "type": "object",
+            "properties": {
+                "feature": {
+                    "description": "功能：voice_input, health_analyze, menu_generate, menu_analyze, cooking_optimize",
+                    "type": "string",
+                    "example": "menu_generate"
+                },
+                "limit": {
+                    "description": "周期内可用次数，-1表示不限",
+                    "type": "integer",
+                    "example": 5
+                },
+                "period": {
+                    "description": "统计周期：daily-每天，weekly-每周",
+                    "type": "string",
+                    "example": "weekly"
+                },
+                "remaining": {
+                    "description": "剩余次数，-1表示不限",
+                    "type": "integer",
+                    "example": 3
+                },
+                "reset_at": {
+                    "description": "额度重置时间",
+                    "type": "string",
+                    "example": "2024-01-08T00:00:00+08:00"
+                },
+                "scope": {
+                    "description": "计数范围：user-每人，family-家庭共享",
+                    "type": "string",
+                    "example": "family"
+                },
+                "used": {
+                    "description": "周期内已用次数",
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "models.AcceptMenuPlanItem": {
+            "type": "object",
+            "required": [
+                "date",
+                "dish_ids",
+                "meal_type"
+            ],
+            "properties": {
+                "date": {
+                    "description": "日期，格式：YYYY-MM-DD",
+                    "type": "string"
+                },
+                "dish_ids": {
+                    "description": "菜式ID列表",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "meal_type": {
+                    "description": "餐次",
+                    "type": "string",
+                    "enum": [
+                        "breakfast",
+                        "lunch",
+                        "dinner"
+                    ]
+                }
+            }
+        },
+        "models.AcceptMenuPlanRequest": {
+            "type": "object",
+            "required": [
+                "menus"
+            ],
+            "properties": {
+                "menus": {
+                    "type": "array",
+                    "maxItems": 42,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/models.AcceptMenuPlanItem"
+                    }
+                }
+            }
+        },
+        "models.AcceptMenuPlanResponse": {
+            "type": "object",
+            "properties": {
+                "menus": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MenuDetail"
+                    }
+                }
+            }
+        },
+        "models.AddShoppingItemRequest": {
+            "type": "object",
+            "required": [
+                "ingredient_id",
+                "total_amount",
+                "unit"
+            ],
+            "properties": {
+                "ingredient_id": {
+                    "description": "基础食材ID",
+                    "type": "string",
+                    "maxLength": 26,
+                    "minLength": 1
+                },
+                "total_amount": {
+                    "description": "数量",
+                    "type": "number"
+                },
+                "unit": {
+                    "description": "单位",
+                    "type": "string",
+                    "maxLength": 20
+                }
+            }
+        },
+        "models.CaptchaResponse": {
+            "description": "图形验证码返回数据",
+            "type": "object",
+            "properties": {
+                "captcha_key": {
+                    "description": "验证码编码",
+                    "type": "string",
+                    "example": "01J0XYZABCD1234EFG567HIJK"
+                },
+                "expire_in": {
+                    "description": "过期时间（秒）",
+                    "type": "integer",
+                    "example": 30
+                },
+                "image_base64": {
+                    "description": "图形验证码Base64",
+                    "type": "string",
+                    "example": "data:image/png;base64,iVBORw0KGgoAAAANSUhEUg..."
+                }
+            }
+        },
+        "models.ChangePasswordRequest": {
+            "description": "已登录用户修改密码请求参数",
+            "type": "object",
+            "required": [
+                "new_password",
+                "old_password"
+            ],
+            "properties": {
+                "new_password": {
+                    "description": "新密码，至少6位",
+                    "type": "string",
+                    "minLength": 6,
+                    "example": "newPassword456"
+                },
+                "old_password": {
+                    "description": "当前密码",
+                    "type": "string",
+                    "example": "password123"
+                }
+            }
+        },
+        "models.ChangePasswordResponse": {
+            "description": "修改密码成功返回的数据",
+            "type": "object",
+            "properties": {
+                "revoked_sessions": {
+                    "description": "被吊销的其他设备会话数量",
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "models.ConfirmMenuGenerationRequest": {
+            "type": "object",
+            "properties": {
+                "overwrite": {
+                    "description": "是否覆盖已有的手动菜单",
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.ConfirmMenuGenerationResponse": {
+            "type": "object",
+            "properties": {
+                "generation_id": {
+                    "type": "string"
+                },
+                "menus": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MenuDetail"
+                    }
+                }
+            }
+        },
+        "models.CookingScheduleResponse": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "equipment": {
+                    "description": "参与排程的厨具数量",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "meal_type": {
+                    "type": "string"
+                },
+                "menu_id": {
+                    "type": "string"
+                },
+                "saved_minutes": {
+                    "description": "节省的时间",
+                    "type": "integer"
+                },
+                "sequential_minutes": {
+                    "description": "逐道菜依次做完的用时",
+                    "type": "integer"
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ScheduledStep"
+                    }
+                },
+                "total_minutes": {
+                    "description": "排程后的总用时",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CookingStep": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "duration_minutes": {
+                    "type": "integer"
+                },
+                "equipment": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "order": {
+                    "type": "integer"
+                },
+                "passive": {
+                    "type": "boolean"
+                },
+                "step_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CookingStepInput": {
+            "type": "object",
+            "required": [
+                "content",
+                "order"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "duration_minutes": {
+                    "description": "步骤时长（分钟），不填表示未设置",
+                    "type": "integer",
+                    "maximum": 1440,
+                    "minimum": 0
+                },
+                "equipment": {
+                    "description": "所需厨具：wok、oven、burner",
+                    "type": "string",
+                    "enum": [
+                        "wok",
+                        "oven",
+                        "burner"
+                    ]
+                },
+                "image_url": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "order": {
+                    "type": "integer",
+                    "maximum": 200,
+                    "minimum": 1
+                },
+                "passive": {
+                    "description": "是否无需看管（炖煮、烤制、腌制等）",
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.CreateDishRequest": {
+            "type": "object",
+            "required": [
+                "ingredients",
+                "name",
+                "steps"
+            ],
+            "properties": {
+                "category": {
+                    "description": "分类，保存时自动关联同名标签，不存在则创建为自定义标签",
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "cook_minutes": {
+                    "description": "烹饪时间（分钟）",
+                    "type": "integer",
+                    "maximum": 1440,
+                    "minimum": 0
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "difficulty": {
+                    "description": "难度：easy、medium、hard",
+                    "type": "string",
+                    "enum": [
+                        "easy",
+                        "medium",
+                        "hard"
+                    ]
+                },
+                "image_url": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "ingredients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.IngredientInput"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "prep_minutes": {
+                    "description": "备料时间（分钟）",
+                    "type": "integer",
+                    "maximum": 1440,
+                    "minimum": 0
+                },
+                "servings": {
+                    "description": "默认份数，不填为2",
+                    "type": "integer",
+                    "maximum": 50,
+                    "minimum": 1
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CookingStepInput"
+                    }
+                },
+                "tag_ids": {
+                    "description": "标签ID",
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.CreateDishTagRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "example": "宝宝辅食"
+                }
+            }
+        },
+        "models.CreateFamilyInvitationRequest": {
+            "type": "object",
+            "properties": {
+                "expires_in_hours": {
+                    "description": "有效时长（小时），默认24",
+                    "type": "integer",
+                    "maximum": 168,
+                    "minimum": 1,
+                    "example": 24
+                },
+                "max_uses": {
+                    "description": "最大使用次数，默认1",
+                    "type": "integer",
+                    "maximum": 10,
+                    "minimum": 1,
+                    "example": 1
+                },
+                "target_phone": {
+                    "description": "指定被邀请人手机号，可选",
+                    "type": "string",
+                    "example": "13800138000"
+                }
+            }
+        },
+        "models.CreateFamilyRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "description": "家庭描述，可选",
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "温馨的家庭"
+                },
+                "name": {
+                    "description": "家庭名称",
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "张家的厨房"
+                }
+            }
+        },
+        "models.CreateHealthRecordRequest": {
+            "type": "object",
+            "properties": {
+                "body_feelings": {
+                    "description": "身体感受",
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "最近睡眠不好，容易疲劳"
+                },
+                "diseases": {
+                    "description": "疾病或不适",
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "高血压",
+                        "胃炎"
+                    ]
+                },
+                "stress_level": {
+                    "description": "压力水平",
+                    "type": "string",
+                    "enum": [
+                        "low",
+                        "medium",
+                        "high"
+                    ],
+                    "example": "medium"
+                },
+                "work_status": {
+                    "description": "工作繁忙程度",
+                    "type": "string",
+                    "enum": [
+                        "relaxed",
+                        "normal",
+                        "busy",
+                        "very_busy"
+                    ],
+                    "example": "busy"
+                }
+            }
+        },
+        "models.CreateMenuRequest": {
+            "type": "object",
+            "required": [
+                "date",
+                "dish_ids",
+                "meal_type"
+            ],
+            "properties": {
+                "date": {
+                    "description": "日期，格式：YYYY-MM-DD",
+                    "type": "string"
+                },
+                "dish_ids": {
+                    "description": "菜式ID列表，至少1个",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "dish_servings": {
+                    "description": "单个菜式的用餐人数，键为菜式ID",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "meal_type": {
+                    "description": "餐次",
+                    "type": "string",
+                    "enum": [
+                        "breakfast",
+                        "lunch",
+                        "dinner"
+                    ]
+                },
+                "servings": {
+                    "description": "用餐人数，不填按各菜式默认份数",
+                    "type": "integer",
+                    "maximum": 50,
+                    "minimum": 1
+                }
+            }
+        },
+        "models.DailyMenuResponse": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "menus": {
+                    "description": "三餐菜单（早餐、午餐、晚餐）",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MenuDetail"
+                    }
+                }
+            }
+        },
+        "models.DishCreateResponse": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "cook_minutes": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "difficulty": {
+                    "type": "string"
+                },
+                "dish_id": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "ingredients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Ingredient"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prep_minutes": {
+                    "type": "integer"
+                },
+                "servings": {
+                    "type": "integer"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DishTag"
+                    }
+                },
+                "total_minutes": {
+                    "description": "备料与烹饪时间之和",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.DishDeleteImpactResponse": {
+            "type": "object",
+            "properties": {
+                "dish_id": {
+                    "type": "string"
+                },
+                "future_menus": {
+                    "description": "今天及以后的菜单",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DishMenuUsage"
+                    }
+                },
+                "in_use": {
+                    "description": "为 true 时删除需传 force=true",
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "past_menus": {
+                    "description": "今天以前的菜单",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DishMenuUsage"
+                    }
+                }
+            }
+        },
+        "models.DishDetailResponse": {
+            "type": "object",
+            "properties": {
+                "base_servings": {
+                    "description": "菜式默认份数",
+                    "type": "integer"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "cook_minutes": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "difficulty": {
+                    "type": "string"
+                },
+                "dish_id": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "ingredients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Ingredient"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prep_minutes": {
+                    "type": "integer"
+                },
+                "servings": {
+                    "description": "食材用量对应的份数",
+                    "type": "integer"
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CookingStep"
+                    }
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DishTag"
+                    }
+                },
+                "total_minutes": {
+                    "description": "备料与烹饪时间之和",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.DishFieldChange": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "description": "name, category, description, image_url, prep_minutes, cook_minutes, servings, difficulty",
+                    "type": "string"
+                },
+                "new": {},
+                "old": {}
+            }
+        },
+        "models.DishImportIngredient": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "candidates": {
+                    "description": "未匹配时的候选食材",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.IngredientSearchResult"
+                    }
+                },
+                "ingredient_id": {
+                    "description": "匹配到的食材ID",
+                    "type": "string"
+                },
+                "ingredient_name": {
+                    "description": "匹配到的食材名称",
+                    "type": "string"
+                },
+                "matched": {
+                    "description": "是否已匹配到食材库",
+                    "type": "boolean"
+                },
+                "name": {
+                    "description": "解析出的食材名称",
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "text": {
+                    "description": "JSON-LD 中的原始食材文本",
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.DishImportItem": {
+            "type": "object",
+            "properties": {
+                "dish_id": {
+                    "type": "string"
+                },
+                "ingredients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DishImportIngredient"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "跳过原因",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "created, ready, skipped",
+                    "type": "string"
+                },
+                "unmatched_count": {
+                    "description": "未匹配到食材库的食材数，这些食材不会导入",
+                    "type": "integer"
+                },
+                "unmatched_tags": {
+                    "description": "本家庭不存在的标签，不会导入",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.DishImportRequest": {
+            "type": "object",
+            "required": [
+                "data",
+                "format"
+            ],
+            "properties": {
+                "data": {
+                    "description": "导出接口返回的 data，或其他应用导出的 Recipe JSON-LD",
+                    "type": "object"
+                },
+                "dry_run": {
+                    "description": "只返回匹配结果，不创建菜式",
+                    "type": "boolean"
+                },
+                "format": {
+                    "type": "string",
+                    "enum": [
+                        "json",
+                        "jsonld"
+                    ]
+                }
+            }
+        },
+        "models.DishImportResponse": {
+            "type": "object",
+            "properties": {
+                "created_count": {
+                    "type": "integer"
+                },
+                "dishes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DishImportItem"
+                    }
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "skipped_count": {
+                    "type": "integer"
+                },
+                "unmatched_count": {
+                    "description": "全部菜式中未匹配的食材数",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.DishIngredientChange": {
+            "type": "object",
+            "properties": {
+                "change": {
+                    "description": "added, removed, modified",
+                    "type": "string"
+                },
+                "fields": {
+                    "description": "修改的字段：amount, notes, sort_order",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "ingredient_id": {
+                    "type": "string"
+                },
+                "ingredient_name": {
+                    "type": "string"
+                },
+                "new": {
+                    "$ref": "#/definitions/models.Ingredient"
+                },
+                "old": {
+                    "$ref": "#/definitions/models.Ingredient"
+                },
+                "unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.DishListResponse": {
+            "type": "object",
+            "properties": {
+                "dishes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DishSummary"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.DishMenuUsage": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "description": "格式：YYYY-MM-DD",
+                    "type": "string"
+                },
+                "meal_type": {
+                    "description": "breakfast, lunch, dinner",
+                    "type": "string"
+                },
+                "menu_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.DishRevision": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "create, update, restore",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "dish_id": {
+                    "type": "string"
+                },
+                "edited_by": {
+                    "type": "string"
+                },
+                "editor_name": {
+                    "type": "string"
+                },
+                "restored_from": {
+                    "description": "恢复自哪个版本号",
+                    "type": "integer"
+                },
+                "revision_id": {
+                    "type": "string"
+                },
+                "revision_no": {
+                    "type": "integer"
+                },
+                "snapshot": {
+                    "description": "列表中不返回",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.DishSnapshot"
+                        }
+                    ]
+                }
+            }
+        },
+        "models.DishRevisionDiffResponse": {
+            "type": "object",
+            "properties": {
+                "dish_id": {
+                    "type": "string"
+                },
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DishFieldChange"
+                    }
+                },
+                "from": {
+                    "type": "integer"
+                },
+                "ingredients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DishIngredientChange"
+                    }
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DishStepChange"
+                    }
+                },
+                "tags_added": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DishTag"
+                    }
+                },
+                "tags_removed": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DishTag"
+                    }
+                },
+                "to": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.DishRevisionListResponse": {
+            "type": "object",
+            "properties": {
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "revisions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DishRevision"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.DishSnapshot": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "cook_minutes": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "difficulty": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "ingredients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Ingredient"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prep_minutes": {
+                    "type": "integer"
+                },
+                "servings": {
+                    "type": "integer"
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CookingStep"
+                    }
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DishTag"
+                    }
+                }
+            }
+        },
+        "models.DishStepChange": {
+            "type": "object",
+            "properties": {
+                "change": {
+                    "description": "added, removed, modified",
+                    "type": "string"
+                },
+                "fields": {
+                    "description": "修改的字段：content, image_url, duration_minutes, passive, equipment",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "new": {
+                    "$ref": "#/definitions/models.CookingStep"
+                },
+                "old": {
+                    "$ref": "#/definitions/models.CookingStep"
+                },
+                "order": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.DishSummary": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "cook_minutes": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "difficulty": {
+                    "type": "string"
+                },
+                "dish_id": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "meal_servings": {
+                    "description": "本餐准备的份数，仅在菜单中返回",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prep_minutes": {
+                    "type": "integer"
+                },
+                "servings": {
+                    "type": "integer"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DishTag"
+                    }
+                },
+                "total_minutes": {
+                    "description": "备料与烹饪时间之和",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.DishTag": {
+            "type": "object",
+            "properties": {
+                "group": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "tag_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.DishTagGroup": {
+            "type": "object",
+            "properties": {
+                "group": {
+                    "description": "main_ingredient, flavor, custom",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DishTag"
+                    }
+                }
+            }
+        },
+        "models.DishTagListResponse": {
+            "type": "object",
+            "properties": {
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DishTagGroup"
+                    }
+                }
+            }
+        },
+        "models.DishTransferBundle": {
+            "type": "object",
+            "properties": {
+                "dishes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PortableDish"
+                    }
+                },
+                "exported_at": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "onetaste.dishes"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.DishTrashListResponse": {
+            "type": "object",
+            "properties": {
+                "dishes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TrashedDish"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.DissolveFamilyRequest": {
+            "type": "object",
+            "required": [
+                "confirm_name"
+            ],
+            "properties": {
+                "confirm_name": {
+                    "description": "再次输入家庭名称以确认解散",
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "张家的厨房"
+                }
+            }
+        },
+        "models.FamilyDissolveResponse": {
+            "type": "object",
+            "properties": {
+                "dissolved_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "family_id": {
+                    "type": "string",
+                    "example": "01HZX1YF8Y6S7K4V9Q2J3M5N6P"
+                },
+                "purge_after": {
+                    "description": "超过该时间后数据将被清理，此前可导出",
+                    "type": "string",
+                    "example": "2024-01-31T00:00:00Z"
+                }
+            }
+        },
+        "models.FamilyExportBundle": {
+            "type": "object",
+            "properties": {
+                "dishes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DishDetailResponse"
+                    }
+                },
+                "exported_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "family": {
+                    "$ref": "#/definitions/models.FamilyExportInfo"
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FamilyExportMember"
+                    }
+                },
+                "menus": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MenuExport"
+                    }
+                },
+                "shopping_lists": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ShoppingListExport"
+                    }
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.FamilyExportInfo": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "dissolved_at": {
+                    "type": "string"
+                },
+                "family_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "owner_id": {
+                    "type": "string"
+                },
+                "purge_after": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.FamilyExportMember": {
+            "type": "object",
+            "properties": {
+                "joined_at": {
+                    "type": "string"
+                },
+                "nickname": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FamilyInfoResponse": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "温馨的家庭"
+                },
+                "dish_count": {
+                    "type": "integer",
+                    "example": 15
+                },
+                "family_id": {
+                    "type": "string",
+                    "example": "01HZX1YF8Y6S7K4V9Q2J3M5N6P"
+                },
+                "max_dishes": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "member_count": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "name": {
+                    "type": "string",
+                    "example": "张家的厨房"
+                },
+                "owner_id": {
+                    "type": "string",
+                    "example": "01HZX1YF8Y6S7K4V9Q2J3M5N6Q"
+                }
+            }
+        },
+        "models.FamilyInvitationPreview": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "family_name": {
+                    "type": "string",
+                    "example": "张家的厨房"
+                },
+                "inviter_avatar": {
+                    "type": "string"
+                },
+                "inviter_nickname": {
+                    "type": "string",
+                    "example": "张三"
+                },
+                "member_count": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "requires_phone": {
+                    "description": "是否仅限指定手机号加入",
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.FamilyInvitationResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "invitation_id": {
+                    "type": "string",
+                    "example": "01HZX1YF8Y6S7K4V9Q2J3M5N6S"
+                },
+                "inviter_id": {
+                    "type": "string",
+                    "example": "01HZX1YF8Y6S7K4V9Q2J3M5N6Q"
+                },
+                "inviter_nickname": {
+                    "type": "string",
+                    "example": "张三"
+                },
+                "max_uses": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "status": {
+                    "description": "active, expired, revoked, exhausted",
+                    "type": "string",
+                    "example": "active"
+                },
+                "target_phone": {
+                    "type": "string",
+                    "example": "138****8000"
+                },
+                "token": {
+                    "description": "仅创建时返回，用于生成二维码",
+                    "type": "string"
+                },
+                "used_count": {
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "models.FamilyInviteRequest": {
+            "type": "object",
+            "required": [
+                "action",
+                "token"
+            ],
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "accept"
+                },
+                "token": {
+                    "description": "二维码中的邀请令牌",
+                    "type": "string",
+                    "maxLength": 128,
+                    "example": "01HZX1YF8Y6S7K4V9Q2J3M5N6S.q3Zk..."
+                }
+            }
+        },
+        "models.FamilyJoinResponse": {
+            "type": "object",
+            "properties": {
+                "family_id": {
+                    "type": "string",
+                    "example": "01HZX1YF8Y6S7K4V9Q2J3M5N6P"
+                },
+                "joined_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "member_role": {
+                    "type": "string",
+                    "example": "member"
+                }
+            }
+        },
+        "models.FamilyMemberInfo": {
+            "type": "object",
+            "properties": {
+                "avatar": {
+                    "type": "string",
+                    "example": "https://.../avatar.jpg"
+                },
+                "joined_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "nickname": {
+                    "type": "string",
+                    "example": "李四"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "member"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "01HZX1YF8Y6S7K4V9Q2J3M5N6R"
+                }
+            }
+        },
+        "models.FamilyResponse": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "温馨的家庭"
+                },
+                "family_id": {
+                    "type": "string",
+                    "example": "01HZX1YF8Y6S7K4V9Q2J3M5N6P"
+                },
+                "max_dishes": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "member_count": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "张家的厨房"
+                }
+            }
+        },
+        "models.GenerateMenuRequest": {
+            "type": "object",
+            "required": [
+                "end_date",
+                "start_date"
+            ],
+            "properties": {
+                "end_date": {
+                    "description": "结束日期，格式：YYYY-MM-DD，最多14天",
+                    "type": "string",
+                    "example": "2024-01-07"
+                },
+                "meal_types": {
+                    "description": "餐次，为空表示三餐",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "breakfast",
+                        "lunch",
+                        "dinner"
+                    ]
+                },
+                "member_ids": {
+                    "description": "参与的家庭成员ID，为空表示全部成员",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "start_date": {
+                    "description": "开始日期，格式：YYYY-MM-DD",
+                    "type": "string",
+                    "example": "2024-01-01"
+                }
+            }
+        },
+        "models.GenerateShoppingListRequest": {
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "description": "结束日期，格式：YYYY-MM-DD",
+                    "type": "string"
+                },
+                "menu_ids": {
+                    "description": "指定菜单ID列表，提供时忽略日期范围",
+                    "type": "array",
+                    "maxItems": 93,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "description": "清单名称，可选",
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "start_date": {
+                    "description": "开始日期，格式：YYYY-MM-DD",
+                    "type": "string"
+                }
+            }
+        },
+        "models.HealthRecord": {
+            "type": "object",
+            "properties": {
+                "analysis_result": {
+                    "type": "string"
+                },
+                "analyzed_at": {
+                    "type": "string"
+                },
+                "body_feelings": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "diet_suggestions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "diseases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "family_id": {
+                    "type": "string"
+                },
+                "raw_data": {
+                    "type": "string"
+                },
+                "recommendations": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "record_id": {
+                    "type": "string"
+                },
+                "stress_level": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "work_status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.HealthRecordAnalysisResponse": {
+            "type": "object",
+            "properties": {
+                "record": {
+                    "$ref": "#/definitions/models.HealthRecord"
+                },
+                "remaining_count": {
+                    "description": "今日剩余分析次数，-1表示不限",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.HealthRecordListResponse": {
+            "type": "object",
+            "properties": {
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "records": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.HealthRecord"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.HealthSharingRequest": {
+            "type": "object",
+            "required": [
+                "shared"
+            ],
+            "properties": {
+                "shared": {
+                    "description": "是否允许家庭管理员查看本人的记录",
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.HealthSharingResponse": {
+            "type": "object",
+            "properties": {
+                "shared": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.Ingredient": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "default_unit": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ingredient_id": {
+                    "type": "string"
+                },
+                "ingredient_name": {
+                    "type": "string"
+                },
+                "ingredient_name_en": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "sort_order": {
+                    "type": "integer"
+                },
+                "storage_days": {
+                    "type": "integer"
+                },
+                "unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.IngredientCategoryListResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.IngredientSearchResult"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.IngredientInput": {
+            "type": "object",
+            "required": [
+                "amount",
+                "ingredient_id",
+                "unit"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "ingredient_id": {
+                    "type": "string",
+                    "maxLength": 26,
+                    "minLength": 1
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "sort_order": {
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 0
+                },
+                "unit": {
+                    "type": "string",
+                    "maxLength": 20
+                }
+            }
+        },
+        "models.IngredientSearchResult": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "default_unit": {
+                    "type": "string"
+                },
+                "ingredient_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "storage_days": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.LeaveFamilyRequest": {
+            "type": "object",
+            "properties": {
+                "new_owner_id": {
+                    "description": "owner退出时必须指定新owner",
+                    "type": "string",
+                    "example": "01HZX1YF8Y6S7K4V9Q2J3M5N6R"
+                }
+            }
+        },
+        "models.LoginGuardStatus": {
+            "description": "登录失败后的限制信息",
+            "type": "object",
+            "properties": {
+                "captcha_required": {
+                    "description": "下次登录是否需要图形验证码",
+                    "type": "boolean",
+                    "example": true
+                },
+                "remaining_attempts": {
+                    "description": "账号被锁定前剩余的尝试次数",
+                    "type": "integer",
+                    "example": 5
+                },
+                "retry_after": {
+                    "description": "需等待的秒数",
+                    "type": "integer",
+                    "example": 60
+                }
+            }
+        },
+        "models.LoginRequest": {
+            "description": "用户登录请求参数",
+            "type": "object",
+            "required": [
+                "password",
+                "phone"
+            ],
+            "properties": {
+                "captcha_key": {
+                    "description": "验证码编码，多次登录失败后必填",
+                    "type": "string",
+                    "example": "01J0XYZABCD1234EFG567HIJK"
+                },
+                "password": {
+                    "description": "密码",
+                    "type": "string",
+                    "example": "password123"
+                },
+                "phone": {
+                    "description": "手机号",
+                    "type": "string",
+                    "example": "13800138000"
+                },
+                "verify_code": {
+                    "description": "图形验证码内容，多次登录失败后必填",
+                    "type": "string",
+                    "example": "A9d3"
+                }
+            }
+        },
+        "models.LoginResponse": {
+            "description": "用户登录成功返回的数据",
+            "type": "object",
+            "properties": {
+                "expires_in": {
+                    "description": "Token过期时间（秒）",
+                    "type": "integer",
+                    "example": 900
+                },
+                "refresh_expires_in": {
+                    "description": "刷新令牌过期时间（秒）",
+                    "type": "integer",
+                    "example": 2592000
+                },
+                "refresh_token": {
+                    "description": "刷新令牌，每次刷新后轮换",
+                    "type": "string",
+                    "example": "q3Zk2lV0d1cR8aXyN5mP7tB4uE6wH9jK0sL2fG3hA1c"
+                },
+                "token": {
+                    "description": "JWT Token",
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "user_id": {
+                    "description": "用户ID",
+                    "type": "string",
+                    "example": "01HZX1YF8Y6S7K4V9Q2J3M5N6P"
+                }
+            }
+        },
+        "models.LogoutOthersResponse": {
+            "type": "object",
+            "properties": {
+                "revoked_count": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "models.MediaUploadResponse": {
+            "type": "object",
+            "properties": {
+                "bucket": {
+                    "type": "string"
+                },
+                "content_type": {
+                    "type": "string"
+                },
+                "directory": {
+                    "type": "string"
+                },
+                "filename": {
+                    "type": "string"
+                },
+                "object_key": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MembershipInfo": {
+            "description": "用户会员信息",
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "description": "到期时间，免费版为null",
+                    "type": "string",
+                    "example": "2024-12-31T23:59:59Z"
+                },
+                "type": {
+                    "description": "会员类型：free-免费版，premium-付费版",
+                    "type": "string",
+                    "example": "free"
+                }
+            }
+        },
+        "models.MembershipPlan": {
+            "description": "可购买的会员套餐",
+            "type": "object",
+            "properties": {
+                "amount_cents": {
+                    "description": "价格（分）",
+                    "type": "integer",
+                    "example": 1500
+                },
+                "name": {
+                    "description": "套餐名称",
+                    "type": "string",
+                    "example": "月度会员"
+                },
+                "plan_type": {
+                    "description": "套餐类型：monthly-月付，yearly-年付",
+                    "type": "string",
+                    "example": "monthly"
+                }
+            }
+        },
+        "models.MembershipResponse": {
+            "description": "当前用户的会员类型、到期时间及权益",
+            "type": "object",
+            "properties": {
+                "dish_count": {
+                    "description": "家庭当前菜式数量",
+                    "type": "integer",
+                    "example": 15
+                },
+                "expires_at": {
+                    "description": "到期时间，免费版为null",
+                    "type": "string",
+                    "example": "2024-02-15T00:00:00Z"
+                },
+                "family_id": {
+                    "description": "权益生效的家庭ID",
+                    "type": "string",
+                    "example": "01HZX1YF8Y6S7K4V9Q2J3M5N6P"
+                },
+                "features": {
+                    "description": "会员权益",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.MembershipTier"
+                        }
+                    ]
+                },
+                "plan_type": {
+                    "description": "当前套餐：monthly-月付，yearly-年付",
+                    "type": "string",
+                    "example": "monthly"
+                },
+                "type": {
+                    "description": "会员类型：free-免费版，premium-付费版",
+                    "type": "string",
+                    "example": "premium"
+                }
+            }
+        },
+        "models.MembershipTier": {
+            "type": "object",
+            "properties": {
+                "max_dishes": {
+                    "description": "家庭最大菜式数量",
+                    "type": "integer",
+                    "example": 60
+                },
+                "type": {
+                    "description": "会员类型：free-免费版，premium-付费版",
+                    "type": "string",
+                    "example": "premium"
+                }
+            }
+        },
+        "models.MenuCreateResponse": {
             "type": "object",
             "properties": {
-                "captcha_key": {
-                    "description": "验证码编码",
-                    "type": "string",
-                    "example": "01J0XYZABCD1234EFG567HIJK"
+                "date": {
+                    "type": "string"
                 },
-                "expire_in": {
-                    "description": "过期时间（秒）",
-                    "type": "integer",
-                    "example": 30
+                "dishes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DishSummary"
+                    }
                 },
-                "image_base64": {
-                    "description": "图形验证码Base64",
-                    "type": "string",
-                    "example": "data:image/png;base64,iVBORw0KGgoAAAANSUhEUg..."
+                "meal_type": {
+                    "type": "string"
+                },
+                "menu_id": {
+                    "type": "string"
+                },
+                "servings": {
+                    "type": "integer"
                 }
             }
         },
-        "models.CookingStep": {
+        "models.MenuDetail": {
             "type": "object",
             "properties": {
-                "content": {
+                "created_at": {
                     "type": "string"
                 },
-                "image_url": {
+                "created_by": {
                     "type": "string"
                 },
-                "order": {
+                "date": {
+                    "description": "格式：YYYY-MM-DD",
+                    "type": "string"
+                },
+                "dishes": {
+                    "description": "菜式列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DishSummary"
+                    }
+                },
+                "family_id": {
+                    "type": "string"
+                },
+                "meal_type": {
+                    "description": "breakfast, lunch, dinner",
+                    "type": "string"
+                },
+                "menu_id": {
+                    "type": "string"
+                },
+                "servings": {
+                    "description": "用餐人数，0表示按各菜式默认份数",
                     "type": "integer"
                 },
-                "step_id": {
+                "source": {
+                    "type": "string"
+                },
+                "total_minutes": {
+                    "description": "预计用时（分钟），按一人依次制作各菜式累计",
+                    "type": "integer"
+                },
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "models.CookingStepInput": {
+        "models.MenuExport": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "date": {
+                    "description": "格式：YYYY-MM-DD",
+                    "type": "string"
+                },
+                "dish_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "meal_type": {
+                    "type": "string"
+                },
+                "menu_id": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MenuGenerationConflictResponse": {
+            "type": "object",
+            "properties": {
+                "conflicts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MenuGenerationSlot"
+                    }
+                }
+            }
+        },
+        "models.MenuGenerationResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "generation_id": {
+                    "type": "string"
+                },
+                "menus": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MenuGenerationSlot"
+                    }
+                },
+                "reason": {
+                    "description": "整体推荐理由",
+                    "type": "string"
+                },
+                "remaining_count": {
+                    "description": "本周期剩余生成次数，-1表示不限",
+                    "type": "integer"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MenuGenerationSlot": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "description": "格式：YYYY-MM-DD",
+                    "type": "string"
+                },
+                "dishes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DishSummary"
+                    }
+                },
+                "existing_menu_id": {
+                    "description": "该餐已有的菜单ID",
+                    "type": "string"
+                },
+                "existing_source": {
+                    "description": "已有菜单来源，manual 需确认覆盖",
+                    "type": "string"
+                },
+                "meal_type": {
+                    "description": "breakfast, lunch, dinner",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "推荐理由",
+                    "type": "string"
+                }
+            }
+        },
+        "models.MenuUpdateResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "date": {
+                    "description": "格式：YYYY-MM-DD",
+                    "type": "string"
+                },
+                "dishes": {
+                    "description": "菜式列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DishSummary"
+                    }
+                },
+                "family_id": {
+                    "type": "string"
+                },
+                "meal_type": {
+                    "description": "breakfast, lunch, dinner",
+                    "type": "string"
+                },
+                "menu_id": {
+                    "type": "string"
+                },
+                "servings": {
+                    "description": "用餐人数，0表示按各菜式默认份数",
+                    "type": "integer"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "total_minutes": {
+                    "description": "预计用时（分钟），按一人依次制作各菜式累计",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MockPayRequest": {
             "type": "object",
             "required": [
-                "content",
-                "order"
+                "order_no"
             ],
             "properties": {
-                "content": {
+                "order_no": {
+                    "description": "订单号",
                     "type": "string",
-                    "maxLength": 2000
+                    "maxLength": 50,
+                    "example": "OT01J0XYZABCD1234EFG567HIJK"
                 },
-                "image_url": {
+                "status": {
+                    "description": "模拟的支付结果，默认success",
                     "type": "string",
-                    "maxLength": 500
+                    "enum": [
+                        "success",
+                        "failed"
+                    ],
+                    "example": "success"
+                }
+            }
+        },
+        "models.PaymentOrderListResponse": {
+            "type": "object",
+            "properties": {
+                "orders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PaymentOrderResponse"
+                    }
                 },
-                "order": {
-                    "type": "integer",
-                    "maximum": 200,
-                    "minimum": 1
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "models.CreateDishRequest": {
+        "models.PaymentOrderResponse": {
+            "description": "支付订单信息，payment 为客户端拉起支付所需参数（仅待支付订单返回）",
             "type": "object",
-            "required": [
-                "ingredients",
-                "name",
-                "steps"
-            ],
             "properties": {
-                "category": {
-                    "type": "string",
-                    "maxLength": 50
+                "amount_cents": {
+                    "description": "金额（分）",
+                    "type": "integer",
+                    "example": 1500
                 },
-                "description": {
+                "created_at": {
                     "type": "string",
-                    "maxLength": 2000
+                    "example": "2024-01-01T00:00:00Z"
                 },
-                "image_url": {
+                "expires_at": {
                     "type": "string",
-                    "maxLength": 500
+                    "example": "2024-01-01T00:30:00Z"
                 },
-                "ingredients": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.IngredientInput"
-                    }
+                "order_no": {
+                    "type": "string",
+                    "example": "OT01J0XYZABCD1234EFG567HIJK"
                 },
-                "name": {
+                "paid_at": {
                     "type": "string",
-                    "maxLength": 100
+                    "example": "2024-01-01T00:05:00Z"
                 },
-                "steps": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.CookingStepInput"
+                "payment": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
                     }
-                }
-            }
-        },
-        "models.CreateFamilyRequest": {
-            "type": "object",
-            "required": [
-                "name"
-            ],
-            "properties": {
-                "description": {
-                    "description": "家庭描述，可选",
+                },
+                "payment_method": {
                     "type": "string",
-                    "maxLength": 500,
-                    "example": "温馨的家庭"
+                    "example": "mock"
                 },
-                "name": {
-                    "description": "家庭名称",
+                "plan_type": {
                     "type": "string",
-                    "maxLength": 100,
-                    "example": "张家的厨房"
+                    "example": "monthly"
+                },
+                "refunded_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态：pending-待支付，paid-已支付，expired-已过期，refunding-退款中，refunded-已退款，failed-失败",
+                    "type": "string",
+                    "example": "pending"
                 }
             }
         },
-        "models.CreateMenuRequest": {
+        "models.PlanMenuRequest": {
             "type": "object",
             "required": [
-                "date",
-                "dish_ids",
-                "meal_type"
+                "end_date",
+                "start_date"
             ],
             "properties": {
-                "date": {
-                    "description": "日期，格式：YYYY-MM-DD",
-                    "type": "string"
+                "end_date": {
+                    "description": "结束日期，格式：YYYY-MM-DD，最多14天",
+                    "type": "string",
+                    "example": "2024-01-07"
                 },
-                "dish_ids": {
-                    "description": "菜式ID列表，至少1个",
+                "excluded_dish_ids": {
+                    "description": "不参与安排的菜式",
                     "type": "array",
-                    "minItems": 1,
+                    "maxItems": 200,
                     "items": {
                         "type": "string"
                     }
                 },
-                "meal_type": {
-                    "description": "餐次",
-                    "type": "string",
-                    "enum": [
+                "meal_types": {
+                    "description": "餐次，为空表示三餐",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
                         "breakfast",
                         "lunch",
                         "dinner"
                     ]
+                },
+                "no_repeat_days": {
+                    "description": "同一菜式间隔天数，默认3天",
+                    "type": "integer",
+                    "maximum": 14,
+                    "minimum": 1,
+                    "example": 3
+                },
+                "pinned_dish_ids": {
+                    "description": "必须安排的菜式，每道至少出现一次",
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "start_date": {
+                    "description": "开始日期，格式：YYYY-MM-DD",
+                    "type": "string",
+                    "example": "2024-01-01"
                 }
             }
         },
-        "models.DailyMenuResponse": {
+        "models.PlanMenuResponse": {
             "type": "object",
             "properties": {
-                "date": {
+                "end_date": {
                     "type": "string"
                 },
                 "menus": {
-                    "description": "三餐菜单（早餐、午餐、晚餐）",
+                    "description": "仅包含原本为空的餐次",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.MenuDetail"
+                        "$ref": "#/definitions/models.PlannedMenu"
+                    }
+                },
+                "no_repeat_days": {
+                    "type": "integer"
+                },
+                "skipped_slots": {
+                    "description": "已有菜单而跳过的餐次数",
+                    "type": "integer"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "warnings": {
+                    "description": "无法完全满足规则时的提示",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
                     }
                 }
             }
         },
-        "models.DishCreateResponse": {
+        "models.PlannedMenu": {
             "type": "object",
             "properties": {
-                "category": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "dish_id": {
-                    "type": "string"
-                },
-                "image_url": {
+                "date": {
+                    "description": "格式：YYYY-MM-DD",
                     "type": "string"
                 },
-                "ingredients": {
+                "dishes": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.Ingredient"
+                        "$ref": "#/definitions/models.DishSummary"
                     }
                 },
-                "name": {
+                "meal_type": {
+                    "description": "breakfast, lunch, dinner",
                     "type": "string"
                 }
             }
         },
-        "models.DishDetailResponse": {
+        "models.PortableDish": {
             "type": "object",
             "properties": {
                 "category": {
                     "type": "string"
                 },
-                "created_at": {
-                    "type": "string"
+                "cook_minutes": {
+                    "type": "integer"
                 },
                 "description": {
                     "type": "string"
                 },
-                "dish_id": {
+                "difficulty": {
                     "type": "string"
                 },
                 "image_url": {
@@ -1657,541 +8728,612 @@ const docTemplate = `{
                 "ingredients": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.Ingredient"
+                        "$ref": "#/definitions/models.PortableIngredient"
                     }
                 },
                 "name": {
                     "type": "string"
                 },
+                "prep_minutes": {
+                    "type": "integer"
+                },
+                "servings": {
+                    "type": "integer"
+                },
                 "steps": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.CookingStep"
+                        "$ref": "#/definitions/models.CookingStepInput"
                     }
                 },
-                "updated_at": {
-                    "type": "string"
-                }
-            }
-        },
-        "models.DishListResponse": {
-            "type": "object",
-            "properties": {
-                "dishes": {
+                "tags": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.DishSummary"
+                        "type": "string"
                     }
-                },
-                "page": {
-                    "type": "integer"
-                },
-                "page_size": {
-                    "type": "integer"
-                },
-                "total": {
-                    "type": "integer"
                 }
             }
         },
-        "models.DishSummary": {
+        "models.PortableIngredient": {
             "type": "object",
             "properties": {
-                "category": {
-                    "type": "string"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
+                "amount": {
+                    "type": "number"
                 },
-                "dish_id": {
+                "ingredient_id": {
+                    "description": "基础食材ID，导入时优先按ID匹配",
                     "type": "string"
                 },
-                "image_url": {
+                "name": {
                     "type": "string"
                 },
-                "name": {
+                "notes": {
                     "type": "string"
                 },
-                "updated_at": {
+                "unit": {
                     "type": "string"
                 }
             }
         },
-        "models.FamilyInfoResponse": {
+        "models.RefreshTokenRequest": {
             "type": "object",
+            "required": [
+                "refresh_token"
+            ],
             "properties": {
-                "description": {
+                "refresh_token": {
+                    "description": "登录或上次刷新返回的刷新令牌",
                     "type": "string",
-                    "example": "温馨的家庭"
+                    "maxLength": 128,
+                    "example": "q3Zk2lV0d1cR8aXyN5mP7tB4uE6wH9jK0sL2fG3hA1c"
+                }
+            }
+        },
+        "models.RegisterRequest": {
+            "description": "用户注册请求参数",
+            "type": "object",
+            "required": [
+                "captcha_key",
+                "nickname",
+                "password",
+                "phone",
+                "verify_code"
+            ],
+            "properties": {
+                "captcha_key": {
+                    "description": "验证码编码",
+                    "type": "string",
+                    "example": "01J0XYZABCD1234EFG567HIJK"
                 },
-                "dish_count": {
-                    "type": "integer",
-                    "example": 15
+                "nickname": {
+                    "description": "昵称",
+                    "type": "string",
+                    "example": "张三"
                 },
-                "family_id": {
+                "password": {
+                    "description": "密码，至少6位",
                     "type": "string",
-                    "example": "01HZX1YF8Y6S7K4V9Q2J3M5N6P"
+                    "minLength": 6,
+                    "example": "password123"
                 },
-                "max_dishes": {
+                "phone": {
+                    "description": "手机号，11位数字",
+                    "type": "string",
+                    "example": "13800138000"
+                },
+                "verify_code": {
+                    "description": "图形验证码内容，不区分大小写",
+                    "type": "string",
+                    "example": "A9d3"
+                }
+            }
+        },
+        "models.RegisterResponse": {
+            "description": "用户注册成功返回的数据",
+            "type": "object",
+            "properties": {
+                "expires_in": {
+                    "description": "Token过期时间（秒）",
                     "type": "integer",
-                    "example": 30
+                    "example": 900
                 },
-                "member_count": {
+                "refresh_expires_in": {
+                    "description": "刷新令牌过期时间（秒）",
                     "type": "integer",
-                    "example": 3
+                    "example": 2592000
                 },
-                "name": {
+                "refresh_token": {
+                    "description": "刷新令牌",
                     "type": "string",
-                    "example": "张家的厨房"
+                    "example": "q3Zk2lV0d1cR8aXyN5mP7tB4uE6wH9jK0sL2fG3hA1c"
                 },
-                "owner_id": {
+                "token": {
+                    "description": "JWT Token",
                     "type": "string",
-                    "example": "01HZX1YF8Y6S7K4V9Q2J3M5N6Q"
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "user_id": {
+                    "description": "用户ID",
+                    "type": "string",
+                    "example": "01HZX1YF8Y6S7K4V9Q2J3M5N6P"
+                }
+            }
+        },
+        "models.ResetPasswordRequest": {
+            "description": "使用短信验证码重置密码的请求参数",
+            "type": "object",
+            "required": [
+                "code",
+                "new_password",
+                "phone"
+            ],
+            "properties": {
+                "code": {
+                    "description": "短信验证码",
+                    "type": "string",
+                    "example": "382915"
+                },
+                "new_password": {
+                    "description": "新密码，至少6位",
+                    "type": "string",
+                    "minLength": 6,
+                    "example": "newPassword456"
+                },
+                "phone": {
+                    "description": "手机号",
+                    "type": "string",
+                    "example": "13800138000"
+                }
+            }
+        },
+        "models.ScheduledStep": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "dish_id": {
+                    "type": "string"
+                },
+                "dish_name": {
+                    "type": "string"
+                },
+                "duration_minutes": {
+                    "type": "integer"
+                },
+                "end_minute": {
+                    "type": "integer"
+                },
+                "equipment": {
+                    "description": "占用的厨具",
+                    "type": "string"
+                },
+                "estimated": {
+                    "description": "步骤未设置时长，按默认时长估算",
+                    "type": "boolean"
+                },
+                "order": {
+                    "description": "时间线中的顺序",
+                    "type": "integer"
+                },
+                "passive": {
+                    "description": "无需看管，可同时进行其他步骤",
+                    "type": "boolean"
+                },
+                "start_minute": {
+                    "description": "相对开始烹饪的分钟数",
+                    "type": "integer"
+                },
+                "step_order": {
+                    "description": "该步骤在菜式中的序号",
+                    "type": "integer"
                 }
             }
         },
-        "models.FamilyInviteRequest": {
+        "models.SendResetCodeRequest": {
+            "description": "找回密码时发送短信验证码的请求参数",
             "type": "object",
             "required": [
-                "action",
-                "family_id",
-                "family_name",
-                "inviter_id",
-                "inviter_nickname"
+                "captcha_key",
+                "phone",
+                "verify_code"
             ],
             "properties": {
-                "action": {
-                    "type": "string",
-                    "example": "accept"
-                },
-                "family_id": {
-                    "type": "string",
-                    "example": "01HZX1YF8Y6S7K4V9Q2J3M5N6P"
-                },
-                "family_name": {
+                "captcha_key": {
+                    "description": "图形验证码编码",
                     "type": "string",
-                    "example": "张家的厨房"
+                    "example": "01J0XYZABCD1234EFG567HIJK"
                 },
-                "inviter_id": {
+                "phone": {
+                    "description": "手机号",
                     "type": "string",
-                    "example": "01HZX1YF8Y6S7K4V9Q2J3M5N6Q"
+                    "example": "13800138000"
                 },
-                "inviter_nickname": {
+                "verify_code": {
+                    "description": "图形验证码内容",
                     "type": "string",
-                    "example": "张三"
+                    "example": "A9d3"
                 }
             }
         },
-        "models.FamilyJoinResponse": {
+        "models.SendResetCodeResponse": {
+            "description": "短信验证码发送结果",
             "type": "object",
             "properties": {
-                "family_id": {
-                    "type": "string",
-                    "example": "01HZX1YF8Y6S7K4V9Q2J3M5N6P"
-                },
-                "joined_at": {
-                    "type": "string",
-                    "example": "2024-01-01T00:00:00Z"
+                "expire_in": {
+                    "description": "短信验证码有效期（秒）",
+                    "type": "integer",
+                    "example": 600
                 },
-                "member_role": {
-                    "type": "string",
-                    "example": "member"
+                "resend_in": {
+                    "description": "距离可再次发送的秒数",
+                    "type": "integer",
+                    "example": 60
                 }
             }
         },
-        "models.FamilyMemberInfo": {
+        "models.SessionInfo": {
             "type": "object",
             "properties": {
-                "avatar": {
-                    "type": "string",
-                    "example": "https://.../avatar.jpg"
-                },
-                "joined_at": {
+                "created_at": {
                     "type": "string",
                     "example": "2024-01-01T00:00:00Z"
                 },
-                "nickname": {
-                    "type": "string",
-                    "example": "李四"
+                "current": {
+                    "description": "是否为当前请求所用会话",
+                    "type": "boolean",
+                    "example": true
                 },
-                "role": {
+                "ip": {
                     "type": "string",
-                    "example": "member"
+                    "example": "192.168.1.10"
                 },
-                "user_id": {
-                    "type": "string",
-                    "example": "01HZX1YF8Y6S7K4V9Q2J3M5N6R"
-                }
-            }
-        },
-        "models.FamilyResponse": {
-            "type": "object",
-            "properties": {
-                "description": {
+                "last_active_at": {
+                    "description": "最近一次登录或刷新时间",
                     "type": "string",
-                    "example": "温馨的家庭"
+                    "example": "2024-01-02T00:00:00Z"
                 },
-                "family_id": {
+                "session_id": {
                     "type": "string",
-                    "example": "01HZX1YF8Y6S7K4V9Q2J3M5N6P"
-                },
-                "max_dishes": {
-                    "type": "integer",
-                    "example": 30
-                },
-                "member_count": {
-                    "type": "integer",
-                    "example": 1
+                    "example": "01HZX1YF8Y6S7K4V9Q2J3M5N6T"
                 },
-                "name": {
+                "user_agent": {
                     "type": "string",
-                    "example": "张家的厨房"
+                    "example": "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X)"
                 }
             }
         },
-        "models.Ingredient": {
+        "models.ShoppingListDetail": {
             "type": "object",
             "properties": {
-                "amount": {
-                    "type": "number"
+                "created_at": {
+                    "type": "string"
                 },
-                "category": {
+                "created_by": {
                     "type": "string"
                 },
-                "default_unit": {
+                "end_date": {
+                    "description": "格式：YYYY-MM-DD",
                     "type": "string"
                 },
-                "id": {
+                "family_id": {
                     "type": "string"
                 },
-                "ingredient_id": {
+                "groups": {
+                    "description": "按食材分类分组",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ShoppingListGroup"
+                    }
+                },
+                "list_id": {
                     "type": "string"
                 },
-                "ingredient_name": {
+                "name": {
                     "type": "string"
                 },
-                "ingredient_name_en": {
+                "purchased_items": {
+                    "type": "integer"
+                },
+                "source": {
+                    "description": "date_range, menus",
                     "type": "string"
                 },
-                "notes": {
+                "start_date": {
+                    "description": "格式：YYYY-MM-DD",
                     "type": "string"
                 },
-                "sort_order": {
-                    "type": "integer"
+                "status": {
+                    "description": "pending, completed",
+                    "type": "string"
                 },
-                "storage_days": {
+                "total_items": {
                     "type": "integer"
                 },
-                "unit": {
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "models.IngredientCategoryListResponse": {
+        "models.ShoppingListExport": {
             "type": "object",
             "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "end_date": {
+                    "description": "格式：YYYY-MM-DD",
+                    "type": "string"
+                },
                 "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.IngredientSearchResult"
+                        "$ref": "#/definitions/models.ShoppingListItem"
                     }
                 },
-                "page": {
-                    "type": "integer"
+                "list_id": {
+                    "type": "string"
                 },
-                "page_size": {
-                    "type": "integer"
+                "name": {
+                    "type": "string"
                 },
-                "total": {
-                    "type": "integer"
+                "source": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "description": "格式：YYYY-MM-DD",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
                 }
             }
         },
-        "models.IngredientInput": {
+        "models.ShoppingListGroup": {
             "type": "object",
-            "required": [
-                "amount",
-                "ingredient_id",
-                "unit"
-            ],
             "properties": {
-                "amount": {
-                    "type": "number"
-                },
-                "ingredient_id": {
-                    "type": "string",
-                    "maxLength": 26,
-                    "minLength": 1
-                },
-                "notes": {
-                    "type": "string",
-                    "maxLength": 255
-                },
-                "sort_order": {
-                    "type": "integer",
-                    "maximum": 1000,
-                    "minimum": 0
+                "category": {
+                    "type": "string"
                 },
-                "unit": {
-                    "type": "string",
-                    "maxLength": 20
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ShoppingListItem"
+                    }
                 }
             }
         },
-        "models.IngredientSearchResult": {
+        "models.ShoppingListItem": {
             "type": "object",
             "properties": {
                 "category": {
                     "type": "string"
                 },
-                "default_unit": {
+                "ingredient_id": {
                     "type": "string"
                 },
-                "ingredient_id": {
+                "ingredient_name": {
                     "type": "string"
                 },
-                "name": {
+                "is_manual": {
+                    "type": "boolean"
+                },
+                "item_id": {
+                    "type": "string"
+                },
+                "sort_order": {
+                    "type": "integer"
+                },
+                "status": {
                     "type": "string"
                 },
                 "storage_days": {
                     "type": "integer"
-                }
-            }
-        },
-        "models.LoginRequest": {
-            "description": "用户登录请求参数",
-            "type": "object",
-            "required": [
-                "password",
-                "phone"
-            ],
-            "properties": {
-                "password": {
-                    "description": "密码",
-                    "type": "string",
-                    "example": "password123"
                 },
-                "phone": {
-                    "description": "手机号",
-                    "type": "string",
-                    "example": "13800138000"
+                "total_amount": {
+                    "type": "number"
+                },
+                "unit": {
+                    "type": "string"
                 }
             }
         },
-        "models.LoginResponse": {
-            "description": "用户登录成功返回的数据",
+        "models.ShoppingListListResponse": {
             "type": "object",
             "properties": {
-                "expires_in": {
-                    "description": "Token过期时间（秒）",
-                    "type": "integer",
-                    "example": 86400
+                "lists": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ShoppingListSummary"
+                    }
                 },
-                "token": {
-                    "description": "JWT Token",
-                    "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                "page": {
+                    "type": "integer"
                 },
-                "user_id": {
-                    "description": "用户ID",
-                    "type": "string",
-                    "example": "01HZX1YF8Y6S7K4V9Q2J3M5N6P"
+                "page_size": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "models.MediaUploadResponse": {
+        "models.ShoppingListSummary": {
             "type": "object",
             "properties": {
-                "bucket": {
-                    "type": "string"
-                },
-                "content_type": {
+                "created_at": {
                     "type": "string"
                 },
-                "directory": {
+                "end_date": {
                     "type": "string"
                 },
-                "filename": {
+                "list_id": {
                     "type": "string"
                 },
-                "object_key": {
+                "name": {
                     "type": "string"
                 },
-                "size": {
+                "purchased_items": {
                     "type": "integer"
                 },
-                "url": {
+                "start_date": {
                     "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "total_items": {
+                    "type": "integer"
                 }
             }
         },
-        "models.MembershipInfo": {
-            "description": "用户会员信息",
+        "models.SubscribeRequest": {
+            "description": "为当前家庭购买会员套餐，创建待支付订单",
             "type": "object",
+            "required": [
+                "plan"
+            ],
             "properties": {
-                "expires_at": {
-                    "description": "到期时间，免费版为null",
+                "payment_method": {
+                    "description": "支付渠道，不传则使用服务端配置的渠道",
                     "type": "string",
-                    "example": "2024-12-31T23:59:59Z"
+                    "maxLength": 20,
+                    "example": "mock"
                 },
-                "type": {
-                    "description": "会员类型：free-免费版，premium-付费版",
+                "plan": {
+                    "description": "套餐类型：monthly-月付，yearly-年付",
                     "type": "string",
-                    "example": "free"
+                    "enum": [
+                        "monthly",
+                        "yearly"
+                    ],
+                    "example": "monthly"
                 }
             }
         },
-        "models.MenuCreateResponse": {
+        "models.TransferFamilyOwnershipRequest": {
             "type": "object",
+            "required": [
+                "user_id"
+            ],
             "properties": {
-                "date": {
-                    "type": "string"
-                },
-                "dishes": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.DishSummary"
-                    }
-                },
-                "meal_type": {
-                    "type": "string"
-                },
-                "menu_id": {
-                    "type": "string"
+                "user_id": {
+                    "description": "新owner的用户ID",
+                    "type": "string",
+                    "example": "01HZX1YF8Y6S7K4V9Q2J3M5N6R"
                 }
             }
         },
-        "models.MenuDetail": {
+        "models.TrashedDish": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "category": {
                     "type": "string"
                 },
                 "created_by": {
                     "type": "string"
                 },
-                "date": {
-                    "description": "格式：YYYY-MM-DD",
-                    "type": "string"
-                },
-                "dishes": {
-                    "description": "菜式列表",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.DishSummary"
-                    }
-                },
-                "family_id": {
+                "deleted_at": {
                     "type": "string"
                 },
-                "meal_type": {
-                    "description": "breakfast, lunch, dinner",
+                "dish_id": {
                     "type": "string"
                 },
-                "menu_id": {
+                "image_url": {
                     "type": "string"
                 },
-                "source": {
+                "name": {
                     "type": "string"
                 },
-                "updated_at": {
+                "purge_after": {
+                    "description": "超过该时间后菜式被彻底删除",
                     "type": "string"
                 }
             }
         },
-        "models.MenuUpdateResponse": {
+        "models.UnitConvertRequest": {
             "type": "object",
+            "required": [
+                "amount",
+                "from_unit"
+            ],
             "properties": {
-                "created_at": {
-                    "type": "string"
+                "amount": {
+                    "description": "数量",
+                    "type": "number"
                 },
-                "created_by": {
-                    "type": "string"
+                "from_unit": {
+                    "description": "原单位",
+                    "type": "string",
+                    "maxLength": 20
                 },
-                "date": {
-                    "description": "格式：YYYY-MM-DD",
+                "ingredient_id": {
+                    "description": "基础食材ID，提供时使用食材的密度与单个重量",
                     "type": "string"
                 },
-                "dishes": {
-                    "description": "菜式列表",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.DishSummary"
-                    }
-                },
-                "family_id": {
-                    "type": "string"
+                "to_unit": {
+                    "description": "目标单位，不传则使用食材推荐单位",
+                    "type": "string",
+                    "maxLength": 20
+                }
+            }
+        },
+        "models.UnitConvertResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
                 },
-                "meal_type": {
-                    "description": "breakfast, lunch, dinner",
+                "from_unit": {
                     "type": "string"
                 },
-                "menu_id": {
+                "ingredient_id": {
                     "type": "string"
                 },
-                "source": {
-                    "type": "string"
+                "result": {
+                    "description": "换算结果，保留两位小数",
+                    "type": "number"
                 },
-                "updated_at": {
+                "to_unit": {
                     "type": "string"
                 }
             }
         },
-        "models.RegisterRequest": {
-            "description": "用户注册请求参数",
+        "models.UnitInfo": {
             "type": "object",
-            "required": [
-                "captcha_key",
-                "nickname",
-                "password",
-                "phone",
-                "verify_code"
-            ],
             "properties": {
-                "captcha_key": {
-                    "description": "验证码编码",
-                    "type": "string",
-                    "example": "01J0XYZABCD1234EFG567HIJK"
-                },
-                "nickname": {
-                    "description": "昵称",
-                    "type": "string",
-                    "example": "张三"
+                "aliases": {
+                    "description": "别名",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "password": {
-                    "description": "密码，至少6位",
-                    "type": "string",
-                    "minLength": 6,
-                    "example": "password123"
+                "dimension": {
+                    "description": "维度：mass, volume, count",
+                    "type": "string"
                 },
-                "phone": {
-                    "description": "手机号，11位数字",
-                    "type": "string",
-                    "example": "13800138000"
+                "factor": {
+                    "description": "换算到基准单位（克、毫升、个）的系数",
+                    "type": "number"
                 },
-                "verify_code": {
-                    "description": "图形验证码内容，不区分大小写",
-                    "type": "string",
-                    "example": "A9d3"
+                "name": {
+                    "description": "标准名称",
+                    "type": "string"
                 }
             }
         },
-        "models.RegisterResponse": {
-            "description": "用户注册成功返回的数据",
+        "models.UnitListResponse": {
             "type": "object",
             "properties": {
-                "token": {
-                    "description": "JWT Token",
-                    "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
-                },
-                "user_id": {
-                    "description": "用户ID",
-                    "type": "string",
-                    "example": "01HZX1YF8Y6S7K4V9Q2J3M5N6P"
+                "units": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.UnitInfo"
+                    }
                 }
             }
         },
@@ -2204,13 +9346,29 @@ const docTemplate = `{
             ],
             "properties": {
                 "category": {
+                    "description": "分类，保存时自动关联同名标签，不存在则创建为自定义标签",
                     "type": "string",
                     "maxLength": 50
                 },
+                "cook_minutes": {
+                    "description": "烹饪时间（分钟），不传保持不变",
+                    "type": "integer",
+                    "maximum": 1440,
+                    "minimum": 0
+                },
                 "description": {
                     "type": "string",
                     "maxLength": 2000
                 },
+                "difficulty": {
+                    "description": "难度：easy、medium、hard，传空字符串清空，不传保持不变",
+                    "type": "string",
+                    "enum": [
+                        "easy",
+                        "medium",
+                        "hard"
+                    ]
+                },
                 "image_url": {
                     "type": "string",
                     "maxLength": 500
@@ -2225,11 +9383,48 @@ const docTemplate = `{
                     "type": "string",
                     "maxLength": 100
                 },
+                "prep_minutes": {
+                    "description": "备料时间（分钟），不传保持不变",
+                    "type": "integer",
+                    "maximum": 1440,
+                    "minimum": 0
+                },
+                "servings": {
+                    "description": "默认份数，不传保持不变",
+                    "type": "integer",
+                    "maximum": 50,
+                    "minimum": 1
+                },
                 "steps": {
                     "type": "array",
                     "items": {
                         "$ref": "#/definitions/models.CookingStepInput"
                     }
+                },
+                "tag_ids": {
+                    "description": "标签ID，传入时整体替换，传空数组清空，不传保持不变",
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.UpdateFamilyMemberRoleRequest": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "description": "目标角色，设为owner即转让家庭",
+                    "type": "string",
+                    "enum": [
+                        "owner",
+                        "member"
+                    ],
+                    "example": "member"
                 }
             }
         },
@@ -2248,6 +9443,13 @@ const docTemplate = `{
                         "type": "string"
                     }
                 },
+                "dish_servings": {
+                    "description": "单个菜式的用餐人数，传入时整体替换，不传保留仍在菜单中的菜式设置",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
                 "meal_type": {
                     "description": "餐次",
                     "type": "string",
@@ -2256,6 +9458,59 @@ const docTemplate = `{
                         "lunch",
                         "dinner"
                     ]
+                },
+                "servings": {
+                    "description": "用餐人数，0表示按各菜式默认份数，不传保持不变",
+                    "type": "integer",
+                    "maximum": 50,
+                    "minimum": 0
+                }
+            }
+        },
+        "models.UpdateProfileRequest": {
+            "description": "更新昵称、头像与饮食偏好，字段不传则保持不变",
+            "type": "object",
+            "properties": {
+                "avatar": {
+                    "description": "头像URL，需为通过媒体上传接口上传到 user/head/{userId} 目录下的文件",
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "http://127.0.0.1:9000/onetaste-media/user/head/01HZX.../01J0.jpg"
+                },
+                "dietary_preferences": {
+                    "description": "饮食偏好标签，传空数组表示清空",
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "少辣",
+                        "低糖"
+                    ]
+                },
+                "nickname": {
+                    "description": "昵称",
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "张三"
+                }
+            }
+        },
+        "models.UpdateShoppingItemRequest": {
+            "type": "object",
+            "required": [
+                "total_amount"
+            ],
+            "properties": {
+                "total_amount": {
+                    "description": "数量",
+                    "type": "number"
+                },
+                "unit": {
+                    "description": "单位，不传则保持不变",
+                    "type": "string",
+                    "maxLength": 20
                 }
             }
         },
@@ -2268,6 +9523,17 @@ const docTemplate = `{
                     "type": "string",
                     "example": "https://..."
                 },
+                "dietary_preferences": {
+                    "description": "饮食偏好标签",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "少辣",
+                        "低糖"
+                    ]
+                },
                 "membership": {
                     "description": "会员信息",
                     "allOf": [
@@ -2293,6 +9559,75 @@ const docTemplate = `{
                 }
             }
         },
+        "models.VoiceDishDraftResponse": {
+            "type": "object",
+            "properties": {
+                "draft": {
+                    "description": "菜式草稿，仅包含已匹配的食材",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.CreateDishRequest"
+                        }
+                    ]
+                },
+                "ingredients": {
+                    "description": "识别出的全部食材",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.VoiceDishIngredient"
+                    }
+                },
+                "remaining_count": {
+                    "description": "今日剩余语音录入次数，-1表示不限",
+                    "type": "integer"
+                },
+                "transcript": {
+                    "description": "语音转写文本",
+                    "type": "string"
+                },
+                "unmatched_count": {
+                    "description": "未匹配到食材库的食材数",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.VoiceDishIngredient": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "candidates": {
+                    "description": "未匹配时的候选食材",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.IngredientSearchResult"
+                    }
+                },
+                "ingredient_id": {
+                    "description": "匹配到的食材ID",
+                    "type": "string"
+                },
+                "ingredient_name": {
+                    "description": "匹配到的食材名称",
+                    "type": "string"
+                },
+                "matched": {
+                    "description": "是否已匹配到食材库",
+                    "type": "boolean"
+                },
+                "name": {
+                    "description": "识别出的食材名称",
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string"
+                }
+            }
+        },
         "models.WeeklyMenuResponse": {
             "type": "object",
             "properties": {
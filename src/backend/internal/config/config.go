@@ -1,21 +1,29 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
+
+	"onetaste-family/backend/pkg/aiclient/aistub"
 )
 
 // Config 应用配置结构
 type Config struct {
-	Server   ServerConfig   `yaml:"server"`
-	Database DatabaseConfig `yaml:"database"`
-	Redis    RedisConfig    `yaml:"redis"`
-	JWT      JWTConfig      `yaml:"jwt"`
-	MinIO    MinIOConfig    `yaml:"minio"`
+	Server    ServerConfig    `yaml:"server"`
+	Database  DatabaseConfig  `yaml:"database"`
+	Redis     RedisConfig     `yaml:"redis"`
+	JWT       JWTConfig       `yaml:"jwt"`
+	Invite    InviteConfig    `yaml:"invite"`
+	MinIO     MinIOConfig     `yaml:"minio"`
+	SMS       SMSConfig       `yaml:"sms"`
+	Payment   PaymentConfig   `yaml:"payment"`
+	AIService AIServiceConfig `yaml:"ai_service"`
 }
 
 // ServerConfig 服务器配置
@@ -23,6 +31,8 @@ type ServerConfig struct {
 	Host string `yaml:"host"`
 	Port int    `yaml:"port"`
 	Mode string `yaml:"mode"` // debug, release, test
+	// TrustedProxies 可信反向代理地址或网段，只有来自这些地址的请求才读取 X-Forwarded-For / X-Real-IP，为空时直接使用连接地址
+	TrustedProxies []string `yaml:"trusted_proxies"`
 }
 
 // DatabaseConfig 数据库配置
@@ -49,8 +59,14 @@ type RedisConfig struct {
 
 // JWTConfig JWT配置
 type JWTConfig struct {
-	Secret     string        `yaml:"secret"`
-	Expiration time.Duration `yaml:"expiration"`
+	Secret            string        `yaml:"secret"`
+	Expiration        time.Duration `yaml:"expiration"`         // 访问令牌有效期
+	RefreshExpiration time.Duration `yaml:"refresh_expiration"` // 刷新令牌有效期
+}
+
+// InviteConfig 家庭邀请配置
+type InviteConfig struct {
+	Secret string `yaml:"secret"` // 邀请令牌签名密钥，须与 JWT 密钥不同
 }
 
 // MinIOConfig 对象存储配置
@@ -64,6 +80,31 @@ type MinIOConfig struct {
 	BaseURL   string `yaml:"base_url"`
 }
 
+// SMSConfig 短信配置
+type SMSConfig struct {
+	Provider string `yaml:"provider"` // log：写入本地日志，仅用于开发与测试，release 模式下禁止使用；http：通过短信网关发送
+	LogFile  string `yaml:"log_file"` // log 方式下短信写入的文件，为空时输出到标准日志
+	URL      string `yaml:"url"`      // http 方式下的短信网关地址
+	APIKey   string `yaml:"api_key"`  // http 方式下调用短信网关的密钥
+}
+
+// PaymentConfig 支付配置
+type PaymentConfig struct {
+	Provider   string `yaml:"provider"`    // mock：本地模拟支付，仅用于开发与测试
+	MockSecret string `yaml:"mock_secret"` // mock 渠道回调签名密钥
+}
+
+// AIServiceConfig Python AI 服务配置
+type AIServiceConfig struct {
+	URL              string        `yaml:"url"`               // AI 服务地址，如 http://ai-service:8000
+	APIKey           string        `yaml:"api_key"`           // 调用 AI 服务的密钥，可选
+	Timeout          time.Duration `yaml:"timeout"`           // 单次请求超时，支持 "30s" 等格式
+	MaxRetries       int           `yaml:"max_retries"`       // 网络错误与 429/502/503/504 的最大重试次数
+	BreakerThreshold int           `yaml:"breaker_threshold"` // 连续失败多少次后熔断
+	BreakerCooldown  time.Duration `yaml:"breaker_cooldown"`  // 熔断持续时间
+	Stub             bool          `yaml:"stub"`              // 使用进程内替身代替真实 AI 服务，需以 -tags aistub 构建，release 模式下禁止使用
+}
+
 var AppConfig *Config
 
 // Load 加载配置文件
@@ -80,9 +121,12 @@ func Load(configPath string) error {
 
 	// 从环境变量覆盖配置（如果存在）
 	loadFromEnv()
+	ensureJWTDefaults()
 	ensureMinioDefaults()
+	ensureSMSDefaults()
+	ensureAIServiceDefaults()
 
-	return nil
+	return validateConfig()
 }
 
 // loadFromEnv 从环境变量加载配置
@@ -105,6 +149,9 @@ func loadFromEnv() {
 	if jwtSecret := os.Getenv("JWT_SECRET"); jwtSecret != "" {
 		AppConfig.JWT.Secret = jwtSecret
 	}
+	if inviteSecret := os.Getenv("INVITE_SECRET"); inviteSecret != "" {
+		AppConfig.Invite.Secret = inviteSecret
+	}
 	if minioEndpoint := os.Getenv("MINIO_ENDPOINT"); minioEndpoint != "" {
 		AppConfig.MinIO.Endpoint = minioEndpoint
 	}
@@ -134,6 +181,48 @@ func loadFromEnv() {
 	} else if minioBaseURL := os.Getenv("MINIO_BASE_URL"); minioBaseURL != "" {
 		AppConfig.MinIO.BaseURL = minioBaseURL
 	}
+	if trustedProxies := os.Getenv("TRUSTED_PROXIES"); trustedProxies != "" {
+		AppConfig.Server.TrustedProxies = splitList(trustedProxies)
+	}
+	if smsProvider := os.Getenv("SMS_PROVIDER"); smsProvider != "" {
+		AppConfig.SMS.Provider = smsProvider
+	}
+	if smsLogFile := os.Getenv("SMS_LOG_FILE"); smsLogFile != "" {
+		AppConfig.SMS.LogFile = smsLogFile
+	}
+	if smsURL := os.Getenv("SMS_URL"); smsURL != "" {
+		AppConfig.SMS.URL = smsURL
+	}
+	if smsAPIKey := os.Getenv("SMS_API_KEY"); smsAPIKey != "" {
+		AppConfig.SMS.APIKey = smsAPIKey
+	}
+	if paymentProvider := os.Getenv("PAYMENT_PROVIDER"); paymentProvider != "" {
+		AppConfig.Payment.Provider = paymentProvider
+	}
+	if paymentMockSecret := os.Getenv("PAYMENT_MOCK_SECRET"); paymentMockSecret != "" {
+		AppConfig.Payment.MockSecret = paymentMockSecret
+	}
+	if aiServiceURL := os.Getenv("AI_SERVICE_URL"); aiServiceURL != "" {
+		AppConfig.AIService.URL = aiServiceURL
+	}
+	if aiServiceAPIKey := os.Getenv("AI_SERVICE_API_KEY"); aiServiceAPIKey != "" {
+		AppConfig.AIService.APIKey = aiServiceAPIKey
+	}
+	if aiServiceStub := os.Getenv("AI_SERVICE_STUB"); aiServiceStub != "" {
+		if parsed, err := strconv.ParseBool(aiServiceStub); err == nil {
+			AppConfig.AIService.Stub = parsed
+		}
+	}
+}
+
+// ensureJWTDefaults 确保令牌有效期存在合理默认值
+func ensureJWTDefaults() {
+	if AppConfig.JWT.Expiration <= 0 {
+		AppConfig.JWT.Expiration = 15 * time.Minute
+	}
+	if AppConfig.JWT.RefreshExpiration <= 0 {
+		AppConfig.JWT.RefreshExpiration = 30 * 24 * time.Hour
+	}
 }
 
 // ensureMinioDefaults 确保 MinIO 配置存在合理默认值
@@ -153,6 +242,119 @@ func ensureMinioDefaults() {
 	}
 }
 
+// ensureSMSDefaults 未配置短信服务商时使用本地日志发送
+func ensureSMSDefaults() {
+	if AppConfig.SMS.Provider == "" {
+		AppConfig.SMS.Provider = "log"
+	}
+}
+
+// validateConfig 校验无法提供安全默认值的配置项
+func validateConfig() error {
+	if err := validateInviteConfig(); err != nil {
+		return err
+	}
+	if err := validateAIServiceConfig(); err != nil {
+		return err
+	}
+	if err := validateSMSConfig(); err != nil {
+		return err
+	}
+	return validatePaymentConfig()
+}
+
+// validateInviteConfig 邀请令牌使用独立密钥签名，避免与登录令牌共用密钥
+func validateInviteConfig() error {
+	if AppConfig.Invite.Secret == "" {
+		return errors.New("invite secret is required")
+	}
+	if AppConfig.Invite.Secret == AppConfig.JWT.Secret {
+		return errors.New("invite secret must differ from jwt secret")
+	}
+	return nil
+}
+
+// validateAIServiceConfig 必须配置 AI 服务地址或显式启用替身；替身仅在 aistub 构建中可用，release 模式下不允许使用
+func validateAIServiceConfig() error {
+	if AppConfig.AIService.Stub {
+		if !aistub.Enabled {
+			return errors.New("ai_service.stub requires a build with -tags aistub")
+		}
+		if AppConfig.Server.Mode == "release" {
+			return errors.New("ai service stub is not allowed in release mode")
+		}
+		return nil
+	}
+	if AppConfig.AIService.URL == "" {
+		return errors.New("ai_service.url is required")
+	}
+	return nil
+}
+
+// validateSMSConfig 校验短信配置：release 模式下不允许把验证码写入日志，网关方式必须配置地址
+func validateSMSConfig() error {
+	switch strings.ToLower(AppConfig.SMS.Provider) {
+	case "log":
+		if AppConfig.Server.Mode == "release" {
+			return errors.New("log sms provider is not allowed in release mode")
+		}
+	case "http":
+		if AppConfig.SMS.URL == "" {
+			return errors.New("sms url is required for http provider")
+		}
+	}
+	return nil
+}
+
+// validatePaymentConfig 校验支付配置：必须显式指定支付渠道，release 模式下不允许使用模拟支付
+func validatePaymentConfig() error {
+	provider := strings.ToLower(AppConfig.Payment.Provider)
+	if provider == "" {
+		return errors.New("payment provider is required")
+	}
+	if provider == "mock" {
+		if AppConfig.Server.Mode == "release" {
+			return errors.New("mock payment provider is not allowed in release mode")
+		}
+		if AppConfig.Payment.MockSecret == "" {
+			return errors.New("payment mock_secret is required for mock provider")
+		}
+	}
+	return nil
+}
+
+// MockEnabled 是否使用本地模拟支付渠道
+func (p PaymentConfig) MockEnabled() bool {
+	return strings.EqualFold(p.Provider, "mock")
+}
+
+// ensureAIServiceDefaults 确保 AI 服务调用参数存在合理默认值
+func ensureAIServiceDefaults() {
+	if AppConfig.AIService.Timeout <= 0 {
+		AppConfig.AIService.Timeout = 30 * time.Second
+	}
+	if AppConfig.AIService.MaxRetries <= 0 {
+		AppConfig.AIService.MaxRetries = 2
+	}
+	if AppConfig.AIService.BreakerThreshold <= 0 {
+		AppConfig.AIService.BreakerThreshold = 5
+	}
+	if AppConfig.AIService.BreakerCooldown <= 0 {
+		AppConfig.AIService.BreakerCooldown = 30 * time.Second
+	}
+}
+
+// splitList 解析逗号分隔的环境变量，忽略空项
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 // GetDatabaseDSN 获取数据库连接字符串
 func (c *Config) GetDatabaseDSN() string {
 	return fmt.Sprintf(
@@ -0,0 +1,27 @@
+package config
+
+import "testing"
+
+func TestValidateSMSConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    string
+		sms     SMSConfig
+		wantErr bool
+	}{
+		{name: "debug 模式允许日志发送", mode: "debug", sms: SMSConfig{Provider: "log"}},
+		{name: "release 模式禁止日志发送", mode: "release", sms: SMSConfig{Provider: "log"}, wantErr: true},
+		{name: "release 模式禁止日志发送（大小写不敏感）", mode: "release", sms: SMSConfig{Provider: "LOG"}, wantErr: true},
+		{name: "网关方式需要地址", mode: "release", sms: SMSConfig{Provider: "http"}, wantErr: true},
+		{name: "网关方式", mode: "release", sms: SMSConfig{Provider: "http", URL: "https://sms.example.com/send"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			AppConfig = &Config{Server: ServerConfig{Mode: tt.mode}, SMS: tt.sms}
+			if err := validateSMSConfig(); (err != nil) != tt.wantErr {
+				t.Fatalf("validateSMSConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
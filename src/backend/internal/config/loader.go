@@ -4,6 +4,8 @@ import (
 	"os"
 	"path/filepath"
 	"time"
+
+	"onetaste-family/backend/pkg/aiclient/aistub"
 )
 
 // LoadConfig 加载配置文件
@@ -51,8 +53,12 @@ func loadDefaultConfig() error {
 			DB:       0,
 		},
 		JWT: JWTConfig{
-			Secret:     "your-secret-key-change-in-production",
-			Expiration: 24 * time.Hour, // 24小时
+			Secret:            "your-secret-key-change-in-production",
+			Expiration:        15 * time.Minute,    // 15分钟
+			RefreshExpiration: 30 * 24 * time.Hour, // 30天
+		},
+		Invite: InviteConfig{
+			Secret: "your-invite-secret-change-in-production",
 		},
 		MinIO: MinIOConfig{
 			Endpoint:  "localhost:9000",
@@ -63,13 +69,29 @@ func loadDefaultConfig() error {
 			UseSSL:    false,
 			BaseURL:   "http://localhost:9000",
 		},
+		SMS: SMSConfig{
+			Provider: "log",
+		},
+		// 默认配置为 debug 模式，使用本地模拟支付
+		Payment: PaymentConfig{
+			Provider:   "mock",
+			MockSecret: "your-mock-pay-secret-change-in-production",
+		},
+		// 以 -tags aistub 构建时使用进程内替身，否则连接本地 AI 服务
+		AIService: AIServiceConfig{
+			URL:  "http://127.0.0.1:8000",
+			Stub: aistub.Enabled,
+		},
 	}
 
 	// 从环境变量覆盖配置
 	loadFromEnv()
+	ensureJWTDefaults()
 	ensureMinioDefaults()
+	ensureSMSDefaults()
+	ensureAIServiceDefaults()
 
-	return nil
+	return validateConfig()
 }
 
 // GetConfigPath 获取配置文件路径
@@ -0,0 +1,27 @@
+package config
+
+import (
+	"testing"
+
+	"onetaste-family/backend/pkg/aiclient/aistub"
+)
+
+func TestLoadDefaultConfig(t *testing.T) {
+	// 空值不会覆盖默认配置，避免运行环境中的变量影响结果
+	for _, key := range []string{"PAYMENT_PROVIDER", "PAYMENT_MOCK_SECRET", "AI_SERVICE_URL", "AI_SERVICE_STUB", "SMS_PROVIDER", "JWT_SECRET", "INVITE_SECRET"} {
+		t.Setenv(key, "")
+	}
+
+	if err := loadDefaultConfig(); err != nil {
+		t.Fatalf("loadDefaultConfig() error = %v", err)
+	}
+	if AppConfig.Server.Mode != "debug" {
+		t.Fatalf("default server mode = %q, want debug", AppConfig.Server.Mode)
+	}
+	if AppConfig.AIService.Stub != aistub.Enabled {
+		t.Fatalf("default ai service stub = %v, want %v to match the build", AppConfig.AIService.Stub, aistub.Enabled)
+	}
+	if !AppConfig.Payment.MockEnabled() {
+		t.Fatalf("default payment provider = %q, want mock", AppConfig.Payment.Provider)
+	}
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"onetaste-family/backend/internal/services"
+	"onetaste-family/backend/internal/utils"
+)
+
+// AIQuotaHandler AI额度处理器
+type AIQuotaHandler struct {
+	quotaService *services.AIQuotaService
+}
+
+// NewAIQuotaHandler 创建AI额度处理器
+func NewAIQuotaHandler() *AIQuotaHandler {
+	return &AIQuotaHandler{
+		quotaService: services.NewAIQuotaService(),
+	}
+}
+
+// GetQuotas 获取AI功能剩余次数
+// @Summary 获取AI功能剩余次数
+// @Description 返回各AI功能在当前周期的额度与剩余次数：语音输入每人每天10次，身体状况分析每人每天2次，菜单生成与菜单分析每个家庭每周5次，烹饪步骤优化不限次数。周期按北京时间计算，每周从周一开始。AI功能仅付费版可用，免费版剩余次数均为0。需要Bearer Token认证。
+// @Tags 用户
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=models.AIQuotaResponse} "获取成功"
+// @Failure 401 {object} utils.Response "未授权或Token无效"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /user/quotas [get]
+func (h *AIQuotaHandler) GetQuotas(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.quotaService.GetQuotas(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, utils.InternalServerError("获取AI额度失败"))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.Success(resp))
+}
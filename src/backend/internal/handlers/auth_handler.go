@@ -1,7 +1,10 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"onetaste-family/backend/internal/models"
@@ -13,6 +16,7 @@ import (
 type AuthHandler struct {
 	userService    *services.UserService
 	captchaService *services.CaptchaService
+	sessionService *services.SessionService
 }
 
 // NewAuthHandler 创建认证处理器
@@ -20,6 +24,7 @@ func NewAuthHandler() *AuthHandler {
 	return &AuthHandler{
 		userService:    services.NewUserService(),
 		captchaService: services.NewCaptchaService(),
+		sessionService: services.NewSessionService(),
 	}
 }
 
@@ -50,7 +55,7 @@ func (h *AuthHandler) GetCaptcha(c *gin.Context) {
 
 // Register 用户注册
 // @Summary 用户注册
-// @Description 用户注册接口，支持手机号、密码、验证码、昵称注册。注册成功后返回用户ID和JWT Token。
+// @Description 用户注册接口，支持手机号、密码、验证码、昵称注册。注册成功后返回用户ID、访问令牌与刷新令牌。
 // @Tags 用户认证
 // @Accept json
 // @Produce json
@@ -65,7 +70,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return // 错误已经在BindJSON中处理并返回响应
 	}
 
-	resp, err := h.userService.Register(c.Request.Context(), req)
+	resp, err := h.userService.Register(c.Request.Context(), req, sessionDeviceFromContext(c))
 	if err != nil {
 		// 根据错误类型返回不同的状态码
 		switch err {
@@ -89,13 +94,16 @@ func (h *AuthHandler) Register(c *gin.Context) {
 
 // Login 用户登录
 // @Summary 用户登录
-// @Description 用户登录接口，支持手机号和密码登录。登录成功后返回用户ID、JWT Token和过期时间。
+// @Description 用户登录接口，支持手机号和密码登录。登录成功后返回用户ID、短期访问令牌、刷新令牌及各自过期时间，每次登录创建一个独立会话。
+// @Description 同一手机号或IP多次登录失败后需要携带图形验证码（code=40001），继续失败需等待后重试（code=42901），失败次数达到上限后临时锁定（code=42301）。失败时 data 返回登录防护状态，retry_after 为需等待的秒数。
 // @Tags 用户认证
 // @Accept json
 // @Produce json
 // @Param request body models.LoginRequest true "登录请求参数"
 // @Success 200 {object} utils.Response{data=models.LoginResponse} "登录成功"
-// @Failure 400 {object} utils.Response "手机号或密码错误"
+// @Failure 400 {object} utils.Response{data=models.LoginGuardStatus} "手机号或密码错误、需要验证码或验证码错误"
+// @Failure 423 {object} utils.Response{data=models.LoginGuardStatus} "账号或IP已被临时锁定"
+// @Failure 429 {object} utils.Response{data=models.LoginGuardStatus} "登录失败过多，需等待后重试"
 // @Failure 500 {object} utils.Response "服务器内部错误"
 // @Router /auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
@@ -104,17 +112,268 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return // 错误已经在BindJSON中处理并返回响应
 	}
 
-	resp, err := h.userService.Login(req)
+	resp, err := h.userService.Login(c.Request.Context(), req, sessionDeviceFromContext(c))
 	if err != nil {
-		switch err {
-		case services.ErrInvalidCredentials:
-			c.JSON(http.StatusBadRequest, utils.BadRequest("手机号或密码错误"))
+		var guardErr *services.LoginGuardError
+		if !errors.As(err, &guardErr) {
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("登录失败"))
 			return
+		}
+
+		status := guardErr.Status
+		if status.RetryAfter > 0 {
+			c.Header("Retry-After", strconv.FormatInt(status.RetryAfter, 10))
+		}
+
+		switch guardErr.Err {
+		case services.ErrAccountLocked:
+			c.JSON(http.StatusLocked, utils.ErrorWithData(utils.CodeAccountLocked, fmt.Sprintf("登录失败次数过多，请%s后再试", retryAfterText(status.RetryAfter)), status))
+		case services.ErrLoginTooFrequent:
+			c.JSON(http.StatusTooManyRequests, utils.ErrorWithData(utils.CodeTooManyRequests, fmt.Sprintf("登录过于频繁，请%s后再试", retryAfterText(status.RetryAfter)), status))
+		case services.ErrCaptchaRequired:
+			c.JSON(http.StatusBadRequest, utils.ErrorWithData(utils.CodeCaptchaRequired, "请输入图形验证码", status))
+		case services.ErrCaptchaExpired:
+			c.JSON(http.StatusBadRequest, utils.ErrorWithData(utils.CodeCaptchaRequired, "验证码已过期", status))
+		case services.ErrInvalidVerifyCode:
+			c.JSON(http.StatusBadRequest, utils.ErrorWithData(utils.CodeCaptchaRequired, "验证码不正确", status))
+		case services.ErrInvalidCredentials:
+			c.JSON(http.StatusBadRequest, utils.ErrorWithData(http.StatusBadRequest, "手机号或密码错误", status))
 		default:
 			c.JSON(http.StatusInternalServerError, utils.InternalServerError("登录失败"))
-			return
 		}
+		return
 	}
 
 	c.JSON(http.StatusOK, utils.SuccessWithMessage("登录成功", resp))
 }
+
+// SendPasswordResetCode 发送重置密码验证码
+// @Summary 发送重置密码验证码
+// @Description 校验图形验证码后向手机号发送6位短信验证码，验证码10分钟内有效，同一手机号60秒内只能发送一次。手机号未注册时同样返回成功。
+// @Tags 用户认证
+// @Accept json
+// @Produce json
+// @Param request body models.SendResetCodeRequest true "发送验证码请求参数"
+// @Success 200 {object} utils.Response{data=models.SendResetCodeResponse} "发送成功"
+// @Failure 400 {object} utils.Response "请求参数错误或图形验证码错误"
+// @Failure 429 {object} utils.Response "发送过于频繁"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /auth/password/reset-code [post]
+func (h *AuthHandler) SendPasswordResetCode(c *gin.Context) {
+	req, err := utils.BindJSON[models.SendResetCodeRequest](c)
+	if err != nil {
+		return
+	}
+
+	resp, err := h.userService.SendPasswordResetCode(c.Request.Context(), req)
+	if err != nil {
+		switch err {
+		case services.ErrCaptchaExpired:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("验证码已过期"))
+		case services.ErrInvalidVerifyCode:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("验证码不正确"))
+		case services.ErrResetCodeTooFrequent:
+			c.JSON(http.StatusTooManyRequests, utils.Error(utils.CodeTooManyRequests, "发送过于频繁，请稍后再试"))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("发送验证码失败"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessWithMessage("验证码已发送", resp))
+}
+
+// ResetPassword 重置密码
+// @Summary 重置密码
+// @Description 使用短信验证码重置密码。验证码只能使用一次，连续输错5次后作废；重置成功后该账号全部设备需重新登录。
+// @Tags 用户认证
+// @Accept json
+// @Produce json
+// @Param request body models.ResetPasswordRequest true "重置密码请求参数"
+// @Success 200 {object} utils.Response "重置成功"
+// @Failure 400 {object} utils.Response "请求参数错误或短信验证码错误"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /auth/password/reset [post]
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	req, err := utils.BindJSON[models.ResetPasswordRequest](c)
+	if err != nil {
+		return
+	}
+
+	if err := h.userService.ResetPassword(c.Request.Context(), req); err != nil {
+		switch err {
+		case services.ErrInvalidResetCode:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("短信验证码错误或已过期"))
+		case services.ErrInvalidPassword:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("新密码至少6位"))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("重置密码失败"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessWithMessage("密码已重置，请重新登录", nil))
+}
+
+// Refresh 刷新令牌
+// @Summary 刷新令牌
+// @Description 使用刷新令牌换取新的访问令牌与刷新令牌。刷新令牌只能使用一次，旧令牌被重复使用时整个会话将被吊销。
+// @Tags 用户认证
+// @Accept json
+// @Produce json
+// @Param request body models.RefreshTokenRequest true "刷新令牌请求参数"
+// @Success 200 {object} utils.Response{data=models.LoginResponse} "刷新成功"
+// @Failure 400 {object} utils.Response "请求参数错误"
+// @Failure 401 {object} utils.Response "刷新令牌无效或已过期"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /auth/refresh [post]
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	req, err := utils.BindJSON[models.RefreshTokenRequest](c)
+	if err != nil {
+		return
+	}
+
+	resp, err := h.sessionService.Refresh(c.Request.Context(), req.RefreshToken, sessionDeviceFromContext(c))
+	if err != nil {
+		switch err {
+		case services.ErrInvalidRefreshToken:
+			c.JSON(http.StatusUnauthorized, utils.Unauthorized("刷新令牌无效或已过期，请重新登录"))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("刷新令牌失败"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessWithMessage("刷新成功", resp))
+}
+
+// Logout 退出登录
+// @Summary 退出登录
+// @Description 吊销当前会话，当前访问令牌与刷新令牌立即失效。需要Bearer Token认证。
+// @Tags 用户认证
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response "退出成功"
+// @Failure 401 {object} utils.Response "未授权或Token无效"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /auth/logout [post]
+func (h *AuthHandler) Logout(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	err := h.sessionService.RevokeSession(c.Request.Context(), userID, c.GetString("session_id"))
+	if err != nil && err != services.ErrSessionNotFound {
+		c.JSON(http.StatusInternalServerError, utils.InternalServerError("退出登录失败"))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessWithMessage("退出成功", nil))
+}
+
+// ListSessions 获取登录会话列表
+// @Summary 获取登录会话列表
+// @Description 获取当前用户在各设备上的有效登录会话，current 标记当前请求所用会话。需要Bearer Token认证。
+// @Tags 用户认证
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=[]models.SessionInfo} "获取成功"
+// @Failure 401 {object} utils.Response "未授权或Token无效"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /auth/sessions [get]
+func (h *AuthHandler) ListSessions(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	sessions, err := h.sessionService.ListSessions(c.Request.Context(), userID, c.GetString("session_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, utils.InternalServerError("获取会话列表失败"))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.Success(sessions))
+}
+
+// RevokeSession 退出指定设备
+// @Summary 退出指定设备
+// @Description 吊销当前用户的指定会话，该设备需重新登录。需要Bearer Token认证。
+// @Tags 用户认证
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "会话ID"
+// @Success 200 {object} utils.Response "退出成功"
+// @Failure 400 {object} utils.Response "请求参数错误"
+// @Failure 401 {object} utils.Response "未授权或Token无效"
+// @Failure 404 {object} utils.Response "会话不存在或已失效"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /auth/sessions/{id} [delete]
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	uri, err := utils.BindURI[models.SessionIDRequest](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	if err := h.sessionService.RevokeSession(c.Request.Context(), userID, uri.ID); err != nil {
+		switch err {
+		case services.ErrSessionNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("会话不存在或已失效"))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("退出设备失败"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessWithMessage("退出成功", nil))
+}
+
+// LogoutOtherSessions 退出其他设备
+// @Summary 退出其他设备
+// @Description 吊销除当前会话以外的全部会话。需要Bearer Token认证。
+// @Tags 用户认证
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=models.LogoutOthersResponse} "退出成功"
+// @Failure 401 {object} utils.Response "未授权或Token无效"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /auth/sessions/logout-others [post]
+func (h *AuthHandler) LogoutOtherSessions(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	count, err := h.sessionService.RevokeOtherSessions(c.Request.Context(), userID, c.GetString("session_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, utils.InternalServerError("退出其他设备失败"))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessWithMessage("退出成功", &models.LogoutOthersResponse{RevokedCount: count}))
+}
+
+func sessionDeviceFromContext(c *gin.Context) models.SessionDevice {
+	return models.SessionDevice{
+		UserAgent: c.Request.UserAgent(),
+		IP:        c.ClientIP(),
+	}
+}
+
+// retryAfterText 将等待秒数转换为便于展示的文案
+func retryAfterText(seconds int64) string {
+	if seconds < 60 {
+		return fmt.Sprintf("%d秒", seconds)
+	}
+	return fmt.Sprintf("%d分钟", (seconds+59)/60)
+}
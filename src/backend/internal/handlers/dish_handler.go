@@ -1,9 +1,13 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
+	"log"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"onetaste-family/backend/internal/middleware"
 	"onetaste-family/backend/internal/models"
 	"onetaste-family/backend/internal/services"
 	"onetaste-family/backend/internal/utils"
@@ -23,7 +27,7 @@ func NewDishHandler() *DishHandler {
 
 // CreateDish 创建菜式
 // @Summary 创建菜式
-// @Description 向当前家庭食谱库添加菜式，至少包含一个食材和一个烹饪步骤。需要Bearer Token认证。
+// @Description 向当前家庭食谱库添加菜式，至少包含一个食材和一个烹饪步骤。填写分类时自动关联同名标签，不存在则创建为自定义标签。需要Bearer Token认证。
 // @Tags 菜式
 // @Accept json
 // @Produce json
@@ -61,6 +65,10 @@ func (h *DishHandler) CreateDish(c *gin.Context) {
 			c.JSON(http.StatusBadRequest, utils.BadRequest("请至少填写一个食材"))
 		case services.ErrInvalidDishSteps:
 			c.JSON(http.StatusBadRequest, utils.BadRequest("请至少填写一个烹饪步骤"))
+		case services.ErrInvalidDishTags:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("标签不存在或不属于当前家庭"))
+		case services.ErrDishTagLimitReached:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("自定义标签数量已达上限，无法将分类转为标签"))
 		default:
 			c.JSON(http.StatusInternalServerError, utils.InternalServerError("创建菜式失败"))
 		}
@@ -72,14 +80,15 @@ func (h *DishHandler) CreateDish(c *gin.Context) {
 
 // GetDishList 获取菜式列表
 // @Summary 获取菜式列表
-// @Description 按照家庭返回菜式列表，支持按分类和名称关键字筛选。需要Bearer Token认证。
+// @Description 按照家庭返回菜式列表及其标签，支持按任意标签组合（需同时满足）、难度和名称关键字筛选。需要Bearer Token认证。
 // @Tags 菜式
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param page query int false "页码（默认1）"
 // @Param page_size query int false "每页数量（默认20，最大100）"
-// @Param category query string false "菜式分类"
+// @Param tag_ids query []string false "标签ID，可重复传入，返回同时带有全部标签的菜式" collectionFormat(multi)
+// @Param difficulty query string false "难度" Enums(easy, medium, hard)
 // @Param keyword query string false "名称关键字"
 // @Success 200 {object} utils.Response{data=models.DishListResponse} "获取成功"
 // @Failure 401 {object} utils.Response "未授权"
@@ -113,13 +122,15 @@ func (h *DishHandler) GetDishList(c *gin.Context) {
 
 // GetDishDetail 获取菜式详情
 // @Summary 获取菜式详情
-// @Description 返回菜式的食材和烹饪步骤信息。需要Bearer Token认证。
+// @Description 返回菜式的食材和烹饪步骤信息。传入 servings 时按该份数缩放食材用量，计数单位取整、勺按半勺取整。需要Bearer Token认证。
 // @Tags 菜式
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "菜式ID"
+// @Param servings query int false "份数（1-50），不填按菜式默认份数"
 // @Success 200 {object} utils.Response{data=models.DishDetailResponse} "获取成功"
+// @Failure 400 {object} utils.Response "参数错误"
 // @Failure 401 {object} utils.Response "未授权"
 // @Failure 404 {object} utils.Response "菜式或家庭不存在"
 // @Failure 500 {object} utils.Response "服务器内部错误"
@@ -130,12 +141,17 @@ func (h *DishHandler) GetDishDetail(c *gin.Context) {
 		return
 	}
 
+	query, err := utils.BindQuery[models.DishDetailQuery](c)
+	if err != nil {
+		return
+	}
+
 	userID, ok := getUserIDFromContext(c)
 	if !ok {
 		return
 	}
 
-	resp, err := h.dishService.GetDishDetail(userID, uri.ID)
+	resp, err := h.dishService.GetDishDetail(userID, uri.ID, query.Servings)
 	if err != nil {
 		switch err {
 		case services.ErrFamilyNotFound:
@@ -153,7 +169,7 @@ func (h *DishHandler) GetDishDetail(c *gin.Context) {
 
 // UpdateDish 更新菜式
 // @Summary 更新菜式
-// @Description 仅允许菜式创建者或家庭管理员编辑菜式。需要Bearer Token认证。
+// @Description 仅允许菜式创建者或家庭管理员编辑菜式。未传 tag_ids、prep_minutes、cook_minutes、servings、difficulty 时保持原值；填写分类时自动关联同名标签。需要Bearer Token认证。
 // @Tags 菜式
 // @Accept json
 // @Produce json
@@ -200,6 +216,10 @@ func (h *DishHandler) UpdateDish(c *gin.Context) {
 			c.JSON(http.StatusBadRequest, utils.BadRequest("请至少填写一个食材"))
 		case services.ErrInvalidDishSteps:
 			c.JSON(http.StatusBadRequest, utils.BadRequest("请至少填写一个烹饪步骤"))
+		case services.ErrInvalidDishTags:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("标签不存在或不属于当前家庭"))
+		case services.ErrDishTagLimitReached:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("自定义标签数量已达上限，无法将分类转为标签"))
 		default:
 			c.JSON(http.StatusInternalServerError, utils.InternalServerError("更新菜式失败"))
 		}
@@ -211,16 +231,18 @@ func (h *DishHandler) UpdateDish(c *gin.Context) {
 
 // DeleteDish 删除菜式
 // @Summary 删除菜式
-// @Description 仅允许菜式创建者或家庭管理员删除菜式。需要Bearer Token认证。
+// @Description 仅允许菜式创建者或家庭管理员删除菜式。删除的菜式进入回收站，30天内可恢复，期满后彻底删除。菜式会从引用它的菜单中移除，已被菜单引用时返回409及受影响的菜单，确认删除请传force=true。需要Bearer Token认证。
 // @Tags 菜式
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "菜式ID"
+// @Param force query bool false "菜式已被菜单引用时确认删除"
 // @Success 200 {object} utils.Response "删除成功"
 // @Failure 401 {object} utils.Response "未授权"
 // @Failure 403 {object} utils.Response "无权限"
 // @Failure 404 {object} utils.Response "菜式或家庭不存在"
+// @Failure 409 {object} utils.Response{data=models.DishDeleteImpactResponse} "菜式已被菜单引用，需确认删除"
 // @Failure 500 {object} utils.Response "服务器内部错误"
 // @Router /dishes/{id} [delete]
 func (h *DishHandler) DeleteDish(c *gin.Context) {
@@ -229,24 +251,660 @@ func (h *DishHandler) DeleteDish(c *gin.Context) {
 		return
 	}
 
+	req, err := utils.BindQuery[models.DeleteDishRequest](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	if err := h.dishService.DeleteDish(userID, uri.ID, req.Force); err != nil {
+		var inUseErr *services.DishInUseError
+		switch {
+		case errors.As(err, &inUseErr):
+			c.JSON(http.StatusConflict, utils.ErrorWithData(http.StatusConflict, "菜式已被菜单引用，删除后将从这些菜单中移除，确认删除请传force=true", inUseErr.Impact))
+		case errors.Is(err, services.ErrFamilyNotFound):
+			c.JSON(http.StatusNotFound, utils.NotFound("请先创建或加入家庭"))
+		case errors.Is(err, services.ErrDishNotFound):
+			c.JSON(http.StatusNotFound, utils.NotFound("菜式不存在或已删除"))
+		case errors.Is(err, services.ErrDishPermissionDenied):
+			c.JSON(http.StatusForbidden, utils.Forbidden("仅创建者或家庭管理员可删除"))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("删除菜式失败"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessWithMessage("删除成功", nil))
+}
+
+// DraftDishFromVoice 语音录入菜式
+// @Summary 语音录入菜式
+// @Description 上传一段描述菜式做法的语音，转写并整理为菜式草稿。识别出的食材会匹配食材库，未匹配的食材在 ingredients 中标出并给出候选。语音仅用于本次识别，处理完成后即删除。草稿不会保存，确认后请使用创建菜式接口提交。每人每天可使用10次，仅付费版可用，识别失败不扣次数。需要Bearer Token认证。
+// @Tags 菜式
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "语音文件，支持 MP3/WAV/M4A/AAC/WebM/OGG/AMR/FLAC，最大10MB"
+// @Success 200 {object} utils.Response{data=models.VoiceDishDraftResponse} "识别成功"
+// @Failure 400 {object} utils.Response "文件为空、过大或格式不支持"
+// @Failure 401 {object} utils.Response "未授权"
+// @Failure 403 {object} utils.Response "AI功能仅付费会员可用"
+// @Failure 404 {object} utils.Response "尚未加入家庭"
+// @Failure 429 {object} utils.Response{data=models.AIQuotaUsage} "今日次数已用完"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Failure 502 {object} utils.Response "AI返回的菜式无效"
+// @Failure 503 {object} utils.Response "AI服务暂不可用"
+// @Router /dishes/voice-draft [post]
+func (h *DishHandler) DraftDishFromVoice(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BadRequest("请选择要上传的语音文件"))
+		return
+	}
+
+	if fileHeader.Size <= 0 {
+		c.JSON(http.StatusBadRequest, utils.BadRequest("文件内容为空"))
+		return
+	}
+
+	if fileHeader.Size > services.MaxVoiceAudioSize {
+		maxMB := services.MaxVoiceAudioSize / (1024 * 1024)
+		c.JSON(http.StatusBadRequest, utils.BadRequest(fmt.Sprintf("文件大小不能超过 %dMB", maxMB)))
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, utils.InternalServerError("读取文件失败"))
+		return
+	}
+	defer file.Close()
+
+	input := &models.VoiceDishInput{
+		OriginalName: fileHeader.Filename,
+		ContentType:  detectContentType(file, fileHeader.Header.Get("Content-Type")),
+		Size:         fileHeader.Size,
+	}
+
+	resp, err := h.dishService.DraftDishFromVoice(c.Request.Context(), userID, file, input)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrFamilyNotFound):
+			c.JSON(http.StatusNotFound, utils.NotFound("请先创建或加入家庭"))
+		case errors.Is(err, services.ErrUnsupportedMediaType):
+			c.JSON(http.StatusBadRequest, utils.BadRequest("仅支持 MP3/WAV/M4A/AAC/WebM/OGG/AMR/FLAC 等常见音频格式"))
+		case errors.Is(err, services.ErrInvalidMediaSize):
+			c.JSON(http.StatusBadRequest, utils.BadRequest("文件大小不合法"))
+		case errors.Is(err, services.ErrAIInvalidResult):
+			log.Printf("ai voice to dish returned invalid result: %v", err)
+			c.JSON(http.StatusBadGateway, utils.Error(http.StatusBadGateway, "未能从语音中识别出菜式，请重新录制"))
+		case errors.Is(err, services.ErrAIServiceUnavailable):
+			log.Printf("ai voice to dish failed: %v", err)
+			c.JSON(http.StatusServiceUnavailable, utils.Error(http.StatusServiceUnavailable, "AI服务暂不可用，请稍后重试"))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("语音录入失败"))
+		}
+		return
+	}
+
+	if ticket, ok := middleware.GetAIQuotaTicket(c); ok {
+		remaining := ticket.Usage.Remaining
+		resp.RemainingCount = &remaining
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessWithMessage("识别成功", resp))
+}
+
+// ListDishTags 获取菜式标签
+// @Summary 获取菜式标签
+// @Description 按主料、口味、自定义分组返回可用的菜式标签，前两组为系统标签，自定义组为本家庭创建的标签。需要Bearer Token认证。
+// @Tags 菜式
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=models.DishTagListResponse} "获取成功"
+// @Failure 401 {object} utils.Response "未授权"
+// @Failure 404 {object} utils.Response "尚未加入家庭"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /dishes/tags [get]
+func (h *DishHandler) ListDishTags(c *gin.Context) {
 	userID, ok := getUserIDFromContext(c)
 	if !ok {
 		return
 	}
 
-	if err := h.dishService.DeleteDish(userID, uri.ID); err != nil {
+	resp, err := h.dishService.ListDishTags(userID)
+	if err != nil {
 		switch err {
 		case services.ErrFamilyNotFound:
 			c.JSON(http.StatusNotFound, utils.NotFound("请先创建或加入家庭"))
-		case services.ErrDishNotFound:
-			c.JSON(http.StatusNotFound, utils.NotFound("菜式不存在或已删除"))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("获取标签失败"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.Success(resp))
+}
+
+// CreateDishTag 新增自定义标签
+// @Summary 新增自定义标签
+// @Description 为当前家庭新增自定义菜式标签，名称不能与系统标签或已有标签重复，每个家庭最多50个。需要Bearer Token认证。
+// @Tags 菜式
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.CreateDishTagRequest true "新增标签请求"
+// @Success 200 {object} utils.Response{data=models.DishTag} "创建成功"
+// @Failure 400 {object} utils.Response "参数错误、名称重复或数量已达上限"
+// @Failure 401 {object} utils.Response "未授权"
+// @Failure 404 {object} utils.Response "尚未加入家庭"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /dishes/tags [post]
+func (h *DishHandler) CreateDishTag(c *gin.Context) {
+	req, err := utils.BindJSON[models.CreateDishTagRequest](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	tag, err := h.dishService.CreateDishTag(userID, req)
+	if err != nil {
+		switch err {
+		case services.ErrFamilyNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("请先创建或加入家庭"))
+		case services.ErrInvalidDishTags:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("标签名称不能为空"))
+		case services.ErrDishTagExists:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("标签名称已存在"))
+		case services.ErrDishTagLimitReached:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("自定义标签数量已达上限"))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("创建标签失败"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessWithMessage("创建成功", tag))
+}
+
+// DeleteDishTag 删除自定义标签
+// @Summary 删除自定义标签
+// @Description 删除本家庭的自定义标签，菜式上的该标签会一并移除。系统标签不能删除，仅标签创建者或家庭管理员可删除。需要Bearer Token认证。
+// @Tags 菜式
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "标签ID"
+// @Success 200 {object} utils.Response "删除成功"
+// @Failure 401 {object} utils.Response "未授权"
+// @Failure 403 {object} utils.Response "无权限"
+// @Failure 404 {object} utils.Response "标签或家庭不存在"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /dishes/tags/{id} [delete]
+func (h *DishHandler) DeleteDishTag(c *gin.Context) {
+	uri, err := utils.BindURI[models.DishTagIDRequest](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	if err := h.dishService.DeleteDishTag(userID, uri.ID); err != nil {
+		switch err {
+		case services.ErrFamilyNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("请先创建或加入家庭"))
+		case services.ErrDishTagNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("标签不存在或为系统标签"))
 		case services.ErrDishPermissionDenied:
 			c.JSON(http.StatusForbidden, utils.Forbidden("仅创建者或家庭管理员可删除"))
 		default:
-			c.JSON(http.StatusInternalServerError, utils.InternalServerError("删除菜式失败"))
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("删除标签失败"))
 		}
 		return
 	}
 
 	c.JSON(http.StatusOK, utils.SuccessWithMessage("删除成功", nil))
 }
+
+// ListDishRevisions 获取菜式修订记录
+// @Summary 获取菜式修订记录
+// @Description 分页返回菜式的修订记录（版本号、操作、操作人、时间），按版本号倒序。每次创建、编辑、恢复菜式都会生成一个新版本。需要Bearer Token认证。
+// @Tags 菜式
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "菜式ID"
+// @Param page query int false "页码" default(1)
+// @Param page_size query int false "每页数量" default(20)
+// @Success 200 {object} utils.Response{data=models.DishRevisionListResponse} "获取成功"
+// @Failure 400 {object} utils.Response "参数错误"
+// @Failure 401 {object} utils.Response "未授权"
+// @Failure 404 {object} utils.Response "菜式或家庭不存在"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /dishes/{id}/revisions [get]
+func (h *DishHandler) ListDishRevisions(c *gin.Context) {
+	uri, err := utils.BindURI[models.DishIDRequest](c)
+	if err != nil {
+		return
+	}
+
+	req, err := utils.BindQuery[models.DishRevisionListRequest](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.dishService.ListDishRevisions(userID, uri.ID, req)
+	if err != nil {
+		switch err {
+		case services.ErrFamilyNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("请先创建或加入家庭"))
+		case services.ErrDishNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("菜式不存在或已删除"))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("获取修订记录失败"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.Success(resp))
+}
+
+// GetDishRevision 获取菜式某一版本
+// @Summary 获取菜式某一版本
+// @Description 返回菜式某一版本的完整内容，包括基本信息、食材、步骤与标签。需要Bearer Token认证。
+// @Tags 菜式
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "菜式ID"
+// @Param revision path int true "版本号"
+// @Success 200 {object} utils.Response{data=models.DishRevision} "获取成功"
+// @Failure 400 {object} utils.Response "参数错误"
+// @Failure 401 {object} utils.Response "未授权"
+// @Failure 404 {object} utils.Response "菜式、版本或家庭不存在"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /dishes/{id}/revisions/{revision} [get]
+func (h *DishHandler) GetDishRevision(c *gin.Context) {
+	uri, err := utils.BindURI[models.DishRevisionRequest](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	revision, err := h.dishService.GetDishRevision(userID, uri.ID, uri.RevisionNo)
+	if err != nil {
+		switch err {
+		case services.ErrFamilyNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("请先创建或加入家庭"))
+		case services.ErrDishNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("菜式不存在或已删除"))
+		case services.ErrDishRevisionNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("版本不存在"))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("获取版本失败"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.Success(revision))
+}
+
+// DiffDishRevisions 比较菜式的两个版本
+// @Summary 比较菜式的两个版本
+// @Description 逐项比较两个版本：基本信息按字段列出新旧值；食材按基础食材与单位对应，列出新增、删除与修改的字段；步骤按序号对应；标签列出新增与移除。需要Bearer Token认证。
+// @Tags 菜式
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "菜式ID"
+// @Param from query int true "旧版本号"
+// @Param to query int true "新版本号"
+// @Success 200 {object} utils.Response{data=models.DishRevisionDiffResponse} "获取成功"
+// @Failure 400 {object} utils.Response "参数错误"
+// @Failure 401 {object} utils.Response "未授权"
+// @Failure 404 {object} utils.Response "菜式、版本或家庭不存在"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /dishes/{id}/revisions/diff [get]
+func (h *DishHandler) DiffDishRevisions(c *gin.Context) {
+	uri, err := utils.BindURI[models.DishIDRequest](c)
+	if err != nil {
+		return
+	}
+
+	req, err := utils.BindQuery[models.DishRevisionDiffRequest](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.dishService.DiffDishRevisions(userID, uri.ID, req)
+	if err != nil {
+		switch err {
+		case services.ErrFamilyNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("请先创建或加入家庭"))
+		case services.ErrDishNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("菜式不存在或已删除"))
+		case services.ErrDishRevisionNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("版本不存在"))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("比较版本失败"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.Success(resp))
+}
+
+// RestoreDishRevision 恢复菜式历史版本
+// @Summary 恢复菜式历史版本
+// @Description 将菜式内容恢复为指定版本，并生成一个新版本，原有版本不受影响。历史版本中已删除的标签会被忽略；食材已停用或名称与其他菜式重复时无法恢复。仅允许菜式创建者或家庭管理员操作。需要Bearer Token认证。
+// @Tags 菜式
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "菜式ID"
+// @Param revision path int true "版本号"
+// @Success 200 {object} utils.Response{data=models.DishDetailResponse} "恢复成功"
+// @Failure 400 {object} utils.Response "名称重复或食材已停用"
+// @Failure 401 {object} utils.Response "未授权"
+// @Failure 403 {object} utils.Response "无权限"
+// @Failure 404 {object} utils.Response "菜式、版本或家庭不存在"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /dishes/{id}/revisions/{revision}/restore [post]
+func (h *DishHandler) RestoreDishRevision(c *gin.Context) {
+	uri, err := utils.BindURI[models.DishRevisionRequest](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.dishService.RestoreDishRevision(userID, uri.ID, uri.RevisionNo)
+	if err != nil {
+		switch err {
+		case services.ErrFamilyNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("请先创建或加入家庭"))
+		case services.ErrDishNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("菜式不存在或已删除"))
+		case services.ErrDishRevisionNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("版本不存在"))
+		case services.ErrDishPermissionDenied:
+			c.JSON(http.StatusForbidden, utils.Forbidden("仅创建者或家庭管理员可编辑"))
+		case services.ErrDishNameExists:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("菜式名称已被其他菜式使用"))
+		case services.ErrInvalidDishIngredients:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("该版本中的食材已停用，无法恢复"))
+		case services.ErrInvalidDishName, services.ErrInvalidDishSteps, services.ErrInvalidDishTags:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("该版本内容不完整，无法恢复"))
+		case services.ErrDishTagLimitReached:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("自定义标签数量已达上限，无法将分类转为标签"))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("恢复版本失败"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessWithMessage("恢复成功", resp))
+}
+
+// GetDishDeleteImpact 删除菜式前检查影响
+// @Summary 删除菜式前检查影响
+// @Description 返回引用该菜式的菜单，按今天划分为未来菜单与历史菜单。删除后菜式会从这些菜单中移除，恢复菜式不会重新加入菜单。需要Bearer Token认证。
+// @Tags 菜式
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "菜式ID"
+// @Success 200 {object} utils.Response{data=models.DishDeleteImpactResponse} "获取成功"
+// @Failure 401 {object} utils.Response "未授权"
+// @Failure 404 {object} utils.Response "菜式或家庭不存在"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /dishes/{id}/delete-impact [get]
+func (h *DishHandler) GetDishDeleteImpact(c *gin.Context) {
+	uri, err := utils.BindURI[models.DishIDRequest](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.dishService.GetDishDeleteImpact(userID, uri.ID)
+	if err != nil {
+		switch err {
+		case services.ErrFamilyNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("请先创建或加入家庭"))
+		case services.ErrDishNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("菜式不存在或已删除"))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("检查删除影响失败"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.Success(resp))
+}
+
+// ListTrashedDishes 获取回收站菜式
+// @Summary 获取回收站菜式
+// @Description 分页返回本家庭已删除的菜式，按删除时间倒序。菜式删除30天后彻底删除，purge_after 为彻底删除时间。需要Bearer Token认证。
+// @Tags 菜式
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "页码" default(1)
+// @Param page_size query int false "每页数量" default(20)
+// @Success 200 {object} utils.Response{data=models.DishTrashListResponse} "获取成功"
+// @Failure 400 {object} utils.Response "请求参数错误"
+// @Failure 401 {object} utils.Response "未授权"
+// @Failure 404 {object} utils.Response "家庭不存在"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /dishes/trash [get]
+func (h *DishHandler) ListTrashedDishes(c *gin.Context) {
+	req, err := utils.BindQuery[models.DishTrashListRequest](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.dishService.ListTrashedDishes(userID, req)
+	if err != nil {
+		switch err {
+		case services.ErrFamilyNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("请先创建或加入家庭"))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("获取回收站失败"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.Success(resp))
+}
+
+// RestoreDish 从回收站恢复菜式
+// @Summary 从回收站恢复菜式
+// @Description 恢复回收站中的菜式，仅创建者或家庭管理员可操作。恢复后占用菜式数量额度，名称不能与现有菜式重复，删除时被移出的菜单不会自动恢复。需要Bearer Token认证。
+// @Tags 菜式
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "菜式ID"
+// @Success 200 {object} utils.Response{data=models.DishDetailResponse} "恢复成功"
+// @Failure 400 {object} utils.Response "菜式数量达到上限、名称重复或内容已清除"
+// @Failure 401 {object} utils.Response "未授权"
+// @Failure 403 {object} utils.Response "无权限"
+// @Failure 404 {object} utils.Response "菜式或家庭不存在"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /dishes/{id}/restore [post]
+func (h *DishHandler) RestoreDish(c *gin.Context) {
+	uri, err := utils.BindURI[models.DishIDRequest](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.dishService.RestoreDish(userID, uri.ID)
+	if err != nil {
+		switch err {
+		case services.ErrFamilyNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("请先创建或加入家庭"))
+		case services.ErrDishNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("回收站中不存在该菜式"))
+		case services.ErrDishPermissionDenied:
+			c.JSON(http.StatusForbidden, utils.Forbidden("仅创建者或家庭管理员可恢复"))
+		case services.ErrDishLimitReached:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("菜式数量已达上限"))
+		case services.ErrDishNameExists:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("已存在同名菜式，请先修改现有菜式名称"))
+		case services.ErrDishNotRestorable:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("菜式内容已清除，无法恢复"))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("恢复菜式失败"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessWithMessage("恢复成功", resp))
+}
+
+// ExportDishes 导出菜式
+// @Summary 导出菜式
+// @Description 导出单个或全部菜式，包含基础食材ID与名称、步骤与图片。format=json 为本应用格式，可原样导入；format=jsonld 为 schema.org Recipe JSON-LD（@graph），可导入其他菜谱应用。需要Bearer Token认证。
+// @Tags 菜式
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param format query string false "导出格式：json、jsonld" default(json)
+// @Param dish_id query string false "菜式ID，不传则导出全部菜式"
+// @Success 200 {object} utils.Response{data=models.DishTransferBundle} "导出成功（format=jsonld 时 data 为 models.RecipeGraph）"
+// @Failure 400 {object} utils.Response "请求参数错误"
+// @Failure 401 {object} utils.Response "未授权"
+// @Failure 404 {object} utils.Response "菜式或家庭不存在"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /dishes/export [get]
+func (h *DishHandler) ExportDishes(c *gin.Context) {
+	query, err := utils.BindQuery[models.DishExportQuery](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var data interface{}
+	filename := "dishes.json"
+	if query.Format == models.DishTransferFormatJSONLD {
+		data, err = h.dishService.ExportDishesJSONLD(userID, query.DishID)
+		filename = "dishes.jsonld"
+	} else {
+		data, err = h.dishService.ExportDishes(userID, query.DishID)
+	}
+	if err != nil {
+		switch err {
+		case services.ErrFamilyNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("请先创建或加入家庭"))
+		case services.ErrDishNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("菜式不存在或已删除"))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("导出菜式失败"))
+		}
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.JSON(http.StatusOK, utils.Success(data))
+}
+
+// ImportDishes 导入菜式
+// @Summary 导入菜式
+// @Description 导入本应用导出的菜式包（format=json）或 schema.org Recipe JSON-LD（format=jsonld，支持单个对象、数组与 @graph）。食材按ID或名称模糊匹配食材库，未匹配的食材与标签不导入并在结果中列出；同名、缺少食材或步骤的菜式跳过。可导入的菜式在同一事务内创建，导入后超过菜式数量上限时整体拒绝。dry_run=true 时只返回匹配结果。单次最多导入100道菜式。需要Bearer Token认证。
+// @Tags 菜式
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.DishImportRequest true "导入数据"
+// @Success 200 {object} utils.Response{data=models.DishImportResponse} "导入成功"
+// @Failure 400 {object} utils.Response "导入数据无效或菜式数量超过上限"
+// @Failure 401 {object} utils.Response "未授权"
+// @Failure 404 {object} utils.Response "家庭不存在"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /dishes/import [post]
+func (h *DishHandler) ImportDishes(c *gin.Context) {
+	req, err := utils.BindJSON[models.DishImportRequest](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.dishService.ImportDishes(userID, req)
+	if err != nil {
+		switch err {
+		case services.ErrFamilyNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("请先创建或加入家庭"))
+		case services.ErrInvalidDishImport:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("导入数据格式错误或没有可识别的菜式"))
+		case services.ErrTooManyImportDishes:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("单次最多导入100道菜式"))
+		case services.ErrDishLimitReached:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("导入后菜式数量将超过上限"))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("导入菜式失败"))
+		}
+		return
+	}
+
+	message := "导入成功"
+	if resp.DryRun {
+		message = "解析成功"
+	}
+	c.JSON(http.StatusOK, utils.SuccessWithMessage(message, resp))
+}
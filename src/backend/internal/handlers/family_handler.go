@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -98,16 +99,17 @@ func (h *FamilyHandler) GetFamilyInfo(c *gin.Context) {
 
 // JoinFamilyViaInvite 扫码加入家庭
 // @Summary 扫码加入家庭
-// @Description 用户在前端点击“同意”后调用该接口，加入邀请人所在家庭。需要Bearer Token认证。
+// @Description 用户在前端点击“同意”后调用该接口，兑换二维码中的邀请令牌并加入邀请人所在家庭。需要Bearer Token认证。
 // @Tags 家庭
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param request body models.FamilyInviteRequest true "扫码加入家庭请求参数"
 // @Success 200 {object} utils.Response{data=models.FamilyJoinResponse} "加入成功"
-// @Failure 400 {object} utils.Response "参数错误或业务限制"
+// @Failure 400 {object} utils.Response "参数错误、邀请无效或业务限制"
 // @Failure 401 {object} utils.Response "未授权或Token无效"
-// @Failure 404 {object} utils.Response "家庭不存在或邀请无效"
+// @Failure 403 {object} utils.Response "邀请仅限指定手机号"
+// @Failure 404 {object} utils.Response "家庭不存在或已解散"
 // @Failure 500 {object} utils.Response "服务器内部错误"
 // @Router /family/member/invite [post]
 func (h *FamilyHandler) JoinFamilyViaInvite(c *gin.Context) {
@@ -124,9 +126,21 @@ func (h *FamilyHandler) JoinFamilyViaInvite(c *gin.Context) {
 	resp, err := h.familyService.JoinFamilyViaInvite(req, userID)
 	if err != nil {
 		switch err {
-		case services.ErrInvalidInviteAction, services.ErrFamilyNameMismatch:
+		case services.ErrInvalidInviteAction, services.ErrInvalidInvitation:
 			c.JSON(http.StatusBadRequest, utils.BadRequest("邀请信息不正确"))
 			return
+		case services.ErrInvitationExpired:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("邀请已过期"))
+			return
+		case services.ErrInvitationRevoked:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("邀请已撤销"))
+			return
+		case services.ErrInvitationExhausted:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("邀请使用次数已用完"))
+			return
+		case services.ErrInvitationPhoneMismatch:
+			c.JSON(http.StatusForbidden, utils.Forbidden("该邀请仅限指定手机号使用"))
+			return
 		case services.ErrUserAlreadyInFamily:
 			c.JSON(http.StatusBadRequest, utils.BadRequest("您已经创建或加入了一个家庭"))
 			return
@@ -181,6 +195,255 @@ func (h *FamilyHandler) GetFamilyMembers(c *gin.Context) {
 	c.JSON(http.StatusOK, utils.Success(members))
 }
 
+// UpdateMemberRole 修改成员角色
+// @Summary 修改成员角色
+// @Description 仅owner可操作。将成员角色设为owner即转让家庭，原owner降为普通成员。需要Bearer Token认证。
+// @Tags 家庭
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param user_id path string true "成员用户ID"
+// @Param request body models.UpdateFamilyMemberRoleRequest true "修改角色请求参数"
+// @Success 200 {object} utils.Response{data=[]models.FamilyMemberInfo} "修改成功"
+// @Failure 400 {object} utils.Response "请求参数错误"
+// @Failure 401 {object} utils.Response "未授权或Token无效"
+// @Failure 403 {object} utils.Response "仅owner可操作"
+// @Failure 404 {object} utils.Response "家庭或成员不存在"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /family/members/{user_id}/role [put]
+func (h *FamilyHandler) UpdateMemberRole(c *gin.Context) {
+	uri, err := utils.BindURI[models.FamilyMemberURIRequest](c)
+	if err != nil {
+		return
+	}
+
+	req, err := utils.BindJSON[models.UpdateFamilyMemberRoleRequest](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	members, err := h.familyService.UpdateMemberRole(userID, uri.UserID, req)
+	if err != nil {
+		handleFamilyMemberError(c, err, "修改成员角色失败")
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessWithMessage("修改成功", members))
+}
+
+// RemoveMember 移除家庭成员
+// @Summary 移除家庭成员
+// @Description 仅owner可操作，被移除成员的状态置为已退出。需要Bearer Token认证。
+// @Tags 家庭
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param user_id path string true "成员用户ID"
+// @Success 200 {object} utils.Response{data=[]models.FamilyMemberInfo} "移除成功"
+// @Failure 400 {object} utils.Response "不能移除自己"
+// @Failure 401 {object} utils.Response "未授权或Token无效"
+// @Failure 403 {object} utils.Response "仅owner可操作"
+// @Failure 404 {object} utils.Response "家庭或成员不存在"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /family/members/{user_id} [delete]
+func (h *FamilyHandler) RemoveMember(c *gin.Context) {
+	uri, err := utils.BindURI[models.FamilyMemberURIRequest](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	members, err := h.familyService.RemoveMember(userID, uri.UserID)
+	if err != nil {
+		handleFamilyMemberError(c, err, "移除成员失败")
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessWithMessage("移除成功", members))
+}
+
+// TransferOwnership 转让家庭
+// @Summary 转让家庭
+// @Description 仅owner可操作，将家庭转让给其他成员，原owner降为普通成员。需要Bearer Token认证。
+// @Tags 家庭
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.TransferFamilyOwnershipRequest true "转让家庭请求参数"
+// @Success 200 {object} utils.Response{data=[]models.FamilyMemberInfo} "转让成功"
+// @Failure 400 {object} utils.Response "请求参数错误"
+// @Failure 401 {object} utils.Response "未授权或Token无效"
+// @Failure 403 {object} utils.Response "仅owner可操作"
+// @Failure 404 {object} utils.Response "家庭或成员不存在"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /family/transfer [post]
+func (h *FamilyHandler) TransferOwnership(c *gin.Context) {
+	req, err := utils.BindJSON[models.TransferFamilyOwnershipRequest](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	members, err := h.familyService.TransferOwnership(userID, req)
+	if err != nil {
+		handleFamilyMemberError(c, err, "转让家庭失败")
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessWithMessage("转让成功", members))
+}
+
+// LeaveFamily 退出家庭
+// @Summary 退出家庭
+// @Description 成员主动退出家庭；owner退出时必须通过 new_owner_id 指定新owner，家庭仅剩owner一人时无法退出。需要Bearer Token认证。
+// @Tags 家庭
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.LeaveFamilyRequest false "退出家庭请求参数"
+// @Success 200 {object} utils.Response "退出成功"
+// @Failure 400 {object} utils.Response "请求参数错误或需要先转让家庭"
+// @Failure 401 {object} utils.Response "未授权或Token无效"
+// @Failure 404 {object} utils.Response "家庭或新owner不存在"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /family/leave [post]
+func (h *FamilyHandler) LeaveFamily(c *gin.Context) {
+	req := &models.LeaveFamilyRequest{}
+	if c.Request.ContentLength > 0 {
+		bound, err := utils.BindJSON[models.LeaveFamilyRequest](c)
+		if err != nil {
+			return
+		}
+		req = bound
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	if err := h.familyService.LeaveFamily(userID, req); err != nil {
+		handleFamilyMemberError(c, err, "退出家庭失败")
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessWithMessage("退出成功", nil))
+}
+
+// DissolveFamily 解散家庭
+// @Summary 解散家庭
+// @Description 仅owner可操作，需再次输入家庭名称确认。家庭标记为解散，全部成员退出后可加入或创建其他家庭；菜式与菜单软删除，30天内owner可导出数据，之后数据被清理。需要Bearer Token认证。
+// @Tags 家庭
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.DissolveFamilyRequest true "解散家庭请求参数"
+// @Success 200 {object} utils.Response{data=models.FamilyDissolveResponse} "解散成功"
+// @Failure 400 {object} utils.Response "请求参数错误或家庭名称不一致"
+// @Failure 401 {object} utils.Response "未授权或Token无效"
+// @Failure 403 {object} utils.Response "仅owner可操作"
+// @Failure 404 {object} utils.Response "尚未创建或加入家庭"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /family/dissolve [post]
+func (h *FamilyHandler) DissolveFamily(c *gin.Context) {
+	req, err := utils.BindJSON[models.DissolveFamilyRequest](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.familyService.DissolveFamily(userID, req)
+	if err != nil {
+		handleFamilyMemberError(c, err, "解散家庭失败")
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessWithMessage("解散成功", resp))
+}
+
+// ExportFamily 导出家庭数据
+// @Summary 导出家庭数据
+// @Description 仅owner可操作，导出家庭信息、成员、菜式、菜单与购物清单的JSON数据包。家庭解散后在数据清理前仍可通过family_id导出。需要Bearer Token认证。
+// @Tags 家庭
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param family_id query string false "已解散家庭的ID，不传则导出当前家庭"
+// @Success 200 {object} utils.Response{data=models.FamilyExportBundle} "导出成功"
+// @Failure 400 {object} utils.Response "请求参数错误"
+// @Failure 401 {object} utils.Response "未授权或Token无效"
+// @Failure 403 {object} utils.Response "仅owner可操作"
+// @Failure 404 {object} utils.Response "家庭不存在或数据已清理"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /family/export [get]
+func (h *FamilyHandler) ExportFamily(c *gin.Context) {
+	query, err := utils.BindQuery[models.FamilyExportQuery](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	bundle, err := h.familyService.ExportFamily(userID, query.FamilyID)
+	if err != nil {
+		switch err {
+		case services.ErrFamilyNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("家庭不存在或数据已清理"))
+		case services.ErrNotFamilyOwner:
+			c.JSON(http.StatusForbidden, utils.Forbidden("仅家庭创建人可导出数据"))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("导出家庭数据失败"))
+		}
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="family-%s.json"`, bundle.Family.FamilyID))
+	c.JSON(http.StatusOK, utils.Success(bundle))
+}
+
+func handleFamilyMemberError(c *gin.Context, err error, message string) {
+	switch err {
+	case services.ErrFamilyNotFound:
+		c.JSON(http.StatusNotFound, utils.NotFound("您还没有创建或加入任何家庭"))
+	case services.ErrFamilyMemberNotFound:
+		c.JSON(http.StatusNotFound, utils.NotFound("成员不存在或已退出家庭"))
+	case services.ErrNotFamilyOwner:
+		c.JSON(http.StatusForbidden, utils.Forbidden("仅家庭创建人可执行此操作"))
+	case services.ErrCannotRemoveSelf:
+		c.JSON(http.StatusBadRequest, utils.BadRequest("不能移除自己，请使用退出家庭"))
+	case services.ErrCannotTransferToSelf:
+		c.JSON(http.StatusBadRequest, utils.BadRequest("不能将家庭转让给自己"))
+	case services.ErrOwnerMustTransfer:
+		c.JSON(http.StatusBadRequest, utils.BadRequest("请先将家庭转让给其他成员"))
+	case services.ErrOwnerLastMember:
+		c.JSON(http.StatusBadRequest, utils.BadRequest("您是家庭唯一成员，无法退出"))
+	case services.ErrFamilyDissolveNameMismatch:
+		c.JSON(http.StatusBadRequest, utils.BadRequest("家庭名称不一致，请确认后再解散"))
+	default:
+		c.JSON(http.StatusInternalServerError, utils.InternalServerError(message))
+	}
+}
+
 func getUserIDFromContext(c *gin.Context) (string, bool) {
 	userID, exists := c.Get("user_id")
 	if !exists {
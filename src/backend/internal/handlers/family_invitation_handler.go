@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"onetaste-family/backend/internal/models"
+	"onetaste-family/backend/internal/services"
+	"onetaste-family/backend/internal/utils"
+)
+
+// CreateInvitation 创建家庭邀请
+// @Summary 创建家庭邀请
+// @Description 家庭成员生成带签名的邀请令牌，可设置有效时长、最大使用次数与指定手机号。令牌仅在创建时返回，用于生成二维码。需要Bearer Token认证。
+// @Tags 家庭
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.CreateFamilyInvitationRequest false "创建邀请请求参数"
+// @Success 200 {object} utils.Response{data=models.FamilyInvitationResponse} "创建成功"
+// @Failure 400 {object} utils.Response "请求参数错误"
+// @Failure 401 {object} utils.Response "未授权或Token无效"
+// @Failure 404 {object} utils.Response "尚未创建或加入家庭"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /family/invitations [post]
+func (h *FamilyHandler) CreateInvitation(c *gin.Context) {
+	req := &models.CreateFamilyInvitationRequest{}
+	if c.Request.ContentLength > 0 {
+		bound, err := utils.BindJSON[models.CreateFamilyInvitationRequest](c)
+		if err != nil {
+			return
+		}
+		req = bound
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.familyService.CreateInvitation(userID, req)
+	if err != nil {
+		handleInvitationError(c, err, "创建邀请失败")
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessWithMessage("创建成功", resp))
+}
+
+// ListInvitations 获取家庭邀请列表
+// @Summary 获取家庭邀请列表
+// @Description 返回当前家庭的全部邀请及其状态（active, expired, revoked, exhausted）。需要Bearer Token认证。
+// @Tags 家庭
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=[]models.FamilyInvitationResponse} "获取成功"
+// @Failure 401 {object} utils.Response "未授权或Token无效"
+// @Failure 404 {object} utils.Response "尚未创建或加入家庭"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /family/invitations [get]
+func (h *FamilyHandler) ListInvitations(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	invitations, err := h.familyService.ListInvitations(userID)
+	if err != nil {
+		handleInvitationError(c, err, "获取邀请列表失败")
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.Success(invitations))
+}
+
+// RevokeInvitation 撤销家庭邀请
+// @Summary 撤销家庭邀请
+// @Description 邀请人本人或owner可撤销邀请，撤销后令牌立即失效。需要Bearer Token认证。
+// @Tags 家庭
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "邀请ID"
+// @Success 200 {object} utils.Response "撤销成功"
+// @Failure 400 {object} utils.Response "邀请已撤销"
+// @Failure 401 {object} utils.Response "未授权或Token无效"
+// @Failure 403 {object} utils.Response "无权撤销"
+// @Failure 404 {object} utils.Response "邀请或家庭不存在"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /family/invitations/{id} [delete]
+func (h *FamilyHandler) RevokeInvitation(c *gin.Context) {
+	uri, err := utils.BindURI[models.FamilyInvitationIDRequest](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	if err := h.familyService.RevokeInvitation(userID, uri.ID); err != nil {
+		handleInvitationError(c, err, "撤销邀请失败")
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessWithMessage("撤销成功", nil))
+}
+
+// PreviewInvitation 邀请令牌预览
+// @Summary 邀请令牌预览
+// @Description 邀请落地页根据二维码中的令牌获取家庭名称与邀请人信息，无需登录。
+// @Tags 家庭
+// @Accept json
+// @Produce json
+// @Param token query string true "邀请令牌"
+// @Success 200 {object} utils.Response{data=models.FamilyInvitationPreview} "获取成功"
+// @Failure 400 {object} utils.Response "邀请无效、已过期、已撤销或次数已用完"
+// @Failure 404 {object} utils.Response "家庭不存在或已解散"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /family/invitations/preview [get]
+func (h *FamilyHandler) PreviewInvitation(c *gin.Context) {
+	req, err := utils.BindQuery[models.FamilyInvitationTokenQuery](c)
+	if err != nil {
+		return
+	}
+
+	preview, err := h.familyService.PreviewInvitation(req.Token)
+	if err != nil {
+		handleInvitationError(c, err, "获取邀请信息失败")
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.Success(preview))
+}
+
+func handleInvitationError(c *gin.Context, err error, message string) {
+	switch err {
+	case services.ErrFamilyNotFound:
+		c.JSON(http.StatusNotFound, utils.NotFound("家庭不存在或已解散"))
+	case services.ErrInvalidInvitation:
+		c.JSON(http.StatusBadRequest, utils.BadRequest("邀请信息不正确"))
+	case services.ErrInvitationExpired:
+		c.JSON(http.StatusBadRequest, utils.BadRequest("邀请已过期"))
+	case services.ErrInvitationRevoked:
+		c.JSON(http.StatusBadRequest, utils.BadRequest("邀请已撤销"))
+	case services.ErrInvitationExhausted:
+		c.JSON(http.StatusBadRequest, utils.BadRequest("邀请使用次数已用完"))
+	case services.ErrNotFamilyOwner:
+		c.JSON(http.StatusForbidden, utils.Forbidden("仅邀请人或家庭创建人可撤销邀请"))
+	default:
+		c.JSON(http.StatusInternalServerError, utils.InternalServerError(message))
+	}
+}
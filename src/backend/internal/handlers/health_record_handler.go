@@ -0,0 +1,280 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"onetaste-family/backend/internal/middleware"
+	"onetaste-family/backend/internal/models"
+	"onetaste-family/backend/internal/services"
+	"onetaste-family/backend/internal/utils"
+)
+
+// HealthRecordHandler 身体状况记录处理器
+type HealthRecordHandler struct {
+	healthService *services.HealthRecordService
+}
+
+// NewHealthRecordHandler 创建身体状况记录处理器
+func NewHealthRecordHandler() *HealthRecordHandler {
+	return &HealthRecordHandler{
+		healthService: services.NewHealthRecordService(),
+	}
+}
+
+// CreateHealthRecord 新增身体状况记录
+// @Summary 新增身体状况记录
+// @Description 记录本人当前的身体状况，包括疾病或不适、工作繁忙程度、压力水平与身体感受，至少填写一项。记录仅本人可见，开启公开后家庭管理员可查看。需要Bearer Token认证。
+// @Tags 身体状况
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.CreateHealthRecordRequest true "身体状况记录"
+// @Success 200 {object} utils.Response{data=models.HealthRecord} "记录成功"
+// @Failure 400 {object} utils.Response "参数错误或内容为空"
+// @Failure 401 {object} utils.Response "未授权"
+// @Failure 404 {object} utils.Response "尚未加入家庭"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /health-records [post]
+func (h *HealthRecordHandler) CreateHealthRecord(c *gin.Context) {
+	req, err := utils.BindJSON[models.CreateHealthRecordRequest](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	record, err := h.healthService.CreateRecord(userID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrFamilyNotFound):
+			c.JSON(http.StatusNotFound, utils.NotFound("请先创建或加入家庭"))
+		case errors.Is(err, services.ErrEmptyHealthRecord):
+			c.JSON(http.StatusBadRequest, utils.BadRequest("请至少填写一项身体状况"))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("记录身体状况失败"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessWithMessage("记录成功", record))
+}
+
+// GetHealthRecordList 获取身体状况记录列表
+// @Summary 获取身体状况记录列表
+// @Description 按记录时间倒序分页返回身体状况记录及AI分析结果。默认返回本人的记录；家庭管理员可通过user_id查看已开启公开的成员记录。需要Bearer Token认证。
+// @Tags 身体状况
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "页码（默认1）"
+// @Param page_size query int false "每页数量（默认20，最大100）"
+// @Param user_id query string false "成员ID，为空表示本人"
+// @Success 200 {object} utils.Response{data=models.HealthRecordListResponse} "获取成功"
+// @Failure 400 {object} utils.Response "参数错误"
+// @Failure 401 {object} utils.Response "未授权"
+// @Failure 403 {object} utils.Response "无权查看该成员的记录"
+// @Failure 404 {object} utils.Response "尚未加入家庭或成员不存在"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /health-records [get]
+func (h *HealthRecordHandler) GetHealthRecordList(c *gin.Context) {
+	req, err := utils.BindQuery[models.HealthRecordListRequest](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.healthService.ListRecords(userID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrFamilyNotFound):
+			c.JSON(http.StatusNotFound, utils.NotFound("请先创建或加入家庭"))
+		case errors.Is(err, services.ErrFamilyMemberNotFound):
+			c.JSON(http.StatusNotFound, utils.NotFound("该成员不在当前家庭中"))
+		case errors.Is(err, services.ErrHealthRecordAccessDenied):
+			c.JSON(http.StatusForbidden, utils.Forbidden("该成员未公开身体状况记录"))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("获取身体状况记录失败"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.Success(resp))
+}
+
+// GetHealthRecord 获取身体状况记录详情
+// @Summary 获取身体状况记录详情
+// @Description 获取一条身体状况记录及其AI分析结果。仅本人或已获公开授权的家庭管理员可查看。需要Bearer Token认证。
+// @Tags 身体状况
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "记录ID"
+// @Success 200 {object} utils.Response{data=models.HealthRecord} "获取成功"
+// @Failure 400 {object} utils.Response "参数错误"
+// @Failure 401 {object} utils.Response "未授权"
+// @Failure 404 {object} utils.Response "记录不存在或无权查看"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /health-records/{id} [get]
+func (h *HealthRecordHandler) GetHealthRecord(c *gin.Context) {
+	uri, err := utils.BindURI[models.HealthRecordIDRequest](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	record, err := h.healthService.GetRecord(userID, uri.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrFamilyNotFound):
+			c.JSON(http.StatusNotFound, utils.NotFound("请先创建或加入家庭"))
+		case errors.Is(err, services.ErrHealthRecordNotFound):
+			c.JSON(http.StatusNotFound, utils.NotFound("记录不存在"))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("获取身体状况记录失败"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.Success(record))
+}
+
+// AnalyzeHealthRecord AI分析身体状况记录
+// @Summary AI分析身体状况记录
+// @Description 由AI分析本人的一条身体状况记录并给出建议与饮食建议，分析结果保存到该记录，重复分析会覆盖上次结果。每人每天可分析2次，仅付费版可用，分析失败不扣次数。需要Bearer Token认证。
+// @Tags 身体状况
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "记录ID"
+// @Success 200 {object} utils.Response{data=models.HealthRecordAnalysisResponse} "分析成功"
+// @Failure 400 {object} utils.Response "参数错误"
+// @Failure 401 {object} utils.Response "未授权"
+// @Failure 403 {object} utils.Response "AI功能仅付费会员可用或只能分析本人的记录"
+// @Failure 404 {object} utils.Response "记录不存在"
+// @Failure 429 {object} utils.Response{data=models.AIQuotaUsage} "今日分析次数已用完"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Failure 502 {object} utils.Response "AI返回的分析结果无效"
+// @Failure 503 {object} utils.Response "AI服务暂不可用"
+// @Router /health-records/{id}/analyze [post]
+func (h *HealthRecordHandler) AnalyzeHealthRecord(c *gin.Context) {
+	uri, err := utils.BindURI[models.HealthRecordIDRequest](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.healthService.AnalyzeRecord(c.Request.Context(), userID, uri.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrFamilyNotFound):
+			c.JSON(http.StatusNotFound, utils.NotFound("请先创建或加入家庭"))
+		case errors.Is(err, services.ErrHealthRecordNotFound):
+			c.JSON(http.StatusNotFound, utils.NotFound("记录不存在"))
+		case errors.Is(err, services.ErrHealthRecordAccessDenied):
+			c.JSON(http.StatusForbidden, utils.Forbidden("只能分析本人的身体状况记录"))
+		case errors.Is(err, services.ErrAIInvalidResult):
+			log.Printf("ai health analysis returned invalid result: %v", err)
+			c.JSON(http.StatusBadGateway, utils.Error(http.StatusBadGateway, "AI返回的分析结果无效，请稍后重试"))
+		case errors.Is(err, services.ErrAIServiceUnavailable):
+			log.Printf("ai health analysis failed: %v", err)
+			c.JSON(http.StatusServiceUnavailable, utils.Error(http.StatusServiceUnavailable, "AI服务暂不可用，请稍后重试"))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("分析身体状况失败"))
+		}
+		return
+	}
+
+	if ticket, ok := middleware.GetAIQuotaTicket(c); ok {
+		remaining := ticket.Usage.Remaining
+		resp.RemainingCount = &remaining
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessWithMessage("分析成功", resp))
+}
+
+// GetHealthSharing 获取身体状况记录公开设置
+// @Summary 获取身体状况记录公开设置
+// @Description 返回本人是否允许家庭管理员查看自己的身体状况记录，默认不公开，退出家庭后重置。需要Bearer Token认证。
+// @Tags 身体状况
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=models.HealthSharingResponse} "获取成功"
+// @Failure 401 {object} utils.Response "未授权"
+// @Failure 404 {object} utils.Response "尚未加入家庭"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /health-records/sharing [get]
+func (h *HealthRecordHandler) GetHealthSharing(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.healthService.GetSharing(userID)
+	if err != nil {
+		if errors.Is(err, services.ErrFamilyNotFound) {
+			c.JSON(http.StatusNotFound, utils.NotFound("请先创建或加入家庭"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, utils.InternalServerError("获取公开设置失败"))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.Success(resp))
+}
+
+// UpdateHealthSharing 设置身体状况记录公开
+// @Summary 设置身体状况记录公开
+// @Description 设置是否允许家庭管理员查看本人的全部身体状况记录，包括AI分析结果。需要Bearer Token认证。
+// @Tags 身体状况
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.HealthSharingRequest true "公开设置"
+// @Success 200 {object} utils.Response{data=models.HealthSharingResponse} "设置成功"
+// @Failure 400 {object} utils.Response "参数错误"
+// @Failure 401 {object} utils.Response "未授权"
+// @Failure 404 {object} utils.Response "尚未加入家庭"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /health-records/sharing [put]
+func (h *HealthRecordHandler) UpdateHealthSharing(c *gin.Context) {
+	req, err := utils.BindJSON[models.HealthSharingRequest](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.healthService.UpdateSharing(userID, *req.Shared)
+	if err != nil {
+		if errors.Is(err, services.ErrFamilyNotFound) {
+			c.JSON(http.StatusNotFound, utils.NotFound("请先创建或加入家庭"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, utils.InternalServerError("设置公开失败"))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessWithMessage("设置成功", resp))
+}
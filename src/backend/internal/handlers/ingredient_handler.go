@@ -80,3 +80,55 @@ func (h *IngredientHandler) GetIngredientsByCategory(c *gin.Context) {
 
 	c.JSON(http.StatusOK, utils.Success(resp))
 }
+
+// ListUnits 获取支持换算的单位
+// @Summary 获取支持换算的单位
+// @Description 返回质量、体积、计数三类单位及其别名，系数为换算到克、毫升、个的倍数
+// @Tags 食材
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=models.UnitListResponse} "获取成功"
+// @Failure 401 {object} utils.Response "未授权"
+// @Router /ingredients/units [get]
+func (h *IngredientHandler) ListUnits(c *gin.Context) {
+	c.JSON(http.StatusOK, utils.Success(h.ingredientService.ListUnits()))
+}
+
+// ConvertUnit 单位换算预览
+// @Summary 单位换算预览
+// @Description 将数量换算为目标单位；提供食材ID时使用食材的密度与单个重量进行体积、计数与质量之间的换算，目标单位缺省为食材推荐单位
+// @Tags 食材
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.UnitConvertRequest true "换算请求"
+// @Success 200 {object} utils.Response{data=models.UnitConvertResponse} "换算成功"
+// @Failure 400 {object} utils.Response "参数错误、单位无法识别或无法换算"
+// @Failure 401 {object} utils.Response "未授权"
+// @Failure 404 {object} utils.Response "食材不存在"
+// @Failure 500 {object} utils.Response "服务器错误"
+// @Router /ingredients/convert [post]
+func (h *IngredientHandler) ConvertUnit(c *gin.Context) {
+	req, err := utils.BindJSON[models.UnitConvertRequest](c)
+	if err != nil {
+		return
+	}
+
+	resp, err := h.ingredientService.ConvertUnit(req)
+	if err != nil {
+		switch err {
+		case services.ErrIngredientNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("食材不存在或已停用"))
+		case services.ErrUnknownUnit:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("无法识别的单位"))
+		case services.ErrIncompatibleUnits:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("单位之间无法换算"))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("单位换算失败"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.Success(resp))
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"onetaste-family/backend/internal/services"
+	"onetaste-family/backend/internal/utils"
+)
+
+// MembershipHandler 会员处理器
+type MembershipHandler struct {
+	membershipService *services.MembershipService
+}
+
+// NewMembershipHandler 创建会员处理器
+func NewMembershipHandler() *MembershipHandler {
+	return &MembershipHandler{
+		membershipService: services.NewMembershipService(),
+	}
+}
+
+// GetMembership 获取会员信息
+// @Summary 获取会员信息
+// @Description 获取当前用户所在家庭的会员类型、到期时间及权益（最大菜式数量等）。会员权益按家庭生效，到期后自动恢复为免费版。需要Bearer Token认证。
+// @Tags 会员
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=models.MembershipResponse} "获取成功"
+// @Failure 401 {object} utils.Response "未授权或Token无效"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /payment/membership [get]
+func (h *MembershipHandler) GetMembership(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.membershipService.GetMembership(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, utils.InternalServerError("获取会员信息失败"))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.Success(resp))
+}
@@ -1,9 +1,12 @@
 package handlers
 
 import (
+	"errors"
+	"log"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"onetaste-family/backend/internal/middleware"
 	"onetaste-family/backend/internal/models"
 	"onetaste-family/backend/internal/services"
 	"onetaste-family/backend/internal/utils"
@@ -23,7 +26,7 @@ func NewMenuHandler() *MenuHandler {
 
 // CreateMenu 创建菜单
 // @Summary 创建菜单
-// @Description 为某一天某一餐创建菜单，支持日期、餐次（早餐/午餐/晚餐）、菜式列表输入。同一日期同一餐次只能有一个菜单，可以覆盖已有菜单。需要Bearer Token认证。
+// @Description 为某一天某一餐创建菜单，支持日期、餐次（早餐/午餐/晚餐）、菜式列表输入。可设置用餐人数，也可为单个菜式单独设置，购物清单中的食材用量按此缩放。同一日期同一餐次只能有一个菜单，可以覆盖已有菜单。需要Bearer Token认证。
 // @Tags 菜单
 // @Accept json
 // @Produce json
@@ -61,6 +64,8 @@ func (h *MenuHandler) CreateMenu(c *gin.Context) {
 			c.JSON(http.StatusNotFound, utils.NotFound("菜式不存在或已删除"))
 		case services.ErrDishNotInFamily:
 			c.JSON(http.StatusBadRequest, utils.BadRequest("菜式不属于当前家庭"))
+		case services.ErrInvalidMenuServings:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("单独设置用餐人数的菜式不在菜单中"))
 		default:
 			c.JSON(http.StatusInternalServerError, utils.InternalServerError("创建菜单失败"))
 		}
@@ -154,7 +159,7 @@ func (h *MenuHandler) GetWeeklyMenu(c *gin.Context) {
 
 // UpdateMenu 更新菜单
 // @Summary 更新菜单
-// @Description 更新已有菜单，支持添加菜式、删除菜式、修改日期或餐次。需要Bearer Token认证。
+// @Description 更新已有菜单，支持添加菜式、删除菜式、修改日期、餐次或用餐人数。需要Bearer Token认证。
 // @Tags 菜单
 // @Accept json
 // @Produce json
@@ -198,6 +203,8 @@ func (h *MenuHandler) UpdateMenu(c *gin.Context) {
 			c.JSON(http.StatusNotFound, utils.NotFound("菜式不存在或已删除"))
 		case services.ErrDishNotInFamily:
 			c.JSON(http.StatusBadRequest, utils.BadRequest("菜式不属于当前家庭"))
+		case services.ErrInvalidMenuServings:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("单独设置用餐人数的菜式不在菜单中"))
 		default:
 			c.JSON(http.StatusInternalServerError, utils.InternalServerError("更新菜单失败"))
 		}
@@ -207,3 +214,355 @@ func (h *MenuHandler) UpdateMenu(c *gin.Context) {
 	c.JSON(http.StatusOK, utils.SuccessWithMessage("更新成功", resp))
 }
 
+// GetCookingSchedule 获取一餐的烹饪排程
+// @Summary 获取一餐的烹饪排程
+// @Description 将菜单中多道菜的烹饪步骤合并为一条时间线：同一道菜的步骤按顺序进行；需要看管的步骤同一时间只做一件，炖煮、烤制等无需看管的步骤与其他步骤并行；炒锅、烤箱各1个、灶眼2个，占用同一厨具的步骤不会冲突。未设置时长的步骤按5分钟估算。返回总用时及相比逐道菜依次烹饪节省的时间。仅付费版可用。需要Bearer Token认证。
+// @Tags 菜单
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "菜单ID"
+// @Success 200 {object} utils.Response{data=models.CookingScheduleResponse} "获取成功"
+// @Failure 400 {object} utils.Response "参数错误"
+// @Failure 401 {object} utils.Response "未授权"
+// @Failure 403 {object} utils.Response "仅付费会员可用"
+// @Failure 404 {object} utils.Response "菜单或家庭不存在"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /menus/{id}/schedule [get]
+func (h *MenuHandler) GetCookingSchedule(c *gin.Context) {
+	uri, err := utils.BindURI[models.MenuIDRequest](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.menuService.GetCookingSchedule(userID, uri.ID)
+	if err != nil {
+		switch err {
+		case services.ErrFamilyNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("请先创建或加入家庭"))
+		case services.ErrCookingScheduleRequiresPremium:
+			c.JSON(http.StatusForbidden, utils.Forbidden("烹饪排程仅付费会员可用"))
+		case services.ErrMenuNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("菜单不存在或已删除"))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("生成烹饪排程失败"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.Success(resp))
+}
+
+// PlanMenus 规则排菜预览
+// @Summary 规则排菜预览
+// @Description 不使用AI，按规则为日期范围（最多14天）内没有菜单的餐次排菜：早餐1道、午餐和晚餐各2道；同一菜式在指定天数内不重复（默认3天）；同一天尽量安排不同分类；指定菜式优先安排，排除的菜式不参与。可选菜式不足时会放宽规则并在warnings中说明。结果仅为预览，需调用采纳接口写入菜单。需要Bearer Token认证。
+// @Tags 菜单
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.PlanMenuRequest true "排菜请求"
+// @Success 200 {object} utils.Response{data=models.PlanMenuResponse} "获取成功"
+// @Failure 400 {object} utils.Response "参数错误或食谱库为空"
+// @Failure 401 {object} utils.Response "未授权"
+// @Failure 404 {object} utils.Response "尚未加入家庭或菜式不存在"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /menus/plan [post]
+func (h *MenuHandler) PlanMenus(c *gin.Context) {
+	req, err := utils.BindJSON[models.PlanMenuRequest](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.menuService.PlanMenus(userID, req)
+	if err != nil {
+		switch err {
+		case services.ErrFamilyNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("请先创建或加入家庭"))
+		case services.ErrInvalidMenuDate:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("日期格式错误，请使用YYYY-MM-DD格式"))
+		case services.ErrInvalidMenuDateRange:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("结束日期不能早于开始日期，且最多安排14天"))
+		case services.ErrInvalidMenuPlan:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("同一菜式不能既指定又排除"))
+		case services.ErrDishNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("菜式不存在或已删除"))
+		case services.ErrDishNotInFamily:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("菜式不属于当前家庭"))
+		case services.ErrEmptyDishLibrary:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("没有可安排的菜式，请先添加菜式或减少排除的菜式"))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("排菜失败"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.Success(resp))
+}
+
+// AcceptMenuPlan 采纳排菜预览
+// @Summary 采纳排菜预览
+// @Description 将排菜预览（可在预览基础上调整）写入菜单。只能写入空餐次，预览之后该餐次已有菜单时整体失败并返回409。需要Bearer Token认证。
+// @Tags 菜单
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.AcceptMenuPlanRequest true "采纳请求"
+// @Success 200 {object} utils.Response{data=models.AcceptMenuPlanResponse} "创建成功"
+// @Failure 400 {object} utils.Response "参数错误"
+// @Failure 401 {object} utils.Response "未授权"
+// @Failure 404 {object} utils.Response "尚未加入家庭或菜式不存在"
+// @Failure 409 {object} utils.Response "餐次已有菜单"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /menus/plan/accept [post]
+func (h *MenuHandler) AcceptMenuPlan(c *gin.Context) {
+	req, err := utils.BindJSON[models.AcceptMenuPlanRequest](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.menuService.AcceptMenuPlan(c.Request.Context(), userID, req)
+	if err != nil {
+		switch err {
+		case services.ErrFamilyNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("请先创建或加入家庭"))
+		case services.ErrInvalidMenuDate:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("日期格式错误，请使用YYYY-MM-DD格式"))
+		case services.ErrInvalidMealType:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("餐次类型错误，必须是breakfast、lunch或dinner"))
+		case services.ErrInvalidMenuPlan:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("同一日期同一餐次只能出现一次"))
+		case services.ErrInvalidDishIDs:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("每餐至少选择一个菜式"))
+		case services.ErrDishNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("菜式不存在或已删除"))
+		case services.ErrDishNotInFamily:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("菜式不属于当前家庭"))
+		case services.ErrMenuSlotOccupied:
+			c.JSON(http.StatusConflict, utils.Error(http.StatusConflict, "部分餐次已有菜单，请重新排菜"))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("创建菜单失败"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessWithMessage("创建成功", resp))
+}
+
+// GenerateMenus AI生成菜单
+// @Summary AI生成菜单
+// @Description 根据家庭食谱库与成员的饮食偏好、最新身体状况（仅包含本人及已开启公开的成员），由AI生成一段时间（最多14天）的菜单草稿。草稿不会直接写入菜单，需调用确认接口后生效。每个家庭每周可生成5次，仅付费版可用，生成失败不扣次数。需要Bearer Token认证。
+// @Tags 菜单
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.GenerateMenuRequest true "生成菜单请求"
+// @Success 200 {object} utils.Response{data=models.MenuGenerationResponse} "生成成功"
+// @Failure 400 {object} utils.Response "参数错误或食谱库为空"
+// @Failure 401 {object} utils.Response "未授权"
+// @Failure 403 {object} utils.Response "AI功能仅付费会员可用"
+// @Failure 404 {object} utils.Response "尚未加入家庭或成员不存在"
+// @Failure 429 {object} utils.Response{data=models.AIQuotaUsage} "本周期生成次数已用完"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Failure 502 {object} utils.Response "AI返回的菜单无效"
+// @Failure 503 {object} utils.Response "AI服务暂不可用"
+// @Router /menus/generate [post]
+func (h *MenuHandler) GenerateMenus(c *gin.Context) {
+	req, err := utils.BindJSON[models.GenerateMenuRequest](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.menuService.GenerateMenus(c.Request.Context(), userID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrFamilyNotFound):
+			c.JSON(http.StatusNotFound, utils.NotFound("请先创建或加入家庭"))
+		case errors.Is(err, services.ErrInvalidMenuDate):
+			c.JSON(http.StatusBadRequest, utils.BadRequest("日期格式错误，请使用YYYY-MM-DD格式"))
+		case errors.Is(err, services.ErrInvalidMenuDateRange):
+			c.JSON(http.StatusBadRequest, utils.BadRequest("结束日期不能早于开始日期，且最多生成14天"))
+		case errors.Is(err, services.ErrFamilyMemberNotFound):
+			c.JSON(http.StatusNotFound, utils.NotFound("所选成员不在当前家庭中"))
+		case errors.Is(err, services.ErrEmptyDishLibrary):
+			c.JSON(http.StatusBadRequest, utils.BadRequest("食谱库为空，请先添加菜式"))
+		case errors.Is(err, services.ErrAIInvalidResult):
+			log.Printf("ai menu generation returned invalid result: %v", err)
+			c.JSON(http.StatusBadGateway, utils.Error(http.StatusBadGateway, "AI生成的菜单无效，请稍后重试"))
+		case errors.Is(err, services.ErrAIServiceUnavailable):
+			log.Printf("ai menu generation failed: %v", err)
+			c.JSON(http.StatusServiceUnavailable, utils.Error(http.StatusServiceUnavailable, "AI服务暂不可用，请稍后重试"))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("生成菜单失败"))
+		}
+		return
+	}
+
+	if ticket, ok := middleware.GetAIQuotaTicket(c); ok {
+		remaining := ticket.Usage.Remaining
+		resp.RemainingCount = &remaining
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessWithMessage("生成成功", resp))
+}
+
+// GetMenuGeneration 获取AI菜单草稿
+// @Summary 获取AI菜单草稿
+// @Description 获取AI生成的菜单草稿详情。草稿待确认时，每餐会标出已有菜单的ID与来源，来源为manual的餐次确认时需要覆盖确认。需要Bearer Token认证。
+// @Tags 菜单
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "草稿ID"
+// @Success 200 {object} utils.Response{data=models.MenuGenerationResponse} "获取成功"
+// @Failure 400 {object} utils.Response "参数错误"
+// @Failure 401 {object} utils.Response "未授权"
+// @Failure 404 {object} utils.Response "草稿或家庭不存在"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /menus/generations/{id} [get]
+func (h *MenuHandler) GetMenuGeneration(c *gin.Context) {
+	uri, err := utils.BindURI[models.MenuGenerationIDRequest](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.menuService.GetGeneration(userID, uri.ID)
+	if err != nil {
+		switch err {
+		case services.ErrFamilyNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("请先创建或加入家庭"))
+		case services.ErrMenuGenerationNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("菜单草稿不存在"))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("获取菜单草稿失败"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.Success(resp))
+}
+
+// ConfirmMenuGeneration 确认AI菜单草稿
+// @Summary 确认AI菜单草稿
+// @Description 将AI菜单草稿写入菜单，来源记为ai。已有AI菜单的餐次直接替换；已有手动菜单的餐次需传 overwrite=true 才会覆盖，否则返回409及冲突的餐次列表。需要Bearer Token认证。
+// @Tags 菜单
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "草稿ID"
+// @Param request body models.ConfirmMenuGenerationRequest false "确认请求"
+// @Success 200 {object} utils.Response{data=models.ConfirmMenuGenerationResponse} "确认成功"
+// @Failure 400 {object} utils.Response "参数错误或草稿已处理"
+// @Failure 401 {object} utils.Response "未授权"
+// @Failure 404 {object} utils.Response "草稿、菜式或家庭不存在"
+// @Failure 409 {object} utils.Response{data=models.MenuGenerationConflictResponse} "需确认覆盖手动菜单"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /menus/generations/{id}/confirm [post]
+func (h *MenuHandler) ConfirmMenuGeneration(c *gin.Context) {
+	uri, err := utils.BindURI[models.MenuGenerationIDRequest](c)
+	if err != nil {
+		return
+	}
+
+	req := &models.ConfirmMenuGenerationRequest{}
+	if c.Request.ContentLength != 0 {
+		if req, err = utils.BindJSON[models.ConfirmMenuGenerationRequest](c); err != nil {
+			return
+		}
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.menuService.ConfirmGeneration(c.Request.Context(), userID, uri.ID, req)
+	if err != nil {
+		var conflictErr *services.MenuGenerationConflictError
+		switch {
+		case errors.As(err, &conflictErr):
+			c.JSON(http.StatusConflict, utils.ErrorWithData(http.StatusConflict, "部分餐次已有手动创建的菜单，确认覆盖请传overwrite=true", &models.MenuGenerationConflictResponse{Conflicts: conflictErr.Conflicts}))
+		case errors.Is(err, services.ErrFamilyNotFound):
+			c.JSON(http.StatusNotFound, utils.NotFound("请先创建或加入家庭"))
+		case errors.Is(err, services.ErrMenuGenerationNotFound):
+			c.JSON(http.StatusNotFound, utils.NotFound("菜单草稿不存在"))
+		case errors.Is(err, services.ErrMenuGenerationNotDraft):
+			c.JSON(http.StatusBadRequest, utils.BadRequest("菜单草稿已确认或已放弃"))
+		case errors.Is(err, services.ErrDishNotFound):
+			c.JSON(http.StatusNotFound, utils.NotFound("草稿中的菜式已删除，请重新生成"))
+		case errors.Is(err, services.ErrDishNotInFamily):
+			c.JSON(http.StatusBadRequest, utils.BadRequest("菜式不属于当前家庭"))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("确认菜单草稿失败"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessWithMessage("确认成功", resp))
+}
+
+// DiscardMenuGeneration 放弃AI菜单草稿
+// @Summary 放弃AI菜单草稿
+// @Description 放弃待确认的AI菜单草稿，已使用的生成次数不退回。需要Bearer Token认证。
+// @Tags 菜单
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "草稿ID"
+// @Success 200 {object} utils.Response "已放弃"
+// @Failure 400 {object} utils.Response "参数错误或草稿已处理"
+// @Failure 401 {object} utils.Response "未授权"
+// @Failure 404 {object} utils.Response "草稿或家庭不存在"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /menus/generations/{id} [delete]
+func (h *MenuHandler) DiscardMenuGeneration(c *gin.Context) {
+	uri, err := utils.BindURI[models.MenuGenerationIDRequest](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	if err := h.menuService.DiscardGeneration(userID, uri.ID); err != nil {
+		switch err {
+		case services.ErrFamilyNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("请先创建或加入家庭"))
+		case services.ErrMenuGenerationNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("菜单草稿不存在"))
+		case services.ErrMenuGenerationNotDraft:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("菜单草稿已确认或已放弃"))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("放弃菜单草稿失败"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessWithMessage("已放弃", nil))
+}
@@ -0,0 +1,285 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"onetaste-family/backend/internal/models"
+	"onetaste-family/backend/internal/services"
+	"onetaste-family/backend/internal/utils"
+	"onetaste-family/backend/pkg/payment"
+)
+
+// maxNotificationBodySize 支付回调请求体大小上限
+const maxNotificationBodySize = 64 << 10
+
+// PaymentHandler 支付订单处理器
+type PaymentHandler struct {
+	paymentService *services.PaymentService
+}
+
+// NewPaymentHandler 创建支付订单处理器
+func NewPaymentHandler() *PaymentHandler {
+	return &PaymentHandler{
+		paymentService: services.NewPaymentService(),
+	}
+}
+
+// GetPlans 获取会员套餐
+// @Summary 获取会员套餐
+// @Description 返回可购买的会员套餐及价格（单位：分）。需要Bearer Token认证。
+// @Tags 会员
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=[]models.MembershipPlan} "获取成功"
+// @Failure 401 {object} utils.Response "未授权或Token无效"
+// @Router /payment/plans [get]
+func (h *PaymentHandler) GetPlans(c *gin.Context) {
+	c.JSON(http.StatusOK, utils.Success(h.paymentService.ListPlans()))
+}
+
+// Subscribe 购买会员
+// @Summary 购买会员
+// @Description 为当前家庭创建会员订单并向支付渠道下单，返回客户端拉起支付所需参数。订单30分钟内未支付将自动关闭。需要Bearer Token认证。
+// @Tags 会员
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.SubscribeRequest true "订阅会员请求"
+// @Success 200 {object} utils.Response{data=models.PaymentOrderResponse} "下单成功"
+// @Failure 400 {object} utils.Response "参数错误或不支持的支付渠道"
+// @Failure 401 {object} utils.Response "未授权或Token无效"
+// @Failure 404 {object} utils.Response "尚未加入家庭"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /payment/subscribe [post]
+func (h *PaymentHandler) Subscribe(c *gin.Context) {
+	req, err := utils.BindJSON[models.SubscribeRequest](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.paymentService.CreateOrder(c.Request.Context(), userID, req)
+	if err != nil {
+		switch err {
+		case services.ErrInvalidMembershipPlan:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("套餐不存在"))
+		case services.ErrUnsupportedPaymentMethod:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("不支持的支付方式"))
+		case services.ErrFamilyNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("请先创建或加入家庭"))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("创建订单失败"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessWithMessage("下单成功", resp))
+}
+
+// GetOrders 获取订单列表
+// @Summary 获取订单列表
+// @Description 分页返回当前用户的支付订单，按创建时间倒序。需要Bearer Token认证。
+// @Tags 会员
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "页码（默认1）"
+// @Param page_size query int false "每页数量（默认20，最大50）"
+// @Success 200 {object} utils.Response{data=models.PaymentOrderListResponse} "获取成功"
+// @Failure 401 {object} utils.Response "未授权或Token无效"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /payment/orders [get]
+func (h *PaymentHandler) GetOrders(c *gin.Context) {
+	req, err := utils.BindQuery[models.PaymentOrderListQuery](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.paymentService.ListOrders(userID, req.Page, req.PageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, utils.InternalServerError("获取订单列表失败"))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.Success(resp))
+}
+
+// GetOrder 获取订单详情
+// @Summary 获取订单详情
+// @Description 查询当前用户的订单状态，客户端支付完成后可轮询该接口确认结果。需要Bearer Token认证。
+// @Tags 会员
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param order_no path string true "订单号"
+// @Success 200 {object} utils.Response{data=models.PaymentOrderResponse} "获取成功"
+// @Failure 401 {object} utils.Response "未授权或Token无效"
+// @Failure 404 {object} utils.Response "订单不存在"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /payment/orders/{order_no} [get]
+func (h *PaymentHandler) GetOrder(c *gin.Context) {
+	req, err := utils.BindURI[models.PaymentOrderNoRequest](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.paymentService.GetOrder(userID, req.OrderNo)
+	if err != nil {
+		switch err {
+		case services.ErrPaymentOrderNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("订单不存在"))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("获取订单失败"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.Success(resp))
+}
+
+// RefundOrder 申请退款
+// @Summary 申请退款
+// @Description 对支付后7天内的订单发起全额退款并取消对应的会员时长。家庭之后已续费时需先退款最近一笔订单。渠道退款未及时完成时订单返回 refunding 状态，由后台自动重试，也可再次调用立即重试。需要Bearer Token认证。
+// @Tags 会员
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param order_no path string true "订单号"
+// @Success 200 {object} utils.Response{data=models.PaymentOrderResponse} "已发起退款"
+// @Failure 400 {object} utils.Response "订单状态不允许退款或已超过退款期限"
+// @Failure 401 {object} utils.Response "未授权或Token无效"
+// @Failure 404 {object} utils.Response "订单不存在"
+// @Failure 409 {object} utils.Response "该订单之后已有续费"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /payment/orders/{order_no}/refund [post]
+func (h *PaymentHandler) RefundOrder(c *gin.Context) {
+	req, err := utils.BindURI[models.PaymentOrderNoRequest](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.paymentService.RefundOrder(c.Request.Context(), userID, req.OrderNo)
+	if err != nil {
+		switch err {
+		case services.ErrPaymentOrderNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("订单不存在"))
+		case services.ErrPaymentOrderNotRefundable:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("订单当前状态不支持退款"))
+		case services.ErrRefundWindowExpired:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("已超过7天退款期限"))
+		case services.ErrMembershipRenewed:
+			c.JSON(http.StatusConflict, utils.Error(http.StatusConflict, "该订单之后已有续费，请先退款最近一笔订单"))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("退款失败"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessWithMessage("退款成功", resp))
+}
+
+// MockPay 模拟支付
+// @Summary 模拟支付
+// @Description 仅在使用 mock 支付渠道时可用：为当前用户的订单生成一条已签名的支付回调并按真实回调流程处理，用于本地联调会员开通流程。需要Bearer Token认证。
+// @Tags 会员
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.MockPayRequest true "模拟支付请求"
+// @Success 200 {object} utils.Response{data=models.PaymentOrderResponse} "处理成功"
+// @Failure 400 {object} utils.Response "参数错误或订单已退款"
+// @Failure 401 {object} utils.Response "未授权或Token无效"
+// @Failure 403 {object} utils.Response "未启用模拟支付"
+// @Failure 404 {object} utils.Response "订单不存在"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /payment/mock/pay [post]
+func (h *PaymentHandler) MockPay(c *gin.Context) {
+	req, err := utils.BindJSON[models.MockPayRequest](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.paymentService.SimulateMockPayment(c.Request.Context(), userID, req)
+	if err != nil {
+		switch err {
+		case services.ErrMockPaymentDisabled:
+			c.JSON(http.StatusForbidden, utils.Forbidden("未启用模拟支付"))
+		case services.ErrPaymentOrderNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("订单不存在"))
+		case services.ErrPaymentOrderNotPayable:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("订单已退款"))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("模拟支付失败"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessWithMessage("处理成功", resp))
+}
+
+// Notify 支付结果回调
+// @Summary 支付结果回调
+// @Description 供支付渠道异步通知支付结果，回调需通过渠道签名校验。同一订单的重复回调只处理一次。无需认证。
+// @Tags 会员
+// @Accept json
+// @Produce json
+// @Param provider path string true "支付渠道，如 mock"
+// @Success 200 {object} utils.Response "处理成功"
+// @Failure 400 {object} utils.Response "签名错误、渠道不匹配或金额不一致"
+// @Failure 404 {object} utils.Response "订单不存在"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /payment/notify/{provider} [post]
+func (h *PaymentHandler) Notify(c *gin.Context) {
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxNotificationBodySize))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BadRequest("请求体读取失败"))
+		return
+	}
+
+	err = h.paymentService.HandleNotification(c.Request.Context(), c.Param("provider"), body, c.Request.Header)
+	if err != nil {
+		switch {
+		case errors.Is(err, payment.ErrInvalidSignature):
+			c.JSON(http.StatusBadRequest, utils.BadRequest("签名校验失败"))
+		case errors.Is(err, services.ErrUnsupportedPaymentMethod):
+			c.JSON(http.StatusBadRequest, utils.BadRequest("不支持的支付渠道"))
+		case errors.Is(err, services.ErrPaymentAmountMismatch):
+			c.JSON(http.StatusBadRequest, utils.BadRequest("支付金额与订单不一致"))
+		case errors.Is(err, services.ErrPaymentOrderNotFound):
+			c.JSON(http.StatusNotFound, utils.NotFound("订单不存在"))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("处理支付回调失败"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.Success(nil))
+}
@@ -2,7 +2,9 @@ package handlers
 
 import (
 	"github.com/gin-gonic/gin"
+	"onetaste-family/backend/internal/config"
 	"onetaste-family/backend/internal/middleware"
+	"onetaste-family/backend/internal/models"
 )
 
 // Router 路由注册接口
@@ -19,17 +21,34 @@ func RegisterAuthRoutes(api *gin.RouterGroup) {
 		auth.GET("/captcha", authHandler.GetCaptcha)
 		auth.POST("/register", authHandler.Register)
 		auth.POST("/login", authHandler.Login)
+		auth.POST("/refresh", authHandler.Refresh)
+		auth.POST("/password/reset-code", authHandler.SendPasswordResetCode)
+		auth.POST("/password/reset", authHandler.ResetPassword)
+	}
+
+	session := auth.Group("")
+	session.Use(middleware.AuthMiddleware()) // 需要认证
+	{
+		session.POST("/logout", authHandler.Logout)
+		session.GET("/sessions", authHandler.ListSessions)
+		session.DELETE("/sessions/:id", authHandler.RevokeSession)
+		session.POST("/sessions/logout-others", authHandler.LogoutOtherSessions)
 	}
 }
 
 // RegisterUserRoutes 注册用户相关路由
 func RegisterUserRoutes(api *gin.RouterGroup) {
 	userHandler := NewUserHandler()
+	aiQuotaHandler := NewAIQuotaHandler()
 
 	user := api.Group("/user")
 	user.Use(middleware.AuthMiddleware()) // 需要认证
 	{
 		user.GET("/info", userHandler.GetUserInfo)
+		user.PUT("/password", userHandler.ChangePassword)
+		user.PUT("/profile", userHandler.UpdateProfile)
+		user.POST("/avatar", userHandler.UploadAvatar)
+		user.GET("/quotas", aiQuotaHandler.GetQuotas)
 	}
 }
 
@@ -37,6 +56,9 @@ func RegisterUserRoutes(api *gin.RouterGroup) {
 func RegisterFamilyRoutes(api *gin.RouterGroup) {
 	familyHandler := NewFamilyHandler()
 
+	// 邀请落地页在登录前展示，预览接口无需认证
+	api.GET("/family/invitations/preview", familyHandler.PreviewInvitation)
+
 	family := api.Group("/family")
 	family.Use(middleware.AuthMiddleware()) // 需要认证
 	{
@@ -44,6 +66,15 @@ func RegisterFamilyRoutes(api *gin.RouterGroup) {
 		family.GET("/info", familyHandler.GetFamilyInfo)
 		family.POST("/member/invite", familyHandler.JoinFamilyViaInvite)
 		family.GET("/members", familyHandler.GetFamilyMembers)
+		family.PUT("/members/:user_id/role", familyHandler.UpdateMemberRole)
+		family.DELETE("/members/:user_id", familyHandler.RemoveMember)
+		family.POST("/transfer", familyHandler.TransferOwnership)
+		family.POST("/leave", familyHandler.LeaveFamily)
+		family.POST("/dissolve", familyHandler.DissolveFamily)
+		family.GET("/export", familyHandler.ExportFamily)
+		family.POST("/invitations", familyHandler.CreateInvitation)
+		family.GET("/invitations", familyHandler.ListInvitations)
+		family.DELETE("/invitations/:id", familyHandler.RevokeInvitation)
 	}
 }
 
@@ -56,9 +87,22 @@ func RegisterDishRoutes(api *gin.RouterGroup) {
 	{
 		dishes.POST("", dishHandler.CreateDish)
 		dishes.GET("", dishHandler.GetDishList)
+		dishes.POST("/voice-draft", middleware.AIQuota(models.AIFeatureVoiceInput), dishHandler.DraftDishFromVoice)
+		dishes.GET("/trash", dishHandler.ListTrashedDishes)
+		dishes.GET("/export", dishHandler.ExportDishes)
+		dishes.POST("/import", dishHandler.ImportDishes)
+		dishes.GET("/tags", dishHandler.ListDishTags)
+		dishes.POST("/tags", dishHandler.CreateDishTag)
+		dishes.DELETE("/tags/:id", dishHandler.DeleteDishTag)
 		dishes.GET("/:id", dishHandler.GetDishDetail)
 		dishes.PUT("/:id", dishHandler.UpdateDish)
 		dishes.DELETE("/:id", dishHandler.DeleteDish)
+		dishes.GET("/:id/delete-impact", dishHandler.GetDishDeleteImpact)
+		dishes.POST("/:id/restore", dishHandler.RestoreDish)
+		dishes.GET("/:id/revisions", dishHandler.ListDishRevisions)
+		dishes.GET("/:id/revisions/diff", dishHandler.DiffDishRevisions)
+		dishes.GET("/:id/revisions/:revision", dishHandler.GetDishRevision)
+		dishes.POST("/:id/revisions/:revision/restore", dishHandler.RestoreDishRevision)
 	}
 }
 
@@ -71,6 +115,8 @@ func RegisterIngredientRoutes(api *gin.RouterGroup) {
     {
         ingredients.GET("/search", ingredientHandler.SearchIngredients)
         ingredients.GET("/by-category", ingredientHandler.GetIngredientsByCategory)
+        ingredients.GET("/units", ingredientHandler.ListUnits)
+        ingredients.POST("/convert", ingredientHandler.ConvertUnit)
     }
 }
 
@@ -96,5 +142,70 @@ func RegisterMenuRoutes(api *gin.RouterGroup) {
 		menus.GET("/daily", menuHandler.GetDailyMenu)
 		menus.GET("/weekly", menuHandler.GetWeeklyMenu)
 		menus.PUT("/:id", menuHandler.UpdateMenu)
+		menus.GET("/:id/schedule", menuHandler.GetCookingSchedule)
+		menus.POST("/plan", menuHandler.PlanMenus)
+		menus.POST("/plan/accept", menuHandler.AcceptMenuPlan)
+		menus.POST("/generate", middleware.AIQuota(models.AIFeatureMenuGenerate), menuHandler.GenerateMenus)
+		menus.GET("/generations/:id", menuHandler.GetMenuGeneration)
+		menus.POST("/generations/:id/confirm", menuHandler.ConfirmMenuGeneration)
+		menus.DELETE("/generations/:id", menuHandler.DiscardMenuGeneration)
+	}
+}
+
+// RegisterShoppingRoutes 注册购物清单相关路由
+func RegisterShoppingRoutes(api *gin.RouterGroup) {
+	shoppingHandler := NewShoppingHandler()
+
+	shopping := api.Group("/shopping-lists")
+	shopping.Use(middleware.AuthMiddleware())
+	{
+		shopping.POST("/generate", shoppingHandler.GenerateShoppingList)
+		shopping.GET("", shoppingHandler.GetShoppingLists)
+		shopping.GET("/:id", shoppingHandler.GetShoppingList)
+		shopping.POST("/:id/regenerate", shoppingHandler.RegenerateShoppingList)
+		shopping.POST("/:id/items", shoppingHandler.AddShoppingItem)
+		shopping.PUT("/:id/items/:itemId", shoppingHandler.UpdateShoppingItem)
+		shopping.DELETE("/:id/items/:itemId", shoppingHandler.RemoveShoppingItem)
+		shopping.POST("/:id/items/:itemId/toggle", shoppingHandler.ToggleShoppingItem)
+	}
+}
+
+// RegisterHealthRecordRoutes 注册身体状况记录相关路由
+func RegisterHealthRecordRoutes(api *gin.RouterGroup) {
+	healthHandler := NewHealthRecordHandler()
+
+	health := api.Group("/health-records")
+	health.Use(middleware.AuthMiddleware())
+	{
+		health.POST("", healthHandler.CreateHealthRecord)
+		health.GET("", healthHandler.GetHealthRecordList)
+		health.GET("/sharing", healthHandler.GetHealthSharing)
+		health.PUT("/sharing", healthHandler.UpdateHealthSharing)
+		health.GET("/:id", healthHandler.GetHealthRecord)
+		health.POST("/:id/analyze", middleware.AIQuota(models.AIFeatureHealthAnalyze), healthHandler.AnalyzeHealthRecord)
+	}
+}
+
+// RegisterPaymentRoutes 注册会员与支付相关路由
+func RegisterPaymentRoutes(api *gin.RouterGroup) {
+	membershipHandler := NewMembershipHandler()
+	paymentHandler := NewPaymentHandler()
+
+	// 支付渠道回调，通过渠道签名校验，无需认证
+	api.POST("/payment/notify/:provider", paymentHandler.Notify)
+
+	payment := api.Group("/payment")
+	payment.Use(middleware.AuthMiddleware())
+	{
+		payment.GET("/membership", membershipHandler.GetMembership)
+		payment.GET("/plans", paymentHandler.GetPlans)
+		payment.POST("/subscribe", paymentHandler.Subscribe)
+		payment.GET("/orders", paymentHandler.GetOrders)
+		payment.GET("/orders/:order_no", paymentHandler.GetOrder)
+		payment.POST("/orders/:order_no/refund", paymentHandler.RefundOrder)
+		// 模拟支付仅在使用 mock 渠道时注册
+		if config.AppConfig.Payment.MockEnabled() {
+			payment.POST("/mock/pay", paymentHandler.MockPay)
+		}
 	}
 }
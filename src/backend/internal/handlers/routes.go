@@ -11,14 +11,16 @@ func RegisterAllRoutes(r *gin.Engine) {
 	api := r.Group("/api/v1")
 	{
 		// 注册各个模块的路由
-		RegisterAuthRoutes(api)        // 认证路由（不需要认证）
-		RegisterUserRoutes(api)        // 用户路由（需要认证）
-		RegisterFamilyRoutes(api)      // 家庭路由
-		RegisterDishRoutes(api)        // 菜式路由
-		RegisterIngredientRoutes(api)  // 基础食材接口
-		RegisterMediaRoutes(api)       // 文件上传路由
-		RegisterMenuRoutes(api)        // 菜单路由
+		RegisterAuthRoutes(api)         // 认证路由（不需要认证）
+		RegisterUserRoutes(api)         // 用户路由（需要认证）
+		RegisterFamilyRoutes(api)       // 家庭路由
+		RegisterDishRoutes(api)         // 菜式路由
+		RegisterIngredientRoutes(api)   // 基础食材接口
+		RegisterMediaRoutes(api)        // 文件上传路由
+		RegisterMenuRoutes(api)         // 菜单路由
+		RegisterShoppingRoutes(api)     // 购物清单路由
+		RegisterHealthRecordRoutes(api) // 身体状况记录路由
+		RegisterPaymentRoutes(api)      // 会员与支付路由
 		// 后续添加新模块时，只需要在这里添加一行即可
-		// RegisterShoppingRoutes(api) // 购物清单路由
 	}
 }
@@ -0,0 +1,345 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"onetaste-family/backend/internal/models"
+	"onetaste-family/backend/internal/services"
+	"onetaste-family/backend/internal/utils"
+)
+
+// ShoppingHandler 购物清单处理器
+type ShoppingHandler struct {
+	shoppingService *services.ShoppingService
+}
+
+// NewShoppingHandler 创建购物清单处理器
+func NewShoppingHandler() *ShoppingHandler {
+	return &ShoppingHandler{
+		shoppingService: services.NewShoppingService(),
+	}
+}
+
+// GenerateShoppingList 生成购物清单
+// @Summary 生成购物清单
+// @Description 根据日期范围（最多31天）或指定菜单ID列表汇总菜式食材，按 ingredient_id + unit 合并数量并按食材分类分组保存。需要Bearer Token认证。
+// @Tags 购物清单
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.GenerateShoppingListRequest true "生成购物清单请求"
+// @Success 200 {object} utils.Response{data=models.ShoppingListDetail} "生成成功"
+// @Failure 400 {object} utils.Response "参数错误或范围内没有菜单"
+// @Failure 401 {object} utils.Response "未授权"
+// @Failure 404 {object} utils.Response "尚未加入家庭或菜单不存在"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /shopping-lists/generate [post]
+func (h *ShoppingHandler) GenerateShoppingList(c *gin.Context) {
+	req, err := utils.BindJSON[models.GenerateShoppingListRequest](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.shoppingService.GenerateShoppingList(userID, req)
+	if err != nil {
+		switch err {
+		case services.ErrFamilyNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("请先创建或加入家庭"))
+		case services.ErrMenuNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("菜单不存在或已删除"))
+		case services.ErrInvalidMenuDate:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("日期格式错误，请使用YYYY-MM-DD格式"))
+		case services.ErrInvalidShoppingRange:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("请提供菜单列表或不超过31天的日期范围"))
+		case services.ErrNoMenusInRange:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("所选范围内没有菜单"))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("生成购物清单失败"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessWithMessage("生成成功", resp))
+}
+
+// GetShoppingLists 获取购物清单列表
+// @Summary 获取购物清单列表
+// @Description 分页返回当前家庭的购物清单，按创建时间倒序。需要Bearer Token认证。
+// @Tags 购物清单
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "页码（默认1）"
+// @Param page_size query int false "每页数量（默认20，最大50）"
+// @Success 200 {object} utils.Response{data=models.ShoppingListListResponse} "获取成功"
+// @Failure 401 {object} utils.Response "未授权"
+// @Failure 404 {object} utils.Response "尚未加入家庭"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /shopping-lists [get]
+func (h *ShoppingHandler) GetShoppingLists(c *gin.Context) {
+	req, err := utils.BindQuery[models.ShoppingListQuery](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.shoppingService.GetShoppingLists(userID, req)
+	if err != nil {
+		switch err {
+		case services.ErrFamilyNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("请先创建或加入家庭"))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("获取购物清单列表失败"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.Success(resp))
+}
+
+// GetShoppingList 获取购物清单详情
+// @Summary 获取购物清单详情
+// @Description 返回购物清单及按食材分类分组的清单项。需要Bearer Token认证。
+// @Tags 购物清单
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "购物清单ID"
+// @Success 200 {object} utils.Response{data=models.ShoppingListDetail} "获取成功"
+// @Failure 401 {object} utils.Response "未授权"
+// @Failure 404 {object} utils.Response "购物清单或家庭不存在"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /shopping-lists/{id} [get]
+func (h *ShoppingHandler) GetShoppingList(c *gin.Context) {
+	uri, err := utils.BindURI[models.ShoppingListIDRequest](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.shoppingService.GetShoppingList(userID, uri.ID)
+	if err != nil {
+		switch err {
+		case services.ErrFamilyNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("请先创建或加入家庭"))
+		case services.ErrShoppingListNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("购物清单不存在"))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("获取购物清单失败"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.Success(resp))
+}
+
+// AddShoppingItem 手动添加清单项
+// @Summary 手动添加清单项
+// @Description 向购物清单添加基础食材，若已存在同食材同单位的清单项则合并数量。手动添加的清单项在重新生成时保留。需要Bearer Token认证。
+// @Tags 购物清单
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "购物清单ID"
+// @Param request body models.AddShoppingItemRequest true "添加清单项请求"
+// @Success 200 {object} utils.Response{data=models.ShoppingListDetail} "添加成功"
+// @Failure 400 {object} utils.Response "参数错误或食材不存在"
+// @Failure 401 {object} utils.Response "未授权"
+// @Failure 404 {object} utils.Response "购物清单或家庭不存在"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /shopping-lists/{id}/items [post]
+func (h *ShoppingHandler) AddShoppingItem(c *gin.Context) {
+	uri, err := utils.BindURI[models.ShoppingListIDRequest](c)
+	if err != nil {
+		return
+	}
+
+	req, err := utils.BindJSON[models.AddShoppingItemRequest](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.shoppingService.AddShoppingItem(userID, uri.ID, req)
+	if err != nil {
+		h.handleItemError(c, err, "添加清单项失败")
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessWithMessage("添加成功", resp))
+}
+
+// UpdateShoppingItem 修改清单项
+// @Summary 修改清单项
+// @Description 修改清单项的数量或单位，修改后的清单项在重新生成时保留；可换算的单位会统一为食材推荐单位，换算后与已有清单项重复时自动合并。需要Bearer Token认证。
+// @Tags 购物清单
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "购物清单ID"
+// @Param itemId path string true "清单项ID"
+// @Param request body models.UpdateShoppingItemRequest true "修改清单项请求"
+// @Success 200 {object} utils.Response{data=models.ShoppingListDetail} "修改成功"
+// @Failure 400 {object} utils.Response "参数错误"
+// @Failure 401 {object} utils.Response "未授权"
+// @Failure 404 {object} utils.Response "购物清单、清单项或家庭不存在"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /shopping-lists/{id}/items/{itemId} [put]
+func (h *ShoppingHandler) UpdateShoppingItem(c *gin.Context) {
+	uri, err := utils.BindURI[models.ShoppingItemIDRequest](c)
+	if err != nil {
+		return
+	}
+
+	req, err := utils.BindJSON[models.UpdateShoppingItemRequest](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.shoppingService.UpdateShoppingItem(userID, uri.ID, uri.ItemID, req)
+	if err != nil {
+		h.handleItemError(c, err, "修改清单项失败")
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessWithMessage("修改成功", resp))
+}
+
+// RemoveShoppingItem 删除清单项
+// @Summary 删除清单项
+// @Description 从购物清单中删除清单项。需要Bearer Token认证。
+// @Tags 购物清单
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "购物清单ID"
+// @Param itemId path string true "清单项ID"
+// @Success 200 {object} utils.Response{data=models.ShoppingListDetail} "删除成功"
+// @Failure 401 {object} utils.Response "未授权"
+// @Failure 404 {object} utils.Response "购物清单、清单项或家庭不存在"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /shopping-lists/{id}/items/{itemId} [delete]
+func (h *ShoppingHandler) RemoveShoppingItem(c *gin.Context) {
+	uri, err := utils.BindURI[models.ShoppingItemIDRequest](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.shoppingService.RemoveShoppingItem(userID, uri.ID, uri.ItemID)
+	if err != nil {
+		h.handleItemError(c, err, "删除清单项失败")
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessWithMessage("删除成功", resp))
+}
+
+// ToggleShoppingItem 切换清单项购买状态
+// @Summary 切换清单项购买状态
+// @Description 在待购买与已购买之间切换清单项状态，全部清单项已购买时购物清单自动标记为完成。需要Bearer Token认证。
+// @Tags 购物清单
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "购物清单ID"
+// @Param itemId path string true "清单项ID"
+// @Success 200 {object} utils.Response{data=models.ShoppingListDetail} "操作成功"
+// @Failure 401 {object} utils.Response "未授权"
+// @Failure 404 {object} utils.Response "购物清单、清单项或家庭不存在"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /shopping-lists/{id}/items/{itemId}/toggle [post]
+func (h *ShoppingHandler) ToggleShoppingItem(c *gin.Context) {
+	uri, err := utils.BindURI[models.ShoppingItemIDRequest](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.shoppingService.ToggleShoppingItem(userID, uri.ID, uri.ItemID)
+	if err != nil {
+		h.handleItemError(c, err, "更新购买状态失败")
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.Success(resp))
+}
+
+// RegenerateShoppingList 重新生成购物清单
+// @Summary 重新生成购物清单
+// @Description 按清单原来的日期范围或菜单重新汇总食材，手动添加或修改过的清单项保留，同食材同单位的清单项保留已购买状态。需要Bearer Token认证。
+// @Tags 购物清单
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "购物清单ID"
+// @Success 200 {object} utils.Response{data=models.ShoppingListDetail} "重新生成成功"
+// @Failure 401 {object} utils.Response "未授权"
+// @Failure 404 {object} utils.Response "购物清单或家庭不存在"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /shopping-lists/{id}/regenerate [post]
+func (h *ShoppingHandler) RegenerateShoppingList(c *gin.Context) {
+	uri, err := utils.BindURI[models.ShoppingListIDRequest](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.shoppingService.RegenerateShoppingList(userID, uri.ID)
+	if err != nil {
+		h.handleItemError(c, err, "重新生成购物清单失败")
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessWithMessage("重新生成成功", resp))
+}
+
+func (h *ShoppingHandler) handleItemError(c *gin.Context, err error, message string) {
+	switch err {
+	case services.ErrFamilyNotFound:
+		c.JSON(http.StatusNotFound, utils.NotFound("请先创建或加入家庭"))
+	case services.ErrShoppingListNotFound:
+		c.JSON(http.StatusNotFound, utils.NotFound("购物清单不存在"))
+	case services.ErrShoppingItemNotFound:
+		c.JSON(http.StatusNotFound, utils.NotFound("清单项不存在"))
+	case services.ErrShoppingIngredientNotFound:
+		c.JSON(http.StatusBadRequest, utils.BadRequest("食材不存在或已停用"))
+	default:
+		c.JSON(http.StatusInternalServerError, utils.InternalServerError(message))
+	}
+}
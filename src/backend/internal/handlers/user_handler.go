@@ -1,9 +1,11 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"onetaste-family/backend/internal/models"
 	"onetaste-family/backend/internal/services"
 	"onetaste-family/backend/internal/utils"
 )
@@ -62,3 +64,155 @@ func (h *UserHandler) GetUserInfo(c *gin.Context) {
 
 	c.JSON(http.StatusOK, utils.Success(info))
 }
+
+// ChangePassword 修改密码
+// @Summary 修改密码
+// @Description 校验当前密码后设置新密码，成功后其他设备的登录会话全部失效，当前会话保持登录。需要Bearer Token认证。
+// @Tags 用户
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.ChangePasswordRequest true "修改密码请求参数"
+// @Success 200 {object} utils.Response{data=models.ChangePasswordResponse} "修改成功"
+// @Failure 400 {object} utils.Response "请求参数错误或当前密码不正确"
+// @Failure 401 {object} utils.Response "未授权或Token无效"
+// @Failure 404 {object} utils.Response "用户不存在"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /user/password [put]
+func (h *UserHandler) ChangePassword(c *gin.Context) {
+	req, err := utils.BindJSON[models.ChangePasswordRequest](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.userService.ChangePassword(c.Request.Context(), userID, c.GetString("session_id"), req)
+	if err != nil {
+		switch err {
+		case services.ErrOldPasswordMismatch:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("当前密码不正确"))
+		case services.ErrInvalidPassword:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("新密码至少6位"))
+		case services.ErrUserNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("用户不存在"))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("修改密码失败"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessWithMessage("密码修改成功", resp))
+}
+
+// UpdateProfile 更新用户资料
+// @Summary 更新用户资料
+// @Description 更新昵称、头像与饮食偏好，未传的字段保持不变。头像需先通过媒体上传接口上传到 user/head/{userId} 目录，替换后旧头像文件会被删除。需要Bearer Token认证。
+// @Tags 用户
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.UpdateProfileRequest true "更新资料请求参数"
+// @Success 200 {object} utils.Response{data=models.UserInfoResponse} "更新成功"
+// @Failure 400 {object} utils.Response "请求参数错误或头像不合法"
+// @Failure 401 {object} utils.Response "未授权或Token无效"
+// @Failure 404 {object} utils.Response "用户不存在"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /user/profile [put]
+func (h *UserHandler) UpdateProfile(c *gin.Context) {
+	req, err := utils.BindJSON[models.UpdateProfileRequest](c)
+	if err != nil {
+		return
+	}
+
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	info, err := h.userService.UpdateProfile(c.Request.Context(), userID, req)
+	if err != nil {
+		switch err {
+		case services.ErrInvalidAvatar:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("头像必须是本人上传的图片"))
+		case services.ErrUserNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("用户不存在"))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("更新资料失败"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessWithMessage("更新成功", info))
+}
+
+// UploadAvatar 上传头像
+// @Summary 上传头像
+// @Description 上传图片到当前用户的头像目录并设为新头像，旧头像文件会被删除。需要Bearer Token认证。
+// @Tags 用户
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "头像图片"
+// @Success 200 {object} utils.Response{data=models.UserInfoResponse} "上传成功"
+// @Failure 400 {object} utils.Response "文件不合法"
+// @Failure 401 {object} utils.Response "未授权或Token无效"
+// @Failure 404 {object} utils.Response "用户不存在"
+// @Failure 500 {object} utils.Response "服务器内部错误"
+// @Router /user/avatar [post]
+func (h *UserHandler) UploadAvatar(c *gin.Context) {
+	userID, ok := getUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BadRequest("请选择要上传的头像"))
+		return
+	}
+
+	if fileHeader.Size <= 0 {
+		c.JSON(http.StatusBadRequest, utils.BadRequest("文件内容为空"))
+		return
+	}
+
+	if fileHeader.Size > services.MaxMediaFileSize {
+		maxMB := services.MaxMediaFileSize / (1024 * 1024)
+		c.JSON(http.StatusBadRequest, utils.BadRequest(fmt.Sprintf("文件大小不能超过 %dMB", maxMB)))
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, utils.InternalServerError("读取文件失败"))
+		return
+	}
+	defer file.Close()
+
+	input := &models.MediaUploadInput{
+		OriginalName: fileHeader.Filename,
+		ContentType:  detectContentType(file, fileHeader.Header.Get("Content-Type")),
+		Size:         fileHeader.Size,
+	}
+
+	info, err := h.userService.UploadAvatar(c.Request.Context(), userID, file, input)
+	if err != nil {
+		switch err {
+		case services.ErrUnsupportedMediaType:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("仅支持上传 JPG/PNG/WebP/GIF/SVG 等常见图片格式"))
+		case services.ErrInvalidMediaSize:
+			c.JSON(http.StatusBadRequest, utils.BadRequest("文件大小不合法"))
+		case services.ErrUserNotFound:
+			c.JSON(http.StatusNotFound, utils.NotFound("用户不存在"))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("上传头像失败"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessWithMessage("上传成功", info))
+}
@@ -0,0 +1,25 @@
+package jobs
+
+import (
+	"log"
+	"time"
+
+	"onetaste-family/backend/internal/services"
+)
+
+// StartDishPurge 启动回收站菜式清理任务，启动时立即执行一次，之后按 interval 周期执行
+// 返回的 stop 函数用于停止任务并等待当前执行结束
+func StartDishPurge(interval time.Duration) (stop func()) {
+	dishService := services.NewDishService()
+	return startPeriodic("dish-purge", interval, func() { runDishPurge(dishService) })
+}
+
+func runDishPurge(dishService *services.DishService) {
+	purged, err := dishService.PurgeExpiredDishes()
+	if err != nil {
+		log.Printf("Dish purge failed: %v", err)
+	}
+	if purged > 0 {
+		log.Printf("Purged %d expired dishes from trash", purged)
+	}
+}
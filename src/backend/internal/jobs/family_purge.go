@@ -0,0 +1,25 @@
+package jobs
+
+import (
+	"log"
+	"time"
+
+	"onetaste-family/backend/internal/services"
+)
+
+// StartFamilyPurge 启动已解散家庭数据清理任务，启动时立即执行一次，之后按 interval 周期执行
+// 返回的 stop 函数用于停止任务并等待当前执行结束
+func StartFamilyPurge(interval time.Duration) (stop func()) {
+	familyService := services.NewFamilyService()
+	return startPeriodic("family-purge", interval, func() { runFamilyPurge(familyService) })
+}
+
+func runFamilyPurge(familyService *services.FamilyService) {
+	purged, err := familyService.PurgeExpiredFamilies()
+	if err != nil {
+		log.Printf("Family purge failed: %v", err)
+	}
+	if purged > 0 {
+		log.Printf("Purged data of %d dissolved families", purged)
+	}
+}
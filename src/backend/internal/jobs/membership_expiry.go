@@ -0,0 +1,25 @@
+package jobs
+
+import (
+	"log"
+	"time"
+
+	"onetaste-family/backend/internal/services"
+)
+
+// StartMembershipExpiry 启动会员到期处理任务，启动时立即执行一次，之后按 interval 周期执行
+// 返回的 stop 函数用于停止任务并等待当前执行结束
+func StartMembershipExpiry(interval time.Duration) (stop func()) {
+	membershipService := services.NewMembershipService()
+	return startPeriodic("membership-expiry", interval, func() { runMembershipExpiry(membershipService) })
+}
+
+func runMembershipExpiry(membershipService *services.MembershipService) {
+	expired, err := membershipService.ExpireMemberships()
+	if err != nil {
+		log.Printf("Membership expiry failed: %v", err)
+	}
+	if expired > 0 {
+		log.Printf("Recomputed limits of %d families with expired memberships", expired)
+	}
+}
@@ -0,0 +1,36 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"onetaste-family/backend/internal/services"
+)
+
+// StartPaymentOrderExpiry 启动超时订单关闭与退款重试任务，启动时立即执行一次，之后按 interval 周期执行
+// 返回的 stop 函数用于停止任务并等待当前执行结束
+func StartPaymentOrderExpiry(interval time.Duration) (stop func()) {
+	paymentService := services.NewPaymentService()
+	return startPeriodic("payment-order-expiry", interval, func() { runPaymentOrderExpiry(paymentService) })
+}
+
+func runPaymentOrderExpiry(paymentService *services.PaymentService) {
+	expired, err := paymentService.ExpirePendingOrders()
+	if err != nil {
+		log.Printf("Payment order expiry failed: %v", err)
+		return
+	}
+	if expired > 0 {
+		log.Printf("Closed %d expired payment orders", expired)
+	}
+
+	refunded, err := paymentService.RetryPendingRefunds(context.Background())
+	if err != nil {
+		log.Printf("Payment refund retry failed: %v", err)
+		return
+	}
+	if refunded > 0 {
+		log.Printf("Completed %d pending refunds", refunded)
+	}
+}
@@ -0,0 +1,50 @@
+package jobs
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// startPeriodic 启动周期任务，启动时立即执行一次 run，之后按 interval 周期执行
+// 单次执行 panic 时记录日志并继续下一轮；返回的 stop 函数用于停止任务并等待当前执行结束
+func startPeriodic(name string, interval time.Duration, run func()) (stop func()) {
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			runOnce(name, run)
+
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+			wg.Wait()
+		})
+	}
+}
+
+func runOnce(name string, run func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Job %s panicked: %v", name, r)
+		}
+	}()
+
+	run()
+}
@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"onetaste-family/backend/internal/services"
+	"onetaste-family/backend/internal/utils"
+)
+
+// aiQuotaTicketKey 上下文中保存本次扣减额度的键
+const aiQuotaTicketKey = "ai_quota_ticket"
+
+// AIQuota AI功能额度中间件，需放在 AuthMiddleware 之后
+// 请求进入前扣减一次额度，处理器返回错误状态码（>=400）时自动退回
+func AIQuota(feature string) gin.HandlerFunc {
+	quotaService := services.NewAIQuotaService()
+
+	return func(c *gin.Context) {
+		ticket, err := quotaService.Consume(c.Request.Context(), c.GetString("user_id"), feature)
+		if err != nil {
+			var quotaErr *services.AIQuotaError
+			switch {
+			case errors.As(err, &quotaErr):
+				c.JSON(http.StatusTooManyRequests, utils.ErrorWithData(utils.CodeTooManyRequests, "本周期AI使用次数已用完", quotaErr.Usage))
+			case errors.Is(err, services.ErrAIFeatureRequiresPremium):
+				c.JSON(http.StatusForbidden, utils.Forbidden("AI功能仅付费会员可用"))
+			case errors.Is(err, services.ErrFamilyNotFound):
+				c.JSON(http.StatusNotFound, utils.NotFound("请先创建或加入家庭"))
+			default:
+				c.JSON(http.StatusInternalServerError, utils.InternalServerError("AI额度校验失败"))
+			}
+			c.Abort()
+			return
+		}
+
+		c.Set(aiQuotaTicketKey, ticket)
+		c.Next()
+
+		if c.Writer.Status() >= http.StatusBadRequest {
+			if err := quotaService.Release(context.Background(), ticket); err != nil {
+				log.Printf("failed to release ai quota for user %s: %v", ticket.UserID, err)
+			}
+		}
+	}
+}
+
+// GetAIQuotaTicket 获取 AIQuota 中间件扣减的额度，处理器可据此返回剩余次数
+func GetAIQuotaTicket(c *gin.Context) (*services.AIQuotaTicket, bool) {
+	value, exists := c.Get(aiQuotaTicketKey)
+	if !exists {
+		return nil, false
+	}
+	ticket, ok := value.(*services.AIQuotaTicket)
+	return ticket, ok
+}
@@ -5,11 +5,14 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"onetaste-family/backend/internal/services"
 	"onetaste-family/backend/internal/utils"
 )
 
 // AuthMiddleware 认证中间件
 func AuthMiddleware() gin.HandlerFunc {
+	sessionService := services.NewSessionService()
+
 	return func(c *gin.Context) {
 		// 获取Authorization header
 		authHeader := c.GetHeader("Authorization")
@@ -36,8 +39,22 @@ func AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		// 将用户ID存储到上下文
+		// 检查token是否已随会话吊销
+		revoked, err := sessionService.IsTokenRevoked(c.Request.Context(), claims.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, utils.InternalServerError("认证服务暂不可用"))
+			c.Abort()
+			return
+		}
+		if revoked {
+			c.JSON(http.StatusUnauthorized, utils.Unauthorized("token已失效，请重新登录"))
+			c.Abort()
+			return
+		}
+
+		// 将用户ID与会话ID存储到上下文
 		c.Set("user_id", claims.UserID)
+		c.Set("session_id", claims.SessionID)
 		c.Next()
 	}
 }
@@ -0,0 +1,66 @@
+package models
+
+import "time"
+
+const (
+	// AIFeatureVoiceInput 语音输入整理菜式
+	AIFeatureVoiceInput = "voice_input"
+	// AIFeatureHealthAnalyze 身体状况分析
+	AIFeatureHealthAnalyze = "health_analyze"
+	// AIFeatureMenuGenerate 菜单生成
+	AIFeatureMenuGenerate = "menu_generate"
+	// AIFeatureMenuAnalyze 菜单分析
+	AIFeatureMenuAnalyze = "menu_analyze"
+	// AIFeatureCookingOptimize 烹饪步骤优化
+	AIFeatureCookingOptimize = "cooking_optimize"
+)
+
+const (
+	// AIQuotaPeriodDaily 按自然日统计
+	AIQuotaPeriodDaily = "daily"
+	// AIQuotaPeriodWeekly 按自然周（周一开始）统计
+	AIQuotaPeriodWeekly = "weekly"
+)
+
+const (
+	// AIQuotaScopeUser 每个用户单独计数
+	AIQuotaScopeUser = "user"
+	// AIQuotaScopeFamily 家庭成员共享计数
+	AIQuotaScopeFamily = "family"
+)
+
+// AIQuotaUnlimited 不限次数
+const AIQuotaUnlimited = -1
+
+// AIUsageLog AI调用记录实体，每个用户每个功能每个周期一行
+type AIUsageLog struct {
+	ID          string
+	UserID      string
+	FamilyID    string
+	FeatureType string
+	PeriodType  string
+	PeriodDate  time.Time
+	UsageCount  int
+	LimitCount  int
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// AIQuotaUsage 单个AI功能的额度使用情况
+type AIQuotaUsage struct {
+	Feature   string    `json:"feature" example:"menu_generate"`              // 功能：voice_input, health_analyze, menu_generate, menu_analyze, cooking_optimize
+	Period    string    `json:"period" example:"weekly"`                      // 统计周期：daily-每天，weekly-每周
+	Scope     string    `json:"scope" example:"family"`                       // 计数范围：user-每人，family-家庭共享
+	Limit     int       `json:"limit" example:"5"`                            // 周期内可用次数，-1表示不限
+	Used      int       `json:"used" example:"2"`                             // 周期内已用次数
+	Remaining int       `json:"remaining" example:"3"`                        // 剩余次数，-1表示不限
+	ResetAt   time.Time `json:"reset_at" example:"2024-01-08T00:00:00+08:00"` // 额度重置时间
+}
+
+// AIQuotaResponse AI额度查询响应
+// @Description 当前用户各AI功能的剩余次数，AI功能仅付费版可用
+type AIQuotaResponse struct {
+	MembershipType string          `json:"membership_type" example:"premium"` // 会员类型：free-免费版，premium-付费版
+	Available      bool            `json:"available" example:"true"`          // 是否可使用AI功能
+	Quotas         []*AIQuotaUsage `json:"quotas"`
+}
@@ -0,0 +1,37 @@
+package models
+
+const (
+	// EquipmentWok 炒锅
+	EquipmentWok = "wok"
+	// EquipmentOven 烤箱
+	EquipmentOven = "oven"
+	// EquipmentBurner 灶眼（炖锅、蒸锅、汤锅等）
+	EquipmentBurner = "burner"
+)
+
+// ScheduledStep 排程后时间线上的一个步骤
+type ScheduledStep struct {
+	Order           int    `json:"order"` // 时间线中的顺序
+	DishID          string `json:"dish_id"`
+	DishName        string `json:"dish_name"`
+	StepOrder       int    `json:"step_order"` // 该步骤在菜式中的序号
+	Content         string `json:"content"`
+	StartMinute     int    `json:"start_minute"` // 相对开始烹饪的分钟数
+	EndMinute       int    `json:"end_minute"`
+	DurationMinutes int    `json:"duration_minutes"`
+	Passive         bool   `json:"passive"`             // 无需看管，可同时进行其他步骤
+	Equipment       string `json:"equipment,omitempty"` // 占用的厨具
+	Estimated       bool   `json:"estimated"`           // 步骤未设置时长，按默认时长估算
+}
+
+// CookingScheduleResponse 一餐多道菜的烹饪排程
+type CookingScheduleResponse struct {
+	MenuID            string           `json:"menu_id"`
+	Date              string           `json:"date"`
+	MealType          string           `json:"meal_type"`
+	TotalMinutes      int              `json:"total_minutes"`      // 排程后的总用时
+	SequentialMinutes int              `json:"sequential_minutes"` // 逐道菜依次做完的用时
+	SavedMinutes      int              `json:"saved_minutes"`      // 节省的时间
+	Equipment         map[string]int   `json:"equipment"`          // 参与排程的厨具数量
+	Steps             []*ScheduledStep `json:"steps"`
+}
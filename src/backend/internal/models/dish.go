@@ -2,6 +2,18 @@ package models
 
 import "time"
 
+const (
+	// DishDifficultyEasy 简单
+	DishDifficultyEasy = "easy"
+	// DishDifficultyMedium 中等
+	DishDifficultyMedium = "medium"
+	// DishDifficultyHard 困难
+	DishDifficultyHard = "hard"
+
+	// DefaultDishServings 未填写时菜式的默认份数
+	DefaultDishServings = 2
+)
+
 // IngredientInput 菜式食材入参
 type IngredientInput struct {
 	IngredientID string  `json:"ingredient_id" binding:"required,min=1,max=26"`
@@ -13,30 +25,56 @@ type IngredientInput struct {
 
 // CookingStepInput 烹饪步骤入参
 type CookingStepInput struct {
-	Order    int    `json:"order" binding:"required,min=1,max=200"`
-	Content  string `json:"content" binding:"required,max=2000"`
-	ImageURL string `json:"image_url" binding:"omitempty,max=500"`
+	Order           int    `json:"order" binding:"required,min=1,max=200"`
+	Content         string `json:"content" binding:"required,max=2000"`
+	ImageURL        string `json:"image_url" binding:"omitempty,max=500"`
+	DurationMinutes int    `json:"duration_minutes" binding:"omitempty,min=0,max=1440"` // 步骤时长（分钟），不填表示未设置
+	Passive         bool   `json:"passive"`                                             // 是否无需看管（炖煮、烤制、腌制等）
+	Equipment       string `json:"equipment" binding:"omitempty,oneof=wok oven burner"` // 所需厨具：wok、oven、burner
 }
 
 // CreateDishRequest 创建菜式请求
 type CreateDishRequest struct {
 	Name        string             `json:"name" binding:"required,max=100"`
-	Category    string             `json:"category" binding:"omitempty,max=50"`
+	Category    string             `json:"category" binding:"omitempty,max=50"` // 分类，保存时自动关联同名标签，不存在则创建为自定义标签
 	Description string             `json:"description" binding:"omitempty,max=2000"`
 	ImageURL    string             `json:"image_url" binding:"omitempty,max=500"`
 	Ingredients []IngredientInput  `json:"ingredients" binding:"required"`
 	Steps       []CookingStepInput `json:"steps" binding:"required"`
+	TagIDs      []string           `json:"tag_ids" binding:"omitempty,max=20,dive,len=26"`        // 标签ID
+	PrepMinutes int                `json:"prep_minutes" binding:"omitempty,min=0,max=1440"`       // 备料时间（分钟）
+	CookMinutes int                `json:"cook_minutes" binding:"omitempty,min=0,max=1440"`       // 烹饪时间（分钟）
+	Servings    int                `json:"servings" binding:"omitempty,min=1,max=50"`             // 默认份数，不填为2
+	Difficulty  string             `json:"difficulty" binding:"omitempty,oneof=easy medium hard"` // 难度：easy、medium、hard
 }
 
 // UpdateDishRequest 更新菜式请求
-type UpdateDishRequest CreateDishRequest
+type UpdateDishRequest struct {
+	Name        string             `json:"name" binding:"required,max=100"`
+	Category    string             `json:"category" binding:"omitempty,max=50"` // 分类，保存时自动关联同名标签，不存在则创建为自定义标签
+	Description string             `json:"description" binding:"omitempty,max=2000"`
+	ImageURL    string             `json:"image_url" binding:"omitempty,max=500"`
+	Ingredients []IngredientInput  `json:"ingredients" binding:"required"`
+	Steps       []CookingStepInput `json:"steps" binding:"required"`
+	TagIDs      []string           `json:"tag_ids" binding:"omitempty,max=20,dive,len=26"`        // 标签ID，传入时整体替换，传空数组清空，不传保持不变
+	PrepMinutes *int               `json:"prep_minutes" binding:"omitempty,min=0,max=1440"`       // 备料时间（分钟），不传保持不变
+	CookMinutes *int               `json:"cook_minutes" binding:"omitempty,min=0,max=1440"`       // 烹饪时间（分钟），不传保持不变
+	Servings    *int               `json:"servings" binding:"omitempty,min=1,max=50"`             // 默认份数，不传保持不变
+	Difficulty  *string            `json:"difficulty" binding:"omitempty,oneof=easy medium hard"` // 难度：easy、medium、hard，传空字符串清空，不传保持不变
+}
 
 // DishListRequest 菜式列表查询请求
 type DishListRequest struct {
-	Page     int    `form:"page,default=1" binding:"min=1"`
-	PageSize int    `form:"page_size,default=20" binding:"min=1,max=100"`
-	Category string `form:"category" binding:"omitempty,max=50"`
-	Keyword  string `form:"keyword" binding:"omitempty,max=100"`
+	Page       int      `form:"page,default=1" binding:"min=1"`
+	PageSize   int      `form:"page_size,default=20" binding:"min=1,max=100"`
+	TagIDs     []string `form:"tag_ids" binding:"omitempty,max=10,dive,len=26"`        // 同时带有全部标签的菜式
+	Difficulty string   `form:"difficulty" binding:"omitempty,oneof=easy medium hard"` // 难度：easy、medium、hard
+	Keyword    string   `form:"keyword" binding:"omitempty,max=100"`
+}
+
+// DishDetailQuery 菜式详情查询参数
+type DishDetailQuery struct {
+	Servings int `form:"servings" binding:"omitempty,min=1,max=50"` // 按指定份数缩放食材用量，不填按菜式默认份数
 }
 
 // DishIDRequest 菜式ID请求
@@ -52,11 +90,20 @@ type Dish struct {
 	Category    string    `json:"category,omitempty"`
 	Description string    `json:"description,omitempty"`
 	ImageURL    string    `json:"image_url,omitempty"`
+	PrepMinutes int       `json:"prep_minutes"`
+	CookMinutes int       `json:"cook_minutes"`
+	Servings    int       `json:"servings"`
+	Difficulty  string    `json:"difficulty,omitempty"`
 	CreatedBy   string    `json:"created_by"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
 
+// TotalMinutes 备料与烹饪总时间（分钟）
+func (d *Dish) TotalMinutes() int {
+	return d.PrepMinutes + d.CookMinutes
+}
+
 // BasicIngredient 基础食材实体
 type BasicIngredient struct {
 	ID          string
@@ -67,6 +114,8 @@ type BasicIngredient struct {
 	StorageDays *int
 	Description string
 	IsActive    bool
+	Density     *float64 // 密度（克/毫升），用于体积与质量换算
+	PieceWeight *float64 // 单个重量（克），用于计数单位与质量换算
 }
 
 // IngredientSearchResult 食材搜索结果
@@ -118,32 +167,48 @@ type Ingredient struct {
 
 // CookingStep 烹饪步骤实体
 type CookingStep struct {
-	ID       string `json:"step_id"`
-	DishID   string `json:"-"`
-	Order    int    `json:"order"`
-	Content  string `json:"content"`
-	ImageURL string `json:"image_url,omitempty"`
+	ID              string `json:"step_id"`
+	DishID          string `json:"-"`
+	Order           int    `json:"order"`
+	Content         string `json:"content"`
+	ImageURL        string `json:"image_url,omitempty"`
+	DurationMinutes int    `json:"duration_minutes"`
+	Passive         bool   `json:"passive"`
+	Equipment       string `json:"equipment,omitempty"`
 }
 
 // DishCreateResponse 创建菜式响应
 type DishCreateResponse struct {
-	DishID      string        `json:"dish_id"`
-	Name        string        `json:"name"`
-	Category    string        `json:"category,omitempty"`
-	Description string        `json:"description,omitempty"`
-	ImageURL    string        `json:"image_url,omitempty"`
-	Ingredients []*Ingredient `json:"ingredients"`
+	DishID       string        `json:"dish_id"`
+	Name         string        `json:"name"`
+	Category     string        `json:"category,omitempty"`
+	Description  string        `json:"description,omitempty"`
+	ImageURL     string        `json:"image_url,omitempty"`
+	Ingredients  []*Ingredient `json:"ingredients"`
+	Tags         []*DishTag    `json:"tags"`
+	PrepMinutes  int           `json:"prep_minutes"`
+	CookMinutes  int           `json:"cook_minutes"`
+	TotalMinutes int           `json:"total_minutes"` // 备料与烹饪时间之和
+	Servings     int           `json:"servings"`
+	Difficulty   string        `json:"difficulty,omitempty"`
 }
 
 // DishSummary 菜式列表项
 type DishSummary struct {
-	DishID      string    `json:"dish_id"`
-	Name        string    `json:"name"`
-	Category    string    `json:"category,omitempty"`
-	Description string    `json:"description,omitempty"`
-	ImageURL    string    `json:"image_url,omitempty"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	DishID       string     `json:"dish_id"`
+	Name         string     `json:"name"`
+	Category     string     `json:"category,omitempty"`
+	Description  string     `json:"description,omitempty"`
+	ImageURL     string     `json:"image_url,omitempty"`
+	Tags         []*DishTag `json:"tags,omitempty"`
+	PrepMinutes  int        `json:"prep_minutes"`
+	CookMinutes  int        `json:"cook_minutes"`
+	TotalMinutes int        `json:"total_minutes"` // 备料与烹饪时间之和
+	Servings     int        `json:"servings"`
+	Difficulty   string     `json:"difficulty,omitempty"`
+	MealServings int        `json:"meal_servings,omitempty"` // 本餐准备的份数，仅在菜单中返回
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
 }
 
 // DishListResponse 菜式列表响应
@@ -156,13 +221,20 @@ type DishListResponse struct {
 
 // DishDetailResponse 菜式详情响应
 type DishDetailResponse struct {
-	DishID      string         `json:"dish_id"`
-	Name        string         `json:"name"`
-	Category    string         `json:"category,omitempty"`
-	Description string         `json:"description,omitempty"`
-	ImageURL    string         `json:"image_url,omitempty"`
-	Ingredients []*Ingredient  `json:"ingredients"`
-	Steps       []*CookingStep `json:"steps"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
+	DishID       string         `json:"dish_id"`
+	Name         string         `json:"name"`
+	Category     string         `json:"category,omitempty"`
+	Description  string         `json:"description,omitempty"`
+	ImageURL     string         `json:"image_url,omitempty"`
+	Ingredients  []*Ingredient  `json:"ingredients"`
+	Steps        []*CookingStep `json:"steps"`
+	Tags         []*DishTag     `json:"tags"`
+	PrepMinutes  int            `json:"prep_minutes"`
+	CookMinutes  int            `json:"cook_minutes"`
+	TotalMinutes int            `json:"total_minutes"` // 备料与烹饪时间之和
+	Servings     int            `json:"servings"`      // 食材用量对应的份数
+	BaseServings int            `json:"base_servings"` // 菜式默认份数
+	Difficulty   string         `json:"difficulty,omitempty"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
 }
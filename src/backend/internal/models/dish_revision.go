@@ -0,0 +1,115 @@
+package models
+
+import "time"
+
+const (
+	// DishRevisionActionCreate 创建菜式
+	DishRevisionActionCreate = "create"
+	// DishRevisionActionUpdate 编辑菜式
+	DishRevisionActionUpdate = "update"
+	// DishRevisionActionRestore 恢复历史版本
+	DishRevisionActionRestore = "restore"
+)
+
+const (
+	// DishChangeAdded 新增
+	DishChangeAdded = "added"
+	// DishChangeRemoved 删除
+	DishChangeRemoved = "removed"
+	// DishChangeModified 修改
+	DishChangeModified = "modified"
+)
+
+// DishSnapshot 菜式某一版本的完整内容
+type DishSnapshot struct {
+	Name        string         `json:"name"`
+	Category    string         `json:"category,omitempty"`
+	Description string         `json:"description,omitempty"`
+	ImageURL    string         `json:"image_url,omitempty"`
+	PrepMinutes int            `json:"prep_minutes"`
+	CookMinutes int            `json:"cook_minutes"`
+	Servings    int            `json:"servings"`
+	Difficulty  string         `json:"difficulty,omitempty"`
+	Ingredients []*Ingredient  `json:"ingredients"`
+	Steps       []*CookingStep `json:"steps"`
+	Tags        []*DishTag     `json:"tags"`
+}
+
+// DishRevision 菜式修订记录，写入后不可修改
+type DishRevision struct {
+	ID           string        `json:"revision_id"`
+	DishID       string        `json:"dish_id"`
+	FamilyID     string        `json:"-"`
+	RevisionNo   int           `json:"revision_no"`
+	Action       string        `json:"action"`                  // create, update, restore
+	RestoredFrom int           `json:"restored_from,omitempty"` // 恢复自哪个版本号
+	EditedBy     string        `json:"edited_by"`
+	EditorName   string        `json:"editor_name,omitempty"`
+	Snapshot     *DishSnapshot `json:"snapshot,omitempty"` // 列表中不返回
+	CreatedAt    time.Time     `json:"created_at"`
+}
+
+// DishRevisionListRequest 菜式修订记录列表请求
+type DishRevisionListRequest struct {
+	Page     int `form:"page,default=1" binding:"min=1"`
+	PageSize int `form:"page_size,default=20" binding:"min=1,max=100"`
+}
+
+// DishRevisionRequest 菜式修订记录请求
+type DishRevisionRequest struct {
+	ID         string `uri:"id" binding:"required,len=26"`
+	RevisionNo int    `uri:"revision" binding:"required,min=1"`
+}
+
+// DishRevisionDiffRequest 比较两个版本请求
+type DishRevisionDiffRequest struct {
+	From int `form:"from" binding:"required,min=1"` // 旧版本号
+	To   int `form:"to" binding:"required,min=1"`   // 新版本号
+}
+
+// DishRevisionListResponse 菜式修订记录列表响应
+type DishRevisionListResponse struct {
+	Revisions []*DishRevision `json:"revisions"`
+	Total     int64           `json:"total"`
+	Page      int             `json:"page"`
+	PageSize  int             `json:"page_size"`
+}
+
+// DishFieldChange 菜式基本信息的字段变化
+type DishFieldChange struct {
+	Field string      `json:"field"` // name, category, description, image_url, prep_minutes, cook_minutes, servings, difficulty
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+}
+
+// DishIngredientChange 食材变化，同一食材与单位视为同一项
+type DishIngredientChange struct {
+	Change         string      `json:"change"` // added, removed, modified
+	IngredientID   string      `json:"ingredient_id"`
+	IngredientName string      `json:"ingredient_name"`
+	Unit           string      `json:"unit"`
+	Fields         []string    `json:"fields,omitempty"` // 修改的字段：amount, notes, sort_order
+	Old            *Ingredient `json:"old,omitempty"`
+	New            *Ingredient `json:"new,omitempty"`
+}
+
+// DishStepChange 烹饪步骤变化，按步骤序号比较
+type DishStepChange struct {
+	Change string       `json:"change"` // added, removed, modified
+	Order  int          `json:"order"`
+	Fields []string     `json:"fields,omitempty"` // 修改的字段：content, image_url, duration_minutes, passive, equipment
+	Old    *CookingStep `json:"old,omitempty"`
+	New    *CookingStep `json:"new,omitempty"`
+}
+
+// DishRevisionDiffResponse 两个版本的逐项差异
+type DishRevisionDiffResponse struct {
+	DishID      string                  `json:"dish_id"`
+	From        int                     `json:"from"`
+	To          int                     `json:"to"`
+	Fields      []*DishFieldChange      `json:"fields"`
+	Ingredients []*DishIngredientChange `json:"ingredients"`
+	Steps       []*DishStepChange       `json:"steps"`
+	TagsAdded   []*DishTag              `json:"tags_added"`
+	TagsRemoved []*DishTag              `json:"tags_removed"`
+}
@@ -0,0 +1,60 @@
+package models
+
+import "time"
+
+const (
+	// DishTagGroupMainIngredient 主料
+	DishTagGroupMainIngredient = "main_ingredient"
+	// DishTagGroupFlavor 口味
+	DishTagGroupFlavor = "flavor"
+	// DishTagGroupCustom 家庭自定义
+	DishTagGroupCustom = "custom"
+)
+
+// DishTagGroupNames 标签分组名称，按展示顺序排列
+var DishTagGroupNames = []struct {
+	Code string
+	Name string
+}{
+	{DishTagGroupMainIngredient, "主料"},
+	{DishTagGroupFlavor, "口味"},
+	{DishTagGroupCustom, "自定义"},
+}
+
+// DishTag 菜式标签
+type DishTag struct {
+	ID        string    `json:"tag_id"`
+	FamilyID  string    `json:"-"` // 为空表示系统标签
+	Group     string    `json:"group"`
+	Name      string    `json:"name"`
+	SortOrder int       `json:"-"`
+	CreatedBy string    `json:"-"`
+	CreatedAt time.Time `json:"-"`
+}
+
+// IsSystem 是否为系统预置标签
+func (t *DishTag) IsSystem() bool {
+	return t.FamilyID == ""
+}
+
+// CreateDishTagRequest 新增自定义标签请求
+type CreateDishTagRequest struct {
+	Name string `json:"name" binding:"required,max=20" example:"宝宝辅食"`
+}
+
+// DishTagIDRequest 标签ID请求
+type DishTagIDRequest struct {
+	ID string `uri:"id" binding:"required,len=26"`
+}
+
+// DishTagGroup 一个分组下的标签
+type DishTagGroup struct {
+	Group string     `json:"group"` // main_ingredient, flavor, custom
+	Name  string     `json:"name"`
+	Tags  []*DishTag `json:"tags"`
+}
+
+// DishTagListResponse 标签列表响应
+type DishTagListResponse struct {
+	Groups []*DishTagGroup `json:"groups"`
+}
@@ -0,0 +1,163 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	// DishTransferFormatJSON 本应用的菜式导出格式
+	DishTransferFormatJSON = "json"
+	// DishTransferFormatJSONLD schema.org Recipe JSON-LD
+	DishTransferFormatJSONLD = "jsonld"
+)
+
+const (
+	// DishTransferKind 菜式导出包的格式标识
+	DishTransferKind = "onetaste.dishes"
+	// DishTransferVersion 菜式导出包的格式版本
+	DishTransferVersion = 1
+)
+
+const (
+	// DishImportStatusCreated 已创建
+	DishImportStatusCreated = "created"
+	// DishImportStatusReady 可导入（预览模式下未创建）
+	DishImportStatusReady = "ready"
+	// DishImportStatusSkipped 已跳过
+	DishImportStatusSkipped = "skipped"
+)
+
+const (
+	// DishImportReasonInvalidName 名称为空
+	DishImportReasonInvalidName = "invalid_name"
+	// DishImportReasonNameExists 家庭中已有同名菜式
+	DishImportReasonNameExists = "name_exists"
+	// DishImportReasonDuplicateName 导入数据中菜式名称重复
+	DishImportReasonDuplicateName = "duplicate_name"
+	// DishImportReasonNoIngredients 没有匹配到食材库的食材
+	DishImportReasonNoIngredients = "no_ingredients"
+	// DishImportReasonNoSteps 没有烹饪步骤
+	DishImportReasonNoSteps = "no_steps"
+	// DishImportReasonTooManyIngredients 食材数量超过上限
+	DishImportReasonTooManyIngredients = "too_many_ingredients"
+	// DishImportReasonTooManySteps 步骤数量超过上限
+	DishImportReasonTooManySteps = "too_many_steps"
+)
+
+// DishExportQuery 菜式导出请求参数
+type DishExportQuery struct {
+	Format string `form:"format,default=json" binding:"oneof=json jsonld"` // json 或 jsonld
+	DishID string `form:"dish_id" binding:"omitempty,len=26"`              // 不传则导出全部菜式
+}
+
+// DishTransferBundle 菜式导出包，导入时原样提交
+type DishTransferBundle struct {
+	Kind       string          `json:"kind" example:"onetaste.dishes"`
+	Version    int             `json:"version" example:"1"`
+	ExportedAt time.Time       `json:"exported_at"`
+	Dishes     []*PortableDish `json:"dishes"`
+}
+
+// PortableDish 导出包中的菜式，标签与食材均带名称，可在其他家庭导入
+type PortableDish struct {
+	Name        string                `json:"name"`
+	Category    string                `json:"category,omitempty"`
+	Description string                `json:"description,omitempty"`
+	ImageURL    string                `json:"image_url,omitempty"`
+	PrepMinutes int                   `json:"prep_minutes"`
+	CookMinutes int                   `json:"cook_minutes"`
+	Servings    int                   `json:"servings"`
+	Difficulty  string                `json:"difficulty,omitempty"`
+	Tags        []string              `json:"tags"`
+	Ingredients []*PortableIngredient `json:"ingredients"`
+	Steps       []*CookingStepInput   `json:"steps"`
+}
+
+// PortableIngredient 导出包中的食材
+type PortableIngredient struct {
+	IngredientID string  `json:"ingredient_id,omitempty"` // 基础食材ID，导入时优先按ID匹配
+	Name         string  `json:"name"`
+	Amount       float64 `json:"amount"`
+	Unit         string  `json:"unit"`
+	Notes        string  `json:"notes,omitempty"`
+}
+
+// RecipeGraph schema.org Recipe JSON-LD 文档
+type RecipeGraph struct {
+	Context string    `json:"@context" example:"https://schema.org"`
+	Graph   []*Recipe `json:"@graph"`
+}
+
+// Recipe schema.org Recipe
+type Recipe struct {
+	Type               string             `json:"@type" example:"Recipe"`
+	Name               string             `json:"name"`
+	Description        string             `json:"description,omitempty"`
+	Image              []string           `json:"image,omitempty"`
+	RecipeCategory     string             `json:"recipeCategory,omitempty"`
+	Keywords           string             `json:"keywords,omitempty"`    // 标签，逗号分隔
+	RecipeYield        string             `json:"recipeYield,omitempty"` // 份数
+	PrepTime           string             `json:"prepTime,omitempty"`    // ISO 8601 时长，如 PT15M
+	CookTime           string             `json:"cookTime,omitempty"`
+	TotalTime          string             `json:"totalTime,omitempty"`
+	RecipeIngredient   []string           `json:"recipeIngredient"`
+	RecipeInstructions []*RecipeHowToStep `json:"recipeInstructions"`
+}
+
+// RecipeHowToStep schema.org HowToStep
+type RecipeHowToStep struct {
+	Type     string `json:"@type" example:"HowToStep"`
+	Position int    `json:"position"`
+	Text     string `json:"text"`
+	Image    string `json:"image,omitempty"`
+}
+
+// DishImportRequest 菜式导入请求
+type DishImportRequest struct {
+	Format string          `json:"format" binding:"required,oneof=json jsonld"`
+	Data   json.RawMessage `json:"data" binding:"required" swaggertype:"object"` // 导出接口返回的 data，或其他应用导出的 Recipe JSON-LD
+	DryRun bool            `json:"dry_run"`                                      // 只返回匹配结果，不创建菜式
+}
+
+// DishImportIngredient 导入的食材及其与食材库的匹配结果
+type DishImportIngredient struct {
+	Text           string                    `json:"text,omitempty"` // JSON-LD 中的原始食材文本
+	Name           string                    `json:"name"`           // 解析出的食材名称
+	Amount         float64                   `json:"amount"`
+	Unit           string                    `json:"unit"`
+	Notes          string                    `json:"notes,omitempty"`
+	Matched        bool                      `json:"matched"`                   // 是否已匹配到食材库
+	IngredientID   string                    `json:"ingredient_id,omitempty"`   // 匹配到的食材ID
+	IngredientName string                    `json:"ingredient_name,omitempty"` // 匹配到的食材名称
+	Candidates     []*IngredientSearchResult `json:"candidates,omitempty"`      // 未匹配时的候选食材
+}
+
+// DishImportItem 单个菜式的导入结果
+type DishImportItem struct {
+	Name           string                  `json:"name"`
+	Status         string                  `json:"status"`           // created, ready, skipped
+	Reason         string                  `json:"reason,omitempty"` // 跳过原因
+	DishID         string                  `json:"dish_id,omitempty"`
+	Ingredients    []*DishImportIngredient `json:"ingredients"`
+	UnmatchedCount int                     `json:"unmatched_count"`          // 未匹配到食材库的食材数，这些食材不会导入
+	UnmatchedTags  []string                `json:"unmatched_tags,omitempty"` // 本家庭不存在的标签，不会导入
+}
+
+// DishImportResponse 菜式导入响应
+type DishImportResponse struct {
+	DryRun         bool              `json:"dry_run"`
+	CreatedCount   int               `json:"created_count"`
+	SkippedCount   int               `json:"skipped_count"`
+	UnmatchedCount int               `json:"unmatched_count"` // 全部菜式中未匹配的食材数
+	Dishes         []*DishImportItem `json:"dishes"`
+}
+
+// DishWithDetails 待创建的菜式及其详情，用于批量写入
+type DishWithDetails struct {
+	Dish        *Dish
+	Ingredients []*Ingredient
+	Steps       []*CookingStep
+	TagIDs      []string
+	Revision    *DishRevision
+}
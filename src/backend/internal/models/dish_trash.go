@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// DeleteDishRequest 删除菜式请求参数
+type DeleteDishRequest struct {
+	Force bool `form:"force"` // 菜式已被菜单引用时需传 true 确认删除
+}
+
+// DishTrashListRequest 回收站菜式列表请求
+type DishTrashListRequest struct {
+	Page     int `form:"page,default=1" binding:"min=1"`
+	PageSize int `form:"page_size,default=20" binding:"min=1,max=100"`
+}
+
+// DishMenuUsage 引用菜式的菜单
+type DishMenuUsage struct {
+	MenuID   string `json:"menu_id"`
+	Date     string `json:"date"`      // 格式：YYYY-MM-DD
+	MealType string `json:"meal_type"` // breakfast, lunch, dinner
+}
+
+// DishDeleteImpactResponse 删除菜式的影响范围
+// 删除后菜式会从这些菜单中移除，恢复菜式不会重新加入菜单
+type DishDeleteImpactResponse struct {
+	DishID      string           `json:"dish_id"`
+	Name        string           `json:"name"`
+	FutureMenus []*DishMenuUsage `json:"future_menus"` // 今天及以后的菜单
+	PastMenus   []*DishMenuUsage `json:"past_menus"`   // 今天以前的菜单
+	InUse       bool             `json:"in_use"`       // 为 true 时删除需传 force=true
+}
+
+// TrashedDish 回收站中的菜式
+type TrashedDish struct {
+	DishID     string    `json:"dish_id"`
+	Name       string    `json:"name"`
+	Category   string    `json:"category,omitempty"`
+	ImageURL   string    `json:"image_url,omitempty"`
+	CreatedBy  string    `json:"created_by"`
+	DeletedAt  time.Time `json:"deleted_at"`
+	PurgeAfter time.Time `json:"purge_after"` // 超过该时间后菜式被彻底删除
+}
+
+// DishTrashListResponse 回收站菜式列表响应
+type DishTrashListResponse struct {
+	Dishes   []*TrashedDish `json:"dishes"`
+	Total    int64          `json:"total"`
+	Page     int            `json:"page"`
+	PageSize int            `json:"page_size"`
+}
@@ -25,14 +25,16 @@ const (
 
 // Family 家庭模型
 type Family struct {
-	ID          string    `json:"family_id" db:"id"`
-	Name        string    `json:"name" db:"name"`
-	Description string    `json:"description,omitempty" db:"description"`
-	OwnerID     string    `json:"owner_id" db:"owner_id"`
-	MaxDishes   int       `json:"max_dishes" db:"max_dishes"`
-	Status      int       `json:"status" db:"status"`
-	CreatedAt   time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+	ID          string     `json:"family_id" db:"id"`
+	Name        string     `json:"name" db:"name"`
+	Description string     `json:"description,omitempty" db:"description"`
+	OwnerID     string     `json:"owner_id" db:"owner_id"`
+	MaxDishes   int        `json:"max_dishes" db:"max_dishes"`
+	Status      int        `json:"status" db:"status"`
+	DissolvedAt *time.Time `json:"dissolved_at,omitempty" db:"dissolved_at"`
+	PurgeAfter  *time.Time `json:"purge_after,omitempty" db:"purge_after"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
 }
 
 // FamilyMember 家庭成员模型
@@ -75,11 +77,8 @@ type FamilyInfoResponse struct {
 
 // FamilyInviteRequest 扫码加入家庭请求
 type FamilyInviteRequest struct {
-	FamilyID        string `json:"family_id" binding:"required,len=26" example:"01HZX1YF8Y6S7K4V9Q2J3M5N6P"`
-	FamilyName      string `json:"family_name" binding:"required" example:"张家的厨房"`
-	InviterID       string `json:"inviter_id" binding:"required,len=26" example:"01HZX1YF8Y6S7K4V9Q2J3M5N6Q"`
-	InviterNickname string `json:"inviter_nickname" binding:"required" example:"张三"`
-	Action          string `json:"action" binding:"required" example:"accept"`
+	Token  string `json:"token" binding:"required,max=128" example:"01HZX1YF8Y6S7K4V9Q2J3M5N6S.q3Zk..."` // 二维码中的邀请令牌
+	Action string `json:"action" binding:"required" example:"accept"`
 }
 
 // FamilyJoinResponse 扫码加入家庭响应
@@ -97,3 +96,40 @@ type FamilyMemberInfo struct {
 	Role     string    `json:"role" example:"member"`
 	JoinedAt time.Time `json:"joined_at" example:"2024-01-01T00:00:00Z"`
 }
+
+// FamilyMemberURIRequest 家庭成员路径参数
+type FamilyMemberURIRequest struct {
+	UserID string `uri:"user_id" binding:"required,len=26"`
+}
+
+// UpdateFamilyMemberRoleRequest 修改成员角色请求
+type UpdateFamilyMemberRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=owner member" example:"member"` // 目标角色，设为owner即转让家庭
+}
+
+// TransferFamilyOwnershipRequest 转让家庭请求
+type TransferFamilyOwnershipRequest struct {
+	UserID string `json:"user_id" binding:"required,len=26" example:"01HZX1YF8Y6S7K4V9Q2J3M5N6R"` // 新owner的用户ID
+}
+
+// LeaveFamilyRequest 退出家庭请求
+type LeaveFamilyRequest struct {
+	NewOwnerID string `json:"new_owner_id" binding:"omitempty,len=26" example:"01HZX1YF8Y6S7K4V9Q2J3M5N6R"` // owner退出时必须指定新owner
+}
+
+// DissolveFamilyRequest 解散家庭请求
+type DissolveFamilyRequest struct {
+	ConfirmName string `json:"confirm_name" binding:"required,max=100" example:"张家的厨房"` // 再次输入家庭名称以确认解散
+}
+
+// FamilyDissolveResponse 解散家庭响应
+type FamilyDissolveResponse struct {
+	FamilyID    string    `json:"family_id" example:"01HZX1YF8Y6S7K4V9Q2J3M5N6P"`
+	DissolvedAt time.Time `json:"dissolved_at" example:"2024-01-01T00:00:00Z"`
+	PurgeAfter  time.Time `json:"purge_after" example:"2024-01-31T00:00:00Z"` // 超过该时间后数据将被清理，此前可导出
+}
+
+// FamilyExportQuery 导出家庭数据请求
+type FamilyExportQuery struct {
+	FamilyID string `form:"family_id" binding:"omitempty,len=26"` // 已解散家庭的ID，不传则导出当前家庭
+}
@@ -0,0 +1,62 @@
+package models
+
+import "time"
+
+// FamilyExportVersion 家庭数据导出格式版本
+const FamilyExportVersion = 1
+
+// FamilyExportBundle 家庭数据导出包
+type FamilyExportBundle struct {
+	Version       int                   `json:"version" example:"1"`
+	ExportedAt    time.Time             `json:"exported_at" example:"2024-01-01T00:00:00Z"`
+	Family        *FamilyExportInfo     `json:"family"`
+	Members       []*FamilyExportMember `json:"members"`
+	Dishes        []*DishDetailResponse `json:"dishes"`
+	Menus         []*MenuExport         `json:"menus"`
+	ShoppingLists []*ShoppingListExport `json:"shopping_lists"`
+}
+
+// FamilyExportInfo 导出包中的家庭信息
+type FamilyExportInfo struct {
+	FamilyID    string     `json:"family_id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	OwnerID     string     `json:"owner_id"`
+	Status      int        `json:"status"`
+	CreatedAt   time.Time  `json:"created_at"`
+	DissolvedAt *time.Time `json:"dissolved_at,omitempty"`
+	PurgeAfter  *time.Time `json:"purge_after,omitempty"`
+}
+
+// FamilyExportMember 导出包中的成员信息（包含已退出成员）
+type FamilyExportMember struct {
+	UserID   string    `json:"user_id"`
+	Nickname string    `json:"nickname"`
+	Role     string    `json:"role"`
+	Status   int       `json:"status"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// MenuExport 导出包中的菜单
+type MenuExport struct {
+	MenuID    string    `json:"menu_id"`
+	Date      string    `json:"date"` // 格式：YYYY-MM-DD
+	MealType  string    `json:"meal_type"`
+	Source    string    `json:"source"`
+	CreatedBy string    `json:"created_by"`
+	DishIDs   []string  `json:"dish_ids"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ShoppingListExport 导出包中的购物清单
+type ShoppingListExport struct {
+	ListID    string              `json:"list_id"`
+	Name      string              `json:"name"`
+	StartDate string              `json:"start_date"` // 格式：YYYY-MM-DD
+	EndDate   string              `json:"end_date"`   // 格式：YYYY-MM-DD
+	Status    string              `json:"status"`
+	Source    string              `json:"source"`
+	CreatedBy string              `json:"created_by"`
+	Items     []*ShoppingListItem `json:"items"`
+	CreatedAt time.Time           `json:"created_at"`
+}
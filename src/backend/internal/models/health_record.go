@@ -0,0 +1,82 @@
+package models
+
+import "time"
+
+const (
+	// WorkStatusRelaxed 工作清闲
+	WorkStatusRelaxed = "relaxed"
+	// WorkStatusNormal 工作正常
+	WorkStatusNormal = "normal"
+	// WorkStatusBusy 工作繁忙
+	WorkStatusBusy = "busy"
+	// WorkStatusVeryBusy 工作非常繁忙
+	WorkStatusVeryBusy = "very_busy"
+
+	// StressLevelLow 压力低
+	StressLevelLow = "low"
+	// StressLevelMedium 压力中等
+	StressLevelMedium = "medium"
+	// StressLevelHigh 压力高
+	StressLevelHigh = "high"
+)
+
+// HealthRecord 身体状况记录数据库实体
+type HealthRecord struct {
+	ID              string     `json:"record_id"`
+	UserID          string     `json:"user_id"`
+	FamilyID        string     `json:"family_id"`
+	Diseases        []string   `json:"diseases"`
+	WorkStatus      string     `json:"work_status"`
+	StressLevel     string     `json:"stress_level"`
+	BodyFeelings    string     `json:"body_feelings"`
+	RawData         string     `json:"raw_data,omitempty"`
+	AnalysisResult  string     `json:"analysis_result"`
+	Recommendations []string   `json:"recommendations"`
+	DietSuggestions []string   `json:"diet_suggestions"`
+	AnalyzedAt      *time.Time `json:"analyzed_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// CreateHealthRecordRequest 新增身体状况记录请求，至少填写一项
+type CreateHealthRecordRequest struct {
+	Diseases     []string `json:"diseases" binding:"omitempty,max=20,dive,min=1,max=50" example:"高血压,胃炎"`             // 疾病或不适
+	WorkStatus   string   `json:"work_status" binding:"omitempty,oneof=relaxed normal busy very_busy" example:"busy"` // 工作繁忙程度
+	StressLevel  string   `json:"stress_level" binding:"omitempty,oneof=low medium high" example:"medium"`            // 压力水平
+	BodyFeelings string   `json:"body_feelings" binding:"omitempty,max=1000" example:"最近睡眠不好，容易疲劳"`                   // 身体感受
+}
+
+// HealthRecordListRequest 身体状况记录列表请求
+type HealthRecordListRequest struct {
+	Page     int    `form:"page,default=1" binding:"min=1"`
+	PageSize int    `form:"page_size,default=20" binding:"min=1,max=100"`
+	UserID   string `form:"user_id" binding:"omitempty,len=26"` // 查看的成员，为空表示本人；家庭管理员仅能查看已公开的成员
+}
+
+// HealthRecordIDRequest 身体状况记录ID请求
+type HealthRecordIDRequest struct {
+	ID string `uri:"id" binding:"required,len=26"`
+}
+
+// HealthRecordListResponse 身体状况记录列表响应
+type HealthRecordListResponse struct {
+	Records  []*HealthRecord `json:"records"`
+	Total    int64           `json:"total"`
+	Page     int             `json:"page"`
+	PageSize int             `json:"page_size"`
+}
+
+// HealthRecordAnalysisResponse 身体状况AI分析响应
+type HealthRecordAnalysisResponse struct {
+	Record         *HealthRecord `json:"record"`
+	RemainingCount *int          `json:"remaining_count,omitempty"` // 今日剩余分析次数，-1表示不限
+}
+
+// HealthSharingRequest 设置身体状况记录公开请求
+type HealthSharingRequest struct {
+	Shared *bool `json:"shared" binding:"required" example:"true"` // 是否允许家庭管理员查看本人的记录
+}
+
+// HealthSharingResponse 身体状况记录公开设置
+type HealthSharingResponse struct {
+	Shared bool `json:"shared"`
+}
@@ -0,0 +1,83 @@
+package models
+
+import "time"
+
+const (
+	// FamilyInvitationStatusActive 邀请可用
+	FamilyInvitationStatusActive = "active"
+	// FamilyInvitationStatusExpired 邀请已过期
+	FamilyInvitationStatusExpired = "expired"
+	// FamilyInvitationStatusRevoked 邀请已撤销
+	FamilyInvitationStatusRevoked = "revoked"
+	// FamilyInvitationStatusExhausted 邀请次数已用完
+	FamilyInvitationStatusExhausted = "exhausted"
+)
+
+// FamilyInvitation 家庭邀请实体
+type FamilyInvitation struct {
+	ID              string
+	FamilyID        string
+	InviterID       string
+	InviterNickname string
+	TargetPhone     string
+	MaxUses         int
+	UsedCount       int
+	ExpiresAt       time.Time
+	RevokedAt       *time.Time
+	CreatedAt       time.Time
+}
+
+// Status 根据撤销、过期、使用次数计算邀请状态
+func (i *FamilyInvitation) Status(now time.Time) string {
+	switch {
+	case i.RevokedAt != nil:
+		return FamilyInvitationStatusRevoked
+	case !now.Before(i.ExpiresAt):
+		return FamilyInvitationStatusExpired
+	case i.UsedCount >= i.MaxUses:
+		return FamilyInvitationStatusExhausted
+	default:
+		return FamilyInvitationStatusActive
+	}
+}
+
+// CreateFamilyInvitationRequest 创建家庭邀请请求
+type CreateFamilyInvitationRequest struct {
+	ExpiresInHours int    `json:"expires_in_hours" binding:"omitempty,min=1,max=168" example:"24"` // 有效时长（小时），默认24
+	MaxUses        int    `json:"max_uses" binding:"omitempty,min=1,max=10" example:"1"`           // 最大使用次数，默认1
+	TargetPhone    string `json:"target_phone" binding:"omitempty,len=11" example:"13800138000"`   // 指定被邀请人手机号，可选
+}
+
+// FamilyInvitationIDRequest 家庭邀请ID请求
+type FamilyInvitationIDRequest struct {
+	ID string `uri:"id" binding:"required,len=26"`
+}
+
+// FamilyInvitationTokenQuery 邀请令牌预览请求
+type FamilyInvitationTokenQuery struct {
+	Token string `form:"token" binding:"required,max=128"`
+}
+
+// FamilyInvitationResponse 家庭邀请信息
+type FamilyInvitationResponse struct {
+	InvitationID    string    `json:"invitation_id" example:"01HZX1YF8Y6S7K4V9Q2J3M5N6S"`
+	Token           string    `json:"token,omitempty"` // 仅创建时返回，用于生成二维码
+	InviterID       string    `json:"inviter_id" example:"01HZX1YF8Y6S7K4V9Q2J3M5N6Q"`
+	InviterNickname string    `json:"inviter_nickname" example:"张三"`
+	TargetPhone     string    `json:"target_phone,omitempty" example:"138****8000"`
+	MaxUses         int       `json:"max_uses" example:"1"`
+	UsedCount       int       `json:"used_count" example:"0"`
+	Status          string    `json:"status" example:"active"` // active, expired, revoked, exhausted
+	ExpiresAt       time.Time `json:"expires_at"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// FamilyInvitationPreview 邀请落地页展示信息
+type FamilyInvitationPreview struct {
+	FamilyName      string    `json:"family_name" example:"张家的厨房"`
+	InviterNickname string    `json:"inviter_nickname" example:"张三"`
+	InviterAvatar   string    `json:"inviter_avatar,omitempty"`
+	MemberCount     int       `json:"member_count" example:"3"`
+	RequiresPhone   bool      `json:"requires_phone"` // 是否仅限指定手机号加入
+	ExpiresAt       time.Time `json:"expires_at"`
+}
@@ -0,0 +1,56 @@
+package models
+
+import "time"
+
+const (
+	// MembershipTierFree 免费版
+	MembershipTierFree = "free"
+	// MembershipTierPremium 付费版
+	MembershipTierPremium = "premium"
+)
+
+const (
+	// MembershipPlanMonthly 月付套餐
+	MembershipPlanMonthly = "monthly"
+	// MembershipPlanYearly 年付套餐
+	MembershipPlanYearly = "yearly"
+)
+
+const (
+	// MembershipStatusActive 会员有效
+	MembershipStatusActive = "active"
+	// MembershipStatusExpired 会员已过期
+	MembershipStatusExpired = "expired"
+	// MembershipStatusCancelled 会员已取消
+	MembershipStatusCancelled = "cancelled"
+)
+
+// Membership 会员实体，会员权益作用于购买时所在的家庭
+type Membership struct {
+	ID        string
+	UserID    string
+	FamilyID  string
+	PlanType  string
+	Status    string
+	StartedAt time.Time
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// MembershipTier 会员等级及其权益上限
+type MembershipTier struct {
+	Type      string `json:"type" example:"premium"`  // 会员类型：free-免费版，premium-付费版
+	MaxDishes int    `json:"max_dishes" example:"60"` // 家庭最大菜式数量
+}
+
+// MembershipResponse 会员信息响应
+// @Description 当前用户的会员类型、到期时间及权益
+type MembershipResponse struct {
+	Type      string         `json:"type" example:"premium"`                                   // 会员类型：free-免费版，premium-付费版
+	PlanType  string         `json:"plan_type,omitempty" example:"monthly"`                    // 当前套餐：monthly-月付，yearly-年付
+	ExpiresAt *time.Time     `json:"expires_at" example:"2024-02-15T00:00:00Z"`                // 到期时间，免费版为null
+	FamilyID  string         `json:"family_id,omitempty" example:"01HZX1YF8Y6S7K4V9Q2J3M5N6P"` // 权益生效的家庭ID
+	Features  MembershipTier `json:"features"`                                                 // 会员权益
+	DishCount int            `json:"dish_count" example:"15"`                                  // 家庭当前菜式数量
+}
@@ -20,16 +20,20 @@ const (
 
 // CreateMenuRequest 创建菜单请求
 type CreateMenuRequest struct {
-	Date      string   `json:"date" binding:"required"`      // 日期，格式：YYYY-MM-DD
-	MealType  string   `json:"meal_type" binding:"required,oneof=breakfast lunch dinner"` // 餐次
-	DishIDs   []string `json:"dish_ids" binding:"required,min=1,dive,len=26"`            // 菜式ID列表，至少1个
+	Date         string         `json:"date" binding:"required"`                                                 // 日期，格式：YYYY-MM-DD
+	MealType     string         `json:"meal_type" binding:"required,oneof=breakfast lunch dinner"`               // 餐次
+	DishIDs      []string       `json:"dish_ids" binding:"required,min=1,dive,len=26"`                           // 菜式ID列表，至少1个
+	Servings     int            `json:"servings" binding:"omitempty,min=1,max=50"`                               // 用餐人数，不填按各菜式默认份数
+	DishServings map[string]int `json:"dish_servings" binding:"omitempty,dive,keys,len=26,endkeys,min=1,max=50"` // 单个菜式的用餐人数，键为菜式ID
 }
 
 // UpdateMenuRequest 更新菜单请求
 type UpdateMenuRequest struct {
-	Date     string   `json:"date" binding:"omitempty"`     // 日期，格式：YYYY-MM-DD
-	MealType string   `json:"meal_type" binding:"omitempty,oneof=breakfast lunch dinner"` // 餐次
-	DishIDs  []string `json:"dish_ids" binding:"omitempty,min=1,dive,len=26"`           // 菜式ID列表
+	Date         string         `json:"date" binding:"omitempty"`                                                // 日期，格式：YYYY-MM-DD
+	MealType     string         `json:"meal_type" binding:"omitempty,oneof=breakfast lunch dinner"`              // 餐次
+	DishIDs      []string       `json:"dish_ids" binding:"omitempty,min=1,dive,len=26"`                          // 菜式ID列表
+	Servings     *int           `json:"servings" binding:"omitempty,min=0,max=50"`                               // 用餐人数，0表示按各菜式默认份数，不传保持不变
+	DishServings map[string]int `json:"dish_servings" binding:"omitempty,dive,keys,len=26,endkeys,min=1,max=50"` // 单个菜式的用餐人数，传入时整体替换，不传保留仍在菜单中的菜式设置
 }
 
 // MenuIDRequest 菜单ID请求
@@ -49,14 +53,16 @@ type WeeklyMenuRequest struct {
 
 // Menu 菜单数据库实体
 type Menu struct {
-	ID        string    `json:"menu_id"`
-	FamilyID string    `json:"family_id"`
-	Date     time.Time `json:"date"`
-	MealType string    `json:"meal_type"`
-	CreatedBy string    `json:"created_by"`
-	Source   string    `json:"source"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID           string         `json:"menu_id"`
+	FamilyID     string         `json:"family_id"`
+	Date         time.Time      `json:"date"`
+	MealType     string         `json:"meal_type"`
+	CreatedBy    string         `json:"created_by"`
+	Source       string         `json:"source"`
+	Servings     int            `json:"servings,omitempty"` // 用餐人数，0表示按各菜式默认份数
+	DishServings map[string]int `json:"-"`                  // 单个菜式的用餐人数，键为菜式ID
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
 }
 
 // MenuDish 菜单菜式关联实体
@@ -64,20 +70,23 @@ type MenuDish struct {
 	ID        string    `json:"id"`
 	MenuID    string    `json:"menu_id"`
 	DishID    string    `json:"dish_id"`
+	Servings  int       `json:"servings,omitempty"` // 0表示沿用菜单的用餐人数
 	CreatedAt time.Time `json:"created_at"`
 }
 
 // MenuDetail 菜单详情（包含菜式信息）
 type MenuDetail struct {
-	MenuID    string         `json:"menu_id"`
-	FamilyID  string         `json:"family_id"`
-	Date      string         `json:"date"`      // 格式：YYYY-MM-DD
-	MealType  string         `json:"meal_type"` // breakfast, lunch, dinner
-	CreatedBy string         `json:"created_by"`
-	Source    string         `json:"source"`
-	Dishes    []*DishSummary `json:"dishes"`   // 菜式列表
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
+	MenuID       string         `json:"menu_id"`
+	FamilyID     string         `json:"family_id"`
+	Date         string         `json:"date"`      // 格式：YYYY-MM-DD
+	MealType     string         `json:"meal_type"` // breakfast, lunch, dinner
+	CreatedBy    string         `json:"created_by"`
+	Source       string         `json:"source"`
+	Servings     int            `json:"servings,omitempty"` // 用餐人数，0表示按各菜式默认份数
+	Dishes       []*DishSummary `json:"dishes"`             // 菜式列表
+	TotalMinutes int            `json:"total_minutes"`      // 预计用时（分钟），按一人依次制作各菜式累计
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
 }
 
 // MenuCreateResponse 创建菜单响应
@@ -85,6 +94,7 @@ type MenuCreateResponse struct {
 	MenuID   string         `json:"menu_id"`
 	Date     string         `json:"date"`
 	MealType string         `json:"meal_type"`
+	Servings int            `json:"servings,omitempty"`
 	Dishes   []*DishSummary `json:"dishes"`
 }
 
@@ -103,4 +113,3 @@ type WeeklyMenuResponse struct {
 	EndDate   string        `json:"end_date"`
 	Menus     []*MenuDetail `json:"menus"` // 一周的菜单列表
 }
-
@@ -0,0 +1,100 @@
+package models
+
+import "time"
+
+const (
+	// MenuGenerationStatusDraft 草稿，等待用户确认
+	MenuGenerationStatusDraft = "draft"
+	// MenuGenerationStatusConfirmed 已确认并写入菜单
+	MenuGenerationStatusConfirmed = "confirmed"
+	// MenuGenerationStatusDiscarded 已放弃
+	MenuGenerationStatusDiscarded = "discarded"
+)
+
+// MenuGeneration 菜单生成草稿数据库实体
+type MenuGeneration struct {
+	ID          string     `json:"generation_id"`
+	FamilyID    string     `json:"family_id"`
+	CreatedBy   string     `json:"created_by"`
+	Source      string     `json:"source"`
+	StartDate   time.Time  `json:"start_date"`
+	EndDate     time.Time  `json:"end_date"`
+	Reason      string     `json:"reason"`
+	Status      string     `json:"status"`
+	ConfirmedAt *time.Time `json:"confirmed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+
+	Items []*MenuGenerationItem `json:"items"`
+}
+
+// MenuGenerationItem 菜单生成草稿中的单餐
+type MenuGenerationItem struct {
+	ID           string    `json:"id"`
+	GenerationID string    `json:"generation_id"`
+	Date         time.Time `json:"date"`
+	MealType     string    `json:"meal_type"`
+	DishIDs      []string  `json:"dish_ids"`
+	Reason       string    `json:"reason"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// PlanningDish 生成菜单时可供选择的菜式
+type PlanningDish struct {
+	ID          string   `json:"dish_id"`
+	Name        string   `json:"name"`
+	Category    string   `json:"category"`
+	Ingredients []string `json:"ingredients"` // 食材名称
+}
+
+// GenerateMenuRequest AI生成菜单请求
+type GenerateMenuRequest struct {
+	StartDate string   `json:"start_date" binding:"required" example:"2024-01-01"`                                                // 开始日期，格式：YYYY-MM-DD
+	EndDate   string   `json:"end_date" binding:"required" example:"2024-01-07"`                                                  // 结束日期，格式：YYYY-MM-DD，最多14天
+	MemberIDs []string `json:"member_ids" binding:"omitempty,dive,len=26"`                                                        // 参与的家庭成员ID，为空表示全部成员
+	MealTypes []string `json:"meal_types" binding:"omitempty,dive,oneof=breakfast lunch dinner" example:"breakfast,lunch,dinner"` // 餐次，为空表示三餐
+}
+
+// MenuGenerationIDRequest 菜单生成草稿ID请求
+type MenuGenerationIDRequest struct {
+	ID string `uri:"id" binding:"required,len=26"`
+}
+
+// ConfirmMenuGenerationRequest 确认菜单生成草稿请求
+type ConfirmMenuGenerationRequest struct {
+	Overwrite bool `json:"overwrite"` // 是否覆盖已有的手动菜单
+}
+
+// MenuGenerationSlot 草稿中的单餐菜单
+type MenuGenerationSlot struct {
+	Date           string         `json:"date"`      // 格式：YYYY-MM-DD
+	MealType       string         `json:"meal_type"` // breakfast, lunch, dinner
+	Dishes         []*DishSummary `json:"dishes"`
+	Reason         string         `json:"reason"`                     // 推荐理由
+	ExistingMenuID string         `json:"existing_menu_id,omitempty"` // 该餐已有的菜单ID
+	ExistingSource string         `json:"existing_source,omitempty"`  // 已有菜单来源，manual 需确认覆盖
+}
+
+// MenuGenerationResponse 菜单生成草稿响应
+type MenuGenerationResponse struct {
+	GenerationID   string                `json:"generation_id"`
+	StartDate      string                `json:"start_date"`
+	EndDate        string                `json:"end_date"`
+	Source         string                `json:"source"`
+	Status         string                `json:"status"`
+	Reason         string                `json:"reason"` // 整体推荐理由
+	Menus          []*MenuGenerationSlot `json:"menus"`
+	RemainingCount *int                  `json:"remaining_count,omitempty"` // 本周期剩余生成次数，-1表示不限
+	CreatedAt      time.Time             `json:"created_at"`
+}
+
+// MenuGenerationConflictResponse 确认时与手动菜单冲突的餐次
+type MenuGenerationConflictResponse struct {
+	Conflicts []*MenuGenerationSlot `json:"conflicts"`
+}
+
+// ConfirmMenuGenerationResponse 确认菜单生成草稿响应
+type ConfirmMenuGenerationResponse struct {
+	GenerationID string        `json:"generation_id"`
+	Menus        []*MenuDetail `json:"menus"`
+}
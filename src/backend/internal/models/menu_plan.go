@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+// MenuPlanDefaultNoRepeatDays 默认的同一菜式不重复天数
+const MenuPlanDefaultNoRepeatDays = 3
+
+// MenuSlot 已有菜单的日期、餐次与菜式ID
+type MenuSlot struct {
+	MenuID   string
+	Date     time.Time
+	MealType string
+	DishIDs  []string
+}
+
+// PlanMenuRequest 规则排菜预览请求
+type PlanMenuRequest struct {
+	StartDate       string   `json:"start_date" binding:"required" example:"2024-01-01"`                                                // 开始日期，格式：YYYY-MM-DD
+	EndDate         string   `json:"end_date" binding:"required" example:"2024-01-07"`                                                  // 结束日期，格式：YYYY-MM-DD，最多14天
+	MealTypes       []string `json:"meal_types" binding:"omitempty,dive,oneof=breakfast lunch dinner" example:"breakfast,lunch,dinner"` // 餐次，为空表示三餐
+	PinnedDishIDs   []string `json:"pinned_dish_ids" binding:"omitempty,max=50,dive,len=26"`                                            // 必须安排的菜式，每道至少出现一次
+	ExcludedDishIDs []string `json:"excluded_dish_ids" binding:"omitempty,max=200,dive,len=26"`                                         // 不参与安排的菜式
+	NoRepeatDays    *int     `json:"no_repeat_days" binding:"omitempty,min=1,max=14" example:"3"`                                       // 同一菜式间隔天数，默认3天
+}
+
+// PlannedMenu 规则排菜生成的单餐菜单
+type PlannedMenu struct {
+	Date     string         `json:"date"`      // 格式：YYYY-MM-DD
+	MealType string         `json:"meal_type"` // breakfast, lunch, dinner
+	Dishes   []*DishSummary `json:"dishes"`
+}
+
+// PlanMenuResponse 规则排菜预览响应
+type PlanMenuResponse struct {
+	StartDate    string         `json:"start_date"`
+	EndDate      string         `json:"end_date"`
+	NoRepeatDays int            `json:"no_repeat_days"`
+	Menus        []*PlannedMenu `json:"menus"`         // 仅包含原本为空的餐次
+	SkippedSlots int            `json:"skipped_slots"` // 已有菜单而跳过的餐次数
+	Warnings     []string       `json:"warnings"`      // 无法完全满足规则时的提示
+}
+
+// AcceptMenuPlanItem 待写入的单餐菜单
+type AcceptMenuPlanItem struct {
+	Date     string   `json:"date" binding:"required"`                                   // 日期，格式：YYYY-MM-DD
+	MealType string   `json:"meal_type" binding:"required,oneof=breakfast lunch dinner"` // 餐次
+	DishIDs  []string `json:"dish_ids" binding:"required,min=1,dive,len=26"`             // 菜式ID列表
+}
+
+// AcceptMenuPlanRequest 采纳规则排菜预览请求
+type AcceptMenuPlanRequest struct {
+	Menus []*AcceptMenuPlanItem `json:"menus" binding:"required,min=1,max=42,dive"`
+}
+
+// AcceptMenuPlanResponse 采纳规则排菜预览响应
+type AcceptMenuPlanResponse struct {
+	Menus []*MenuDetail `json:"menus"`
+}
@@ -0,0 +1,92 @@
+package models
+
+import "time"
+
+const (
+	// PaymentOrderStatusPending 待支付
+	PaymentOrderStatusPending = "pending"
+	// PaymentOrderStatusPaid 已支付
+	PaymentOrderStatusPaid = "paid"
+	// PaymentOrderStatusExpired 超时未支付
+	PaymentOrderStatusExpired = "expired"
+	// PaymentOrderStatusRefunding 退款中，已向渠道发起或待发起退款
+	PaymentOrderStatusRefunding = "refunding"
+	// PaymentOrderStatusRefunded 已退款
+	PaymentOrderStatusRefunded = "refunded"
+	// PaymentOrderStatusFailed 支付失败
+	PaymentOrderStatusFailed = "failed"
+)
+
+// PaymentOrder 支付订单实体，金额以分为单位
+type PaymentOrder struct {
+	ID            string
+	OrderNo       string
+	UserID        string
+	FamilyID      string
+	PlanType      string
+	AmountCents   int64
+	PaymentMethod string
+	Status        string
+	TransactionID string
+	MembershipID  string
+	ExpiresAt     *time.Time
+	PaidAt        *time.Time
+	RefundedAt    *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// MembershipPlan 会员套餐
+// @Description 可购买的会员套餐
+type MembershipPlan struct {
+	PlanType    string `json:"plan_type" example:"monthly"` // 套餐类型：monthly-月付，yearly-年付
+	Name        string `json:"name" example:"月度会员"`         // 套餐名称
+	AmountCents int64  `json:"amount_cents" example:"1500"` // 价格（分）
+}
+
+// SubscribeRequest 订阅会员请求
+// @Description 为当前家庭购买会员套餐，创建待支付订单
+type SubscribeRequest struct {
+	Plan          string `json:"plan" binding:"required,oneof=monthly yearly" example:"monthly"` // 套餐类型：monthly-月付，yearly-年付
+	PaymentMethod string `json:"payment_method" binding:"omitempty,max=20" example:"mock"`       // 支付渠道，不传则使用服务端配置的渠道
+}
+
+// PaymentOrderNoRequest 订单号路径参数
+type PaymentOrderNoRequest struct {
+	OrderNo string `uri:"order_no" binding:"required,max=50"`
+}
+
+// PaymentOrderListQuery 订单列表查询请求
+type PaymentOrderListQuery struct {
+	Page     int `form:"page,default=1" binding:"min=1"`
+	PageSize int `form:"page_size,default=20" binding:"min=1,max=50"`
+}
+
+// MockPayRequest 模拟支付请求
+type MockPayRequest struct {
+	OrderNo string `json:"order_no" binding:"required,max=50" example:"OT01J0XYZABCD1234EFG567HIJK"` // 订单号
+	Status  string `json:"status" binding:"omitempty,oneof=success failed" example:"success"`        // 模拟的支付结果，默认success
+}
+
+// PaymentOrderResponse 支付订单响应
+// @Description 支付订单信息，payment 为客户端拉起支付所需参数（仅待支付订单返回）
+type PaymentOrderResponse struct {
+	OrderNo       string            `json:"order_no" example:"OT01J0XYZABCD1234EFG567HIJK"`
+	PlanType      string            `json:"plan_type" example:"monthly"`
+	AmountCents   int64             `json:"amount_cents" example:"1500"` // 金额（分）
+	PaymentMethod string            `json:"payment_method" example:"mock"`
+	Status        string            `json:"status" example:"pending"` // 状态：pending-待支付，paid-已支付，expired-已过期，refunding-退款中，refunded-已退款，failed-失败
+	ExpiresAt     *time.Time        `json:"expires_at,omitempty" example:"2024-01-01T00:30:00Z"`
+	PaidAt        *time.Time        `json:"paid_at,omitempty" example:"2024-01-01T00:05:00Z"`
+	RefundedAt    *time.Time        `json:"refunded_at,omitempty"`
+	CreatedAt     time.Time         `json:"created_at" example:"2024-01-01T00:00:00Z"`
+	Payment       map[string]string `json:"payment,omitempty"`
+}
+
+// PaymentOrderListResponse 订单列表响应
+type PaymentOrderListResponse struct {
+	Orders   []*PaymentOrderResponse `json:"orders"`
+	Total    int64                   `json:"total"`
+	Page     int                     `json:"page"`
+	PageSize int                     `json:"page_size"`
+}
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// SessionDevice 登录设备信息
+type SessionDevice struct {
+	UserAgent string
+	IP        string
+}
+
+// RefreshTokenRequest 刷新令牌请求
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required,max=128" example:"q3Zk2lV0d1cR8aXyN5mP7tB4uE6wH9jK0sL2fG3hA1c"` // 登录或上次刷新返回的刷新令牌
+}
+
+// SessionIDRequest 会话ID请求
+type SessionIDRequest struct {
+	ID string `uri:"id" binding:"required,len=26"`
+}
+
+// SessionInfo 登录会话信息
+type SessionInfo struct {
+	SessionID    string    `json:"session_id" example:"01HZX1YF8Y6S7K4V9Q2J3M5N6T"`
+	UserAgent    string    `json:"user_agent" example:"Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X)"`
+	IP           string    `json:"ip" example:"192.168.1.10"`
+	Current      bool      `json:"current" example:"true"` // 是否为当前请求所用会话
+	CreatedAt    time.Time `json:"created_at" example:"2024-01-01T00:00:00Z"`
+	LastActiveAt time.Time `json:"last_active_at" example:"2024-01-02T00:00:00Z"` // 最近一次登录或刷新时间
+}
+
+// LogoutOthersResponse 退出其他设备响应
+type LogoutOthersResponse struct {
+	RevokedCount int `json:"revoked_count" example:"2"`
+}
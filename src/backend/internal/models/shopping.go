@@ -0,0 +1,154 @@
+package models
+
+import "time"
+
+const (
+	// ShoppingListStatusPending 待购买
+	ShoppingListStatusPending = "pending"
+	// ShoppingListStatusCompleted 已完成
+	ShoppingListStatusCompleted = "completed"
+)
+
+const (
+	// ShoppingItemStatusPending 待购买
+	ShoppingItemStatusPending = "pending"
+	// ShoppingItemStatusPurchased 已购买
+	ShoppingItemStatusPurchased = "purchased"
+)
+
+const (
+	// ShoppingListSourceDateRange 按日期范围生成
+	ShoppingListSourceDateRange = "date_range"
+	// ShoppingListSourceMenus 按指定菜单生成
+	ShoppingListSourceMenus = "menus"
+)
+
+// ShoppingCategoryOther 未分类食材的分组名称
+const ShoppingCategoryOther = "other"
+
+// GenerateShoppingListRequest 生成购物清单请求
+type GenerateShoppingListRequest struct {
+	StartDate string   `json:"start_date" binding:"omitempty"`                  // 开始日期，格式：YYYY-MM-DD
+	EndDate   string   `json:"end_date" binding:"omitempty"`                    // 结束日期，格式：YYYY-MM-DD
+	MenuIDs   []string `json:"menu_ids" binding:"omitempty,max=93,dive,len=26"` // 指定菜单ID列表，提供时忽略日期范围
+	Name      string   `json:"name" binding:"omitempty,max=100"`                // 清单名称，可选
+}
+
+// ShoppingListIDRequest 购物清单ID请求
+type ShoppingListIDRequest struct {
+	ID string `uri:"id" binding:"required,len=26"`
+}
+
+// ShoppingItemIDRequest 购物清单项ID请求
+type ShoppingItemIDRequest struct {
+	ID     string `uri:"id" binding:"required,len=26"`
+	ItemID string `uri:"itemId" binding:"required,len=26"`
+}
+
+// AddShoppingItemRequest 添加购物清单项请求
+type AddShoppingItemRequest struct {
+	IngredientID string  `json:"ingredient_id" binding:"required,min=1,max=26"` // 基础食材ID
+	TotalAmount  float64 `json:"total_amount" binding:"required,gt=0"`          // 数量
+	Unit         string  `json:"unit" binding:"required,max=20"`                // 单位
+}
+
+// UpdateShoppingItemRequest 修改购物清单项请求
+type UpdateShoppingItemRequest struct {
+	TotalAmount float64 `json:"total_amount" binding:"required,gt=0"` // 数量
+	Unit        string  `json:"unit" binding:"omitempty,max=20"`      // 单位，不传则保持不变
+}
+
+// ShoppingListQuery 购物清单列表查询请求
+type ShoppingListQuery struct {
+	Page     int `form:"page,default=1" binding:"min=1"`
+	PageSize int `form:"page_size,default=20" binding:"min=1,max=50"`
+}
+
+// ShoppingList 购物清单数据库实体
+type ShoppingList struct {
+	ID        string    `json:"list_id"`
+	FamilyID  string    `json:"family_id"`
+	Name      string    `json:"name"`
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+	Status    string    `json:"status"`
+	Source    string    `json:"source"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ShoppingListItem 购物清单项实体
+type ShoppingListItem struct {
+	ID             string  `json:"item_id"`
+	ListID         string  `json:"-"`
+	IngredientID   string  `json:"ingredient_id"`
+	IngredientName string  `json:"ingredient_name"`
+	Category       string  `json:"category"`
+	TotalAmount    float64 `json:"total_amount"`
+	Unit           string  `json:"unit"`
+	StorageDays    *int    `json:"storage_days,omitempty"`
+	Status         string  `json:"status"`
+	IsManual       bool    `json:"is_manual"`
+	SortOrder      int     `json:"sort_order"`
+}
+
+// MenuIngredient 菜单中菜式引用的食材明细（用于汇总购物清单）
+type MenuIngredient struct {
+	MenuID         string
+	DishID         string
+	IngredientID   string
+	IngredientName string
+	Category       string
+	DefaultUnit    string
+	StorageDays    *int
+	Density        *float64
+	PieceWeight    *float64
+	Amount         float64
+	Unit           string
+	BaseServings   int // 菜式默认份数
+	Servings       int // 本餐份数，Amount 需按两者之比缩放
+}
+
+// ShoppingListGroup 按食材分类分组的清单项
+type ShoppingListGroup struct {
+	Category string              `json:"category"`
+	Items    []*ShoppingListItem `json:"items"`
+}
+
+// ShoppingListDetail 购物清单详情
+type ShoppingListDetail struct {
+	ListID         string               `json:"list_id"`
+	FamilyID       string               `json:"family_id"`
+	Name           string               `json:"name"`
+	StartDate      string               `json:"start_date"` // 格式：YYYY-MM-DD
+	EndDate        string               `json:"end_date"`   // 格式：YYYY-MM-DD
+	Status         string               `json:"status"`     // pending, completed
+	Source         string               `json:"source"`     // date_range, menus
+	CreatedBy      string               `json:"created_by"`
+	Groups         []*ShoppingListGroup `json:"groups"` // 按食材分类分组
+	TotalItems     int                  `json:"total_items"`
+	PurchasedItems int                  `json:"purchased_items"`
+	CreatedAt      time.Time            `json:"created_at"`
+	UpdatedAt      time.Time            `json:"updated_at"`
+}
+
+// ShoppingListSummary 购物清单列表项
+type ShoppingListSummary struct {
+	ListID         string    `json:"list_id"`
+	Name           string    `json:"name"`
+	StartDate      string    `json:"start_date"`
+	EndDate        string    `json:"end_date"`
+	Status         string    `json:"status"`
+	TotalItems     int       `json:"total_items"`
+	PurchasedItems int       `json:"purchased_items"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// ShoppingListListResponse 购物清单列表响应
+type ShoppingListListResponse struct {
+	Lists    []*ShoppingListSummary `json:"lists"`
+	Total    int64                  `json:"total"`
+	Page     int                    `json:"page"`
+	PageSize int                    `json:"page_size"`
+}
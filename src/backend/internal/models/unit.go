@@ -0,0 +1,31 @@
+package models
+
+// UnitConvertRequest 单位换算预览请求
+type UnitConvertRequest struct {
+	IngredientID string  `json:"ingredient_id" binding:"omitempty,len=26"` // 基础食材ID，提供时使用食材的密度与单个重量
+	Amount       float64 `json:"amount" binding:"required,gt=0"`           // 数量
+	FromUnit     string  `json:"from_unit" binding:"required,max=20"`      // 原单位
+	ToUnit       string  `json:"to_unit" binding:"omitempty,max=20"`       // 目标单位，不传则使用食材推荐单位
+}
+
+// UnitConvertResponse 单位换算预览结果
+type UnitConvertResponse struct {
+	IngredientID string  `json:"ingredient_id,omitempty"`
+	Amount       float64 `json:"amount"`
+	FromUnit     string  `json:"from_unit"`
+	ToUnit       string  `json:"to_unit"`
+	Result       float64 `json:"result"` // 换算结果，保留两位小数
+}
+
+// UnitInfo 单位信息
+type UnitInfo struct {
+	Name      string   `json:"name"`      // 标准名称
+	Dimension string   `json:"dimension"` // 维度：mass, volume, count
+	Factor    float64  `json:"factor"`    // 换算到基准单位（克、毫升、个）的系数
+	Aliases   []string `json:"aliases"`   // 别名
+}
+
+// UnitListResponse 支持的单位列表
+type UnitListResponse struct {
+	Units []*UnitInfo `json:"units"`
+}
@@ -12,6 +12,8 @@ type User struct {
 	Status    int       `json:"status" db:"status"` // 1-正常，0-禁用
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+
+	DietaryPreferences []string `json:"dietary_preferences" db:"dietary_preferences"` // 饮食偏好标签
 }
 
 // RegisterRequest 注册请求
@@ -27,23 +29,74 @@ type RegisterRequest struct {
 // LoginRequest 登录请求
 // @Description 用户登录请求参数
 type LoginRequest struct {
-	Phone    string `json:"phone" binding:"required" example:"13800138000"`    // 手机号
-	Password string `json:"password" binding:"required" example:"password123"` // 密码
+	Phone      string `json:"phone" binding:"required" example:"13800138000"`    // 手机号
+	Password   string `json:"password" binding:"required" example:"password123"` // 密码
+	CaptchaKey string `json:"captcha_key" example:"01J0XYZABCD1234EFG567HIJK"`   // 验证码编码，多次登录失败后必填
+	VerifyCode string `json:"verify_code" example:"A9d3"`                        // 图形验证码内容，多次登录失败后必填
 }
 
 // RegisterResponse 注册响应
 // @Description 用户注册成功返回的数据
 type RegisterResponse struct {
-	UserID string `json:"user_id" example:"01HZX1YF8Y6S7K4V9Q2J3M5N6P"`            // 用户ID
-	Token  string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."` // JWT Token
+	UserID           string `json:"user_id" example:"01HZX1YF8Y6S7K4V9Q2J3M5N6P"`                        // 用户ID
+	Token            string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`             // JWT Token
+	ExpiresIn        int64  `json:"expires_in" example:"900"`                                            // Token过期时间（秒）
+	RefreshToken     string `json:"refresh_token" example:"q3Zk2lV0d1cR8aXyN5mP7tB4uE6wH9jK0sL2fG3hA1c"` // 刷新令牌
+	RefreshExpiresIn int64  `json:"refresh_expires_in" example:"2592000"`                                // 刷新令牌过期时间（秒）
 }
 
 // LoginResponse 登录响应
 // @Description 用户登录成功返回的数据
 type LoginResponse struct {
-	UserID    string `json:"user_id" example:"01HZX1YF8Y6S7K4V9Q2J3M5N6P"`            // 用户ID
-	Token     string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."` // JWT Token
-	ExpiresIn int64  `json:"expires_in" example:"86400"`                              // Token过期时间（秒）
+	UserID           string `json:"user_id" example:"01HZX1YF8Y6S7K4V9Q2J3M5N6P"`                        // 用户ID
+	Token            string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`             // JWT Token
+	ExpiresIn        int64  `json:"expires_in" example:"900"`                                            // Token过期时间（秒）
+	RefreshToken     string `json:"refresh_token" example:"q3Zk2lV0d1cR8aXyN5mP7tB4uE6wH9jK0sL2fG3hA1c"` // 刷新令牌，每次刷新后轮换
+	RefreshExpiresIn int64  `json:"refresh_expires_in" example:"2592000"`                                // 刷新令牌过期时间（秒）
+}
+
+// LoginGuardStatus 登录防护状态，登录失败时随错误返回
+// @Description 登录失败后的限制信息
+type LoginGuardStatus struct {
+	CaptchaRequired   bool  `json:"captcha_required" example:"true"`    // 下次登录是否需要图形验证码
+	RetryAfter        int64 `json:"retry_after,omitempty" example:"60"` // 需等待的秒数
+	RemainingAttempts int   `json:"remaining_attempts" example:"5"`     // 账号被锁定前剩余的尝试次数
+}
+
+// ChangePasswordRequest 修改密码请求
+// @Description 已登录用户修改密码请求参数
+type ChangePasswordRequest struct {
+	OldPassword string `json:"old_password" binding:"required" example:"password123"`          // 当前密码
+	NewPassword string `json:"new_password" binding:"required,min=6" example:"newPassword456"` // 新密码，至少6位
+}
+
+// ChangePasswordResponse 修改密码响应
+// @Description 修改密码成功返回的数据
+type ChangePasswordResponse struct {
+	RevokedSessions int `json:"revoked_sessions" example:"2"` // 被吊销的其他设备会话数量
+}
+
+// SendResetCodeRequest 发送重置密码验证码请求
+// @Description 找回密码时发送短信验证码的请求参数
+type SendResetCodeRequest struct {
+	Phone      string `json:"phone" binding:"required" example:"13800138000"`                     // 手机号
+	CaptchaKey string `json:"captcha_key" binding:"required" example:"01J0XYZABCD1234EFG567HIJK"` // 图形验证码编码
+	VerifyCode string `json:"verify_code" binding:"required" example:"A9d3"`                      // 图形验证码内容
+}
+
+// SendResetCodeResponse 发送重置密码验证码响应
+// @Description 短信验证码发送结果
+type SendResetCodeResponse struct {
+	ExpireIn int64 `json:"expire_in" example:"600"` // 短信验证码有效期（秒）
+	ResendIn int64 `json:"resend_in" example:"60"`  // 距离可再次发送的秒数
+}
+
+// ResetPasswordRequest 重置密码请求
+// @Description 使用短信验证码重置密码的请求参数
+type ResetPasswordRequest struct {
+	Phone       string `json:"phone" binding:"required" example:"13800138000"`                 // 手机号
+	Code        string `json:"code" binding:"required,len=6" example:"382915"`                 // 短信验证码
+	NewPassword string `json:"new_password" binding:"required,min=6" example:"newPassword456"` // 新密码，至少6位
 }
 
 // UserInfoResponse 用户信息响应
@@ -54,6 +107,16 @@ type UserInfoResponse struct {
 	Nickname   string         `json:"nickname" example:"张三"`                        // 昵称
 	Avatar     string         `json:"avatar" example:"https://..."`                 // 头像URL
 	Membership MembershipInfo `json:"membership"`                                   // 会员信息
+
+	DietaryPreferences []string `json:"dietary_preferences" example:"少辣,低糖"` // 饮食偏好标签
+}
+
+// UpdateProfileRequest 更新用户资料请求
+// @Description 更新昵称、头像与饮食偏好，字段不传则保持不变
+type UpdateProfileRequest struct {
+	Nickname           string   `json:"nickname" binding:"omitempty,max=50" example:"张三"`                                                              // 昵称
+	Avatar             string   `json:"avatar" binding:"omitempty,max=500" example:"http://127.0.0.1:9000/onetaste-media/user/head/01HZX.../01J0.jpg"` // 头像URL，需为通过媒体上传接口上传到 user/head/{userId} 目录下的文件
+	DietaryPreferences []string `json:"dietary_preferences" binding:"omitempty,max=20,dive,min=1,max=20" example:"少辣,低糖"`                              // 饮食偏好标签，传空数组表示清空
 }
 
 // MembershipInfo 会员信息
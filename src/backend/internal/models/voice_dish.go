@@ -0,0 +1,53 @@
+package models
+
+// voiceAudioFormats 语音录入支持的音频 MIME 及对应格式
+var voiceAudioFormats = map[string]string{
+	"audio/mpeg":   "mp3",
+	"audio/mp3":    "mp3",
+	"audio/wav":    "wav",
+	"audio/x-wav":  "wav",
+	"audio/wave":   "wav",
+	"audio/mp4":    "m4a",
+	"audio/x-m4a":  "m4a",
+	"audio/aac":    "aac",
+	"audio/webm":   "webm",
+	"audio/ogg":    "ogg",
+	"audio/amr":    "amr",
+	"video/webm":   "webm", // 部分浏览器录音的 MIME 为 video/webm
+	"audio/x-flac": "flac",
+	"audio/flac":   "flac",
+}
+
+// VoiceAudioFormat 返回音频 MIME 对应的格式，不支持时返回 false
+func VoiceAudioFormat(contentType string) (string, bool) {
+	format, ok := voiceAudioFormats[normalizeContentType(contentType)]
+	return format, ok
+}
+
+// VoiceDishInput 语音录入菜式时上传的音频
+type VoiceDishInput struct {
+	OriginalName string
+	ContentType  string
+	Size         int64
+}
+
+// VoiceDishIngredient 语音识别出的食材及其与食材库的匹配结果
+type VoiceDishIngredient struct {
+	Name           string                    `json:"name"` // 识别出的食材名称
+	Amount         float64                   `json:"amount"`
+	Unit           string                    `json:"unit"`
+	Notes          string                    `json:"notes,omitempty"`
+	Matched        bool                      `json:"matched"`                   // 是否已匹配到食材库
+	IngredientID   string                    `json:"ingredient_id,omitempty"`   // 匹配到的食材ID
+	IngredientName string                    `json:"ingredient_name,omitempty"` // 匹配到的食材名称
+	Candidates     []*IngredientSearchResult `json:"candidates,omitempty"`      // 未匹配时的候选食材
+}
+
+// VoiceDishDraftResponse 语音录入菜式草稿，确认后可直接作为创建菜式请求提交
+type VoiceDishDraftResponse struct {
+	Transcript     string                 `json:"transcript"`                // 语音转写文本
+	Draft          *CreateDishRequest     `json:"draft"`                     // 菜式草稿，仅包含已匹配的食材
+	Ingredients    []*VoiceDishIngredient `json:"ingredients"`               // 识别出的全部食材
+	UnmatchedCount int                    `json:"unmatched_count"`           // 未匹配到食材库的食材数
+	RemainingCount *int                   `json:"remaining_count,omitempty"` // 今日剩余语音录入次数，-1表示不限
+}
@@ -0,0 +1,119 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"onetaste-family/backend/internal/models"
+	"onetaste-family/backend/pkg/database"
+)
+
+// aiUsageDateLayout period_date 为 DATE 列，按调用方给定时区的日期写入，避免时区换算导致跨日
+const aiUsageDateLayout = "2006-01-02"
+
+// AIUsageRepository AI调用记录数据访问层
+// ai_usage_logs 是额度计数的唯一可信来源，Redis 中的计数只是读缓存
+type AIUsageRepository struct {
+	db *sql.DB
+}
+
+// NewAIUsageRepository 创建AI调用记录仓储
+func NewAIUsageRepository() *AIUsageRepository {
+	return &AIUsageRepository{
+		db: database.GetDB(),
+	}
+}
+
+// BeginTx 开启事务
+func (r *AIUsageRepository) BeginTx(ctx context.Context) (*sql.Tx, error) {
+	return r.db.BeginTx(ctx, nil)
+}
+
+// LockTx 在事务内获取按 key 区分的咨询锁，事务结束时自动释放，用于串行化同一计数范围的扣减
+func (r *AIUsageRepository) LockTx(ctx context.Context, tx *sql.Tx, key string) error {
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, key); err != nil {
+		return fmt.Errorf("failed to lock ai usage: %w", err)
+	}
+	return nil
+}
+
+// SumUsageTx 在事务内统计周期内的使用次数，scope 为 family 时汇总全体家庭成员
+func (r *AIUsageRepository) SumUsageTx(ctx context.Context, tx *sql.Tx, scope, scopeID, feature, periodType string, periodDate time.Time) (int, error) {
+	return sumUsage(ctx, tx, scope, scopeID, feature, periodType, periodDate)
+}
+
+// SumUsage 统计周期内的使用次数
+func (r *AIUsageRepository) SumUsage(ctx context.Context, scope, scopeID, feature, periodType string, periodDate time.Time) (int, error) {
+	return sumUsage(ctx, r.db, scope, scopeID, feature, periodType, periodDate)
+}
+
+type usageQueryer interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+func sumUsage(ctx context.Context, q usageQueryer, scope, scopeID, feature, periodType string, periodDate time.Time) (int, error) {
+	column := "user_id"
+	if scope == models.AIQuotaScopeFamily {
+		column = "family_id"
+	}
+
+	query := `
+		SELECT COALESCE(SUM(usage_count), 0)
+		FROM ai_usage_logs
+		WHERE ` + column + ` = $1 AND feature_type = $2 AND period_type = $3 AND period_date = $4
+	`
+
+	var used int
+	if err := q.QueryRowContext(ctx, query, scopeID, feature, periodType, periodDate.Format(aiUsageDateLayout)).Scan(&used); err != nil {
+		return 0, fmt.Errorf("failed to sum ai usage: %w", err)
+	}
+
+	return used, nil
+}
+
+// IncrementTx 在事务内为用户在当前家庭的周期记录增加一次使用，记录不存在时创建
+// 用户切换家庭后会在新家庭下产生新记录，原家庭的记录保持不变
+func (r *AIUsageRepository) IncrementTx(ctx context.Context, tx *sql.Tx, usage *models.AIUsageLog) error {
+	query := `
+		INSERT INTO ai_usage_logs (id, user_id, family_id, feature_type, period_type, period_date, usage_count, limit_count)
+		VALUES ($1, $2, $3, $4, $5, $6, 1, $7)
+		ON CONFLICT (user_id, family_id, feature_type, period_type, period_date)
+		DO UPDATE SET usage_count = ai_usage_logs.usage_count + 1,
+			limit_count = EXCLUDED.limit_count
+		RETURNING usage_count
+	`
+
+	err := tx.QueryRowContext(
+		ctx,
+		query,
+		usage.ID,
+		usage.UserID,
+		usage.FamilyID,
+		usage.FeatureType,
+		usage.PeriodType,
+		usage.PeriodDate.Format(aiUsageDateLayout),
+		usage.LimitCount,
+	).Scan(&usage.UsageCount)
+	if err != nil {
+		return fmt.Errorf("failed to increment ai usage: %w", err)
+	}
+
+	return nil
+}
+
+// Decrement 退回用户在扣减时所在家庭的周期记录中的一次使用（AI调用失败时）
+func (r *AIUsageRepository) Decrement(ctx context.Context, userID, familyID, feature, periodType string, periodDate time.Time) error {
+	query := `
+		UPDATE ai_usage_logs
+		SET usage_count = usage_count - 1
+		WHERE user_id = $1 AND family_id = $2 AND feature_type = $3 AND period_type = $4 AND period_date = $5 AND usage_count > 0
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, userID, familyID, feature, periodType, periodDate.Format(aiUsageDateLayout)); err != nil {
+		return fmt.Errorf("failed to decrement ai usage: %w", err)
+	}
+
+	return nil
+}
@@ -6,7 +6,9 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/lib/pq"
 	"onetaste-family/backend/internal/models"
 	"onetaste-family/backend/pkg/database"
 )
@@ -28,6 +30,11 @@ func NewDishRepository() *DishRepository {
 	}
 }
 
+// BeginTx 开启事务
+func (r *DishRepository) BeginTx(ctx context.Context) (*sql.Tx, error) {
+	return r.db.BeginTx(ctx, nil)
+}
+
 // CountByFamily 统计家庭菜式数量
 func (r *DishRepository) CountByFamily(familyID string) (int, error) {
 	query := `SELECT COUNT(*) FROM dishes WHERE family_id = $1 AND deleted_at IS NULL`
@@ -40,6 +47,18 @@ func (r *DishRepository) CountByFamily(familyID string) (int, error) {
 	return count, nil
 }
 
+// CountByFamilyTx 在事务内统计家庭菜式数量
+func (r *DishRepository) CountByFamilyTx(ctx context.Context, tx *sql.Tx, familyID string) (int, error) {
+	query := `SELECT COUNT(*) FROM dishes WHERE family_id = $1 AND deleted_at IS NULL`
+
+	var count int
+	if err := tx.QueryRowContext(ctx, query, familyID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count dishes: %w", err)
+	}
+
+	return count, nil
+}
+
 // ExistsByName 判断菜式名称是否存在（同一家庭内）
 func (r *DishRepository) ExistsByName(familyID string, name string, excludeID string) (bool, error) {
 	query := `SELECT EXISTS(
@@ -63,8 +82,19 @@ func (r *DishRepository) ExistsByName(familyID string, name string, excludeID st
 	return exists, nil
 }
 
-// CreateDishWithDetails 创建菜式并保存详情
-func (r *DishRepository) CreateDishWithDetails(dish *models.Dish, ingredients []*models.Ingredient, steps []*models.CookingStep) error {
+// CreateDishWithDetails 创建菜式并保存详情与标签，同时写入首个修订记录
+func (r *DishRepository) CreateDishWithDetails(dish *models.Dish, ingredients []*models.Ingredient, steps []*models.CookingStep, tagIDs []string, revision *models.DishRevision) error {
+	return r.CreateDishesWithDetails([]*models.DishWithDetails{{
+		Dish:        dish,
+		Ingredients: ingredients,
+		Steps:       steps,
+		TagIDs:      tagIDs,
+		Revision:    revision,
+	}})
+}
+
+// CreateDishesWithDetails 在同一事务内批量创建菜式，任一菜式失败时全部回滚
+func (r *DishRepository) CreateDishesWithDetails(items []*models.DishWithDetails) error {
 	ctx := context.Background()
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -77,13 +107,37 @@ func (r *DishRepository) CreateDishWithDetails(dish *models.Dish, ingredients []
 		}
 	}()
 
+	if err = r.CreateDishesWithDetailsTx(ctx, tx, items); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction failed: %w", err)
+	}
+
+	return nil
+}
+
+// CreateDishesWithDetailsTx 在调用方事务内批量创建菜式
+func (r *DishRepository) CreateDishesWithDetailsTx(ctx context.Context, tx *sql.Tx, items []*models.DishWithDetails) error {
+	for _, item := range items {
+		if err := r.insertDishWithDetails(ctx, tx, item); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *DishRepository) insertDishWithDetails(ctx context.Context, tx *sql.Tx, item *models.DishWithDetails) error {
+	dish := item.Dish
 	insertDish := `
-		INSERT INTO dishes (id, family_id, name, category, description, image_url, created_by)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO dishes (id, family_id, name, category, description, image_url, prep_minutes, cook_minutes, servings, difficulty, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		RETURNING created_at, updated_at
 	`
 
-	err = tx.QueryRowContext(
+	if err := tx.QueryRowContext(
 		ctx,
 		insertDish,
 		dish.ID,
@@ -92,29 +146,32 @@ func (r *DishRepository) CreateDishWithDetails(dish *models.Dish, ingredients []
 		nullString(dish.Category),
 		nullString(dish.Description),
 		nullString(dish.ImageURL),
+		dish.PrepMinutes,
+		dish.CookMinutes,
+		dish.Servings,
+		nullString(dish.Difficulty),
 		dish.CreatedBy,
-	).Scan(&dish.CreatedAt, &dish.UpdatedAt)
-	if err != nil {
+	).Scan(&dish.CreatedAt, &dish.UpdatedAt); err != nil {
 		return fmt.Errorf("failed to insert dish: %w", err)
 	}
 
-	if err = r.insertIngredients(ctx, tx, dish.ID, ingredients); err != nil {
+	if err := r.insertIngredients(ctx, tx, dish.ID, item.Ingredients); err != nil {
 		return err
 	}
 
-	if err = r.insertCookingSteps(ctx, tx, dish.ID, steps); err != nil {
+	if err := r.insertCookingSteps(ctx, tx, dish.ID, item.Steps); err != nil {
 		return err
 	}
 
-	if err = tx.Commit(); err != nil {
-		return fmt.Errorf("commit transaction failed: %w", err)
+	if err := replaceDishTags(ctx, tx, dish.ID, item.TagIDs); err != nil {
+		return err
 	}
 
-	return nil
+	return insertDishRevision(ctx, tx, item.Revision)
 }
 
-// UpdateDishWithDetails 更新菜式及其详情
-func (r *DishRepository) UpdateDishWithDetails(dish *models.Dish, ingredients []*models.Ingredient, steps []*models.CookingStep) error {
+// UpdateDishWithDetails 更新菜式及其详情，标签整体替换，同时写入修订记录
+func (r *DishRepository) UpdateDishWithDetails(dish *models.Dish, ingredients []*models.Ingredient, steps []*models.CookingStep, tagIDs []string, revision *models.DishRevision) error {
 	ctx := context.Background()
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -129,8 +186,9 @@ func (r *DishRepository) UpdateDishWithDetails(dish *models.Dish, ingredients []
 
 	updateDish := `
 		UPDATE dishes
-		SET name = $1, category = $2, description = $3, image_url = $4, updated_at = NOW()
-		WHERE id = $5 AND family_id = $6 AND deleted_at IS NULL
+		SET name = $1, category = $2, description = $3, image_url = $4,
+			prep_minutes = $5, cook_minutes = $6, servings = $7, difficulty = $8, updated_at = NOW()
+		WHERE id = $9 AND family_id = $10 AND deleted_at IS NULL
 		RETURNING updated_at
 	`
 
@@ -141,6 +199,10 @@ func (r *DishRepository) UpdateDishWithDetails(dish *models.Dish, ingredients []
 		nullString(dish.Category),
 		nullString(dish.Description),
 		nullString(dish.ImageURL),
+		dish.PrepMinutes,
+		dish.CookMinutes,
+		dish.Servings,
+		nullString(dish.Difficulty),
 		dish.ID,
 		dish.FamilyID,
 	).Scan(&dish.UpdatedAt)
@@ -167,6 +229,14 @@ func (r *DishRepository) UpdateDishWithDetails(dish *models.Dish, ingredients []
 		return err
 	}
 
+	if err = replaceDishTags(ctx, tx, dish.ID, tagIDs); err != nil {
+		return err
+	}
+
+	if err = insertDishRevision(ctx, tx, revision); err != nil {
+		return err
+	}
+
 	if err = tx.Commit(); err != nil {
 		return fmt.Errorf("commit transaction failed: %w", err)
 	}
@@ -176,14 +246,23 @@ func (r *DishRepository) UpdateDishWithDetails(dish *models.Dish, ingredients []
 
 // GetDishByID 根据ID获取菜式
 func (r *DishRepository) GetDishByID(dishID, familyID string) (*models.Dish, error) {
+	return r.getDish(dishID, familyID, "deleted_at IS NULL")
+}
+
+// GetDeletedDishByID 根据ID获取回收站中的菜式
+func (r *DishRepository) GetDeletedDishByID(dishID, familyID string) (*models.Dish, error) {
+	return r.getDish(dishID, familyID, "deleted_at IS NOT NULL")
+}
+
+func (r *DishRepository) getDish(dishID, familyID, deletedCond string) (*models.Dish, error) {
 	query := `
-		SELECT id, family_id, name, category, description, image_url, created_by, created_at, updated_at
+		SELECT id, family_id, name, category, description, image_url,
+			prep_minutes, cook_minutes, servings, difficulty, created_by, created_at, updated_at
 		FROM dishes
-		WHERE id = $1 AND family_id = $2 AND deleted_at IS NULL
-	`
+		WHERE id = $1 AND family_id = $2 AND ` + deletedCond
 
 	dish := &models.Dish{}
-	var category, description, image sql.NullString
+	var category, description, image, difficulty sql.NullString
 	if err := r.db.QueryRow(
 		query,
 		dishID,
@@ -195,6 +274,10 @@ func (r *DishRepository) GetDishByID(dishID, familyID string) (*models.Dish, err
 		&category,
 		&description,
 		&image,
+		&dish.PrepMinutes,
+		&dish.CookMinutes,
+		&dish.Servings,
+		&difficulty,
 		&dish.CreatedBy,
 		&dish.CreatedAt,
 		&dish.UpdatedAt,
@@ -208,6 +291,7 @@ func (r *DishRepository) GetDishByID(dishID, familyID string) (*models.Dish, err
 	dish.Category = nullableString(category)
 	dish.Description = nullableString(description)
 	dish.ImageURL = nullableString(image)
+	dish.Difficulty = nullableString(difficulty)
 
 	return dish, nil
 }
@@ -288,7 +372,7 @@ func (r *DishRepository) GetIngredients(dishID string) ([]*models.Ingredient, er
 // GetCookingSteps 获取烹饪步骤
 func (r *DishRepository) GetCookingSteps(dishID string) ([]*models.CookingStep, error) {
 	query := `
-		SELECT id, dish_id, step_order, content, image_url
+		SELECT id, dish_id, step_order, content, image_url, duration_minutes, is_passive, equipment
 		FROM cooking_steps
 		WHERE dish_id = $1
 		ORDER BY step_order ASC, id ASC
@@ -303,18 +387,22 @@ func (r *DishRepository) GetCookingSteps(dishID string) ([]*models.CookingStep,
 	var steps []*models.CookingStep
 	for rows.Next() {
 		step := &models.CookingStep{}
-		var image sql.NullString
+		var image, equipment sql.NullString
 		if err := rows.Scan(
 			&step.ID,
 			&step.DishID,
 			&step.Order,
 			&step.Content,
 			&image,
+			&step.DurationMinutes,
+			&step.Passive,
+			&equipment,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan cooking step: %w", err)
 		}
 
 		step.ImageURL = nullableString(image)
+		step.Equipment = nullableString(equipment)
 		steps = append(steps, step)
 	}
 
@@ -325,17 +413,28 @@ func (r *DishRepository) GetCookingSteps(dishID string) ([]*models.CookingStep,
 	return steps, nil
 }
 
-// GetDishList 获取菜式列表
-func (r *DishRepository) GetDishList(familyID string, page, pageSize int, category, keyword string) ([]*models.DishSummary, int64, error) {
+// GetDishList 获取菜式列表，tagIDs 不为空时只返回同时带有全部标签的菜式，difficulty 不为空时按难度筛选
+func (r *DishRepository) GetDishList(familyID string, page, pageSize int, tagIDs []string, difficulty, keyword string) ([]*models.DishSummary, int64, error) {
 	var whereBuilder strings.Builder
 	whereBuilder.WriteString("WHERE family_id = $1 AND deleted_at IS NULL")
 
 	args := []interface{}{familyID}
 	placeholder := 2
 
-	if category != "" {
-		whereBuilder.WriteString(fmt.Sprintf(" AND category = $%d", placeholder))
-		args = append(args, category)
+	if len(tagIDs) > 0 {
+		whereBuilder.WriteString(fmt.Sprintf(` AND id IN (
+			SELECT dish_id FROM dish_tag_relations
+			WHERE tag_id = ANY($%d)
+			GROUP BY dish_id
+			HAVING COUNT(*) = $%d
+		)`, placeholder, placeholder+1))
+		args = append(args, pq.Array(tagIDs), len(tagIDs))
+		placeholder += 2
+	}
+
+	if difficulty != "" {
+		whereBuilder.WriteString(fmt.Sprintf(" AND difficulty = $%d", placeholder))
+		args = append(args, difficulty)
 		placeholder++
 	}
 
@@ -357,7 +456,8 @@ func (r *DishRepository) GetDishList(familyID string, page, pageSize int, catego
 	offsetPlaceholder := placeholder + 1
 
 	listQuery := fmt.Sprintf(`
-		SELECT id, name, category, description, image_url, created_at, updated_at
+		SELECT id, name, category, description, image_url,
+			prep_minutes, cook_minutes, servings, difficulty, created_at, updated_at
 		FROM dishes
 		%s
 		ORDER BY created_at DESC
@@ -379,12 +479,17 @@ func (r *DishRepository) GetDishList(familyID string, page, pageSize int, catego
 		var category sql.NullString
 		var description sql.NullString
 		var image sql.NullString
+		var difficulty sql.NullString
 		if err := rows.Scan(
 			&item.DishID,
 			&item.Name,
 			&category,
 			&description,
 			&image,
+			&item.PrepMinutes,
+			&item.CookMinutes,
+			&item.Servings,
+			&difficulty,
 			&item.CreatedAt,
 			&item.UpdatedAt,
 		); err != nil {
@@ -394,6 +499,8 @@ func (r *DishRepository) GetDishList(familyID string, page, pageSize int, catego
 		item.Category = nullableString(category)
 		item.Description = nullableString(description)
 		item.ImageURL = nullableString(image)
+		item.Difficulty = nullableString(difficulty)
+		item.TotalMinutes = item.PrepMinutes + item.CookMinutes
 		dishes = append(dishes, item)
 	}
 
@@ -404,7 +511,8 @@ func (r *DishRepository) GetDishList(familyID string, page, pageSize int, catego
 	return dishes, total, nil
 }
 
-// SoftDeleteDish 软删除菜式
+// SoftDeleteDish 软删除菜式并将其移出所有菜单
+// 食材与步骤保留，菜式在回收站中可恢复，超过保留期后由后台任务彻底删除
 func (r *DishRepository) SoftDeleteDish(dishID, familyID string) error {
 	ctx := context.Background()
 	tx, err := r.db.BeginTx(ctx, nil)
@@ -434,14 +542,6 @@ func (r *DishRepository) SoftDeleteDish(dishID, familyID string) error {
 		return err
 	}
 
-	if _, err = tx.ExecContext(ctx, `DELETE FROM dish_ingredients WHERE dish_id = $1`, dishID); err != nil {
-		return fmt.Errorf("failed to delete ingredients: %w", err)
-	}
-
-	if _, err = tx.ExecContext(ctx, `DELETE FROM cooking_steps WHERE dish_id = $1`, dishID); err != nil {
-		return fmt.Errorf("failed to delete cooking steps: %w", err)
-	}
-
 	if _, err = tx.ExecContext(ctx, `DELETE FROM menu_dishes WHERE dish_id = $1`, dishID); err != nil {
 		return fmt.Errorf("failed to delete menu relation: %w", err)
 	}
@@ -453,6 +553,213 @@ func (r *DishRepository) SoftDeleteDish(dishID, familyID string) error {
 	return nil
 }
 
+// ListDeletedDishes 分页获取家庭回收站中的菜式，按删除时间倒序
+func (r *DishRepository) ListDeletedDishes(familyID string, page, pageSize int) ([]*models.TrashedDish, int64, error) {
+	var total int64
+	if err := r.db.QueryRow(
+		`SELECT COUNT(*) FROM dishes WHERE family_id = $1 AND deleted_at IS NOT NULL`,
+		familyID,
+	).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count deleted dishes: %w", err)
+	}
+
+	query := `
+		SELECT id, name, category, image_url, created_by, deleted_at
+		FROM dishes
+		WHERE family_id = $1 AND deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.Query(query, familyID, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query deleted dishes: %w", err)
+	}
+	defer rows.Close()
+
+	dishes := make([]*models.TrashedDish, 0)
+	for rows.Next() {
+		dish := &models.TrashedDish{}
+		var category, image sql.NullString
+		if err := rows.Scan(
+			&dish.DishID,
+			&dish.Name,
+			&category,
+			&image,
+			&dish.CreatedBy,
+			&dish.DeletedAt,
+		); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan deleted dish: %w", err)
+		}
+
+		dish.Category = nullableString(category)
+		dish.ImageURL = nullableString(image)
+		dishes = append(dishes, dish)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate deleted dishes: %w", err)
+	}
+
+	return dishes, total, nil
+}
+
+// RestoreDish 从回收站恢复菜式
+func (r *DishRepository) RestoreDish(dishID, familyID string) error {
+	res, err := r.db.Exec(
+		`UPDATE dishes SET deleted_at = NULL, updated_at = NOW() WHERE id = $1 AND family_id = $2 AND deleted_at IS NOT NULL`,
+		dishID,
+		familyID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to restore dish: %w", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to fetch affected rows: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrDishNotFound
+	}
+
+	return nil
+}
+
+// PurgeDeletedDishes 彻底删除在 before 之前进入回收站的菜式，返回删除数量
+// 已解散家庭的菜式由家庭数据清理任务统一处理，这里跳过
+// 食材、步骤、标签关联与修订记录随菜式级联删除
+func (r *DishRepository) PurgeDeletedDishes(before time.Time, limit int) (int64, error) {
+	query := `
+		DELETE FROM dishes
+		WHERE id IN (
+			SELECT d.id
+			FROM dishes d
+			JOIN families f ON f.id = d.family_id
+			WHERE d.deleted_at < $1 AND f.status = $2
+			ORDER BY d.deleted_at ASC
+			LIMIT $3
+		)
+	`
+
+	res, err := r.db.Exec(query, before, models.FamilyStatusActive, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge deleted dishes: %w", err)
+	}
+
+	purged, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch affected rows: %w", err)
+	}
+
+	return purged, nil
+}
+
+// SoftDeleteByFamilyTx 在事务内软删除家庭全部菜式，保留食材与步骤以便导出
+func (r *DishRepository) SoftDeleteByFamilyTx(ctx context.Context, tx *sql.Tx, familyID string, deletedAt time.Time) error {
+	query := `UPDATE dishes SET deleted_at = $1 WHERE family_id = $2 AND deleted_at IS NULL`
+
+	if _, err := tx.ExecContext(ctx, query, deletedAt, familyID); err != nil {
+		return fmt.Errorf("failed to delete family dishes: %w", err)
+	}
+
+	return nil
+}
+
+// ListDishesForExport 获取家庭菜式用于导出
+// deletedAt 不为空时同时返回在该时间点被批量软删除的菜式（家庭解散）
+func (r *DishRepository) ListDishesForExport(familyID string, deletedAt *time.Time) ([]*models.Dish, error) {
+	query := `
+		SELECT id, family_id, name, category, description, image_url,
+			prep_minutes, cook_minutes, servings, difficulty, created_by, created_at, updated_at
+		FROM dishes
+		WHERE family_id = $1 AND (deleted_at IS NULL OR deleted_at = $2)
+		ORDER BY created_at ASC, id ASC
+	`
+
+	rows, err := r.db.Query(query, familyID, deletedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dishes: %w", err)
+	}
+	defer rows.Close()
+
+	var dishes []*models.Dish
+	for rows.Next() {
+		dish := &models.Dish{}
+		var category, description, image, difficulty sql.NullString
+		if err := rows.Scan(
+			&dish.ID,
+			&dish.FamilyID,
+			&dish.Name,
+			&category,
+			&description,
+			&image,
+			&dish.PrepMinutes,
+			&dish.CookMinutes,
+			&dish.Servings,
+			&difficulty,
+			&dish.CreatedBy,
+			&dish.CreatedAt,
+			&dish.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan dish: %w", err)
+		}
+
+		dish.Category = nullableString(category)
+		dish.Description = nullableString(description)
+		dish.ImageURL = nullableString(image)
+		dish.Difficulty = nullableString(difficulty)
+		dishes = append(dishes, dish)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate dishes: %w", err)
+	}
+
+	return dishes, nil
+}
+
+// ListPlanningDishes 获取家庭全部有效菜式及其食材名称，用于生成菜单
+func (r *DishRepository) ListPlanningDishes(familyID string) ([]*models.PlanningDish, error) {
+	query := `
+		SELECT
+			d.id,
+			d.name,
+			d.category,
+			COALESCE(array_agg(bi.name ORDER BY di.sort_order, di.id) FILTER (WHERE bi.name IS NOT NULL), '{}')
+		FROM dishes d
+		LEFT JOIN dish_ingredients di ON di.dish_id = d.id
+		LEFT JOIN ingredients bi ON bi.id = di.ingredient_id
+		WHERE d.family_id = $1 AND d.deleted_at IS NULL
+		GROUP BY d.id
+		ORDER BY d.created_at ASC, d.id ASC
+	`
+
+	rows, err := r.db.Query(query, familyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query planning dishes: %w", err)
+	}
+	defer rows.Close()
+
+	dishes := make([]*models.PlanningDish, 0)
+	for rows.Next() {
+		dish := &models.PlanningDish{}
+		var category sql.NullString
+		if err := rows.Scan(&dish.ID, &dish.Name, &category, pq.Array(&dish.Ingredients)); err != nil {
+			return nil, fmt.Errorf("failed to scan planning dish: %w", err)
+		}
+
+		dish.Category = nullableString(category)
+		dishes = append(dishes, dish)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate planning dishes: %w", err)
+	}
+
+	return dishes, nil
+}
+
 func (r *DishRepository) insertIngredients(ctx context.Context, tx *sql.Tx, dishID string, ingredients []*models.Ingredient) error {
 	if len(ingredients) == 0 {
 		return nil
@@ -490,8 +797,8 @@ func (r *DishRepository) insertCookingSteps(ctx context.Context, tx *sql.Tx, dis
 	}
 
 	query := `
-		INSERT INTO cooking_steps (id, dish_id, step_order, content, image_url)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO cooking_steps (id, dish_id, step_order, content, image_url, duration_minutes, is_passive, equipment)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 	`
 
 	for _, step := range steps {
@@ -504,6 +811,9 @@ func (r *DishRepository) insertCookingSteps(ctx context.Context, tx *sql.Tx, dis
 			step.Order,
 			step.Content,
 			nullString(step.ImageURL),
+			step.DurationMinutes,
+			step.Passive,
+			nullString(step.Equipment),
 		); err != nil {
 			return fmt.Errorf("failed to insert cooking step: %w", err)
 		}
@@ -519,9 +829,24 @@ func nullableString(ns sql.NullString) string {
 	return ""
 }
 
+func nullableFloat(nf sql.NullFloat64) *float64 {
+	if nf.Valid {
+		value := nf.Float64
+		return &value
+	}
+	return nil
+}
+
 func nullString(value string) interface{} {
 	if strings.TrimSpace(value) == "" {
 		return nil
 	}
 	return value
 }
+
+func nullInt(value int) interface{} {
+	if value <= 0 {
+		return nil
+	}
+	return value
+}
@@ -0,0 +1,179 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"onetaste-family/backend/internal/models"
+	"onetaste-family/backend/pkg/database"
+)
+
+// ErrDishRevisionNotFound 菜式修订记录不存在
+var ErrDishRevisionNotFound = errors.New("dish revision not found")
+
+// DishRevisionRepository 菜式修订记录数据访问层
+// snapshot 列以 JSON 文本存储菜式的完整内容，记录写入后不再修改
+type DishRevisionRepository struct {
+	db *sql.DB
+}
+
+// NewDishRevisionRepository 创建菜式修订记录仓储
+func NewDishRevisionRepository() *DishRevisionRepository {
+	return &DishRevisionRepository{
+		db: database.GetDB(),
+	}
+}
+
+// Create 新增一条修订记录，版本号自动递增
+func (r *DishRevisionRepository) Create(revision *models.DishRevision) error {
+	return insertDishRevision(context.Background(), r.db, revision)
+}
+
+// CountByDish 统计菜式的修订记录数量
+func (r *DishRevisionRepository) CountByDish(dishID string) (int64, error) {
+	var total int64
+	if err := r.db.QueryRow(`SELECT COUNT(*) FROM dish_revisions WHERE dish_id = $1`, dishID).Scan(&total); err != nil {
+		return 0, fmt.Errorf("failed to count dish revisions: %w", err)
+	}
+	return total, nil
+}
+
+// ListByDish 分页获取菜式的修订记录，按版本号倒序，不包含快照内容
+func (r *DishRevisionRepository) ListByDish(dishID string, page, pageSize int) ([]*models.DishRevision, int64, error) {
+	total, err := r.CountByDish(dishID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT dr.id, dr.dish_id, dr.family_id, dr.revision_no, dr.action, dr.restored_from,
+			dr.edited_by, u.nickname, dr.created_at
+		FROM dish_revisions dr
+		LEFT JOIN users u ON u.id = dr.edited_by
+		WHERE dr.dish_id = $1
+		ORDER BY dr.revision_no DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.Query(query, dishID, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query dish revisions: %w", err)
+	}
+	defer rows.Close()
+
+	revisions := make([]*models.DishRevision, 0)
+	for rows.Next() {
+		revision := &models.DishRevision{}
+		var restoredFrom sql.NullInt64
+		var editedBy, editorName sql.NullString
+		if err := rows.Scan(
+			&revision.ID,
+			&revision.DishID,
+			&revision.FamilyID,
+			&revision.RevisionNo,
+			&revision.Action,
+			&restoredFrom,
+			&editedBy,
+			&editorName,
+			&revision.CreatedAt,
+		); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan dish revision: %w", err)
+		}
+
+		revision.RestoredFrom = int(restoredFrom.Int64)
+		revision.EditedBy = nullableString(editedBy)
+		revision.EditorName = nullableString(editorName)
+		revisions = append(revisions, revision)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate dish revisions: %w", err)
+	}
+
+	return revisions, total, nil
+}
+
+// GetByNo 根据版本号获取修订记录及快照内容
+func (r *DishRevisionRepository) GetByNo(dishID string, revisionNo int) (*models.DishRevision, error) {
+	query := `
+		SELECT dr.id, dr.dish_id, dr.family_id, dr.revision_no, dr.action, dr.restored_from,
+			dr.edited_by, u.nickname, dr.snapshot, dr.created_at
+		FROM dish_revisions dr
+		LEFT JOIN users u ON u.id = dr.edited_by
+		WHERE dr.dish_id = $1 AND dr.revision_no = $2
+	`
+
+	revision := &models.DishRevision{}
+	var restoredFrom sql.NullInt64
+	var editedBy, editorName sql.NullString
+	var snapshot string
+	if err := r.db.QueryRow(query, dishID, revisionNo).Scan(
+		&revision.ID,
+		&revision.DishID,
+		&revision.FamilyID,
+		&revision.RevisionNo,
+		&revision.Action,
+		&restoredFrom,
+		&editedBy,
+		&editorName,
+		&snapshot,
+		&revision.CreatedAt,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrDishRevisionNotFound
+		}
+		return nil, fmt.Errorf("failed to get dish revision: %w", err)
+	}
+
+	revision.Snapshot = &models.DishSnapshot{}
+	if err := json.Unmarshal([]byte(snapshot), revision.Snapshot); err != nil {
+		return nil, fmt.Errorf("failed to decode dish snapshot: %w", err)
+	}
+	revision.RestoredFrom = int(restoredFrom.Int64)
+	revision.EditedBy = nullableString(editedBy)
+	revision.EditorName = nullableString(editorName)
+
+	return revision, nil
+}
+
+type dishRevisionQueryer interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// insertDishRevision 写入修订记录，版本号取该菜式当前最大版本号加1
+// 在更新菜式的事务内调用时，菜式行锁保证同一菜式的版本号依次递增
+func insertDishRevision(ctx context.Context, q dishRevisionQueryer, revision *models.DishRevision) error {
+	snapshot, err := json.Marshal(revision.Snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to encode dish snapshot: %w", err)
+	}
+
+	query := `
+		INSERT INTO dish_revisions (id, dish_id, family_id, revision_no, action, restored_from, snapshot, edited_by)
+		VALUES (
+			$1, $2, $3,
+			(SELECT COALESCE(MAX(revision_no), 0) + 1 FROM dish_revisions WHERE dish_id = $2),
+			$4, $5, $6, $7
+		)
+		RETURNING revision_no, created_at
+	`
+
+	if err := q.QueryRowContext(
+		ctx,
+		query,
+		revision.ID,
+		revision.DishID,
+		revision.FamilyID,
+		revision.Action,
+		nullInt(revision.RestoredFrom),
+		string(snapshot),
+		nullString(revision.EditedBy),
+	).Scan(&revision.RevisionNo, &revision.CreatedAt); err != nil {
+		return fmt.Errorf("failed to insert dish revision: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,261 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+	"onetaste-family/backend/internal/models"
+	"onetaste-family/backend/pkg/database"
+)
+
+var (
+	// ErrDishTagNotFound 标签不存在
+	ErrDishTagNotFound = errors.New("dish tag not found")
+	// ErrDishTagExists 家庭内已有同名标签
+	ErrDishTagExists = errors.New("dish tag exists")
+)
+
+// uniqueViolation PostgreSQL 唯一约束冲突错误码
+const uniqueViolation = "23505"
+
+// DishTagRepository 菜式标签数据访问层
+type DishTagRepository struct {
+	db *sql.DB
+}
+
+// NewDishTagRepository 创建菜式标签仓储
+func NewDishTagRepository() *DishTagRepository {
+	return &DishTagRepository{
+		db: database.GetDB(),
+	}
+}
+
+// ListByFamily 获取系统标签与家庭自定义标签
+func (r *DishTagRepository) ListByFamily(familyID string) ([]*models.DishTag, error) {
+	query := `
+		SELECT id, family_id, group_code, name, sort_order, created_by, created_at
+		FROM dish_tags
+		WHERE family_id IS NULL OR family_id = $1
+		ORDER BY group_code, sort_order, created_at, id
+	`
+
+	rows, err := r.db.Query(query, familyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dish tags: %w", err)
+	}
+	defer rows.Close()
+
+	return scanDishTags(rows)
+}
+
+// GetByIDs 获取家庭可用的标签，不可用或不存在的ID不返回
+func (r *DishTagRepository) GetByIDs(familyID string, ids []string) (map[string]*models.DishTag, error) {
+	tags := make(map[string]*models.DishTag, len(ids))
+	if len(ids) == 0 {
+		return tags, nil
+	}
+
+	query := `
+		SELECT id, family_id, group_code, name, sort_order, created_by, created_at
+		FROM dish_tags
+		WHERE id = ANY($1) AND (family_id IS NULL OR family_id = $2)
+	`
+
+	rows, err := r.db.Query(query, pq.Array(ids), familyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dish tags: %w", err)
+	}
+	defer rows.Close()
+
+	list, err := scanDishTags(rows)
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range list {
+		tags[tag.ID] = tag
+	}
+
+	return tags, nil
+}
+
+// GetByDishIDs 批量获取菜式的标签，按分组与组内顺序排列
+func (r *DishTagRepository) GetByDishIDs(dishIDs []string) (map[string][]*models.DishTag, error) {
+	result := make(map[string][]*models.DishTag, len(dishIDs))
+	if len(dishIDs) == 0 {
+		return result, nil
+	}
+
+	query := `
+		SELECT dtr.dish_id, t.id, t.family_id, t.group_code, t.name, t.sort_order, t.created_by, t.created_at
+		FROM dish_tag_relations dtr
+		INNER JOIN dish_tags t ON t.id = dtr.tag_id
+		WHERE dtr.dish_id = ANY($1)
+		ORDER BY dtr.dish_id, t.group_code, t.sort_order, t.created_at, t.id
+	`
+
+	rows, err := r.db.Query(query, pq.Array(dishIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dish tag relations: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var dishID string
+		tag := &models.DishTag{}
+		var familyID, createdBy sql.NullString
+		if err := rows.Scan(&dishID, &tag.ID, &familyID, &tag.Group, &tag.Name, &tag.SortOrder, &createdBy, &tag.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan dish tag: %w", err)
+		}
+		tag.FamilyID = nullableString(familyID)
+		tag.CreatedBy = nullableString(createdBy)
+		result[dishID] = append(result[dishID], tag)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate dish tags: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetFamilyTag 获取家庭自定义标签
+func (r *DishTagRepository) GetFamilyTag(id, familyID string) (*models.DishTag, error) {
+	query := `
+		SELECT id, family_id, group_code, name, sort_order, created_by, created_at
+		FROM dish_tags
+		WHERE id = $1 AND family_id = $2
+	`
+
+	rows, err := r.db.Query(query, id, familyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dish tag: %w", err)
+	}
+	defer rows.Close()
+
+	tags, err := scanDishTags(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(tags) == 0 {
+		return nil, ErrDishTagNotFound
+	}
+
+	return tags[0], nil
+}
+
+// GetByName 按名称（不区分大小写）查找系统标签或家庭自定义标签，系统标签优先
+func (r *DishTagRepository) GetByName(familyID, name string) (*models.DishTag, error) {
+	query := `
+		SELECT id, family_id, group_code, name, sort_order, created_by, created_at
+		FROM dish_tags
+		WHERE (family_id IS NULL OR family_id = $1) AND LOWER(name) = LOWER($2)
+		ORDER BY family_id NULLS FIRST
+		LIMIT 1
+	`
+
+	rows, err := r.db.Query(query, familyID, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dish tag by name: %w", err)
+	}
+	defer rows.Close()
+
+	tags, err := scanDishTags(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(tags) == 0 {
+		return nil, ErrDishTagNotFound
+	}
+
+	return tags[0], nil
+}
+
+// Create 新增家庭自定义标签
+func (r *DishTagRepository) Create(tag *models.DishTag) error {
+	query := `
+		INSERT INTO dish_tags (id, family_id, group_code, name, sort_order, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING created_at
+	`
+
+	err := r.db.QueryRow(
+		query,
+		tag.ID,
+		tag.FamilyID,
+		tag.Group,
+		tag.Name,
+		tag.SortOrder,
+		nullString(tag.CreatedBy),
+	).Scan(&tag.CreatedAt)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation && pqErr.Constraint == "uk_dish_tags_family_name" {
+			return ErrDishTagExists
+		}
+		return fmt.Errorf("failed to create dish tag: %w", err)
+	}
+
+	return nil
+}
+
+// Delete 删除家庭自定义标签，菜式上的该标签随之移除
+func (r *DishTagRepository) Delete(id, familyID string) error {
+	res, err := r.db.Exec(`DELETE FROM dish_tags WHERE id = $1 AND family_id = $2`, id, familyID)
+	if err != nil {
+		return fmt.Errorf("failed to delete dish tag: %w", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to fetch affected rows: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrDishTagNotFound
+	}
+
+	return nil
+}
+
+// replaceDishTags 在事务内替换菜式的全部标签
+func replaceDishTags(ctx context.Context, tx *sql.Tx, dishID string, tagIDs []string) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM dish_tag_relations WHERE dish_id = $1`, dishID); err != nil {
+		return fmt.Errorf("failed to delete dish tags: %w", err)
+	}
+	if len(tagIDs) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO dish_tag_relations (dish_id, tag_id)
+		SELECT $1, UNNEST($2::CHAR(26)[])
+		ON CONFLICT DO NOTHING
+	`
+	if _, err := tx.ExecContext(ctx, query, dishID, pq.Array(tagIDs)); err != nil {
+		return fmt.Errorf("failed to insert dish tags: %w", err)
+	}
+
+	return nil
+}
+
+func scanDishTags(rows *sql.Rows) ([]*models.DishTag, error) {
+	tags := []*models.DishTag{}
+	for rows.Next() {
+		tag := &models.DishTag{}
+		var familyID, createdBy sql.NullString
+		if err := rows.Scan(&tag.ID, &familyID, &tag.Group, &tag.Name, &tag.SortOrder, &createdBy, &tag.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan dish tag: %w", err)
+		}
+		tag.FamilyID = nullableString(familyID)
+		tag.CreatedBy = nullableString(createdBy)
+		tags = append(tags, tag)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate dish tags: %w", err)
+	}
+
+	return tags, nil
+}
@@ -0,0 +1,210 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"onetaste-family/backend/internal/models"
+	"onetaste-family/backend/pkg/database"
+)
+
+var (
+	// ErrFamilyInvitationNotFound 家庭邀请不存在
+	ErrFamilyInvitationNotFound = errors.New("family invitation not found")
+)
+
+// FamilyInvitationRepository 家庭邀请数据访问层
+type FamilyInvitationRepository struct {
+	db *sql.DB
+}
+
+// NewFamilyInvitationRepository 创建家庭邀请仓储
+func NewFamilyInvitationRepository() *FamilyInvitationRepository {
+	return &FamilyInvitationRepository{
+		db: database.GetDB(),
+	}
+}
+
+// Create 创建家庭邀请
+func (r *FamilyInvitationRepository) Create(invitation *models.FamilyInvitation) error {
+	query := `
+		INSERT INTO family_invitations (id, family_id, inviter_id, target_phone, max_uses, used_count, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING created_at
+	`
+
+	err := r.db.QueryRow(
+		query,
+		invitation.ID,
+		invitation.FamilyID,
+		invitation.InviterID,
+		nullString(invitation.TargetPhone),
+		invitation.MaxUses,
+		invitation.UsedCount,
+		invitation.ExpiresAt,
+	).Scan(&invitation.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create family invitation: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID 根据ID获取家庭邀请（含邀请人昵称）
+func (r *FamilyInvitationRepository) GetByID(invitationID string) (*models.FamilyInvitation, error) {
+	query := `
+		SELECT fi.id, fi.family_id, fi.inviter_id, COALESCE(u.nickname, ''), fi.target_phone,
+			fi.max_uses, fi.used_count, fi.expires_at, fi.revoked_at, fi.created_at
+		FROM family_invitations fi
+		INNER JOIN users u ON u.id = fi.inviter_id
+		WHERE fi.id = $1
+	`
+
+	invitation, err := scanFamilyInvitation(r.db.QueryRow(query, invitationID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrFamilyInvitationNotFound
+		}
+		return nil, fmt.Errorf("failed to get family invitation: %w", err)
+	}
+
+	return invitation, nil
+}
+
+// ListByFamily 获取家庭的邀请列表，按创建时间倒序
+func (r *FamilyInvitationRepository) ListByFamily(familyID string) ([]*models.FamilyInvitation, error) {
+	query := `
+		SELECT fi.id, fi.family_id, fi.inviter_id, COALESCE(u.nickname, ''), fi.target_phone,
+			fi.max_uses, fi.used_count, fi.expires_at, fi.revoked_at, fi.created_at
+		FROM family_invitations fi
+		INNER JOIN users u ON u.id = fi.inviter_id
+		WHERE fi.family_id = $1
+		ORDER BY fi.created_at DESC
+	`
+
+	rows, err := r.db.Query(query, familyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query family invitations: %w", err)
+	}
+	defer rows.Close()
+
+	var invitations []*models.FamilyInvitation
+	for rows.Next() {
+		invitation, err := scanFamilyInvitation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan family invitation: %w", err)
+		}
+		invitations = append(invitations, invitation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate family invitations: %w", err)
+	}
+
+	return invitations, nil
+}
+
+// Revoke 撤销家庭邀请
+func (r *FamilyInvitationRepository) Revoke(invitationID, familyID string) error {
+	query := `
+		UPDATE family_invitations
+		SET revoked_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND family_id = $2 AND revoked_at IS NULL
+	`
+
+	res, err := r.db.Exec(query, invitationID, familyID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke family invitation: %w", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to fetch affected rows: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrFamilyInvitationNotFound
+	}
+
+	return nil
+}
+
+// LockByIDTx 在事务内锁定邀请记录
+func (r *FamilyInvitationRepository) LockByIDTx(ctx context.Context, tx *sql.Tx, invitationID string) (*models.FamilyInvitation, error) {
+	query := `
+		SELECT fi.id, fi.family_id, fi.inviter_id, '', fi.target_phone,
+			fi.max_uses, fi.used_count, fi.expires_at, fi.revoked_at, fi.created_at
+		FROM family_invitations fi
+		WHERE fi.id = $1
+		FOR UPDATE
+	`
+
+	invitation, err := scanFamilyInvitation(tx.QueryRowContext(ctx, query, invitationID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrFamilyInvitationNotFound
+		}
+		return nil, fmt.Errorf("failed to lock family invitation: %w", err)
+	}
+
+	return invitation, nil
+}
+
+// IncrementUsedTx 在事务内累加邀请使用次数
+func (r *FamilyInvitationRepository) IncrementUsedTx(ctx context.Context, tx *sql.Tx, invitationID string) error {
+	query := `UPDATE family_invitations SET used_count = used_count + 1 WHERE id = $1`
+
+	if _, err := tx.ExecContext(ctx, query, invitationID); err != nil {
+		return fmt.Errorf("failed to update family invitation usage: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeAllByFamilyTx 在事务内撤销家庭全部未撤销的邀请
+func (r *FamilyInvitationRepository) RevokeAllByFamilyTx(ctx context.Context, tx *sql.Tx, familyID string) error {
+	query := `
+		UPDATE family_invitations
+		SET revoked_at = CURRENT_TIMESTAMP
+		WHERE family_id = $1 AND revoked_at IS NULL
+	`
+
+	if _, err := tx.ExecContext(ctx, query, familyID); err != nil {
+		return fmt.Errorf("failed to revoke family invitations: %w", err)
+	}
+
+	return nil
+}
+
+type invitationScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanFamilyInvitation(scanner invitationScanner) (*models.FamilyInvitation, error) {
+	invitation := &models.FamilyInvitation{}
+	var targetPhone sql.NullString
+	var revokedAt sql.NullTime
+	if err := scanner.Scan(
+		&invitation.ID,
+		&invitation.FamilyID,
+		&invitation.InviterID,
+		&invitation.InviterNickname,
+		&targetPhone,
+		&invitation.MaxUses,
+		&invitation.UsedCount,
+		&invitation.ExpiresAt,
+		&revokedAt,
+		&invitation.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	invitation.TargetPhone = nullableString(targetPhone)
+	if revokedAt.Valid {
+		value := revokedAt.Time
+		invitation.RevokedAt = &value
+	}
+
+	return invitation, nil
+}
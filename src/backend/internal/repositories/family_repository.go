@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"onetaste-family/backend/internal/models"
 	"onetaste-family/backend/pkg/database"
@@ -15,6 +16,8 @@ var (
 	ErrFamilyNotFound = errors.New("family not found")
 	// ErrFamilyMemberExists 成员已存在
 	ErrFamilyMemberExists = errors.New("family member already exists")
+	// ErrFamilyMemberNotFound 成员不存在或已退出
+	ErrFamilyMemberNotFound = errors.New("family member not found")
 )
 
 // FamilyRepository 家庭数据访问层
@@ -177,14 +180,18 @@ func (r *FamilyRepository) CreateFamilyTx(ctx context.Context, tx *sql.Tx, famil
 }
 
 // AddFamilyMemberTx 在事务内添加成员
+// 曾经退出过该家庭的用户重新加入时复用原成员记录
 func (r *FamilyRepository) AddFamilyMemberTx(ctx context.Context, tx *sql.Tx, member *models.FamilyMember) error {
 	query := `
 		INSERT INTO family_members (id, family_id, user_id, role, status)
 		VALUES ($1, $2, $3, $4, $5)
-		RETURNING joined_at, created_at, updated_at
+		ON CONFLICT (family_id, user_id) DO UPDATE
+		SET role = EXCLUDED.role, status = EXCLUDED.status, joined_at = CURRENT_TIMESTAMP
+		WHERE family_members.status <> EXCLUDED.status
+		RETURNING id, joined_at, created_at, updated_at
 	`
 
-	return tx.QueryRowContext(
+	err := tx.QueryRowContext(
 		ctx,
 		query,
 		member.ID,
@@ -192,31 +199,12 @@ func (r *FamilyRepository) AddFamilyMemberTx(ctx context.Context, tx *sql.Tx, me
 		member.UserID,
 		member.Role,
 		member.Status,
-	).Scan(&member.JoinedAt, &member.CreatedAt, &member.UpdatedAt)
-}
-
-// AddFamilyMember 添加家庭成员（非事务）
-func (r *FamilyRepository) AddFamilyMember(member *models.FamilyMember) error {
-	query := `
-		INSERT INTO family_members (id, family_id, user_id, role, status)
-		VALUES ($1, $2, $3, $4, $5)
-		RETURNING joined_at, created_at, updated_at
-	`
-
-	err := r.db.QueryRow(
-		query,
-		member.ID,
-		member.FamilyID,
-		member.UserID,
-		member.Role,
-		member.Status,
-	).Scan(&member.JoinedAt, &member.CreatedAt, &member.UpdatedAt)
-
+	).Scan(&member.ID, &member.JoinedAt, &member.CreatedAt, &member.UpdatedAt)
 	if err != nil {
-		if err.Error() == "pq: duplicate key value violates unique constraint \"family_members_family_id_user_id_key\"" {
+		if errors.Is(err, sql.ErrNoRows) {
 			return ErrFamilyMemberExists
 		}
-		return fmt.Errorf("failed to add family member: %w", err)
+		return err
 	}
 
 	return nil
@@ -253,3 +241,403 @@ func (r *FamilyRepository) GetFamilyMembers(familyID string) ([]*models.FamilyMe
 
 	return members, nil
 }
+
+// LockFamilyTx 在事务内锁定家庭记录并返回最新数据
+func (r *FamilyRepository) LockFamilyTx(ctx context.Context, tx *sql.Tx, familyID string) (*models.Family, error) {
+	query := `
+		SELECT id, name, description, owner_id, max_dishes, status, created_at, updated_at
+		FROM families
+		WHERE id = $1 AND status = $2
+		FOR UPDATE
+	`
+
+	family := &models.Family{}
+	err := tx.QueryRowContext(ctx, query, familyID, models.FamilyStatusActive).Scan(
+		&family.ID,
+		&family.Name,
+		&family.Description,
+		&family.OwnerID,
+		&family.MaxDishes,
+		&family.Status,
+		&family.CreatedAt,
+		&family.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrFamilyNotFound
+		}
+		return nil, fmt.Errorf("failed to lock family: %w", err)
+	}
+
+	return family, nil
+}
+
+// GetFamilyMemberTx 在事务内获取有效成员记录
+func (r *FamilyRepository) GetFamilyMemberTx(ctx context.Context, tx *sql.Tx, familyID, userID string) (*models.FamilyMember, error) {
+	query := `
+		SELECT id, family_id, user_id, role, status, joined_at, created_at, updated_at
+		FROM family_members
+		WHERE family_id = $1 AND user_id = $2 AND status = $3
+		FOR UPDATE
+	`
+
+	member := &models.FamilyMember{}
+	err := tx.QueryRowContext(ctx, query, familyID, userID, models.FamilyMemberStatusActive).Scan(
+		&member.ID,
+		&member.FamilyID,
+		&member.UserID,
+		&member.Role,
+		&member.Status,
+		&member.JoinedAt,
+		&member.CreatedAt,
+		&member.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrFamilyMemberNotFound
+		}
+		return nil, fmt.Errorf("failed to get family member: %w", err)
+	}
+
+	return member, nil
+}
+
+// CountFamilyMembersTx 在事务内统计家庭有效成员数量
+func (r *FamilyRepository) CountFamilyMembersTx(ctx context.Context, tx *sql.Tx, familyID string) (int, error) {
+	query := `SELECT COUNT(*) FROM family_members WHERE family_id = $1 AND status = $2`
+
+	var count int
+	if err := tx.QueryRowContext(ctx, query, familyID, models.FamilyMemberStatusActive).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count family members: %w", err)
+	}
+
+	return count, nil
+}
+
+// UpdateMemberRoleTx 在事务内修改成员角色
+func (r *FamilyRepository) UpdateMemberRoleTx(ctx context.Context, tx *sql.Tx, familyID, userID, role string) error {
+	query := `
+		UPDATE family_members
+		SET role = $1
+		WHERE family_id = $2 AND user_id = $3 AND status = $4
+	`
+
+	res, err := tx.ExecContext(ctx, query, role, familyID, userID, models.FamilyMemberStatusActive)
+	if err != nil {
+		return fmt.Errorf("failed to update family member role: %w", err)
+	}
+
+	return expectAffectedMember(res)
+}
+
+// DeactivateMemberTx 在事务内将成员标记为已退出，并取消身体状况记录的公开
+func (r *FamilyRepository) DeactivateMemberTx(ctx context.Context, tx *sql.Tx, familyID, userID string) error {
+	query := `
+		UPDATE family_members
+		SET status = $1, role = $2, share_health_records = FALSE
+		WHERE family_id = $3 AND user_id = $4 AND status = $5
+	`
+
+	res, err := tx.ExecContext(
+		ctx,
+		query,
+		models.FamilyMemberStatusInactive,
+		models.FamilyRoleMember,
+		familyID,
+		userID,
+		models.FamilyMemberStatusActive,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to deactivate family member: %w", err)
+	}
+
+	return expectAffectedMember(res)
+}
+
+// GetHealthSharing 获取成员是否向家庭管理员公开身体状况记录
+func (r *FamilyRepository) GetHealthSharing(familyID, userID string) (bool, error) {
+	query := `
+		SELECT share_health_records
+		FROM family_members
+		WHERE family_id = $1 AND user_id = $2 AND status = $3
+	`
+
+	var shared bool
+	err := r.db.QueryRow(query, familyID, userID, models.FamilyMemberStatusActive).Scan(&shared)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, ErrFamilyMemberNotFound
+		}
+		return false, fmt.Errorf("failed to get health sharing: %w", err)
+	}
+
+	return shared, nil
+}
+
+// UpdateHealthSharing 设置成员是否向家庭管理员公开身体状况记录
+func (r *FamilyRepository) UpdateHealthSharing(familyID, userID string, shared bool) error {
+	query := `
+		UPDATE family_members
+		SET share_health_records = $1
+		WHERE family_id = $2 AND user_id = $3 AND status = $4
+	`
+
+	res, err := r.db.Exec(query, shared, familyID, userID, models.FamilyMemberStatusActive)
+	if err != nil {
+		return fmt.Errorf("failed to update health sharing: %w", err)
+	}
+
+	return expectAffectedMember(res)
+}
+
+// UpdateFamilyOwnerTx 在事务内更新家庭owner
+func (r *FamilyRepository) UpdateFamilyOwnerTx(ctx context.Context, tx *sql.Tx, familyID, ownerID string) error {
+	query := `UPDATE families SET owner_id = $1 WHERE id = $2 AND status = $3`
+
+	res, err := tx.ExecContext(ctx, query, ownerID, familyID, models.FamilyStatusActive)
+	if err != nil {
+		return fmt.Errorf("failed to update family owner: %w", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to fetch affected rows: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrFamilyNotFound
+	}
+
+	return nil
+}
+
+// UpdateMaxDishes 更新家庭的菜式数量上限
+func (r *FamilyRepository) UpdateMaxDishes(familyID string, maxDishes int) error {
+	query := `UPDATE families SET max_dishes = $1 WHERE id = $2 AND max_dishes IS DISTINCT FROM $1`
+
+	if _, err := r.db.Exec(query, maxDishes, familyID); err != nil {
+		return fmt.Errorf("failed to update family max dishes: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateMaxDishesTx 在事务内更新家庭的菜式数量上限
+func (r *FamilyRepository) UpdateMaxDishesTx(ctx context.Context, tx *sql.Tx, familyID string, maxDishes int) error {
+	query := `UPDATE families SET max_dishes = $1 WHERE id = $2`
+
+	if _, err := tx.ExecContext(ctx, query, maxDishes, familyID); err != nil {
+		return fmt.Errorf("failed to update family max dishes: %w", err)
+	}
+
+	return nil
+}
+
+// DissolveFamilyTx 在事务内将家庭标记为解散，并记录数据保留截止时间
+func (r *FamilyRepository) DissolveFamilyTx(ctx context.Context, tx *sql.Tx, familyID string, dissolvedAt, purgeAfter time.Time) error {
+	query := `
+		UPDATE families
+		SET status = $1, dissolved_at = $2, purge_after = $3
+		WHERE id = $4 AND status = $5
+	`
+
+	res, err := tx.ExecContext(ctx, query, models.FamilyStatusDisabled, dissolvedAt, purgeAfter, familyID, models.FamilyStatusActive)
+	if err != nil {
+		return fmt.Errorf("failed to dissolve family: %w", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to fetch affected rows: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrFamilyNotFound
+	}
+
+	return nil
+}
+
+// DeactivateAllMembersTx 在事务内将家庭全部成员标记为已退出
+func (r *FamilyRepository) DeactivateAllMembersTx(ctx context.Context, tx *sql.Tx, familyID string) error {
+	query := `UPDATE family_members SET status = $1, share_health_records = FALSE WHERE family_id = $2 AND status = $3`
+
+	if _, err := tx.ExecContext(ctx, query, models.FamilyMemberStatusInactive, familyID, models.FamilyMemberStatusActive); err != nil {
+		return fmt.Errorf("failed to deactivate family members: %w", err)
+	}
+
+	return nil
+}
+
+// GetFamilyForExport 获取尚未清理数据的家庭（包含已解散家庭）
+func (r *FamilyRepository) GetFamilyForExport(familyID string) (*models.Family, error) {
+	query := `
+		SELECT id, name, description, owner_id, max_dishes, status, dissolved_at, purge_after, created_at, updated_at
+		FROM families
+		WHERE id = $1 AND purged_at IS NULL
+	`
+
+	family := &models.Family{}
+	var description sql.NullString
+	var dissolvedAt, purgeAfter sql.NullTime
+	err := r.db.QueryRow(query, familyID).Scan(
+		&family.ID,
+		&family.Name,
+		&description,
+		&family.OwnerID,
+		&family.MaxDishes,
+		&family.Status,
+		&dissolvedAt,
+		&purgeAfter,
+		&family.CreatedAt,
+		&family.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrFamilyNotFound
+		}
+		return nil, fmt.Errorf("failed to get family for export: %w", err)
+	}
+
+	family.Description = nullableString(description)
+	if dissolvedAt.Valid {
+		family.DissolvedAt = &dissolvedAt.Time
+	}
+	if purgeAfter.Valid {
+		family.PurgeAfter = &purgeAfter.Time
+	}
+
+	return family, nil
+}
+
+// GetAllFamilyMembers 获取家庭全部成员（包含已退出成员），用于数据导出
+func (r *FamilyRepository) GetAllFamilyMembers(familyID string) ([]*models.FamilyExportMember, error) {
+	query := `
+		SELECT fm.user_id, COALESCE(u.nickname, ''), fm.role, fm.status, fm.joined_at
+		FROM family_members fm
+		INNER JOIN users u ON u.id = fm.user_id
+		WHERE fm.family_id = $1
+		ORDER BY fm.joined_at ASC
+	`
+
+	rows, err := r.db.Query(query, familyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query family members: %w", err)
+	}
+	defer rows.Close()
+
+	members := make([]*models.FamilyExportMember, 0)
+	for rows.Next() {
+		member := &models.FamilyExportMember{}
+		if err := rows.Scan(&member.UserID, &member.Nickname, &member.Role, &member.Status, &member.JoinedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan family member: %w", err)
+		}
+		members = append(members, member)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate family members: %w", err)
+	}
+
+	return members, nil
+}
+
+// ListFamiliesToPurge 获取已超过保留期限、等待清理数据的家庭ID
+func (r *FamilyRepository) ListFamiliesToPurge(now time.Time, limit int) ([]string, error) {
+	query := `
+		SELECT id
+		FROM families
+		WHERE status = $1 AND purged_at IS NULL AND purge_after IS NOT NULL AND purge_after <= $2
+		ORDER BY purge_after ASC
+		LIMIT $3
+	`
+
+	rows, err := r.db.Query(query, models.FamilyStatusDisabled, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query families to purge: %w", err)
+	}
+	defer rows.Close()
+
+	var familyIDs []string
+	for rows.Next() {
+		var familyID string
+		if err := rows.Scan(&familyID); err != nil {
+			return nil, fmt.Errorf("failed to scan family id: %w", err)
+		}
+		familyIDs = append(familyIDs, familyID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate families to purge: %w", err)
+	}
+
+	return familyIDs, nil
+}
+
+// PurgeFamilyData 清理已解散家庭创建的数据，家庭记录保留用于关联订单等历史数据
+func (r *FamilyRepository) PurgeFamilyData(familyID string, purgedAt time.Time) (err error) {
+	ctx := context.Background()
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction failed: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	var status int
+	if err = tx.QueryRowContext(
+		ctx,
+		`SELECT status FROM families WHERE id = $1 AND purged_at IS NULL FOR UPDATE`,
+		familyID,
+	).Scan(&status); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = ErrFamilyNotFound
+			return err
+		}
+		return fmt.Errorf("failed to lock family: %w", err)
+	}
+	if status != models.FamilyStatusDisabled {
+		err = ErrFamilyNotFound
+		return err
+	}
+
+	// 菜式食材、步骤、标签关联、菜单关联、菜单草稿明细、购物清单项均随主表级联删除
+	purgeQueries := []string{
+		`DELETE FROM shopping_lists WHERE family_id = $1`,
+		`DELETE FROM menus WHERE family_id = $1`,
+		`DELETE FROM menu_generations WHERE family_id = $1`,
+		`DELETE FROM dishes WHERE family_id = $1`,
+		`DELETE FROM dish_tags WHERE family_id = $1`,
+		`DELETE FROM health_records WHERE family_id = $1`,
+		`DELETE FROM family_invitations WHERE family_id = $1`,
+		`DELETE FROM family_members WHERE family_id = $1`,
+	}
+	for _, query := range purgeQueries {
+		if _, err = tx.ExecContext(ctx, query, familyID); err != nil {
+			return fmt.Errorf("failed to purge family data: %w", err)
+		}
+	}
+
+	if _, err = tx.ExecContext(ctx, `UPDATE families SET purged_at = $1 WHERE id = $2`, purgedAt, familyID); err != nil {
+		return fmt.Errorf("failed to mark family purged: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction failed: %w", err)
+	}
+
+	return nil
+}
+
+func expectAffectedMember(res sql.Result) error {
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to fetch affected rows: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrFamilyMemberNotFound
+	}
+	return nil
+}
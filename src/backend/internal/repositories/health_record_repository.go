@@ -0,0 +1,259 @@
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+	"onetaste-family/backend/internal/models"
+	"onetaste-family/backend/pkg/database"
+)
+
+// ErrHealthRecordNotFound 身体状况记录不存在
+var ErrHealthRecordNotFound = errors.New("health record not found")
+
+// healthRecordColumns 查询身体状况记录的列，与 scanHealthRecord 对应
+const healthRecordColumns = `id, user_id, family_id, diseases, work_status, stress_level, body_feelings,
+			analysis_result, recommendations, diet_suggestions, analyzed_at, created_at`
+
+// HealthRecordRepository 身体状况记录数据访问层
+// diseases 与 recommendations 列以 JSON 数组文本存储
+type HealthRecordRepository struct {
+	db *sql.DB
+}
+
+// NewHealthRecordRepository 创建身体状况记录仓储
+func NewHealthRecordRepository() *HealthRecordRepository {
+	return &HealthRecordRepository{
+		db: database.GetDB(),
+	}
+}
+
+// GetLatestByUsers 获取家庭中指定成员各自最新的一条记录，没有记录的成员不返回
+func (r *HealthRecordRepository) GetLatestByUsers(familyID string, userIDs []string) (map[string]*models.HealthRecord, error) {
+	records := make(map[string]*models.HealthRecord)
+	if len(userIDs) == 0 {
+		return records, nil
+	}
+
+	query := `
+		SELECT DISTINCT ON (user_id) ` + healthRecordColumns + `
+		FROM health_records
+		WHERE family_id = $1 AND user_id = ANY($2)
+		ORDER BY user_id, created_at DESC, id DESC
+	`
+
+	rows, err := r.db.Query(query, familyID, pq.Array(userIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query health records: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		record, err := scanHealthRecord(rows)
+		if err != nil {
+			return nil, err
+		}
+		records[record.UserID] = record
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate health records: %w", err)
+	}
+
+	return records, nil
+}
+
+// Create 新增身体状况记录
+func (r *HealthRecordRepository) Create(record *models.HealthRecord) error {
+	diseases, err := encodeStringList(record.Diseases)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO health_records (id, user_id, family_id, diseases, work_status, stress_level, body_feelings, raw_data)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING created_at
+	`
+
+	err = r.db.QueryRow(
+		query,
+		record.ID,
+		record.UserID,
+		record.FamilyID,
+		diseases,
+		nullString(record.WorkStatus),
+		nullString(record.StressLevel),
+		nullString(record.BodyFeelings),
+		nullString(record.RawData),
+	).Scan(&record.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create health record: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID 获取家庭中的一条身体状况记录
+func (r *HealthRecordRepository) GetByID(id, familyID string) (*models.HealthRecord, error) {
+	query := `
+		SELECT ` + healthRecordColumns + `
+		FROM health_records
+		WHERE id = $1 AND family_id = $2
+	`
+
+	record, err := scanHealthRecord(r.db.QueryRow(query, id, familyID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrHealthRecordNotFound
+		}
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// ListByUser 分页获取成员在家庭中的身体状况记录，按记录时间倒序
+func (r *HealthRecordRepository) ListByUser(familyID, userID string, page, pageSize int) ([]*models.HealthRecord, int64, error) {
+	var total int64
+	countQuery := `SELECT COUNT(*) FROM health_records WHERE family_id = $1 AND user_id = $2`
+	if err := r.db.QueryRow(countQuery, familyID, userID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count health records: %w", err)
+	}
+
+	query := `
+		SELECT ` + healthRecordColumns + `
+		FROM health_records
+		WHERE family_id = $1 AND user_id = $2
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := r.db.Query(query, familyID, userID, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query health records: %w", err)
+	}
+	defer rows.Close()
+
+	records := make([]*models.HealthRecord, 0, pageSize)
+	for rows.Next() {
+		record, err := scanHealthRecord(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		records = append(records, record)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate health records: %w", err)
+	}
+
+	return records, total, nil
+}
+
+// UpdateAnalysis 保存AI分析结果
+func (r *HealthRecordRepository) UpdateAnalysis(record *models.HealthRecord) error {
+	recommendations, err := encodeStringList(record.Recommendations)
+	if err != nil {
+		return err
+	}
+	dietSuggestions, err := encodeStringList(record.DietSuggestions)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE health_records
+		SET analysis_result = $1, recommendations = $2, diet_suggestions = $3, analyzed_at = $4
+		WHERE id = $5 AND family_id = $6
+	`
+
+	res, err := r.db.Exec(
+		query,
+		record.AnalysisResult,
+		recommendations,
+		dietSuggestions,
+		record.AnalyzedAt,
+		record.ID,
+		record.FamilyID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update health analysis: %w", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to fetch affected rows: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrHealthRecordNotFound
+	}
+
+	return nil
+}
+
+type healthRecordScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanHealthRecord(row healthRecordScanner) (*models.HealthRecord, error) {
+	record := &models.HealthRecord{}
+	var diseases, workStatus, stressLevel, bodyFeelings, analysis, recommendations, dietSuggestions sql.NullString
+	var analyzedAt sql.NullTime
+	if err := row.Scan(
+		&record.ID,
+		&record.UserID,
+		&record.FamilyID,
+		&diseases,
+		&workStatus,
+		&stressLevel,
+		&bodyFeelings,
+		&analysis,
+		&recommendations,
+		&dietSuggestions,
+		&analyzedAt,
+		&record.CreatedAt,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to scan health record: %w", err)
+	}
+
+	record.Diseases = decodeStringList(diseases)
+	record.WorkStatus = nullableString(workStatus)
+	record.StressLevel = nullableString(stressLevel)
+	record.BodyFeelings = nullableString(bodyFeelings)
+	record.AnalysisResult = nullableString(analysis)
+	record.Recommendations = decodeStringList(recommendations)
+	record.DietSuggestions = decodeStringList(dietSuggestions)
+	record.AnalyzedAt = nullableTime(analyzedAt)
+	return record, nil
+}
+
+// encodeStringList 将列表编码为 JSON 数组文本
+func encodeStringList(list []string) (string, error) {
+	if list == nil {
+		list = []string{}
+	}
+	data, err := json.Marshal(list)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode string list: %w", err)
+	}
+	return string(data), nil
+}
+
+// decodeStringList 解析 JSON 数组文本，历史数据格式不正确时按空列表处理
+func decodeStringList(ns sql.NullString) []string {
+	list := []string{}
+	if !ns.Valid || ns.String == "" {
+		return list
+	}
+	if err := json.Unmarshal([]byte(ns.String), &list); err != nil {
+		return []string{}
+	}
+	return list
+}
@@ -30,7 +30,7 @@ func (r *IngredientRepository) GetActiveByIDs(ids []string) (map[string]*models.
 	}
 
 	query := `
-		SELECT id, name, name_en, category, default_unit, storage_days, description
+		SELECT id, name, name_en, category, default_unit, storage_days, description, density, piece_weight
 		FROM ingredients
 		WHERE id = ANY($1) AND is_active = TRUE
 	`
@@ -48,6 +48,7 @@ func (r *IngredientRepository) GetActiveByIDs(ids []string) (map[string]*models.
 		var unit sql.NullString
 		var storage sql.NullInt64
 		var description sql.NullString
+		var density, pieceWeight sql.NullFloat64
 		if err := rows.Scan(
 			&item.ID,
 			&item.Name,
@@ -56,6 +57,8 @@ func (r *IngredientRepository) GetActiveByIDs(ids []string) (map[string]*models.
 			&unit,
 			&storage,
 			&description,
+			&density,
+			&pieceWeight,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan ingredient: %w", err)
 		}
@@ -69,6 +72,8 @@ func (r *IngredientRepository) GetActiveByIDs(ids []string) (map[string]*models.
 			value := int(storage.Int64)
 			item.StorageDays = &value
 		}
+		item.Density = nullableFloat(density)
+		item.PieceWeight = nullableFloat(pieceWeight)
 
 		result[item.ID] = item
 	}
@@ -57,6 +57,64 @@ func (r *IngredientRepository) SearchActiveIngredients(keyword string, limit int
 	return results, nil
 }
 
+// FindActiveContainedIn 查找名称（或英文名）包含在给定文本中的启用食材，按匹配名称长度倒序
+// 用于把“新鲜猪肉末”“large eggs”这类带修饰词的名称对应到食材库
+func (r *IngredientRepository) FindActiveContainedIn(text string, limit int) ([]*models.IngredientSearchResult, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return []*models.IngredientSearchResult{}, nil
+	}
+
+	query := `
+        SELECT id, name, category, default_unit, storage_days
+        FROM (
+            SELECT id, name, category, default_unit, storage_days,
+                GREATEST(
+                    CASE WHEN $1 ILIKE '%' || name || '%' THEN char_length(name) ELSE 0 END,
+                    CASE WHEN char_length(COALESCE(name_en, '')) >= 3 AND $1 ILIKE '%' || name_en || '%' THEN char_length(name_en) ELSE 0 END
+                ) AS match_length
+            FROM ingredients
+            WHERE is_active = TRUE
+        ) matched
+        WHERE match_length > 0
+        ORDER BY match_length DESC, name ASC
+        LIMIT $2
+    `
+
+	rows, err := r.db.Query(query, text, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ingredients: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*models.IngredientSearchResult
+	for rows.Next() {
+		item := &models.IngredientSearchResult{}
+		var category sql.NullString
+		var unit sql.NullString
+		var storage sql.NullInt64
+		if err := rows.Scan(&item.IngredientID, &item.Name, &category, &unit, &storage); err != nil {
+			return nil, fmt.Errorf("failed to scan ingredient: %w", err)
+		}
+
+		item.IngredientID = strings.TrimSpace(item.IngredientID)
+		item.Category = nullableString(category)
+		item.DefaultUnit = nullableString(unit)
+		if storage.Valid {
+			value := int(storage.Int64)
+			item.StorageDays = &value
+		}
+
+		results = append(results, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate ingredients: %w", err)
+	}
+
+	return results, nil
+}
+
 // GetActiveByCategory 分类分页查询
 func (r *IngredientRepository) GetActiveByCategory(category, keyword string, page, pageSize int) ([]*models.IngredientSearchResult, int64, error) {
 	args := []interface{}{category}
@@ -0,0 +1,176 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"onetaste-family/backend/internal/models"
+	"onetaste-family/backend/pkg/database"
+)
+
+var (
+	// ErrMembershipNotFound 会员记录不存在
+	ErrMembershipNotFound = errors.New("membership not found")
+)
+
+// MembershipRepository 会员数据访问层
+type MembershipRepository struct {
+	db *sql.DB
+}
+
+// NewMembershipRepository 创建会员仓储
+func NewMembershipRepository() *MembershipRepository {
+	return &MembershipRepository{
+		db: database.GetDB(),
+	}
+}
+
+// CreateTx 在事务中创建会员记录
+func (r *MembershipRepository) CreateTx(ctx context.Context, tx *sql.Tx, membership *models.Membership) error {
+	query := `
+		INSERT INTO memberships (id, user_id, family_id, plan_type, status, started_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING created_at, updated_at
+	`
+
+	err := tx.QueryRowContext(
+		ctx,
+		query,
+		membership.ID,
+		membership.UserID,
+		membership.FamilyID,
+		membership.PlanType,
+		membership.Status,
+		membership.StartedAt,
+		membership.ExpiresAt,
+	).Scan(&membership.CreatedAt, &membership.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create membership: %w", err)
+	}
+
+	return nil
+}
+
+// GetActiveByFamily 获取家庭在 now 时刻有效的会员记录，多条时返回到期最晚的一条
+func (r *MembershipRepository) GetActiveByFamily(familyID string, now time.Time) (*models.Membership, error) {
+	query := `
+		SELECT id, user_id, family_id, plan_type, status, started_at, expires_at, created_at, updated_at
+		FROM memberships
+		WHERE family_id = $1 AND status = $2 AND started_at <= $3 AND expires_at > $3
+		ORDER BY expires_at DESC
+		LIMIT 1
+	`
+
+	membership, err := scanMembership(r.db.QueryRow(query, familyID, models.MembershipStatusActive, now))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrMembershipNotFound
+		}
+		return nil, fmt.Errorf("failed to get active membership: %w", err)
+	}
+
+	return membership, nil
+}
+
+const latestActiveMembershipQuery = `
+	SELECT id, user_id, family_id, plan_type, status, started_at, expires_at, created_at, updated_at
+	FROM memberships
+	WHERE family_id = $1 AND status = $2 AND expires_at > $3
+	ORDER BY expires_at DESC
+	LIMIT 1
+`
+
+// GetLatestActiveByFamily 获取家庭到期最晚的有效会员记录（含已续费尚未开始的记录）
+func (r *MembershipRepository) GetLatestActiveByFamily(familyID string, now time.Time) (*models.Membership, error) {
+	membership, err := scanMembership(r.db.QueryRow(latestActiveMembershipQuery, familyID, models.MembershipStatusActive, now))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrMembershipNotFound
+		}
+		return nil, fmt.Errorf("failed to get latest membership: %w", err)
+	}
+
+	return membership, nil
+}
+
+// GetLatestActiveByFamilyTx 在事务中获取家庭到期最晚的有效会员记录，用于续费时顺延
+func (r *MembershipRepository) GetLatestActiveByFamilyTx(ctx context.Context, tx *sql.Tx, familyID string, now time.Time) (*models.Membership, error) {
+	membership, err := scanMembership(tx.QueryRowContext(ctx, latestActiveMembershipQuery, familyID, models.MembershipStatusActive, now))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrMembershipNotFound
+		}
+		return nil, fmt.Errorf("failed to get latest membership: %w", err)
+	}
+
+	return membership, nil
+}
+
+// CancelTx 在事务中取消会员记录（退款时使用）
+func (r *MembershipRepository) CancelTx(ctx context.Context, tx *sql.Tx, membershipID string) error {
+	query := `UPDATE memberships SET status = $2 WHERE id = $1 AND status = $3`
+
+	if _, err := tx.ExecContext(ctx, query, membershipID, models.MembershipStatusCancelled, models.MembershipStatusActive); err != nil {
+		return fmt.Errorf("failed to cancel membership: %w", err)
+	}
+
+	return nil
+}
+
+// ExpireDue 将已到期的有效会员标记为过期，返回受影响的家庭ID
+func (r *MembershipRepository) ExpireDue(now time.Time) ([]string, error) {
+	query := `
+		UPDATE memberships
+		SET status = $1
+		WHERE status = $2 AND expires_at <= $3
+		RETURNING family_id
+	`
+
+	rows, err := r.db.Query(query, models.MembershipStatusExpired, models.MembershipStatusActive, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expire memberships: %w", err)
+	}
+	defer rows.Close()
+
+	seen := make(map[string]struct{})
+	var familyIDs []string
+	for rows.Next() {
+		var familyID string
+		if err := rows.Scan(&familyID); err != nil {
+			return nil, fmt.Errorf("failed to scan expired membership: %w", err)
+		}
+		if _, ok := seen[familyID]; ok {
+			continue
+		}
+		seen[familyID] = struct{}{}
+		familyIDs = append(familyIDs, familyID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate expired memberships: %w", err)
+	}
+
+	return familyIDs, nil
+}
+
+func scanMembership(row *sql.Row) (*models.Membership, error) {
+	membership := &models.Membership{}
+	err := row.Scan(
+		&membership.ID,
+		&membership.UserID,
+		&membership.FamilyID,
+		&membership.PlanType,
+		&membership.Status,
+		&membership.StartedAt,
+		&membership.ExpiresAt,
+		&membership.CreatedAt,
+		&membership.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return membership, nil
+}
@@ -0,0 +1,241 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	"onetaste-family/backend/internal/models"
+	"onetaste-family/backend/pkg/database"
+)
+
+var (
+	// ErrMenuGenerationNotFound 菜单生成草稿不存在
+	ErrMenuGenerationNotFound = errors.New("menu generation not found")
+)
+
+// MenuGenerationRepository 菜单生成草稿数据访问层
+type MenuGenerationRepository struct {
+	db *sql.DB
+}
+
+// NewMenuGenerationRepository 创建菜单生成草稿仓储
+func NewMenuGenerationRepository() *MenuGenerationRepository {
+	return &MenuGenerationRepository{
+		db: database.GetDB(),
+	}
+}
+
+// BeginTx 开启事务
+func (r *MenuGenerationRepository) BeginTx(ctx context.Context) (*sql.Tx, error) {
+	return r.db.BeginTx(ctx, nil)
+}
+
+const menuGenerationColumns = `
+	id, family_id, created_by, source, start_date, end_date, reason, status,
+	confirmed_at, created_at, updated_at
+`
+
+// CreateWithItems 保存草稿及其全部餐次
+func (r *MenuGenerationRepository) CreateWithItems(generation *models.MenuGeneration) (err error) {
+	ctx := context.Background()
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction failed: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	insertGeneration := `
+		INSERT INTO menu_generations (id, family_id, created_by, source, start_date, end_date, reason, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING created_at, updated_at
+	`
+
+	err = tx.QueryRowContext(
+		ctx,
+		insertGeneration,
+		generation.ID,
+		generation.FamilyID,
+		generation.CreatedBy,
+		generation.Source,
+		generation.StartDate,
+		generation.EndDate,
+		nullString(generation.Reason),
+		generation.Status,
+	).Scan(&generation.CreatedAt, &generation.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert menu generation: %w", err)
+	}
+
+	insertItem := `
+		INSERT INTO menu_generation_items (id, generation_id, date, meal_type, dish_ids, reason)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING created_at
+	`
+
+	for _, item := range generation.Items {
+		item.GenerationID = generation.ID
+		err = tx.QueryRowContext(
+			ctx,
+			insertItem,
+			item.ID,
+			item.GenerationID,
+			item.Date,
+			item.MealType,
+			pq.Array(item.DishIDs),
+			nullString(item.Reason),
+		).Scan(&item.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to insert menu generation item: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction failed: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID 获取家庭的草稿及其餐次
+func (r *MenuGenerationRepository) GetByID(generationID, familyID string) (*models.MenuGeneration, error) {
+	query := `SELECT ` + menuGenerationColumns + ` FROM menu_generations WHERE id = $1 AND family_id = $2`
+
+	return r.getWithItems(context.Background(), r.db, query, generationID, familyID)
+}
+
+// GetByIDForUpdateTx 在事务内锁定草稿并返回其餐次，防止重复确认
+func (r *MenuGenerationRepository) GetByIDForUpdateTx(ctx context.Context, tx *sql.Tx, generationID, familyID string) (*models.MenuGeneration, error) {
+	query := `SELECT ` + menuGenerationColumns + ` FROM menu_generations WHERE id = $1 AND family_id = $2 FOR UPDATE`
+
+	return r.getWithItems(ctx, tx, query, generationID, familyID)
+}
+
+// UpdateStatusTx 在事务内更新草稿状态
+func (r *MenuGenerationRepository) UpdateStatusTx(ctx context.Context, tx *sql.Tx, generation *models.MenuGeneration) error {
+	query := `
+		UPDATE menu_generations
+		SET status = $1, confirmed_at = $2, updated_at = NOW()
+		WHERE id = $3
+		RETURNING updated_at
+	`
+
+	if err := tx.QueryRowContext(ctx, query, generation.Status, generation.ConfirmedAt, generation.ID).Scan(&generation.UpdatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrMenuGenerationNotFound
+		}
+		return fmt.Errorf("failed to update menu generation: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateStatus 更新草稿状态，仅当草稿仍处于 fromStatus 时生效
+func (r *MenuGenerationRepository) UpdateStatus(generationID, familyID, fromStatus, toStatus string) error {
+	query := `
+		UPDATE menu_generations
+		SET status = $1, updated_at = NOW()
+		WHERE id = $2 AND family_id = $3 AND status = $4
+	`
+
+	result, err := r.db.Exec(query, toStatus, generationID, familyID, fromStatus)
+	if err != nil {
+		return fmt.Errorf("failed to update menu generation: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrMenuGenerationNotFound
+	}
+
+	return nil
+}
+
+type menuGenerationQueryer interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+func (r *MenuGenerationRepository) getWithItems(ctx context.Context, q menuGenerationQueryer, query, generationID, familyID string) (*models.MenuGeneration, error) {
+	generation := &models.MenuGeneration{}
+	var reason sql.NullString
+	var confirmedAt sql.NullTime
+	if err := q.QueryRowContext(ctx, query, generationID, familyID).Scan(
+		&generation.ID,
+		&generation.FamilyID,
+		&generation.CreatedBy,
+		&generation.Source,
+		&generation.StartDate,
+		&generation.EndDate,
+		&reason,
+		&generation.Status,
+		&confirmedAt,
+		&generation.CreatedAt,
+		&generation.UpdatedAt,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrMenuGenerationNotFound
+		}
+		return nil, fmt.Errorf("failed to get menu generation: %w", err)
+	}
+
+	generation.Reason = nullableString(reason)
+	generation.ConfirmedAt = nullableTime(confirmedAt)
+
+	itemsQuery := `
+		SELECT id, generation_id, date, meal_type, dish_ids, reason, created_at
+		FROM menu_generation_items
+		WHERE generation_id = $1
+		ORDER BY date ASC,
+			CASE meal_type WHEN 'breakfast' THEN 0 WHEN 'lunch' THEN 1 ELSE 2 END ASC
+	`
+
+	rows, err := q.QueryContext(ctx, itemsQuery, generation.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query menu generation items: %w", err)
+	}
+	defer rows.Close()
+
+	generation.Items = make([]*models.MenuGenerationItem, 0)
+	for rows.Next() {
+		item := &models.MenuGenerationItem{}
+		var itemReason sql.NullString
+		var dishIDs []string
+		if err := rows.Scan(
+			&item.ID,
+			&item.GenerationID,
+			&item.Date,
+			&item.MealType,
+			pq.Array(&dishIDs),
+			&itemReason,
+			&item.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan menu generation item: %w", err)
+		}
+
+		item.Reason = nullableString(itemReason)
+		item.DishIDs = make([]string, 0, len(dishIDs))
+		for _, dishID := range dishIDs {
+			item.DishIDs = append(item.DishIDs, strings.TrimSpace(dishID))
+		}
+		generation.Items = append(generation.Items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate menu generation items: %w", err)
+	}
+
+	return generation, nil
+}
@@ -5,8 +5,10 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/lib/pq"
 	"onetaste-family/backend/internal/models"
 	"onetaste-family/backend/internal/utils"
 	"onetaste-family/backend/pkg/database"
@@ -29,23 +31,43 @@ func NewMenuRepository() *MenuRepository {
 	}
 }
 
+// BeginTx 开启事务
+func (r *MenuRepository) BeginTx(ctx context.Context) (*sql.Tx, error) {
+	return r.db.BeginTx(ctx, nil)
+}
+
 // GetMenuByDateAndMealType 根据日期和餐次获取菜单
 func (r *MenuRepository) GetMenuByDateAndMealType(familyID string, date time.Time, mealType string) (*models.Menu, error) {
+	return getMenuByDateAndMealType(context.Background(), r.db, familyID, date, mealType)
+}
+
+// GetMenuByDateAndMealTypeTx 在事务内根据日期和餐次获取菜单
+func (r *MenuRepository) GetMenuByDateAndMealTypeTx(ctx context.Context, tx *sql.Tx, familyID string, date time.Time, mealType string) (*models.Menu, error) {
+	return getMenuByDateAndMealType(ctx, tx, familyID, date, mealType)
+}
+
+type menuQueryer interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+func getMenuByDateAndMealType(ctx context.Context, q menuQueryer, familyID string, date time.Time, mealType string) (*models.Menu, error) {
 	query := `
-		SELECT id, family_id, date, meal_type, created_by, source, created_at, updated_at
+		SELECT id, family_id, date, meal_type, created_by, source, servings, created_at, updated_at
 		FROM menus
-		WHERE family_id = $1 AND date = $2 AND meal_type = $3
+		WHERE family_id = $1 AND date = $2 AND meal_type = $3 AND deleted_at IS NULL
 	`
 
 	menu := &models.Menu{}
 	var source sql.NullString
-	if err := r.db.QueryRow(query, familyID, date, mealType).Scan(
+	var servings sql.NullInt64
+	if err := q.QueryRowContext(ctx, query, familyID, date, mealType).Scan(
 		&menu.ID,
 		&menu.FamilyID,
 		&menu.Date,
 		&menu.MealType,
 		&menu.CreatedBy,
 		&source,
+		&servings,
 		&menu.CreatedAt,
 		&menu.UpdatedAt,
 	); err != nil {
@@ -59,6 +81,7 @@ func (r *MenuRepository) GetMenuByDateAndMealType(familyID string, date time.Tim
 	if menu.Source == "" {
 		menu.Source = models.MenuSourceManual
 	}
+	menu.Servings = int(servings.Int64)
 
 	return menu, nil
 }
@@ -77,14 +100,27 @@ func (r *MenuRepository) CreateMenuWithDishes(menu *models.Menu, dishIDs []strin
 		}
 	}()
 
+	if err = r.CreateMenuWithDishesTx(ctx, tx, menu, dishIDs); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction failed: %w", err)
+	}
+
+	return nil
+}
+
+// CreateMenuWithDishesTx 在事务内创建菜单并关联菜式
+func (r *MenuRepository) CreateMenuWithDishesTx(ctx context.Context, tx *sql.Tx, menu *models.Menu, dishIDs []string) error {
 	// 插入菜单
 	insertMenu := `
-		INSERT INTO menus (id, family_id, date, meal_type, created_by, source)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO menus (id, family_id, date, meal_type, created_by, source, servings)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
 		RETURNING created_at, updated_at
 	`
 
-	err = tx.QueryRowContext(
+	err := tx.QueryRowContext(
 		ctx,
 		insertMenu,
 		menu.ID,
@@ -93,21 +129,14 @@ func (r *MenuRepository) CreateMenuWithDishes(menu *models.Menu, dishIDs []strin
 		menu.MealType,
 		menu.CreatedBy,
 		nullString(menu.Source),
+		nullInt(menu.Servings),
 	).Scan(&menu.CreatedAt, &menu.UpdatedAt)
 	if err != nil {
 		return fmt.Errorf("failed to insert menu: %w", err)
 	}
 
 	// 插入菜单菜式关联
-	if err = r.insertMenuDishes(ctx, tx, menu.ID, dishIDs); err != nil {
-		return err
-	}
-
-	if err = tx.Commit(); err != nil {
-		return fmt.Errorf("commit transaction failed: %w", err)
-	}
-
-	return nil
+	return r.insertMenuDishes(ctx, tx, menu.ID, dishIDs, menu.DishServings)
 }
 
 // UpdateMenuWithDishes 更新菜单并关联菜式
@@ -124,20 +153,34 @@ func (r *MenuRepository) UpdateMenuWithDishes(menu *models.Menu, dishIDs []strin
 		}
 	}()
 
+	if err = r.UpdateMenuWithDishesTx(ctx, tx, menu, dishIDs); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction failed: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateMenuWithDishesTx 在事务内更新菜单并替换关联菜式
+func (r *MenuRepository) UpdateMenuWithDishesTx(ctx context.Context, tx *sql.Tx, menu *models.Menu, dishIDs []string) error {
 	// 更新菜单
 	updateMenu := `
 		UPDATE menus
-		SET date = $1, meal_type = $2, source = $3, updated_at = NOW()
-		WHERE id = $4 AND family_id = $5
+		SET date = $1, meal_type = $2, source = $3, servings = $4, updated_at = NOW()
+		WHERE id = $5 AND family_id = $6
 		RETURNING updated_at
 	`
 
-	err = tx.QueryRowContext(
+	err := tx.QueryRowContext(
 		ctx,
 		updateMenu,
 		menu.Date,
 		menu.MealType,
 		nullString(menu.Source),
+		nullInt(menu.Servings),
 		menu.ID,
 		menu.FamilyID,
 	).Scan(&menu.UpdatedAt)
@@ -154,27 +197,20 @@ func (r *MenuRepository) UpdateMenuWithDishes(menu *models.Menu, dishIDs []strin
 	}
 
 	// 插入新的菜单菜式关联
-	if err = r.insertMenuDishes(ctx, tx, menu.ID, dishIDs); err != nil {
-		return err
-	}
-
-	if err = tx.Commit(); err != nil {
-		return fmt.Errorf("commit transaction failed: %w", err)
-	}
-
-	return nil
+	return r.insertMenuDishes(ctx, tx, menu.ID, dishIDs, menu.DishServings)
 }
 
 // GetMenuByID 根据ID获取菜单
 func (r *MenuRepository) GetMenuByID(menuID, familyID string) (*models.Menu, error) {
 	query := `
-		SELECT id, family_id, date, meal_type, created_by, source, created_at, updated_at
+		SELECT id, family_id, date, meal_type, created_by, source, servings, created_at, updated_at
 		FROM menus
-		WHERE id = $1 AND family_id = $2
+		WHERE id = $1 AND family_id = $2 AND deleted_at IS NULL
 	`
 
 	menu := &models.Menu{}
 	var source sql.NullString
+	var servings sql.NullInt64
 	if err := r.db.QueryRow(query, menuID, familyID).Scan(
 		&menu.ID,
 		&menu.FamilyID,
@@ -182,6 +218,7 @@ func (r *MenuRepository) GetMenuByID(menuID, familyID string) (*models.Menu, err
 		&menu.MealType,
 		&menu.CreatedBy,
 		&source,
+		&servings,
 		&menu.CreatedAt,
 		&menu.UpdatedAt,
 	); err != nil {
@@ -195,6 +232,7 @@ func (r *MenuRepository) GetMenuByID(menuID, familyID string) (*models.Menu, err
 	if menu.Source == "" {
 		menu.Source = models.MenuSourceManual
 	}
+	menu.Servings = int(servings.Int64)
 
 	return menu, nil
 }
@@ -230,12 +268,43 @@ func (r *MenuRepository) GetMenuDishes(menuID string) ([]string, error) {
 	return dishIDs, nil
 }
 
+// GetMenuDishServings 获取菜单中单独设置了用餐人数的菜式，键为菜式ID
+func (r *MenuRepository) GetMenuDishServings(menuID string) (map[string]int, error) {
+	query := `
+		SELECT dish_id, servings
+		FROM menu_dishes
+		WHERE menu_id = $1 AND servings IS NOT NULL
+	`
+
+	rows, err := r.db.Query(query, menuID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query menu dish servings: %w", err)
+	}
+	defer rows.Close()
+
+	servings := make(map[string]int)
+	for rows.Next() {
+		var dishID string
+		var value int
+		if err := rows.Scan(&dishID, &value); err != nil {
+			return nil, fmt.Errorf("failed to scan menu dish servings: %w", err)
+		}
+		servings[strings.TrimSpace(dishID)] = value
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate menu dish servings: %w", err)
+	}
+
+	return servings, nil
+}
+
 // GetMenusByDateRange 根据日期范围获取菜单列表
 func (r *MenuRepository) GetMenusByDateRange(familyID string, startDate, endDate time.Time) ([]*models.Menu, error) {
 	query := `
-		SELECT id, family_id, date, meal_type, created_by, source, created_at, updated_at
+		SELECT id, family_id, date, meal_type, created_by, source, servings, created_at, updated_at
 		FROM menus
-		WHERE family_id = $1 AND date >= $2 AND date <= $3
+		WHERE family_id = $1 AND date >= $2 AND date <= $3 AND deleted_at IS NULL
 		ORDER BY date ASC, meal_type ASC
 	`
 
@@ -249,6 +318,7 @@ func (r *MenuRepository) GetMenusByDateRange(familyID string, startDate, endDate
 	for rows.Next() {
 		menu := &models.Menu{}
 		var source sql.NullString
+		var servings sql.NullInt64
 		if err := rows.Scan(
 			&menu.ID,
 			&menu.FamilyID,
@@ -256,6 +326,7 @@ func (r *MenuRepository) GetMenusByDateRange(familyID string, startDate, endDate
 			&menu.MealType,
 			&menu.CreatedBy,
 			&source,
+			&servings,
 			&menu.CreatedAt,
 			&menu.UpdatedAt,
 		); err != nil {
@@ -266,6 +337,7 @@ func (r *MenuRepository) GetMenusByDateRange(familyID string, startDate, endDate
 		if menu.Source == "" {
 			menu.Source = models.MenuSourceManual
 		}
+		menu.Servings = int(servings.Int64)
 
 		menus = append(menus, menu)
 	}
@@ -277,6 +349,165 @@ func (r *MenuRepository) GetMenusByDateRange(familyID string, startDate, endDate
 	return menus, nil
 }
 
+// ListMenusByDish 获取引用了指定菜式的有效菜单，按日期与餐次排序
+func (r *MenuRepository) ListMenusByDish(familyID, dishID string) ([]*models.DishMenuUsage, error) {
+	query := `
+		SELECT m.id, m.date, m.meal_type
+		FROM menu_dishes md
+		JOIN menus m ON m.id = md.menu_id
+		WHERE md.dish_id = $1 AND m.family_id = $2 AND m.deleted_at IS NULL
+		ORDER BY m.date ASC, m.meal_type ASC
+	`
+
+	rows, err := r.db.Query(query, dishID, familyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dish menus: %w", err)
+	}
+	defer rows.Close()
+
+	usages := make([]*models.DishMenuUsage, 0)
+	for rows.Next() {
+		usage := &models.DishMenuUsage{}
+		var date time.Time
+		if err := rows.Scan(&usage.MenuID, &date, &usage.MealType); err != nil {
+			return nil, fmt.Errorf("failed to scan dish menu: %w", err)
+		}
+		usage.Date = date.Format("2006-01-02")
+		usages = append(usages, usage)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate dish menus: %w", err)
+	}
+
+	return usages, nil
+}
+
+// GetMenuSlotsByDateRange 获取日期范围内的菜单及其菜式ID，用于排菜时判断空餐次与近期重复
+func (r *MenuRepository) GetMenuSlotsByDateRange(familyID string, startDate, endDate time.Time) ([]*models.MenuSlot, error) {
+	query := `
+		SELECT
+			m.id,
+			m.date,
+			m.meal_type,
+			COALESCE(array_agg(md.dish_id ORDER BY md.created_at) FILTER (WHERE md.dish_id IS NOT NULL), '{}')
+		FROM menus m
+		LEFT JOIN menu_dishes md ON md.menu_id = m.id
+		WHERE m.family_id = $1 AND m.date >= $2 AND m.date <= $3 AND m.deleted_at IS NULL
+		GROUP BY m.id
+		ORDER BY m.date ASC, m.meal_type ASC
+	`
+
+	rows, err := r.db.Query(query, familyID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query menu slots: %w", err)
+	}
+	defer rows.Close()
+
+	slots := make([]*models.MenuSlot, 0)
+	for rows.Next() {
+		slot := &models.MenuSlot{}
+		var dishIDs []string
+		if err := rows.Scan(&slot.MenuID, &slot.Date, &slot.MealType, pq.Array(&dishIDs)); err != nil {
+			return nil, fmt.Errorf("failed to scan menu slot: %w", err)
+		}
+
+		slot.DishIDs = make([]string, 0, len(dishIDs))
+		for _, dishID := range dishIDs {
+			slot.DishIDs = append(slot.DishIDs, strings.TrimSpace(dishID))
+		}
+		slots = append(slots, slot)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate menu slots: %w", err)
+	}
+
+	return slots, nil
+}
+
+// GetIngredientsByMenuIDs 获取菜单中所有有效菜式的食材明细
+// 同一菜式出现在多个菜单中时会返回多条记录，由调用方负责按份数缩放并汇总
+func (r *MenuRepository) GetIngredientsByMenuIDs(menuIDs []string) ([]*models.MenuIngredient, error) {
+	if len(menuIDs) == 0 {
+		return []*models.MenuIngredient{}, nil
+	}
+
+	query := `
+		SELECT
+			md.menu_id,
+			md.dish_id,
+			di.ingredient_id,
+			bi.name,
+			bi.category,
+			bi.default_unit,
+			bi.storage_days,
+			bi.density,
+			bi.piece_weight,
+			di.amount,
+			di.unit,
+			d.servings,
+			COALESCE(md.servings, m.servings, d.servings)
+		FROM menu_dishes md
+		JOIN menus m ON m.id = md.menu_id
+		JOIN dishes d ON d.id = md.dish_id AND d.deleted_at IS NULL
+		JOIN dish_ingredients di ON di.dish_id = d.id
+		JOIN ingredients bi ON bi.id = di.ingredient_id
+		WHERE md.menu_id = ANY($1)
+		ORDER BY md.menu_id ASC, di.sort_order ASC, di.id ASC
+	`
+
+	rows, err := r.db.Query(query, pq.Array(menuIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query menu ingredients: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*models.MenuIngredient
+	for rows.Next() {
+		item := &models.MenuIngredient{}
+		var category sql.NullString
+		var defaultUnit sql.NullString
+		var storage sql.NullInt64
+		var density, pieceWeight sql.NullFloat64
+		if err := rows.Scan(
+			&item.MenuID,
+			&item.DishID,
+			&item.IngredientID,
+			&item.IngredientName,
+			&category,
+			&defaultUnit,
+			&storage,
+			&density,
+			&pieceWeight,
+			&item.Amount,
+			&item.Unit,
+			&item.BaseServings,
+			&item.Servings,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan menu ingredient: %w", err)
+		}
+
+		item.IngredientID = strings.TrimSpace(item.IngredientID)
+		item.Category = nullableString(category)
+		item.DefaultUnit = nullableString(defaultUnit)
+		if storage.Valid {
+			value := int(storage.Int64)
+			item.StorageDays = &value
+		}
+		item.Density = nullableFloat(density)
+		item.PieceWeight = nullableFloat(pieceWeight)
+
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate menu ingredients: %w", err)
+	}
+
+	return items, nil
+}
+
 // DeleteMenu 删除菜单
 func (r *MenuRepository) DeleteMenu(menuID, familyID string) error {
 	query := `DELETE FROM menus WHERE id = $1 AND family_id = $2`
@@ -298,21 +529,95 @@ func (r *MenuRepository) DeleteMenu(menuID, familyID string) error {
 	return nil
 }
 
-// insertMenuDishes 插入菜单菜式关联
-func (r *MenuRepository) insertMenuDishes(ctx context.Context, tx *sql.Tx, menuID string, dishIDs []string) error {
+// SoftDeleteByFamilyTx 在事务内软删除家庭全部菜单
+func (r *MenuRepository) SoftDeleteByFamilyTx(ctx context.Context, tx *sql.Tx, familyID string, deletedAt time.Time) error {
+	query := `UPDATE menus SET deleted_at = $1 WHERE family_id = $2 AND deleted_at IS NULL`
+
+	if _, err := tx.ExecContext(ctx, query, deletedAt, familyID); err != nil {
+		return fmt.Errorf("failed to delete family menus: %w", err)
+	}
+
+	return nil
+}
+
+// ListMenusForExport 获取家庭菜单及菜式ID用于导出
+// deletedAt 不为空时同时返回在该时间点被批量软删除的菜单（家庭解散）
+func (r *MenuRepository) ListMenusForExport(familyID string, deletedAt *time.Time) ([]*models.MenuExport, error) {
+	query := `
+		SELECT
+			m.id,
+			m.date,
+			m.meal_type,
+			m.source,
+			m.created_by,
+			m.created_at,
+			COALESCE(array_agg(md.dish_id ORDER BY md.created_at) FILTER (WHERE md.dish_id IS NOT NULL), '{}')
+		FROM menus m
+		LEFT JOIN menu_dishes md ON md.menu_id = m.id
+		WHERE m.family_id = $1 AND (m.deleted_at IS NULL OR m.deleted_at = $2)
+		GROUP BY m.id
+		ORDER BY m.date ASC, m.meal_type ASC
+	`
+
+	rows, err := r.db.Query(query, familyID, deletedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query menus: %w", err)
+	}
+	defer rows.Close()
+
+	menus := make([]*models.MenuExport, 0)
+	for rows.Next() {
+		menu := &models.MenuExport{}
+		var date time.Time
+		var source sql.NullString
+		var dishIDs []string
+		if err := rows.Scan(
+			&menu.MenuID,
+			&date,
+			&menu.MealType,
+			&source,
+			&menu.CreatedBy,
+			&menu.CreatedAt,
+			pq.Array(&dishIDs),
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan menu: %w", err)
+		}
+
+		menu.Date = date.Format("2006-01-02")
+		menu.Source = nullableString(source)
+		if menu.Source == "" {
+			menu.Source = models.MenuSourceManual
+		}
+		menu.DishIDs = make([]string, 0, len(dishIDs))
+		for _, dishID := range dishIDs {
+			menu.DishIDs = append(menu.DishIDs, strings.TrimSpace(dishID))
+		}
+
+		menus = append(menus, menu)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate menus: %w", err)
+	}
+
+	return menus, nil
+}
+
+// insertMenuDishes 插入菜单菜式关联，servings 中没有的菜式沿用菜单的用餐人数
+func (r *MenuRepository) insertMenuDishes(ctx context.Context, tx *sql.Tx, menuID string, dishIDs []string, servings map[string]int) error {
 	if len(dishIDs) == 0 {
 		return nil
 	}
 
 	query := `
-		INSERT INTO menu_dishes (id, menu_id, dish_id)
-		VALUES ($1, $2, $3)
+		INSERT INTO menu_dishes (id, menu_id, dish_id, servings)
+		VALUES ($1, $2, $3, $4)
 		ON CONFLICT (menu_id, dish_id) DO NOTHING
 	`
 
 	for _, dishID := range dishIDs {
 		menuDishID := utils.GenerateULID()
-		if _, err := tx.ExecContext(ctx, query, menuDishID, menuID, dishID); err != nil {
+		if _, err := tx.ExecContext(ctx, query, menuDishID, menuID, dishID, nullInt(servings[dishID])); err != nil {
 			return fmt.Errorf("failed to insert menu dish: %w", err)
 		}
 	}
@@ -0,0 +1,298 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"onetaste-family/backend/internal/models"
+	"onetaste-family/backend/pkg/database"
+)
+
+var (
+	// ErrPaymentOrderNotFound 支付订单不存在
+	ErrPaymentOrderNotFound = errors.New("payment order not found")
+)
+
+// PaymentOrderRepository 支付订单数据访问层
+// amount 列为 DECIMAL(10,2)，读写时统一换算为分，避免浮点误差
+type PaymentOrderRepository struct {
+	db *sql.DB
+}
+
+// NewPaymentOrderRepository 创建支付订单仓储
+func NewPaymentOrderRepository() *PaymentOrderRepository {
+	return &PaymentOrderRepository{
+		db: database.GetDB(),
+	}
+}
+
+// BeginTx 开启事务
+func (r *PaymentOrderRepository) BeginTx(ctx context.Context) (*sql.Tx, error) {
+	return r.db.BeginTx(ctx, nil)
+}
+
+const paymentOrderColumns = `
+	id, order_no, user_id, family_id, plan_type, ROUND(amount * 100)::BIGINT,
+	payment_method, status, transaction_id, membership_id,
+	expires_at, paid_at, refunded_at, created_at, updated_at
+`
+
+// Create 创建待支付订单
+func (r *PaymentOrderRepository) Create(order *models.PaymentOrder) error {
+	query := `
+		INSERT INTO payment_orders (id, order_no, user_id, family_id, plan_type, amount, payment_method, status, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6::numeric / 100, $7, $8, $9)
+		RETURNING created_at, updated_at
+	`
+
+	err := r.db.QueryRow(
+		query,
+		order.ID,
+		order.OrderNo,
+		order.UserID,
+		order.FamilyID,
+		order.PlanType,
+		order.AmountCents,
+		order.PaymentMethod,
+		order.Status,
+		order.ExpiresAt,
+	).Scan(&order.CreatedAt, &order.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create payment order: %w", err)
+	}
+
+	return nil
+}
+
+// GetByUserAndOrderNo 获取用户自己的订单
+func (r *PaymentOrderRepository) GetByUserAndOrderNo(userID, orderNo string) (*models.PaymentOrder, error) {
+	query := `SELECT ` + paymentOrderColumns + ` FROM payment_orders WHERE order_no = $1 AND user_id = $2`
+
+	order, err := scanPaymentOrder(r.db.QueryRow(query, orderNo, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPaymentOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to get payment order: %w", err)
+	}
+
+	return order, nil
+}
+
+// GetByOrderNoForUpdateTx 在事务内按订单号锁定订单，保证回调与退款串行处理
+func (r *PaymentOrderRepository) GetByOrderNoForUpdateTx(ctx context.Context, tx *sql.Tx, orderNo string) (*models.PaymentOrder, error) {
+	query := `SELECT ` + paymentOrderColumns + ` FROM payment_orders WHERE order_no = $1 FOR UPDATE`
+
+	order, err := scanPaymentOrder(tx.QueryRowContext(ctx, query, orderNo))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPaymentOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to lock payment order: %w", err)
+	}
+
+	return order, nil
+}
+
+// ListByUser 分页获取用户的订单，按创建时间倒序
+func (r *PaymentOrderRepository) ListByUser(userID string, page, pageSize int) ([]*models.PaymentOrder, int64, error) {
+	var total int64
+	if err := r.db.QueryRow(`SELECT COUNT(*) FROM payment_orders WHERE user_id = $1`, userID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count payment orders: %w", err)
+	}
+
+	query := `
+		SELECT ` + paymentOrderColumns + `
+		FROM payment_orders
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	offset := (page - 1) * pageSize
+	rows, err := r.db.Query(query, userID, pageSize, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query payment orders: %w", err)
+	}
+	defer rows.Close()
+
+	var orders []*models.PaymentOrder
+	for rows.Next() {
+		order, err := scanPaymentOrder(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan payment order: %w", err)
+		}
+		orders = append(orders, order)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate payment orders: %w", err)
+	}
+
+	return orders, total, nil
+}
+
+// MarkPaidTx 在事务内将订单标记为已支付，membershipID 为空时不关联会员记录
+func (r *PaymentOrderRepository) MarkPaidTx(ctx context.Context, tx *sql.Tx, order *models.PaymentOrder) error {
+	query := `
+		UPDATE payment_orders
+		SET status = $2, transaction_id = $3, membership_id = NULLIF($4, ''), paid_at = $5
+		WHERE id = $1
+	`
+
+	if _, err := tx.ExecContext(
+		ctx,
+		query,
+		order.ID,
+		models.PaymentOrderStatusPaid,
+		order.TransactionID,
+		order.MembershipID,
+		order.PaidAt,
+	); err != nil {
+		return fmt.Errorf("failed to mark payment order paid: %w", err)
+	}
+
+	return nil
+}
+
+// MarkFailedTx 在事务内将待支付订单标记为支付失败
+func (r *PaymentOrderRepository) MarkFailedTx(ctx context.Context, tx *sql.Tx, orderID string) error {
+	query := `UPDATE payment_orders SET status = $2 WHERE id = $1 AND status = $3`
+
+	if _, err := tx.ExecContext(ctx, query, orderID, models.PaymentOrderStatusFailed, models.PaymentOrderStatusPending); err != nil {
+		return fmt.Errorf("failed to mark payment order failed: %w", err)
+	}
+
+	return nil
+}
+
+// MarkRefundingTx 在事务内将订单标记为退款中
+func (r *PaymentOrderRepository) MarkRefundingTx(ctx context.Context, tx *sql.Tx, orderID string) error {
+	query := `UPDATE payment_orders SET status = $2 WHERE id = $1`
+
+	if _, err := tx.ExecContext(ctx, query, orderID, models.PaymentOrderStatusRefunding); err != nil {
+		return fmt.Errorf("failed to mark payment order refunding: %w", err)
+	}
+
+	return nil
+}
+
+// MarkRefunded 将退款中的订单标记为已退款，订单不处于退款中时不做修改
+func (r *PaymentOrderRepository) MarkRefunded(orderID string, refundedAt time.Time) error {
+	query := `UPDATE payment_orders SET status = $2, refunded_at = $3 WHERE id = $1 AND status = $4`
+
+	if _, err := r.db.Exec(query, orderID, models.PaymentOrderStatusRefunded, refundedAt, models.PaymentOrderStatusRefunding); err != nil {
+		return fmt.Errorf("failed to mark payment order refunded: %w", err)
+	}
+
+	return nil
+}
+
+// ListRefunding 获取进入退款中已超过 minAge、仍未完成退款的订单
+// 时间在数据库侧比较，与 updated_at 触发器使用同一时钟
+func (r *PaymentOrderRepository) ListRefunding(minAge time.Duration, limit int) ([]*models.PaymentOrder, error) {
+	query := `
+		SELECT ` + paymentOrderColumns + `
+		FROM payment_orders
+		WHERE status = $1 AND updated_at <= CURRENT_TIMESTAMP - make_interval(secs => $2)
+		ORDER BY updated_at
+		LIMIT $3
+	`
+
+	rows, err := r.db.Query(query, models.PaymentOrderStatusRefunding, minAge.Seconds(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query refunding payment orders: %w", err)
+	}
+	defer rows.Close()
+
+	var orders []*models.PaymentOrder
+	for rows.Next() {
+		order, err := scanPaymentOrder(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan payment order: %w", err)
+		}
+		orders = append(orders, order)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate payment orders: %w", err)
+	}
+
+	return orders, nil
+}
+
+// ExpirePending 关闭已超过支付期限的待支付订单，返回关闭的数量
+func (r *PaymentOrderRepository) ExpirePending(now time.Time) (int64, error) {
+	query := `
+		UPDATE payment_orders
+		SET status = $1
+		WHERE status = $2 AND expires_at <= $3
+	`
+
+	result, err := r.db.Exec(query, models.PaymentOrderStatusExpired, models.PaymentOrderStatusPending, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to expire payment orders: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get expired payment orders: %w", err)
+	}
+
+	return affected, nil
+}
+
+type paymentOrderScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPaymentOrder(row paymentOrderScanner) (*models.PaymentOrder, error) {
+	order := &models.PaymentOrder{}
+	var paymentMethod, status, transactionID, membershipID sql.NullString
+	var expiresAt, paidAt, refundedAt sql.NullTime
+
+	err := row.Scan(
+		&order.ID,
+		&order.OrderNo,
+		&order.UserID,
+		&order.FamilyID,
+		&order.PlanType,
+		&order.AmountCents,
+		&paymentMethod,
+		&status,
+		&transactionID,
+		&membershipID,
+		&expiresAt,
+		&paidAt,
+		&refundedAt,
+		&order.CreatedAt,
+		&order.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	order.PaymentMethod = nullableString(paymentMethod)
+	order.Status = nullableString(status)
+	if order.Status == "" {
+		order.Status = models.PaymentOrderStatusPending
+	}
+	order.TransactionID = nullableString(transactionID)
+	order.MembershipID = nullableString(membershipID)
+	order.ExpiresAt = nullableTime(expiresAt)
+	order.PaidAt = nullableTime(paidAt)
+	order.RefundedAt = nullableTime(refundedAt)
+
+	return order, nil
+}
+
+func nullableTime(nt sql.NullTime) *time.Time {
+	if nt.Valid {
+		value := nt.Time
+		return &value
+	}
+	return nil
+}
@@ -0,0 +1,644 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"onetaste-family/backend/internal/models"
+	"onetaste-family/backend/internal/utils"
+	"onetaste-family/backend/pkg/database"
+)
+
+var (
+	// ErrShoppingListNotFound 购物清单不存在
+	ErrShoppingListNotFound = errors.New("shopping list not found")
+	// ErrShoppingItemNotFound 购物清单项不存在
+	ErrShoppingItemNotFound = errors.New("shopping list item not found")
+)
+
+// ShoppingRepository 购物清单数据访问层
+type ShoppingRepository struct {
+	db *sql.DB
+}
+
+// NewShoppingRepository 创建购物清单仓储
+func NewShoppingRepository() *ShoppingRepository {
+	return &ShoppingRepository{
+		db: database.GetDB(),
+	}
+}
+
+// CreateListWithItems 创建购物清单并保存清单项与来源菜单
+func (r *ShoppingRepository) CreateListWithItems(list *models.ShoppingList, items []*models.ShoppingListItem, menuIDs []string) error {
+	ctx := context.Background()
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction failed: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	insertList := `
+		INSERT INTO shopping_lists (id, family_id, name, start_date, end_date, status, source, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING created_at, updated_at
+	`
+
+	err = tx.QueryRowContext(
+		ctx,
+		insertList,
+		list.ID,
+		list.FamilyID,
+		list.Name,
+		list.StartDate,
+		list.EndDate,
+		list.Status,
+		list.Source,
+		list.CreatedBy,
+	).Scan(&list.CreatedAt, &list.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert shopping list: %w", err)
+	}
+
+	if err = r.insertItems(ctx, tx, list.ID, items); err != nil {
+		return err
+	}
+
+	if err = r.insertListMenus(ctx, tx, list.ID, menuIDs); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction failed: %w", err)
+	}
+
+	return nil
+}
+
+// GetListByID 根据ID获取购物清单
+func (r *ShoppingRepository) GetListByID(listID, familyID string) (*models.ShoppingList, error) {
+	query := `
+		SELECT id, family_id, name, start_date, end_date, status, source, created_by, created_at, updated_at
+		FROM shopping_lists
+		WHERE id = $1 AND family_id = $2
+	`
+
+	list := &models.ShoppingList{}
+	var name, status, source sql.NullString
+	var startDate, endDate sql.NullTime
+	if err := r.db.QueryRow(query, listID, familyID).Scan(
+		&list.ID,
+		&list.FamilyID,
+		&name,
+		&startDate,
+		&endDate,
+		&status,
+		&source,
+		&list.CreatedBy,
+		&list.CreatedAt,
+		&list.UpdatedAt,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrShoppingListNotFound
+		}
+		return nil, fmt.Errorf("failed to get shopping list: %w", err)
+	}
+
+	list.Name = nullableString(name)
+	list.Status = nullableString(status)
+	if list.Status == "" {
+		list.Status = models.ShoppingListStatusPending
+	}
+	list.Source = nullableString(source)
+	if list.Source == "" {
+		list.Source = models.ShoppingListSourceDateRange
+	}
+	if startDate.Valid {
+		list.StartDate = startDate.Time
+	}
+	if endDate.Valid {
+		list.EndDate = endDate.Time
+	}
+
+	return list, nil
+}
+
+// GetListItems 获取购物清单项
+func (r *ShoppingRepository) GetListItems(listID string) ([]*models.ShoppingListItem, error) {
+	query := `
+		SELECT id, list_id, ingredient_id, ingredient_name, category, total_amount, unit, storage_days, status, is_manual, sort_order
+		FROM shopping_list_items
+		WHERE list_id = $1
+		ORDER BY sort_order ASC, id ASC
+	`
+
+	rows, err := r.db.Query(query, listID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query shopping list items: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*models.ShoppingListItem
+	for rows.Next() {
+		item := &models.ShoppingListItem{}
+		var ingredientID, category, status sql.NullString
+		var storage sql.NullInt64
+		var isManual sql.NullBool
+		if err := rows.Scan(
+			&item.ID,
+			&item.ListID,
+			&ingredientID,
+			&item.IngredientName,
+			&category,
+			&item.TotalAmount,
+			&item.Unit,
+			&storage,
+			&status,
+			&isManual,
+			&item.SortOrder,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan shopping list item: %w", err)
+		}
+
+		item.IngredientID = strings.TrimSpace(nullableString(ingredientID))
+		item.Category = nullableString(category)
+		item.Status = nullableString(status)
+		if item.Status == "" {
+			item.Status = models.ShoppingItemStatusPending
+		}
+		item.IsManual = isManual.Valid && isManual.Bool
+		if storage.Valid {
+			value := int(storage.Int64)
+			item.StorageDays = &value
+		}
+
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate shopping list items: %w", err)
+	}
+
+	return items, nil
+}
+
+// GetListsByFamily 分页获取家庭购物清单
+func (r *ShoppingRepository) GetListsByFamily(familyID string, page, pageSize int) ([]*models.ShoppingListSummary, int64, error) {
+	countQuery := `SELECT COUNT(*) FROM shopping_lists WHERE family_id = $1`
+	var total int64
+	if err := r.db.QueryRow(countQuery, familyID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count shopping lists: %w", err)
+	}
+
+	listQuery := `
+		SELECT
+			sl.id,
+			sl.name,
+			sl.start_date,
+			sl.end_date,
+			sl.status,
+			sl.created_at,
+			COUNT(sli.id),
+			COUNT(sli.id) FILTER (WHERE sli.status = $2)
+		FROM shopping_lists sl
+		LEFT JOIN shopping_list_items sli ON sli.list_id = sl.id
+		WHERE sl.family_id = $1
+		GROUP BY sl.id
+		ORDER BY sl.created_at DESC
+		LIMIT $3 OFFSET $4
+	`
+
+	offset := (page - 1) * pageSize
+	rows, err := r.db.Query(listQuery, familyID, models.ShoppingItemStatusPurchased, pageSize, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query shopping lists: %w", err)
+	}
+	defer rows.Close()
+
+	var lists []*models.ShoppingListSummary
+	for rows.Next() {
+		item := &models.ShoppingListSummary{}
+		var name, status sql.NullString
+		var startDate, endDate sql.NullTime
+		if err := rows.Scan(
+			&item.ListID,
+			&name,
+			&startDate,
+			&endDate,
+			&status,
+			&item.CreatedAt,
+			&item.TotalItems,
+			&item.PurchasedItems,
+		); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan shopping list: %w", err)
+		}
+
+		item.Name = nullableString(name)
+		item.Status = nullableString(status)
+		if item.Status == "" {
+			item.Status = models.ShoppingListStatusPending
+		}
+		if startDate.Valid {
+			item.StartDate = startDate.Time.Format("2006-01-02")
+		}
+		if endDate.Valid {
+			item.EndDate = endDate.Time.Format("2006-01-02")
+		}
+
+		lists = append(lists, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate shopping lists: %w", err)
+	}
+
+	return lists, total, nil
+}
+
+// ListAllByFamily 获取家庭全部购物清单（不含清单项），用于数据导出
+func (r *ShoppingRepository) ListAllByFamily(familyID string) ([]*models.ShoppingListExport, error) {
+	query := `
+		SELECT id, name, start_date, end_date, status, source, created_by, created_at
+		FROM shopping_lists
+		WHERE family_id = $1
+		ORDER BY created_at ASC, id ASC
+	`
+
+	rows, err := r.db.Query(query, familyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query shopping lists: %w", err)
+	}
+	defer rows.Close()
+
+	lists := make([]*models.ShoppingListExport, 0)
+	for rows.Next() {
+		list := &models.ShoppingListExport{}
+		var name, status, source sql.NullString
+		var startDate, endDate sql.NullTime
+		if err := rows.Scan(
+			&list.ListID,
+			&name,
+			&startDate,
+			&endDate,
+			&status,
+			&source,
+			&list.CreatedBy,
+			&list.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan shopping list: %w", err)
+		}
+
+		list.Name = nullableString(name)
+		list.Status = nullableString(status)
+		if list.Status == "" {
+			list.Status = models.ShoppingListStatusPending
+		}
+		list.Source = nullableString(source)
+		if list.Source == "" {
+			list.Source = models.ShoppingListSourceDateRange
+		}
+		if startDate.Valid {
+			list.StartDate = startDate.Time.Format("2006-01-02")
+		}
+		if endDate.Valid {
+			list.EndDate = endDate.Time.Format("2006-01-02")
+		}
+
+		lists = append(lists, list)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate shopping lists: %w", err)
+	}
+
+	return lists, nil
+}
+
+// GetListMenuIDs 获取购物清单的来源菜单ID
+func (r *ShoppingRepository) GetListMenuIDs(listID string) ([]string, error) {
+	query := `SELECT menu_id FROM shopping_list_menus WHERE list_id = $1 ORDER BY created_at ASC, id ASC`
+
+	rows, err := r.db.Query(query, listID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query shopping list menus: %w", err)
+	}
+	defer rows.Close()
+
+	var menuIDs []string
+	for rows.Next() {
+		var menuID string
+		if err := rows.Scan(&menuID); err != nil {
+			return nil, fmt.Errorf("failed to scan shopping list menu: %w", err)
+		}
+		menuIDs = append(menuIDs, menuID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate shopping list menus: %w", err)
+	}
+
+	return menuIDs, nil
+}
+
+// AddItem 添加购物清单项
+func (r *ShoppingRepository) AddItem(item *models.ShoppingListItem) error {
+	ctx := context.Background()
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction failed: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if err = r.insertItems(ctx, tx, item.ListID, []*models.ShoppingListItem{item}); err != nil {
+		return err
+	}
+
+	if err = r.refreshListStatus(ctx, tx, item.ListID); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction failed: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateItem 更新购物清单项的数量、单位、状态与手动标记
+func (r *ShoppingRepository) UpdateItem(item *models.ShoppingListItem) error {
+	ctx := context.Background()
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction failed: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if err = r.updateItem(ctx, tx, item); err != nil {
+		return err
+	}
+
+	if err = r.refreshListStatus(ctx, tx, item.ListID); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction failed: %w", err)
+	}
+
+	return nil
+}
+
+// MergeItem 将 source 清单项合并到 target 后删除 source
+func (r *ShoppingRepository) MergeItem(target *models.ShoppingListItem, sourceID string) error {
+	ctx := context.Background()
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction failed: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if err = r.updateItem(ctx, tx, target); err != nil {
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, `DELETE FROM shopping_list_items WHERE id = $1 AND list_id = $2`, sourceID, target.ListID); err != nil {
+		return fmt.Errorf("failed to delete merged shopping list item: %w", err)
+	}
+
+	if err = r.refreshListStatus(ctx, tx, target.ListID); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction failed: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteItem 删除购物清单项
+func (r *ShoppingRepository) DeleteItem(itemID, listID string) error {
+	ctx := context.Background()
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction failed: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	res, execErr := tx.ExecContext(ctx, `DELETE FROM shopping_list_items WHERE id = $1 AND list_id = $2`, itemID, listID)
+	if execErr != nil {
+		err = fmt.Errorf("failed to delete shopping list item: %w", execErr)
+		return err
+	}
+
+	rowsAffected, execErr := res.RowsAffected()
+	if execErr != nil {
+		err = fmt.Errorf("failed to fetch affected rows: %w", execErr)
+		return err
+	}
+	if rowsAffected == 0 {
+		err = ErrShoppingItemNotFound
+		return err
+	}
+
+	if err = r.refreshListStatus(ctx, tx, listID); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction failed: %w", err)
+	}
+
+	return nil
+}
+
+// ReplaceGeneratedItems 重新生成清单：替换非手动清单项与来源菜单，保留手动清单项
+func (r *ShoppingRepository) ReplaceGeneratedItems(list *models.ShoppingList, generated, manual []*models.ShoppingListItem, menuIDs []string) error {
+	ctx := context.Background()
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction failed: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	updateList := `
+		UPDATE shopping_lists
+		SET start_date = $1, end_date = $2, updated_at = NOW()
+		WHERE id = $3 AND family_id = $4
+		RETURNING updated_at
+	`
+
+	err = tx.QueryRowContext(ctx, updateList, list.StartDate, list.EndDate, list.ID, list.FamilyID).Scan(&list.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrShoppingListNotFound
+		}
+		return fmt.Errorf("failed to update shopping list: %w", err)
+	}
+
+	if _, err = tx.ExecContext(ctx, `DELETE FROM shopping_list_items WHERE list_id = $1 AND is_manual = FALSE`, list.ID); err != nil {
+		return fmt.Errorf("failed to delete generated shopping list items: %w", err)
+	}
+
+	if err = r.insertItems(ctx, tx, list.ID, generated); err != nil {
+		return err
+	}
+
+	for _, item := range manual {
+		if _, err = tx.ExecContext(ctx, `UPDATE shopping_list_items SET sort_order = $1 WHERE id = $2`, item.SortOrder, item.ID); err != nil {
+			return fmt.Errorf("failed to update shopping list item order: %w", err)
+		}
+	}
+
+	if _, err = tx.ExecContext(ctx, `DELETE FROM shopping_list_menus WHERE list_id = $1`, list.ID); err != nil {
+		return fmt.Errorf("failed to delete shopping list menus: %w", err)
+	}
+
+	if err = r.insertListMenus(ctx, tx, list.ID, menuIDs); err != nil {
+		return err
+	}
+
+	if err = r.refreshListStatus(ctx, tx, list.ID); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ShoppingRepository) updateItem(ctx context.Context, tx *sql.Tx, item *models.ShoppingListItem) error {
+	query := `
+		UPDATE shopping_list_items
+		SET total_amount = $1, unit = $2, status = $3, is_manual = $4, sort_order = $5, updated_at = NOW()
+		WHERE id = $6 AND list_id = $7
+	`
+
+	res, err := tx.ExecContext(ctx, query, item.TotalAmount, item.Unit, item.Status, item.IsManual, item.SortOrder, item.ID, item.ListID)
+	if err != nil {
+		return fmt.Errorf("failed to update shopping list item: %w", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to fetch affected rows: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrShoppingItemNotFound
+	}
+
+	return nil
+}
+
+// refreshListStatus 所有清单项均已购买时标记清单完成，否则恢复为待购买
+func (r *ShoppingRepository) refreshListStatus(ctx context.Context, tx *sql.Tx, listID string) error {
+	query := `
+		UPDATE shopping_lists
+		SET status = CASE
+			WHEN EXISTS(SELECT 1 FROM shopping_list_items WHERE list_id = $1)
+				AND NOT EXISTS(SELECT 1 FROM shopping_list_items WHERE list_id = $1 AND status <> $2)
+			THEN $3 ELSE $4 END
+		WHERE id = $1
+	`
+
+	if _, err := tx.ExecContext(
+		ctx,
+		query,
+		listID,
+		models.ShoppingItemStatusPurchased,
+		models.ShoppingListStatusCompleted,
+		models.ShoppingListStatusPending,
+	); err != nil {
+		return fmt.Errorf("failed to refresh shopping list status: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ShoppingRepository) insertItems(ctx context.Context, tx *sql.Tx, listID string, items []*models.ShoppingListItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO shopping_list_items (id, list_id, ingredient_id, ingredient_name, total_amount, unit, category, storage_days, status, is_manual, sort_order)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	for _, item := range items {
+		item.ListID = listID
+		if _, err := tx.ExecContext(
+			ctx,
+			query,
+			item.ID,
+			item.ListID,
+			nullString(item.IngredientID),
+			item.IngredientName,
+			item.TotalAmount,
+			item.Unit,
+			nullString(item.Category),
+			item.StorageDays,
+			item.Status,
+			item.IsManual,
+			item.SortOrder,
+		); err != nil {
+			return fmt.Errorf("failed to insert shopping list item: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *ShoppingRepository) insertListMenus(ctx context.Context, tx *sql.Tx, listID string, menuIDs []string) error {
+	if len(menuIDs) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO shopping_list_menus (id, list_id, menu_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (list_id, menu_id) DO NOTHING
+	`
+
+	for _, menuID := range menuIDs {
+		if _, err := tx.ExecContext(ctx, query, utils.GenerateULID(), listID, menuID); err != nil {
+			return fmt.Errorf("failed to insert shopping list menu: %w", err)
+		}
+	}
+
+	return nil
+}
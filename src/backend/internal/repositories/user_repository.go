@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/lib/pq"
+
 	"onetaste-family/backend/internal/models"
 	"onetaste-family/backend/pkg/database"
 )
@@ -60,7 +62,7 @@ func (r *UserRepository) Create(user *models.User) error {
 // GetByPhone 根据手机号获取用户
 func (r *UserRepository) GetByPhone(phone string) (*models.User, error) {
 	query := `
-		SELECT id, phone, password, nickname, avatar, status, created_at, updated_at
+		SELECT id, phone, password, nickname, avatar, dietary_preferences, status, created_at, updated_at
 		FROM users
 		WHERE phone = $1 AND status = 1
 	`
@@ -72,6 +74,7 @@ func (r *UserRepository) GetByPhone(phone string) (*models.User, error) {
 		&user.Password,
 		&user.Nickname,
 		&user.Avatar,
+		pq.Array(&user.DietaryPreferences),
 		&user.Status,
 		&user.CreatedAt,
 		&user.UpdatedAt,
@@ -90,7 +93,7 @@ func (r *UserRepository) GetByPhone(phone string) (*models.User, error) {
 // GetByID 根据ID获取用户
 func (r *UserRepository) GetByID(id string) (*models.User, error) {
 	query := `
-		SELECT id, phone, password, nickname, avatar, status, created_at, updated_at
+		SELECT id, phone, password, nickname, avatar, dietary_preferences, status, created_at, updated_at
 		FROM users
 		WHERE id = $1 AND status = 1
 	`
@@ -102,6 +105,7 @@ func (r *UserRepository) GetByID(id string) (*models.User, error) {
 		&user.Password,
 		&user.Nickname,
 		&user.Avatar,
+		pq.Array(&user.DietaryPreferences),
 		&user.Status,
 		&user.CreatedAt,
 		&user.UpdatedAt,
@@ -129,3 +133,50 @@ func (r *UserRepository) ExistsByPhone(phone string) (bool, error) {
 
 	return exists, nil
 }
+
+// UpdatePassword 更新用户密码
+func (r *UserRepository) UpdatePassword(id, hashedPassword string) error {
+	query := `UPDATE users SET password = $1 WHERE id = $2 AND status = 1`
+
+	result, err := r.db.Exec(query, hashedPassword, id)
+	if err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
+// UpdateProfile 更新用户昵称、头像与饮食偏好
+func (r *UserRepository) UpdateProfile(user *models.User) error {
+	query := `
+		UPDATE users
+		SET nickname = $1, avatar = $2, dietary_preferences = $3
+		WHERE id = $4 AND status = 1
+		RETURNING updated_at
+	`
+
+	err := r.db.QueryRow(
+		query,
+		user.Nickname,
+		user.Avatar,
+		pq.Array(user.DietaryPreferences),
+		user.ID,
+	).Scan(&user.UpdatedAt)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("failed to update user profile: %w", err)
+	}
+
+	return nil
+}
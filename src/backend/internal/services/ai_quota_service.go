@@ -0,0 +1,335 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"onetaste-family/backend/internal/models"
+	"onetaste-family/backend/internal/repositories"
+	"onetaste-family/backend/internal/utils"
+	"onetaste-family/backend/pkg/cache"
+)
+
+const aiQuotaKeyPrefix = "ai_quota:"
+
+// aiQuotaLocation 额度周期按北京时间的自然日、自然周切分
+var aiQuotaLocation = time.FixedZone("UTC+8", 8*60*60)
+
+// aiQuotaRule 单个AI功能的额度规则
+type aiQuotaRule struct {
+	Feature string
+	Period  string
+	Scope   string
+	Limit   int
+}
+
+// aiQuotaRules 付费版各AI功能的额度，免费版不可使用AI功能
+var aiQuotaRules = []aiQuotaRule{
+	{Feature: models.AIFeatureVoiceInput, Period: models.AIQuotaPeriodDaily, Scope: models.AIQuotaScopeUser, Limit: 10},
+	{Feature: models.AIFeatureHealthAnalyze, Period: models.AIQuotaPeriodDaily, Scope: models.AIQuotaScopeUser, Limit: 2},
+	{Feature: models.AIFeatureMenuGenerate, Period: models.AIQuotaPeriodWeekly, Scope: models.AIQuotaScopeFamily, Limit: 5},
+	{Feature: models.AIFeatureMenuAnalyze, Period: models.AIQuotaPeriodWeekly, Scope: models.AIQuotaScopeFamily, Limit: 5},
+}
+
+var (
+	// ErrUnknownAIFeature AI功能不存在
+	ErrUnknownAIFeature = errors.New("unknown ai feature")
+	// ErrAIFeatureRequiresPremium AI功能仅付费版可用
+	ErrAIFeatureRequiresPremium = errors.New("ai feature requires premium")
+	// ErrAIQuotaExceeded 本周期AI额度已用完
+	ErrAIQuotaExceeded = errors.New("ai quota exceeded")
+)
+
+// AIQuotaError 额度不足，携带当前周期的使用情况
+type AIQuotaError struct {
+	Err   error
+	Usage *models.AIQuotaUsage
+}
+
+func (e *AIQuotaError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *AIQuotaError) Unwrap() error {
+	return e.Err
+}
+
+// AIQuotaTicket 一次已扣减的额度，AI调用失败时交给 Release 退回
+type AIQuotaTicket struct {
+	UserID   string
+	FamilyID string
+	Usage    *models.AIQuotaUsage
+
+	periodDate time.Time
+	cacheKey   string
+}
+
+// AIQuotaService AI额度服务
+// 扣减在数据库事务内完成：按计数范围加咨询锁后统计用量并写入 ai_usage_logs，保证并发下不会超额；
+// Redis 只缓存当前周期的用量，用于快速拒绝已用完的请求和额度查询，缓存缺失或异常时回源数据库。
+type AIQuotaService struct {
+	usageRepo  *repositories.AIUsageRepository
+	familyRepo *repositories.FamilyRepository
+	membership *MembershipService
+	redis      *redis.Client
+}
+
+// NewAIQuotaService 创建AI额度服务
+func NewAIQuotaService() *AIQuotaService {
+	return &AIQuotaService{
+		usageRepo:  repositories.NewAIUsageRepository(),
+		familyRepo: repositories.NewFamilyRepository(),
+		membership: NewMembershipService(),
+		redis:      cache.GetRedis(),
+	}
+}
+
+// Consume 校验并扣减一次AI功能额度，额度不足时返回 *AIQuotaError
+func (s *AIQuotaService) Consume(ctx context.Context, userID, feature string) (ticket *AIQuotaTicket, err error) {
+	rule, ok := findAIQuotaRule(feature)
+	if !ok {
+		return nil, ErrUnknownAIFeature
+	}
+
+	familyID, err := s.premiumFamilyID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	periodDate, resetAt := aiQuotaPeriod(rule.Period, aiQuotaNow())
+	scopeID := aiQuotaScopeID(rule, userID, familyID)
+	cacheKey := aiQuotaCacheKey(rule, scopeID, periodDate)
+
+	if rule.Limit != models.AIQuotaUnlimited {
+		if used, ok := s.cachedUsage(ctx, cacheKey); ok && used >= rule.Limit {
+			return nil, &AIQuotaError{Err: ErrAIQuotaExceeded, Usage: buildAIQuotaUsage(rule, used, resetAt)}
+		}
+	}
+
+	tx, err := s.usageRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction failed: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if err = s.usageRepo.LockTx(ctx, tx, cacheKey); err != nil {
+		return nil, err
+	}
+
+	used, err := s.usageRepo.SumUsageTx(ctx, tx, rule.Scope, scopeID, rule.Feature, rule.Period, periodDate)
+	if err != nil {
+		return nil, err
+	}
+	if rule.Limit != models.AIQuotaUnlimited && used >= rule.Limit {
+		s.cacheUsage(ctx, cacheKey, used, resetAt)
+		return nil, &AIQuotaError{Err: ErrAIQuotaExceeded, Usage: buildAIQuotaUsage(rule, used, resetAt)}
+	}
+
+	usage := &models.AIUsageLog{
+		ID:          utils.GenerateULID(),
+		UserID:      userID,
+		FamilyID:    familyID,
+		FeatureType: rule.Feature,
+		PeriodType:  rule.Period,
+		PeriodDate:  periodDate,
+		LimitCount:  rule.Limit,
+	}
+	if err = s.usageRepo.IncrementTx(ctx, tx, usage); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit transaction failed: %w", err)
+	}
+
+	used++
+	s.cacheUsage(ctx, cacheKey, used, resetAt)
+
+	return &AIQuotaTicket{
+		UserID:     userID,
+		FamilyID:   familyID,
+		Usage:      buildAIQuotaUsage(rule, used, resetAt),
+		periodDate: periodDate,
+		cacheKey:   cacheKey,
+	}, nil
+}
+
+// Release 退回一次已扣减的额度，用于AI调用失败的情况
+func (s *AIQuotaService) Release(ctx context.Context, ticket *AIQuotaTicket) error {
+	if ticket == nil {
+		return nil
+	}
+
+	if err := s.usageRepo.Decrement(ctx, ticket.UserID, ticket.FamilyID, ticket.Usage.Feature, ticket.Usage.Period, ticket.periodDate); err != nil {
+		return err
+	}
+
+	// 删除缓存，下次读取时回源数据库
+	if s.redis != nil {
+		s.redis.Del(ctx, ticket.cacheKey)
+	}
+
+	return nil
+}
+
+// GetQuotas 获取用户各AI功能在当前周期的剩余额度
+func (s *AIQuotaService) GetQuotas(ctx context.Context, userID string) (*models.AIQuotaResponse, error) {
+	resp := &models.AIQuotaResponse{
+		MembershipType: models.MembershipTierFree,
+		Quotas:         make([]*models.AIQuotaUsage, 0, len(aiQuotaRules)),
+	}
+
+	familyID, err := s.premiumFamilyID(userID)
+	switch {
+	case err == nil:
+		resp.MembershipType = models.MembershipTierPremium
+		resp.Available = true
+	case errors.Is(err, ErrFamilyNotFound), errors.Is(err, ErrAIFeatureRequiresPremium):
+	default:
+		return nil, err
+	}
+
+	now := aiQuotaNow()
+	for _, rule := range aiQuotaRules {
+		periodDate, resetAt := aiQuotaPeriod(rule.Period, now)
+		if !resp.Available {
+			item := buildAIQuotaUsage(rule, 0, resetAt)
+			item.Remaining = 0
+			resp.Quotas = append(resp.Quotas, item)
+			continue
+		}
+
+		scopeID := aiQuotaScopeID(rule, userID, familyID)
+		cacheKey := aiQuotaCacheKey(rule, scopeID, periodDate)
+		used, ok := s.cachedUsage(ctx, cacheKey)
+		if !ok {
+			used, err = s.usageRepo.SumUsage(ctx, rule.Scope, scopeID, rule.Feature, rule.Period, periodDate)
+			if err != nil {
+				return nil, err
+			}
+			s.cacheUsage(ctx, cacheKey, used, resetAt)
+		}
+
+		resp.Quotas = append(resp.Quotas, buildAIQuotaUsage(rule, used, resetAt))
+	}
+
+	return resp, nil
+}
+
+// premiumFamilyID 返回用户所在的付费版家庭ID
+func (s *AIQuotaService) premiumFamilyID(userID string) (string, error) {
+	family, err := s.familyRepo.GetFamilyByUserID(userID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrFamilyNotFound) {
+			return "", ErrFamilyNotFound
+		}
+		return "", fmt.Errorf("failed to get family: %w", err)
+	}
+
+	tier, _, err := s.membership.FamilyTier(family.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve membership tier: %w", err)
+	}
+	if tier.Type != models.MembershipTierPremium {
+		return "", ErrAIFeatureRequiresPremium
+	}
+
+	return family.ID, nil
+}
+
+// cachedUsage 读取缓存的周期用量，缓存缺失或 Redis 不可用时返回 false
+func (s *AIQuotaService) cachedUsage(ctx context.Context, key string) (int, bool) {
+	if s.redis == nil {
+		return 0, false
+	}
+
+	value, err := s.redis.Get(ctx, key).Result()
+	if err != nil {
+		return 0, false
+	}
+
+	used, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, false
+	}
+
+	return used, true
+}
+
+// cacheUsage 缓存周期用量，过期时间覆盖到周期结束
+func (s *AIQuotaService) cacheUsage(ctx context.Context, key string, used int, resetAt time.Time) {
+	if s.redis == nil {
+		return
+	}
+
+	ttl := time.Until(resetAt) + time.Hour
+	s.redis.Set(ctx, key, used, ttl)
+}
+
+func findAIQuotaRule(feature string) (aiQuotaRule, bool) {
+	for _, rule := range aiQuotaRules {
+		if rule.Feature == feature {
+			return rule, true
+		}
+	}
+	return aiQuotaRule{}, false
+}
+
+func aiQuotaScopeID(rule aiQuotaRule, userID, familyID string) string {
+	if rule.Scope == models.AIQuotaScopeFamily {
+		return familyID
+	}
+	return userID
+}
+
+func aiQuotaCacheKey(rule aiQuotaRule, scopeID string, periodDate time.Time) string {
+	return fmt.Sprintf("%s%s:%s:%s:%s", aiQuotaKeyPrefix, rule.Scope, scopeID, rule.Feature, periodDate.Format("20060102"))
+}
+
+// aiQuotaPeriod 返回 now 所在周期的起始日期和重置时间，周从周一开始
+func aiQuotaPeriod(period string, now time.Time) (time.Time, time.Time) {
+	local := now.In(aiQuotaLocation)
+	start := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, aiQuotaLocation)
+
+	if period == models.AIQuotaPeriodWeekly {
+		offset := (int(start.Weekday()) + 6) % 7
+		start = start.AddDate(0, 0, -offset)
+		return start, start.AddDate(0, 0, 7)
+	}
+
+	return start, start.AddDate(0, 0, 1)
+}
+
+func buildAIQuotaUsage(rule aiQuotaRule, used int, resetAt time.Time) *models.AIQuotaUsage {
+	remaining := models.AIQuotaUnlimited
+	if rule.Limit != models.AIQuotaUnlimited {
+		remaining = rule.Limit - used
+		if remaining < 0 {
+			remaining = 0
+		}
+	}
+
+	return &models.AIQuotaUsage{
+		Feature:   rule.Feature,
+		Period:    rule.Period,
+		Scope:     rule.Scope,
+		Limit:     rule.Limit,
+		Used:      used,
+		Remaining: remaining,
+		ResetAt:   resetAt,
+	}
+}
+
+func aiQuotaNow() time.Time {
+	return time.Now().UTC()
+}
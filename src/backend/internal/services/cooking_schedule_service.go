@@ -0,0 +1,231 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"onetaste-family/backend/internal/models"
+	"onetaste-family/backend/internal/repositories"
+)
+
+// defaultStepMinutes 未设置时长的步骤按此估算
+const defaultStepMinutes = 5
+
+var (
+	// ErrCookingScheduleRequiresPremium 烹饪排程仅付费版可用
+	ErrCookingScheduleRequiresPremium = errors.New("cooking schedule requires premium")
+)
+
+// cookingEquipmentCapacity 家庭厨房常见的厨具数量，未列出的厨具按1件计
+var cookingEquipmentCapacity = map[string]int{
+	models.EquipmentWok:    1,
+	models.EquipmentOven:   1,
+	models.EquipmentBurner: 2,
+}
+
+// GetCookingSchedule 为一餐的多道菜生成合并的烹饪时间线
+// 同一道菜的步骤按顺序进行；需要看管的步骤同一时间只能做一件，无需看管的步骤可与其他步骤并行；
+// 占用同一厨具的步骤不超过厨具数量。
+func (s *MenuService) GetCookingSchedule(userID, menuID string) (*models.CookingScheduleResponse, error) {
+	family, err := s.getFamilyForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 排程按规则计算，不调用AI服务，因此只校验会员等级，不占用AI额度
+	tier, _, err := s.membership.FamilyTier(family.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve membership tier: %w", err)
+	}
+	if tier.Type != models.MembershipTierPremium {
+		return nil, ErrCookingScheduleRequiresPremium
+	}
+
+	menu, err := s.menuRepo.GetMenuByID(menuID, family.ID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrMenuNotFound) {
+			return nil, ErrMenuNotFound
+		}
+		return nil, fmt.Errorf("failed to get menu: %w", err)
+	}
+
+	dishIDs, err := s.menuRepo.GetMenuDishes(menu.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get menu dishes: %w", err)
+	}
+
+	dishes := make([]*scheduleDish, 0, len(dishIDs))
+	for _, dishID := range dishIDs {
+		dish, err := s.dishRepo.GetDishByID(dishID, family.ID)
+		if err != nil {
+			if errors.Is(err, repositories.ErrDishNotFound) {
+				continue // 跳过已删除的菜式
+			}
+			return nil, fmt.Errorf("failed to get dish: %w", err)
+		}
+
+		steps, err := s.dishRepo.GetCookingSteps(dish.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get cooking steps: %w", err)
+		}
+
+		dishes = append(dishes, &scheduleDish{id: dish.ID, name: dish.Name, steps: steps})
+	}
+
+	steps, total, sequential := scheduleCooking(dishes)
+
+	equipment := make(map[string]int)
+	for _, step := range steps {
+		if step.Equipment != "" {
+			equipment[step.Equipment] = equipmentCapacity(step.Equipment)
+		}
+	}
+
+	saved := sequential - total
+	if saved < 0 {
+		saved = 0
+	}
+
+	return &models.CookingScheduleResponse{
+		MenuID:            menu.ID,
+		Date:              formatDate(menu.Date),
+		MealType:          menu.MealType,
+		TotalMinutes:      total,
+		SequentialMinutes: sequential,
+		SavedMinutes:      saved,
+		Equipment:         equipment,
+		Steps:             steps,
+	}, nil
+}
+
+// scheduleDish 参与排程的一道菜
+type scheduleDish struct {
+	id    string
+	name  string
+	steps []*models.CookingStep
+
+	next    int // 下一个待安排的步骤
+	readyAt int // 上一个步骤结束的时间
+}
+
+// remaining 返回尚未安排的步骤总时长
+func (d *scheduleDish) remaining() int {
+	total := 0
+	for _, step := range d.steps[d.next:] {
+		total += stepMinutes(step)
+	}
+	return total
+}
+
+// scheduleCooking 列表排程：每次从各道菜的下一个步骤中选出最早能开始的一个，
+// 开始时间相同时优先剩余用时更长的菜，保证总用时尽量短。
+// 返回按开始时间排列的时间线、总用时与依次烹饪的用时。
+func scheduleCooking(dishes []*scheduleDish) ([]*models.ScheduledStep, int, int) {
+	sequential := 0
+	pending := 0
+	for _, dish := range dishes {
+		dish.next = 0
+		dish.readyAt = 0
+		for _, step := range dish.steps {
+			sequential += stepMinutes(step)
+		}
+		pending += len(dish.steps)
+	}
+
+	cookFreeAt := 0
+	equipmentFreeAt := make(map[string][]int)
+	timeline := make([]*models.ScheduledStep, 0, pending)
+	total := 0
+
+	for ; pending > 0; pending-- {
+		var best *scheduleDish
+		bestStart, bestUnit, bestRemaining := 0, -1, 0
+		for _, dish := range dishes {
+			if dish.next >= len(dish.steps) {
+				continue
+			}
+
+			step := dish.steps[dish.next]
+			start := dish.readyAt
+			if !step.Passive && cookFreeAt > start {
+				start = cookFreeAt
+			}
+			unit := -1
+			if step.Equipment != "" {
+				var freeAt int
+				unit, freeAt = earliestUnit(equipmentFreeAt, step.Equipment)
+				if freeAt > start {
+					start = freeAt
+				}
+			}
+
+			remaining := dish.remaining()
+			if best == nil || start < bestStart || (start == bestStart && remaining > bestRemaining) {
+				best, bestStart, bestUnit, bestRemaining = dish, start, unit, remaining
+			}
+		}
+
+		step := best.steps[best.next]
+		duration := stepMinutes(step)
+		end := bestStart + duration
+
+		best.next++
+		best.readyAt = end
+		if !step.Passive {
+			cookFreeAt = end
+		}
+		if bestUnit >= 0 {
+			equipmentFreeAt[step.Equipment][bestUnit] = end
+		}
+		if end > total {
+			total = end
+		}
+
+		timeline = append(timeline, &models.ScheduledStep{
+			Order:           len(timeline) + 1,
+			DishID:          best.id,
+			DishName:        best.name,
+			StepOrder:       step.Order,
+			Content:         step.Content,
+			StartMinute:     bestStart,
+			EndMinute:       end,
+			DurationMinutes: duration,
+			Passive:         step.Passive,
+			Equipment:       step.Equipment,
+			Estimated:       step.DurationMinutes <= 0,
+		})
+	}
+
+	return timeline, total, sequential
+}
+
+// earliestUnit 返回最早空闲的厨具编号及其空闲时间
+func earliestUnit(freeAt map[string][]int, equipment string) (int, int) {
+	units, ok := freeAt[equipment]
+	if !ok {
+		units = make([]int, equipmentCapacity(equipment))
+		freeAt[equipment] = units
+	}
+
+	best := 0
+	for i := range units {
+		if units[i] < units[best] {
+			best = i
+		}
+	}
+	return best, units[best]
+}
+
+func equipmentCapacity(equipment string) int {
+	if capacity, ok := cookingEquipmentCapacity[equipment]; ok {
+		return capacity
+	}
+	return 1
+}
+
+func stepMinutes(step *models.CookingStep) int {
+	if step.DurationMinutes > 0 {
+		return step.DurationMinutes
+	}
+	return defaultStepMinutes
+}
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"onetaste-family/backend/internal/models"
+)
+
+func cookingStep(order, minutes int, passive bool, equipment string) *models.CookingStep {
+	return &models.CookingStep{
+		Order:           order,
+		Content:         fmt.Sprintf("step %d", order),
+		DurationMinutes: minutes,
+		Passive:         passive,
+		Equipment:       equipment,
+	}
+}
+
+// formatTimeline 将时间线格式化为 "菜式:步骤@开始-结束"，估算时长的步骤以 ~ 结尾
+func formatTimeline(steps []*models.ScheduledStep) []string {
+	lines := make([]string, 0, len(steps))
+	for i, step := range steps {
+		if step.Order != i+1 {
+			return []string{fmt.Sprintf("order %d at index %d", step.Order, i)}
+		}
+		line := fmt.Sprintf("%s:%d@%d-%d", step.DishID, step.StepOrder, step.StartMinute, step.EndMinute)
+		if step.Estimated {
+			line += "~"
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func TestScheduleCooking(t *testing.T) {
+	tests := []struct {
+		name           string
+		dishes         map[string][]*models.CookingStep
+		order          []string // 菜式在菜单中的顺序
+		want           []string
+		wantTotal      int
+		wantSequential int
+	}{
+		{
+			name:           "没有菜式",
+			want:           []string{},
+			wantTotal:      0,
+			wantSequential: 0,
+		},
+		{
+			name: "同一道菜按顺序进行",
+			dishes: map[string][]*models.CookingStep{
+				"a": {cookingStep(1, 3, false, ""), cookingStep(2, 4, false, "")},
+			},
+			order:          []string{"a"},
+			want:           []string{"a:1@0-3", "a:2@3-7"},
+			wantTotal:      7,
+			wantSequential: 7,
+		},
+		{
+			name: "未设置时长按默认值估算",
+			dishes: map[string][]*models.CookingStep{
+				"a": {cookingStep(1, 0, false, "")},
+			},
+			order:          []string{"a"},
+			want:           []string{"a:1@0-5~"},
+			wantTotal:      defaultStepMinutes,
+			wantSequential: defaultStepMinutes,
+		},
+		{
+			name: "需要看管的步骤不能并行，剩余用时长的菜先做",
+			dishes: map[string][]*models.CookingStep{
+				"a": {cookingStep(1, 3, false, "")},
+				"b": {cookingStep(1, 5, false, "")},
+			},
+			order:          []string{"a", "b"},
+			want:           []string{"b:1@0-5", "a:1@5-8"},
+			wantTotal:      8,
+			wantSequential: 8,
+		},
+		{
+			name: "无需看管的步骤与其他步骤并行",
+			dishes: map[string][]*models.CookingStep{
+				"a": {cookingStep(1, 30, true, "")},
+				"b": {cookingStep(1, 5, false, ""), cookingStep(2, 5, false, "")},
+			},
+			order:          []string{"a", "b"},
+			want:           []string{"a:1@0-30", "b:1@0-5", "b:2@5-10"},
+			wantTotal:      30,
+			wantSequential: 40,
+		},
+		{
+			name: "烤箱只有一台时依次使用",
+			dishes: map[string][]*models.CookingStep{
+				"a": {cookingStep(1, 20, true, models.EquipmentOven)},
+				"b": {cookingStep(1, 15, true, models.EquipmentOven)},
+			},
+			order:          []string{"a", "b"},
+			want:           []string{"a:1@0-20", "b:1@20-35"},
+			wantTotal:      35,
+			wantSequential: 35,
+		},
+		{
+			name: "灶眼有两个时两道菜同时使用",
+			dishes: map[string][]*models.CookingStep{
+				"a": {cookingStep(1, 10, true, models.EquipmentBurner)},
+				"b": {cookingStep(1, 10, true, models.EquipmentBurner)},
+				"c": {cookingStep(1, 10, true, models.EquipmentBurner)},
+			},
+			order:          []string{"a", "b", "c"},
+			want:           []string{"a:1@0-10", "b:1@0-10", "c:1@10-20"},
+			wantTotal:      20,
+			wantSequential: 30,
+		},
+		{
+			name: "炒锅被无需看管的步骤占用时等待",
+			dishes: map[string][]*models.CookingStep{
+				"a": {cookingStep(1, 3, false, ""), cookingStep(2, 10, true, models.EquipmentWok)},
+				"b": {cookingStep(1, 5, false, models.EquipmentWok)},
+			},
+			order:          []string{"a", "b"},
+			want:           []string{"a:1@0-3", "a:2@3-13", "b:1@13-18"},
+			wantTotal:      18,
+			wantSequential: 18,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dishes := make([]*scheduleDish, 0, len(tt.order))
+			for _, dishID := range tt.order {
+				dishes = append(dishes, &scheduleDish{id: dishID, name: dishID, steps: tt.dishes[dishID]})
+			}
+
+			steps, total, sequential := scheduleCooking(dishes)
+			if got := formatTimeline(steps); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("timeline = %v, want %v", got, tt.want)
+			}
+			if total != tt.wantTotal {
+				t.Fatalf("total = %d, want %d", total, tt.wantTotal)
+			}
+			if sequential != tt.wantSequential {
+				t.Fatalf("sequential = %d, want %d", sequential, tt.wantSequential)
+			}
+		})
+	}
+}
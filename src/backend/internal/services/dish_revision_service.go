@@ -0,0 +1,390 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"onetaste-family/backend/internal/models"
+	"onetaste-family/backend/internal/repositories"
+	"onetaste-family/backend/internal/utils"
+)
+
+// ErrDishRevisionNotFound 菜式修订记录不存在
+var ErrDishRevisionNotFound = errors.New("dish revision not found")
+
+// ListDishRevisions 分页获取菜式的修订记录，家庭成员均可查看
+func (s *DishService) ListDishRevisions(userID, dishID string, req *models.DishRevisionListRequest) (*models.DishRevisionListResponse, error) {
+	dish, err := s.getFamilyDish(userID, dishID)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, total, err := s.revisionRepo.ListByDish(dish.ID, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.DishRevisionListResponse{
+		Revisions: revisions,
+		Total:     total,
+		Page:      req.Page,
+		PageSize:  req.PageSize,
+	}, nil
+}
+
+// GetDishRevision 获取菜式某一版本的完整内容
+func (s *DishService) GetDishRevision(userID, dishID string, revisionNo int) (*models.DishRevision, error) {
+	dish, err := s.getFamilyDish(userID, dishID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getDishRevision(dish.ID, revisionNo)
+}
+
+// DiffDishRevisions 逐项比较菜式的两个版本
+func (s *DishService) DiffDishRevisions(userID, dishID string, req *models.DishRevisionDiffRequest) (*models.DishRevisionDiffResponse, error) {
+	dish, err := s.getFamilyDish(userID, dishID)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := s.getDishRevision(dish.ID, req.From)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.getDishRevision(dish.ID, req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	tagsAdded, tagsRemoved := diffDishTags(from.Snapshot.Tags, to.Snapshot.Tags)
+	return &models.DishRevisionDiffResponse{
+		DishID:      dish.ID,
+		From:        from.RevisionNo,
+		To:          to.RevisionNo,
+		Fields:      diffDishFields(from.Snapshot, to.Snapshot),
+		Ingredients: diffDishIngredients(from.Snapshot.Ingredients, to.Snapshot.Ingredients),
+		Steps:       diffDishSteps(from.Snapshot.Steps, to.Snapshot.Steps),
+		TagsAdded:   tagsAdded,
+		TagsRemoved: tagsRemoved,
+	}, nil
+}
+
+// RestoreDishRevision 将菜式恢复为某一历史版本的内容，并写入一条新的修订记录
+// 历史版本中已删除的标签会被忽略；已停用的食材或重名的菜式名称会导致恢复失败。
+func (s *DishService) RestoreDishRevision(userID, dishID string, revisionNo int) (*models.DishDetailResponse, error) {
+	family, dish, err := s.getEditableDish(userID, dishID)
+	if err != nil {
+		return nil, err
+	}
+
+	revision, err := s.getDishRevision(dish.ID, revisionNo)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := revision.Snapshot
+
+	req := &models.UpdateDishRequest{
+		Name:        snapshot.Name,
+		Category:    snapshot.Category,
+		Description: snapshot.Description,
+		ImageURL:    snapshot.ImageURL,
+		Ingredients: make([]models.IngredientInput, 0, len(snapshot.Ingredients)),
+		Steps:       make([]models.CookingStepInput, 0, len(snapshot.Steps)),
+		TagIDs:      []string{}, // 非 nil，快照没有标签时清空当前标签
+		PrepMinutes: &snapshot.PrepMinutes,
+		CookMinutes: &snapshot.CookMinutes,
+		Servings:    &snapshot.Servings,
+		Difficulty:  &snapshot.Difficulty,
+	}
+	for _, ingredient := range snapshot.Ingredients {
+		req.Ingredients = append(req.Ingredients, models.IngredientInput{
+			IngredientID: ingredient.IngredientID,
+			Amount:       ingredient.Amount,
+			Unit:         ingredient.Unit,
+			Notes:        ingredient.Notes,
+			SortOrder:    ingredient.SortOrder,
+		})
+	}
+	for _, step := range snapshot.Steps {
+		req.Steps = append(req.Steps, models.CookingStepInput{
+			Order:           step.Order,
+			Content:         step.Content,
+			ImageURL:        step.ImageURL,
+			DurationMinutes: step.DurationMinutes,
+			Passive:         step.Passive,
+			Equipment:       step.Equipment,
+		})
+	}
+
+	tagIDs := make([]string, 0, len(snapshot.Tags))
+	for _, tag := range snapshot.Tags {
+		tagIDs = append(tagIDs, tag.ID)
+	}
+	available, err := s.tagRepo.GetByIDs(family.ID, uniqueStrings(tagIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load dish tags: %w", err)
+	}
+	for _, id := range tagIDs {
+		if _, ok := available[id]; ok {
+			req.TagIDs = append(req.TagIDs, id)
+		}
+	}
+
+	return s.saveDishUpdate(family.ID, dish, userID, req, models.DishRevisionActionRestore, revision.RevisionNo)
+}
+
+// getFamilyDish 获取当前用户家庭中的菜式
+func (s *DishService) getFamilyDish(userID, dishID string) (*models.Dish, error) {
+	family, err := s.getFamilyForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	dish, err := s.dishRepo.GetDishByID(dishID, family.ID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrDishNotFound) {
+			return nil, ErrDishNotFound
+		}
+		return nil, fmt.Errorf("failed to get dish: %w", err)
+	}
+
+	return dish, nil
+}
+
+func (s *DishService) getDishRevision(dishID string, revisionNo int) (*models.DishRevision, error) {
+	revision, err := s.revisionRepo.GetByNo(dishID, revisionNo)
+	if err != nil {
+		if errors.Is(err, repositories.ErrDishRevisionNotFound) {
+			return nil, ErrDishRevisionNotFound
+		}
+		return nil, err
+	}
+	return revision, nil
+}
+
+// ensureBaselineRevision 修订功能上线前创建的菜式没有修订记录，首次编辑前先保存当前内容作为第1版
+func (s *DishService) ensureBaselineRevision(dish *models.Dish) error {
+	count, err := s.revisionRepo.CountByDish(dish.ID)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	ingredients, err := s.dishRepo.GetIngredients(dish.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get ingredients: %w", err)
+	}
+	steps, err := s.dishRepo.GetCookingSteps(dish.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get cooking steps: %w", err)
+	}
+	tags, err := s.getDishTags(dish.ID)
+	if err != nil {
+		return err
+	}
+
+	revision := newDishRevision(dish, ingredients, steps, tags, models.DishRevisionActionCreate, dish.CreatedBy, 0)
+	return s.revisionRepo.Create(revision)
+}
+
+// newDishRevision 根据菜式当前内容生成修订记录
+func newDishRevision(dish *models.Dish, ingredients []*models.Ingredient, steps []*models.CookingStep, tags []*models.DishTag, action, editedBy string, restoredFrom int) *models.DishRevision {
+	return &models.DishRevision{
+		ID:           utils.GenerateULID(),
+		DishID:       dish.ID,
+		FamilyID:     dish.FamilyID,
+		Action:       action,
+		RestoredFrom: restoredFrom,
+		EditedBy:     editedBy,
+		Snapshot: &models.DishSnapshot{
+			Name:        dish.Name,
+			Category:    dish.Category,
+			Description: dish.Description,
+			ImageURL:    dish.ImageURL,
+			PrepMinutes: dish.PrepMinutes,
+			CookMinutes: dish.CookMinutes,
+			Servings:    dish.Servings,
+			Difficulty:  dish.Difficulty,
+			Ingredients: ingredients,
+			Steps:       steps,
+			Tags:        tags,
+		},
+	}
+}
+
+// diffDishFields 比较菜式基本信息
+func diffDishFields(from, to *models.DishSnapshot) []*models.DishFieldChange {
+	pairs := []struct {
+		field      string
+		prev, next interface{}
+	}{
+		{"name", from.Name, to.Name},
+		{"category", from.Category, to.Category},
+		{"description", from.Description, to.Description},
+		{"image_url", from.ImageURL, to.ImageURL},
+		{"prep_minutes", from.PrepMinutes, to.PrepMinutes},
+		{"cook_minutes", from.CookMinutes, to.CookMinutes},
+		{"servings", from.Servings, to.Servings},
+		{"difficulty", from.Difficulty, to.Difficulty},
+	}
+
+	changes := make([]*models.DishFieldChange, 0)
+	for _, pair := range pairs {
+		if pair.prev != pair.next {
+			changes = append(changes, &models.DishFieldChange{Field: pair.field, Old: pair.prev, New: pair.next})
+		}
+	}
+	return changes
+}
+
+// diffDishIngredients 比较食材，同一基础食材与单位视为同一项；按新版本顺序列出新增与修改，再列出删除
+func diffDishIngredients(from, to []*models.Ingredient) []*models.DishIngredientChange {
+	key := func(item *models.Ingredient) string {
+		return item.IngredientID + "|" + strings.ToLower(item.Unit)
+	}
+	old := make(map[string]*models.Ingredient, len(from))
+	for _, item := range from {
+		old[key(item)] = item
+	}
+
+	changes := make([]*models.DishIngredientChange, 0)
+	kept := make(map[string]struct{}, len(to))
+	for _, item := range to {
+		k := key(item)
+		kept[k] = struct{}{}
+		prev, ok := old[k]
+		if !ok {
+			changes = append(changes, newIngredientChange(models.DishChangeAdded, nil, item))
+			continue
+		}
+
+		fields := make([]string, 0)
+		if prev.Amount != item.Amount {
+			fields = append(fields, "amount")
+		}
+		if prev.Notes != item.Notes {
+			fields = append(fields, "notes")
+		}
+		if prev.SortOrder != item.SortOrder {
+			fields = append(fields, "sort_order")
+		}
+		if len(fields) > 0 {
+			change := newIngredientChange(models.DishChangeModified, prev, item)
+			change.Fields = fields
+			changes = append(changes, change)
+		}
+	}
+
+	for _, item := range from {
+		if _, ok := kept[key(item)]; !ok {
+			changes = append(changes, newIngredientChange(models.DishChangeRemoved, item, nil))
+		}
+	}
+
+	return changes
+}
+
+func newIngredientChange(change string, prev, next *models.Ingredient) *models.DishIngredientChange {
+	ref := next
+	if ref == nil {
+		ref = prev
+	}
+	return &models.DishIngredientChange{
+		Change:         change,
+		IngredientID:   ref.IngredientID,
+		IngredientName: ref.IngredientName,
+		Unit:           ref.Unit,
+		Old:            prev,
+		New:            next,
+	}
+}
+
+// diffDishSteps 按步骤序号比较烹饪步骤
+func diffDishSteps(from, to []*models.CookingStep) []*models.DishStepChange {
+	old := make(map[int]*models.CookingStep, len(from))
+	current := make(map[int]*models.CookingStep, len(to))
+	orders := make([]int, 0, len(from)+len(to))
+	for _, step := range from {
+		old[step.Order] = step
+		orders = append(orders, step.Order)
+	}
+	for _, step := range to {
+		if _, ok := old[step.Order]; !ok {
+			orders = append(orders, step.Order)
+		}
+		current[step.Order] = step
+	}
+	sort.Ints(orders)
+
+	changes := make([]*models.DishStepChange, 0)
+	for _, order := range orders {
+		prev, next := old[order], current[order]
+		switch {
+		case prev == nil:
+			changes = append(changes, &models.DishStepChange{Change: models.DishChangeAdded, Order: order, New: next})
+		case next == nil:
+			changes = append(changes, &models.DishStepChange{Change: models.DishChangeRemoved, Order: order, Old: prev})
+		default:
+			fields := make([]string, 0)
+			if prev.Content != next.Content {
+				fields = append(fields, "content")
+			}
+			if prev.ImageURL != next.ImageURL {
+				fields = append(fields, "image_url")
+			}
+			if prev.DurationMinutes != next.DurationMinutes {
+				fields = append(fields, "duration_minutes")
+			}
+			if prev.Passive != next.Passive {
+				fields = append(fields, "passive")
+			}
+			if prev.Equipment != next.Equipment {
+				fields = append(fields, "equipment")
+			}
+			if len(fields) > 0 {
+				changes = append(changes, &models.DishStepChange{
+					Change: models.DishChangeModified,
+					Order:  order,
+					Fields: fields,
+					Old:    prev,
+					New:    next,
+				})
+			}
+		}
+	}
+
+	return changes
+}
+
+// diffDishTags 比较标签，返回新增与移除的标签
+func diffDishTags(from, to []*models.DishTag) ([]*models.DishTag, []*models.DishTag) {
+	old := make(map[string]struct{}, len(from))
+	for _, tag := range from {
+		old[tag.ID] = struct{}{}
+	}
+	current := make(map[string]struct{}, len(to))
+	for _, tag := range to {
+		current[tag.ID] = struct{}{}
+	}
+
+	added := make([]*models.DishTag, 0)
+	for _, tag := range to {
+		if _, ok := old[tag.ID]; !ok {
+			added = append(added, tag)
+		}
+	}
+	removed := make([]*models.DishTag, 0)
+	for _, tag := range from {
+		if _, ok := current[tag.ID]; !ok {
+			removed = append(removed, tag)
+		}
+	}
+
+	return added, removed
+}
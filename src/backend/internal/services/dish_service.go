@@ -38,6 +38,11 @@ type DishService struct {
 	dishRepo       *repositories.DishRepository
 	familyRepo     *repositories.FamilyRepository
 	ingredientRepo *repositories.IngredientRepository
+	tagRepo        *repositories.DishTagRepository
+	revisionRepo   *repositories.DishRevisionRepository
+	menuRepo       *repositories.MenuRepository
+	membership     *MembershipService
+	transcriber    DishTranscriber
 }
 
 // NewDishService 创建DishService
@@ -46,6 +51,11 @@ func NewDishService() *DishService {
 		dishRepo:       repositories.NewDishRepository(),
 		familyRepo:     repositories.NewFamilyRepository(),
 		ingredientRepo: repositories.NewIngredientRepository(),
+		tagRepo:        repositories.NewDishTagRepository(),
+		revisionRepo:   repositories.NewDishRevisionRepository(),
+		menuRepo:       repositories.NewMenuRepository(),
+		membership:     NewMembershipService(),
+		transcriber:    aiDishTranscriber{},
 	}
 }
 
@@ -68,11 +78,17 @@ func (s *DishService) CreateDish(userID string, req *models.CreateDishRequest) (
 		return nil, ErrInvalidDishSteps
 	}
 
+	// 菜式上限按家庭当前会员等级计算，升级或到期后立即生效
+	tier, _, err := s.membership.FamilyTier(family.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve membership tier: %w", err)
+	}
+
 	count, err := s.dishRepo.CountByFamily(family.ID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to count dishes: %w", err)
 	}
-	if count >= family.MaxDishes {
+	if count >= tier.MaxDishes {
 		return nil, ErrDishLimitReached
 	}
 
@@ -103,27 +119,50 @@ func (s *DishService) CreateDish(userID string, req *models.CreateDishRequest) (
 		return nil, err
 	}
 
+	tagIDs, tags, err := s.resolveDishTags(family.ID, req.TagIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	category := strings.TrimSpace(req.Category)
+	categoryTag, err := s.ensureCategoryTag(family.ID, userID, category)
+	if err != nil {
+		return nil, err
+	}
+	tagIDs, tags = withCategoryTag(tagIDs, tags, categoryTag)
+
 	dish := &models.Dish{
 		ID:          utils.GenerateULID(),
 		FamilyID:    family.ID,
 		Name:        name,
-		Category:    strings.TrimSpace(req.Category),
+		Category:    category,
 		Description: strings.TrimSpace(req.Description),
 		ImageURL:    strings.TrimSpace(req.ImageURL),
+		PrepMinutes: req.PrepMinutes,
+		CookMinutes: req.CookMinutes,
+		Servings:    dishServings(req.Servings),
+		Difficulty:  req.Difficulty,
 		CreatedBy:   userID,
 	}
 
-	if err := s.dishRepo.CreateDishWithDetails(dish, ingredients, steps); err != nil {
+	revision := newDishRevision(dish, ingredients, steps, tags, models.DishRevisionActionCreate, userID, 0)
+	if err := s.dishRepo.CreateDishWithDetails(dish, ingredients, steps, tagIDs, revision); err != nil {
 		return nil, fmt.Errorf("failed to create dish: %w", err)
 	}
 
 	return &models.DishCreateResponse{
-		DishID:      dish.ID,
-		Name:        dish.Name,
-		Category:    dish.Category,
-		Description: dish.Description,
-		ImageURL:    dish.ImageURL,
-		Ingredients: ingredients,
+		DishID:       dish.ID,
+		Name:         dish.Name,
+		Category:     dish.Category,
+		Description:  dish.Description,
+		ImageURL:     dish.ImageURL,
+		Ingredients:  ingredients,
+		Tags:         tags,
+		PrepMinutes:  dish.PrepMinutes,
+		CookMinutes:  dish.CookMinutes,
+		TotalMinutes: dish.TotalMinutes(),
+		Servings:     dish.Servings,
+		Difficulty:   dish.Difficulty,
 	}, nil
 }
 
@@ -134,14 +173,26 @@ func (s *DishService) GetDishList(userID string, req *models.DishListRequest) (*
 		return nil, err
 	}
 
-	category := strings.TrimSpace(req.Category)
 	keyword := strings.TrimSpace(req.Keyword)
 
-	dishes, total, err := s.dishRepo.GetDishList(family.ID, req.Page, req.PageSize, category, keyword)
+	dishes, total, err := s.dishRepo.GetDishList(family.ID, req.Page, req.PageSize, uniqueStrings(req.TagIDs), req.Difficulty, keyword)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query dishes: %w", err)
 	}
 
+	dishIDs := make([]string, 0, len(dishes))
+	for _, dish := range dishes {
+		dishIDs = append(dishIDs, dish.DishID)
+	}
+	tags, err := s.tagRepo.GetByDishIDs(dishIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dish tags: %w", err)
+	}
+	for _, dish := range dishes {
+		dish.Tags = tags[dish.DishID]
+		sortDishTags(dish.Tags)
+	}
+
 	return &models.DishListResponse{
 		Dishes:   dishes,
 		Total:    total,
@@ -150,8 +201,8 @@ func (s *DishService) GetDishList(userID string, req *models.DishListRequest) (*
 	}, nil
 }
 
-// GetDishDetail 获取菜式详情
-func (s *DishService) GetDishDetail(userID, dishID string) (*models.DishDetailResponse, error) {
+// GetDishDetail 获取菜式详情，servings 大于0时按该份数缩放食材用量
+func (s *DishService) GetDishDetail(userID, dishID string, servings int) (*models.DishDetailResponse, error) {
 	family, err := s.getFamilyForUser(userID)
 	if err != nil {
 		return nil, err
@@ -175,28 +226,54 @@ func (s *DishService) GetDishDetail(userID, dishID string) (*models.DishDetailRe
 		return nil, fmt.Errorf("failed to get cooking steps: %w", err)
 	}
 
-	return buildDishDetailResponse(dish, ingredients, steps), nil
+	tags, err := s.getDishTags(dish.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := buildDishDetailResponse(dish, ingredients, steps, tags)
+	if servings > 0 {
+		scaleDishIngredients(resp.Ingredients, dish.Servings, servings)
+		resp.Servings = servings
+	}
+
+	return resp, nil
 }
 
-// UpdateDish 更新菜式
+// UpdateDish 更新菜式，每次更新都会写入一条修订记录
 func (s *DishService) UpdateDish(userID, dishID string, req *models.UpdateDishRequest) (*models.DishDetailResponse, error) {
-	family, err := s.getFamilyForUser(userID)
+	family, dish, err := s.getEditableDish(userID, dishID)
 	if err != nil {
 		return nil, err
 	}
 
+	return s.saveDishUpdate(family.ID, dish, userID, req, models.DishRevisionActionUpdate, 0)
+}
+
+// getEditableDish 获取当前用户可编辑的菜式，仅创建者或家庭管理员可编辑
+func (s *DishService) getEditableDish(userID, dishID string) (*models.Family, *models.Dish, error) {
+	family, err := s.getFamilyForUser(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	dish, err := s.dishRepo.GetDishByID(dishID, family.ID)
 	if err != nil {
 		if errors.Is(err, repositories.ErrDishNotFound) {
-			return nil, ErrDishNotFound
+			return nil, nil, ErrDishNotFound
 		}
-		return nil, fmt.Errorf("failed to get dish: %w", err)
+		return nil, nil, fmt.Errorf("failed to get dish: %w", err)
 	}
 
 	if dish.CreatedBy != userID && family.OwnerID != userID {
-		return nil, ErrDishPermissionDenied
+		return nil, nil, ErrDishPermissionDenied
 	}
 
+	return family, dish, nil
+}
+
+// saveDishUpdate 校验并保存菜式的新内容，同时写入修订记录
+func (s *DishService) saveDishUpdate(familyID string, dish *models.Dish, userID string, req *models.UpdateDishRequest, action string, restoredFrom int) (*models.DishDetailResponse, error) {
 	name := strings.TrimSpace(req.Name)
 	if name == "" {
 		return nil, ErrInvalidDishName
@@ -210,7 +287,7 @@ func (s *DishService) UpdateDish(userID, dishID string, req *models.UpdateDishRe
 	}
 
 	if !strings.EqualFold(name, dish.Name) {
-		exists, err := s.dishRepo.ExistsByName(family.ID, name, dish.ID)
+		exists, err := s.dishRepo.ExistsByName(familyID, name, dish.ID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to check dish name: %w", err)
 		}
@@ -238,23 +315,53 @@ func (s *DishService) UpdateDish(userID, dishID string, req *models.UpdateDishRe
 		return nil, err
 	}
 
+	tagIDs, tags, err := s.resolveUpdatedDishTags(familyID, dish.ID, req.TagIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	category := strings.TrimSpace(req.Category)
+	categoryTag, err := s.ensureCategoryTag(familyID, userID, category)
+	if err != nil {
+		return nil, err
+	}
+	tagIDs, tags = withCategoryTag(tagIDs, tags, categoryTag)
+
+	if err := s.ensureBaselineRevision(dish); err != nil {
+		return nil, err
+	}
+
 	dish.Name = name
-	dish.Category = strings.TrimSpace(req.Category)
+	dish.Category = category
 	dish.Description = strings.TrimSpace(req.Description)
 	dish.ImageURL = strings.TrimSpace(req.ImageURL)
+	if req.PrepMinutes != nil {
+		dish.PrepMinutes = *req.PrepMinutes
+	}
+	if req.CookMinutes != nil {
+		dish.CookMinutes = *req.CookMinutes
+	}
+	if req.Servings != nil {
+		dish.Servings = dishServings(*req.Servings)
+	}
+	if req.Difficulty != nil {
+		dish.Difficulty = *req.Difficulty
+	}
 
-	if err := s.dishRepo.UpdateDishWithDetails(dish, ingredients, steps); err != nil {
+	revision := newDishRevision(dish, ingredients, steps, tags, action, userID, restoredFrom)
+	if err := s.dishRepo.UpdateDishWithDetails(dish, ingredients, steps, tagIDs, revision); err != nil {
 		if errors.Is(err, repositories.ErrDishNotFound) {
 			return nil, ErrDishNotFound
 		}
 		return nil, fmt.Errorf("failed to update dish: %w", err)
 	}
 
-	return buildDishDetailResponse(dish, ingredients, steps), nil
+	return buildDishDetailResponse(dish, ingredients, steps, tags), nil
 }
 
-// DeleteDish 删除菜式
-func (s *DishService) DeleteDish(userID, dishID string) error {
+// DeleteDish 删除菜式，菜式进入回收站并从所有菜单中移除
+// 菜式已被菜单引用且未传 force 时返回 DishInUseError，由用户确认后再删除
+func (s *DishService) DeleteDish(userID, dishID string, force bool) error {
 	family, err := s.getFamilyForUser(userID)
 	if err != nil {
 		return err
@@ -272,6 +379,16 @@ func (s *DishService) DeleteDish(userID, dishID string) error {
 		return ErrDishPermissionDenied
 	}
 
+	if !force {
+		impact, err := s.buildDishDeleteImpact(family.ID, dish)
+		if err != nil {
+			return err
+		}
+		if impact.InUse {
+			return &DishInUseError{Impact: impact}
+		}
+	}
+
 	if err := s.dishRepo.SoftDeleteDish(dish.ID, family.ID); err != nil {
 		if errors.Is(err, repositories.ErrDishNotFound) {
 			return ErrDishNotFound
@@ -282,6 +399,21 @@ func (s *DishService) DeleteDish(userID, dishID string) error {
 	return nil
 }
 
+// getDishTags 获取单个菜式的标签
+func (s *DishService) getDishTags(dishID string) ([]*models.DishTag, error) {
+	tags, err := s.tagRepo.GetByDishIDs([]string{dishID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dish tags: %w", err)
+	}
+
+	list := tags[dishID]
+	if list == nil {
+		list = []*models.DishTag{}
+	}
+	sortDishTags(list)
+	return list, nil
+}
+
 func (s *DishService) getFamilyForUser(userID string) (*models.Family, error) {
 	family, err := s.familyRepo.GetFamilyByUserID(userID)
 	if err != nil {
@@ -389,10 +521,13 @@ func convertCookingSteps(inputs []models.CookingStepInput) ([]*models.CookingSte
 		}
 
 		step := &models.CookingStep{
-			ID:       utils.GenerateULID(),
-			Order:    order,
-			Content:  content,
-			ImageURL: strings.TrimSpace(input.ImageURL),
+			ID:              utils.GenerateULID(),
+			Order:           order,
+			Content:         content,
+			ImageURL:        strings.TrimSpace(input.ImageURL),
+			DurationMinutes: input.DurationMinutes,
+			Passive:         input.Passive,
+			Equipment:       input.Equipment,
 		}
 		steps = append(steps, step)
 	}
@@ -407,16 +542,38 @@ func convertCookingSteps(inputs []models.CookingStepInput) ([]*models.CookingSte
 	return steps, nil
 }
 
-func buildDishDetailResponse(dish *models.Dish, ingredients []*models.Ingredient, steps []*models.CookingStep) *models.DishDetailResponse {
+func buildDishDetailResponse(dish *models.Dish, ingredients []*models.Ingredient, steps []*models.CookingStep, tags []*models.DishTag) *models.DishDetailResponse {
 	return &models.DishDetailResponse{
-		DishID:      dish.ID,
-		Name:        dish.Name,
-		Category:    dish.Category,
-		Description: dish.Description,
-		ImageURL:    dish.ImageURL,
-		Ingredients: ingredients,
-		Steps:       steps,
-		CreatedAt:   dish.CreatedAt,
-		UpdatedAt:   dish.UpdatedAt,
+		DishID:       dish.ID,
+		Name:         dish.Name,
+		Category:     dish.Category,
+		Description:  dish.Description,
+		ImageURL:     dish.ImageURL,
+		Ingredients:  ingredients,
+		Steps:        steps,
+		Tags:         tags,
+		PrepMinutes:  dish.PrepMinutes,
+		CookMinutes:  dish.CookMinutes,
+		TotalMinutes: dish.TotalMinutes(),
+		Servings:     dish.Servings,
+		BaseServings: dish.Servings,
+		Difficulty:   dish.Difficulty,
+		CreatedAt:    dish.CreatedAt,
+		UpdatedAt:    dish.UpdatedAt,
+	}
+}
+
+// scaleDishIngredients 将食材用量从菜式默认份数缩放到指定份数
+func scaleDishIngredients(ingredients []*models.Ingredient, baseServings, servings int) {
+	for _, ingredient := range ingredients {
+		ingredient.Amount = utils.ScaleAmount(ingredient.Amount, ingredient.Unit, baseServings, servings)
+	}
+}
+
+// dishServings 未填写份数时使用默认份数
+func dishServings(servings int) int {
+	if servings <= 0 {
+		return models.DefaultDishServings
 	}
+	return servings
 }
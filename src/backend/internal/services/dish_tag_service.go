@@ -0,0 +1,234 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"onetaste-family/backend/internal/models"
+	"onetaste-family/backend/internal/repositories"
+	"onetaste-family/backend/internal/utils"
+)
+
+// maxCustomDishTags 每个家庭的自定义标签数量上限
+const maxCustomDishTags = 50
+
+var (
+	// ErrDishTagNotFound 标签不存在
+	ErrDishTagNotFound = errors.New("dish tag not found")
+	// ErrDishTagExists 标签名称重复
+	ErrDishTagExists = errors.New("dish tag exists")
+	// ErrDishTagLimitReached 自定义标签数量已达上限
+	ErrDishTagLimitReached = errors.New("dish tag limit reached")
+	// ErrInvalidDishTags 标签不存在或不属于当前家庭
+	ErrInvalidDishTags = errors.New("invalid dish tags")
+)
+
+// ListDishTags 按分组返回系统标签与家庭自定义标签
+func (s *DishService) ListDishTags(userID string) (*models.DishTagListResponse, error) {
+	family, err := s.getFamilyForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := s.tagRepo.ListByFamily(family.ID)
+	if err != nil {
+		return nil, err
+	}
+	sortDishTags(tags)
+
+	groups := make([]*models.DishTagGroup, 0, len(models.DishTagGroupNames))
+	index := make(map[string]*models.DishTagGroup, len(models.DishTagGroupNames))
+	for _, info := range models.DishTagGroupNames {
+		group := &models.DishTagGroup{Group: info.Code, Name: info.Name, Tags: []*models.DishTag{}}
+		groups = append(groups, group)
+		index[info.Code] = group
+	}
+	for _, tag := range tags {
+		if group, ok := index[tag.Group]; ok {
+			group.Tags = append(group.Tags, tag)
+		}
+	}
+
+	return &models.DishTagListResponse{Groups: groups}, nil
+}
+
+// CreateDishTag 新增家庭自定义标签，名称不能与系统标签或已有标签重复
+func (s *DishService) CreateDishTag(userID string, req *models.CreateDishTagRequest) (*models.DishTag, error) {
+	family, err := s.getFamilyForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, ErrInvalidDishTags
+	}
+
+	if _, err := s.tagRepo.GetByName(family.ID, name); err == nil {
+		return nil, ErrDishTagExists
+	} else if !errors.Is(err, repositories.ErrDishTagNotFound) {
+		return nil, err
+	}
+
+	return s.createCustomDishTag(family.ID, userID, name)
+}
+
+// createCustomDishTag 校验数量上限后写入自定义标签，并发创建同名标签时返回 ErrDishTagExists
+func (s *DishService) createCustomDishTag(familyID, userID, name string) (*models.DishTag, error) {
+	tags, err := s.tagRepo.ListByFamily(familyID)
+	if err != nil {
+		return nil, err
+	}
+	custom := 0
+	for _, tag := range tags {
+		if !tag.IsSystem() {
+			custom++
+		}
+	}
+	if custom >= maxCustomDishTags {
+		return nil, ErrDishTagLimitReached
+	}
+
+	tag := &models.DishTag{
+		ID:        utils.GenerateULID(),
+		FamilyID:  familyID,
+		Group:     models.DishTagGroupCustom,
+		Name:      name,
+		SortOrder: custom + 1,
+		CreatedBy: userID,
+	}
+	if err := s.tagRepo.Create(tag); err != nil {
+		if errors.Is(err, repositories.ErrDishTagExists) {
+			return nil, ErrDishTagExists
+		}
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+// ensureCategoryTag 将菜式分类落为标签：已有同名标签直接复用，否则创建家庭自定义标签；分类为空时返回 nil
+func (s *DishService) ensureCategoryTag(familyID, userID, category string) (*models.DishTag, error) {
+	if category == "" {
+		return nil, nil
+	}
+
+	tag, err := s.tagRepo.GetByName(familyID, category)
+	if err == nil {
+		return tag, nil
+	}
+	if !errors.Is(err, repositories.ErrDishTagNotFound) {
+		return nil, err
+	}
+
+	tag, err = s.createCustomDishTag(familyID, userID, category)
+	if errors.Is(err, ErrDishTagExists) {
+		// 并发请求已创建同名标签，重新读取
+		return s.tagRepo.GetByName(familyID, category)
+	}
+	return tag, err
+}
+
+// withCategoryTag 把分类标签并入菜式标签，已包含时原样返回
+func withCategoryTag(ids []string, tags []*models.DishTag, categoryTag *models.DishTag) ([]string, []*models.DishTag) {
+	if categoryTag == nil {
+		return ids, tags
+	}
+	for _, id := range ids {
+		if id == categoryTag.ID {
+			return ids, tags
+		}
+	}
+
+	ids = append(ids, categoryTag.ID)
+	tags = append(tags, categoryTag)
+	sortDishTags(tags)
+	return ids, tags
+}
+
+// DeleteDishTag 删除家庭自定义标签，仅创建者或家庭管理员可删除，系统标签不能删除
+func (s *DishService) DeleteDishTag(userID, tagID string) error {
+	family, err := s.getFamilyForUser(userID)
+	if err != nil {
+		return err
+	}
+
+	tag, err := s.tagRepo.GetFamilyTag(tagID, family.ID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrDishTagNotFound) {
+			return ErrDishTagNotFound
+		}
+		return err
+	}
+
+	if tag.CreatedBy != userID && family.OwnerID != userID {
+		return ErrDishPermissionDenied
+	}
+
+	if err := s.tagRepo.Delete(tag.ID, family.ID); err != nil {
+		if errors.Is(err, repositories.ErrDishTagNotFound) {
+			return ErrDishTagNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// resolveDishTags 校验菜式标签均为系统标签或本家庭的自定义标签，返回去重后的ID与排好序的标签
+func (s *DishService) resolveDishTags(familyID string, tagIDs []string) ([]string, []*models.DishTag, error) {
+	ids := uniqueStrings(tagIDs)
+	dict, err := s.tagRepo.GetByIDs(familyID, ids)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load dish tags: %w", err)
+	}
+	if len(dict) != len(ids) {
+		return nil, nil, ErrInvalidDishTags
+	}
+
+	tags := make([]*models.DishTag, 0, len(ids))
+	for _, id := range ids {
+		tags = append(tags, dict[id])
+	}
+	sortDishTags(tags)
+
+	return ids, tags, nil
+}
+
+// resolveUpdatedDishTags 未传 tag_ids 时沿用菜式当前的标签
+func (s *DishService) resolveUpdatedDishTags(familyID, dishID string, tagIDs []string) ([]string, []*models.DishTag, error) {
+	if tagIDs != nil {
+		return s.resolveDishTags(familyID, tagIDs)
+	}
+
+	tags, err := s.getDishTags(dishID)
+	if err != nil {
+		return nil, nil, err
+	}
+	ids := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		ids = append(ids, tag.ID)
+	}
+	return ids, tags, nil
+}
+
+// sortDishTags 按分组展示顺序、组内顺序排列标签
+func sortDishTags(tags []*models.DishTag) {
+	groupOrder := make(map[string]int, len(models.DishTagGroupNames))
+	for idx, info := range models.DishTagGroupNames {
+		groupOrder[info.Code] = idx
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		gi, gj := groupOrder[tags[i].Group], groupOrder[tags[j].Group]
+		if gi != gj {
+			return gi < gj
+		}
+		if tags[i].SortOrder != tags[j].SortOrder {
+			return tags[i].SortOrder < tags[j].SortOrder
+		}
+		return tags[i].CreatedAt.Before(tags[j].CreatedAt)
+	})
+}
@@ -0,0 +1,984 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"onetaste-family/backend/internal/models"
+	"onetaste-family/backend/internal/repositories"
+	"onetaste-family/backend/internal/utils"
+)
+
+const (
+	// maxImportDishes 单次导入的菜式数量上限
+	maxImportDishes = 100
+	// importIngredientCandidates 未匹配食材返回的候选数量
+	importIngredientCandidates = 5
+	// importDefaultUnit 导入食材没有单位且食材库未设置默认单位时使用
+	importDefaultUnit = "份"
+	// maxImportDishTags 单个菜式导入的标签数量上限，与创建菜式的校验一致
+	maxImportDishTags = 20
+	// maxImportDishServings 菜式默认份数上限，与创建菜式的校验一致
+	maxImportDishServings = 50
+)
+
+var (
+	// ErrInvalidDishImport 导入数据无法解析或不包含菜式
+	ErrInvalidDishImport = errors.New("invalid dish import data")
+	// ErrTooManyImportDishes 单次导入的菜式过多
+	ErrTooManyImportDishes = errors.New("too many dishes to import")
+)
+
+var (
+	// recipeQuantityPattern 食材文本中的数量，支持小数、分数、带分数与范围（取上限）
+	recipeQuantityPattern = regexp.MustCompile(`(\d+\s+\d+/\d+|\d+/\d+|\d+(?:\.\d+)?)(?:\s*[-~～]\s*(\d+(?:\.\d+)?))?`)
+	// recipeNotesPattern 食材文本中括号内的备注
+	recipeNotesPattern = regexp.MustCompile(`[（(]([^）)]*)[）)]`)
+	// recipeSeparatorPattern 逗号后的内容视为备注，如 "2 cloves garlic, minced"
+	recipeSeparatorPattern = regexp.MustCompile(`[,，、]`)
+	// isoDurationPattern ISO 8601 时长，如 PT1H30M
+	isoDurationPattern = regexp.MustCompile(`(?i)^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+)
+
+// recipeVagueAmounts 表示用量不确定的词，解析时移入备注
+var recipeVagueAmounts = []string{"适量", "少许", "少量", "若干", "to taste"}
+
+// recipeFractions 常见的 Unicode 分数字符
+var recipeFractions = map[string]string{"½": " 1/2", "⅓": " 1/3", "⅔": " 2/3", "¼": " 1/4", "¾": " 3/4"}
+
+// dishImportDraft 从导入数据中解析出的菜式
+type dishImportDraft struct {
+	dish *models.PortableDish
+	// ingredientTexts JSON-LD 中的原始食材文本，与 dish.Ingredients 一一对应
+	ingredientTexts []string
+}
+
+// ExportDishes 以本应用格式导出菜式，dishID 为空时导出全部菜式
+func (s *DishService) ExportDishes(userID, dishID string) (*models.DishTransferBundle, error) {
+	details, err := s.loadDishesForExport(userID, dishID)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := &models.DishTransferBundle{
+		Kind:       models.DishTransferKind,
+		Version:    models.DishTransferVersion,
+		ExportedAt: time.Now().UTC(),
+		Dishes:     make([]*models.PortableDish, 0, len(details)),
+	}
+	for _, detail := range details {
+		bundle.Dishes = append(bundle.Dishes, buildPortableDish(detail))
+	}
+
+	return bundle, nil
+}
+
+// ExportDishesJSONLD 以 schema.org Recipe JSON-LD 导出菜式，dishID 为空时导出全部菜式
+func (s *DishService) ExportDishesJSONLD(userID, dishID string) (*models.RecipeGraph, error) {
+	details, err := s.loadDishesForExport(userID, dishID)
+	if err != nil {
+		return nil, err
+	}
+
+	graph := &models.RecipeGraph{
+		Context: "https://schema.org",
+		Graph:   make([]*models.Recipe, 0, len(details)),
+	}
+	for _, detail := range details {
+		graph.Graph = append(graph.Graph, buildRecipe(detail))
+	}
+
+	return graph, nil
+}
+
+// ImportDishes 导入菜式
+// 食材按ID或名称匹配食材库，未匹配的食材与标签不导入并在结果中列出；名称重复或缺少食材、步骤的菜式跳过。
+// 可导入的菜式在同一事务内创建，导入后超过家庭菜式上限时整体拒绝。
+func (s *DishService) ImportDishes(userID string, req *models.DishImportRequest) (*models.DishImportResponse, error) {
+	family, err := s.getFamilyForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var drafts []*dishImportDraft
+	switch req.Format {
+	case models.DishTransferFormatJSONLD:
+		drafts, err = parseRecipeJSONLD(req.Data)
+	default:
+		drafts, err = parseDishTransferBundle(req.Data)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(drafts) == 0 {
+		return nil, ErrInvalidDishImport
+	}
+	if len(drafts) > maxImportDishes {
+		return nil, ErrTooManyImportDishes
+	}
+
+	familyTags, err := s.tagRepo.ListByFamily(family.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dish tags: %w", err)
+	}
+	tagDict := make(map[string]*models.DishTag, len(familyTags))
+	for _, tag := range familyTags {
+		key := strings.ToLower(tag.Name)
+		if _, exists := tagDict[key]; !exists {
+			tagDict[key] = tag
+		}
+	}
+
+	resp := &models.DishImportResponse{
+		DryRun: req.DryRun,
+		Dishes: make([]*models.DishImportItem, 0, len(drafts)),
+	}
+	pending := make([]*models.DishWithDetails, 0, len(drafts))
+	pendingItems := make([]*models.DishImportItem, 0, len(drafts))
+	seenNames := make(map[string]struct{}, len(drafts))
+
+	for _, draft := range drafts {
+		item, details, err := s.prepareDishImport(family.ID, userID, draft, tagDict, seenNames)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Dishes = append(resp.Dishes, item)
+		resp.UnmatchedCount += item.UnmatchedCount
+		if details == nil {
+			resp.SkippedCount++
+			continue
+		}
+		pending = append(pending, details)
+		pendingItems = append(pendingItems, item)
+	}
+
+	// 预检查用于试运行提前返回结果，实际导入时在事务内复核
+	if len(pending) > 0 {
+		tier, _, err := s.membership.FamilyTier(family.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve membership tier: %w", err)
+		}
+
+		count, err := s.dishRepo.CountByFamily(family.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count dishes: %w", err)
+		}
+		if count+len(pending) > tier.MaxDishes {
+			return nil, ErrDishLimitReached
+		}
+	}
+
+	if req.DryRun || len(pending) == 0 {
+		return resp, nil
+	}
+
+	if err := s.createImportedDishes(family.ID, pending); err != nil {
+		return nil, err
+	}
+
+	for idx, item := range pendingItems {
+		item.Status = models.DishImportStatusCreated
+		item.DishID = pending[idx].Dish.ID
+	}
+	resp.CreatedCount = len(pending)
+
+	return resp, nil
+}
+
+// createImportedDishes 锁定家庭后在同一事务内复核菜式上限并创建菜式，避免并发导入或创建突破上限
+func (s *DishService) createImportedDishes(familyID string, pending []*models.DishWithDetails) (err error) {
+	ctx := context.Background()
+	tx, err := s.dishRepo.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction failed: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if _, err = s.familyRepo.LockFamilyTx(ctx, tx, familyID); err != nil {
+		if errors.Is(err, repositories.ErrFamilyNotFound) {
+			return ErrFamilyNotFound
+		}
+		return fmt.Errorf("failed to lock family: %w", err)
+	}
+
+	tier, _, err := s.membership.FamilyTier(familyID)
+	if err != nil {
+		return fmt.Errorf("failed to resolve membership tier: %w", err)
+	}
+
+	count, err := s.dishRepo.CountByFamilyTx(ctx, tx, familyID)
+	if err != nil {
+		return err
+	}
+	if count+len(pending) > tier.MaxDishes {
+		return ErrDishLimitReached
+	}
+
+	if err = s.dishRepo.CreateDishesWithDetailsTx(ctx, tx, pending); err != nil {
+		return fmt.Errorf("failed to import dishes: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction failed: %w", err)
+	}
+
+	return nil
+}
+
+// loadDishesForExport 获取待导出菜式的详情
+func (s *DishService) loadDishesForExport(userID, dishID string) ([]*models.DishDetailResponse, error) {
+	family, err := s.getFamilyForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var dishes []*models.Dish
+	if dishID != "" {
+		dish, err := s.dishRepo.GetDishByID(dishID, family.ID)
+		if err != nil {
+			if errors.Is(err, repositories.ErrDishNotFound) {
+				return nil, ErrDishNotFound
+			}
+			return nil, fmt.Errorf("failed to get dish: %w", err)
+		}
+		dishes = []*models.Dish{dish}
+	} else {
+		dishes, err = s.dishRepo.ListDishesForExport(family.ID, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	dishIDs := make([]string, 0, len(dishes))
+	for _, dish := range dishes {
+		dishIDs = append(dishIDs, dish.ID)
+	}
+	dishTags, err := s.tagRepo.GetByDishIDs(dishIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dish tags: %w", err)
+	}
+
+	details := make([]*models.DishDetailResponse, 0, len(dishes))
+	for _, dish := range dishes {
+		ingredients, err := s.dishRepo.GetIngredients(dish.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ingredients: %w", err)
+		}
+		steps, err := s.dishRepo.GetCookingSteps(dish.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get cooking steps: %w", err)
+		}
+		tags := dishTags[dish.ID]
+		if tags == nil {
+			tags = []*models.DishTag{}
+		}
+		sortDishTags(tags)
+		details = append(details, buildDishDetailResponse(dish, ingredients, steps, tags))
+	}
+
+	return details, nil
+}
+
+// prepareDishImport 校验并转换单个导入菜式，无法导入时返回的 details 为 nil
+func (s *DishService) prepareDishImport(familyID, userID string, draft *dishImportDraft, tagDict map[string]*models.DishTag, seenNames map[string]struct{}) (*models.DishImportItem, *models.DishWithDetails, error) {
+	source := draft.dish
+	name := truncateRunes(strings.TrimSpace(source.Name), 100)
+	item := &models.DishImportItem{
+		Name:        name,
+		Status:      models.DishImportStatusReady,
+		Ingredients: make([]*models.DishImportIngredient, 0, len(source.Ingredients)),
+	}
+
+	inputs, err := s.matchImportIngredients(draft, item)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	skip := func(reason string) (*models.DishImportItem, *models.DishWithDetails, error) {
+		item.Status = models.DishImportStatusSkipped
+		item.Reason = reason
+		return item, nil, nil
+	}
+
+	if name == "" {
+		return skip(models.DishImportReasonInvalidName)
+	}
+	key := strings.ToLower(name)
+	if _, exists := seenNames[key]; exists {
+		return skip(models.DishImportReasonDuplicateName)
+	}
+	seenNames[key] = struct{}{}
+
+	exists, err := s.dishRepo.ExistsByName(familyID, name, "")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to check dish name: %w", err)
+	}
+	if exists {
+		return skip(models.DishImportReasonNameExists)
+	}
+
+	if len(inputs) == 0 {
+		return skip(models.DishImportReasonNoIngredients)
+	}
+	if len(inputs) > maxDishIngredients {
+		return skip(models.DishImportReasonTooManyIngredients)
+	}
+
+	stepInputs := buildImportSteps(source.Steps)
+	if len(stepInputs) == 0 {
+		return skip(models.DishImportReasonNoSteps)
+	}
+	if len(stepInputs) > maxDishSteps {
+		return skip(models.DishImportReasonTooManySteps)
+	}
+
+	baseDict, err := s.ingredientRepo.GetActiveByIDs(collectIngredientIDs(inputs))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load ingredients: %w", err)
+	}
+	ingredients, err := convertIngredients(inputs, baseDict)
+	if err != nil {
+		return nil, nil, err
+	}
+	steps, err := convertCookingSteps(stepInputs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tagIDs := make([]string, 0, len(source.Tags))
+	tags := make([]*models.DishTag, 0, len(source.Tags))
+	for _, tagName := range uniqueStrings(trimStrings(source.Tags)) {
+		tag, ok := tagDict[strings.ToLower(tagName)]
+		if !ok {
+			item.UnmatchedTags = append(item.UnmatchedTags, tagName)
+			continue
+		}
+		if len(tagIDs) >= maxImportDishTags {
+			continue
+		}
+		tagIDs = append(tagIDs, tag.ID)
+		tags = append(tags, tag)
+	}
+	tagIDs = uniqueStrings(tagIDs)
+	sortDishTags(tags)
+
+	dish := &models.Dish{
+		ID:          utils.GenerateULID(),
+		FamilyID:    familyID,
+		Name:        name,
+		Category:    truncateRunes(strings.TrimSpace(source.Category), 50),
+		Description: truncateRunes(strings.TrimSpace(source.Description), 2000),
+		ImageURL:    importURL(source.ImageURL),
+		PrepMinutes: clampInt(source.PrepMinutes, 0, maxStepMinutes),
+		CookMinutes: clampInt(source.CookMinutes, 0, maxStepMinutes),
+		Servings:    dishServings(clampInt(source.Servings, 0, maxImportDishServings)),
+		Difficulty:  importDifficulty(source.Difficulty),
+		CreatedBy:   userID,
+	}
+
+	return item, &models.DishWithDetails{
+		Dish:        dish,
+		Ingredients: ingredients,
+		Steps:       steps,
+		TagIDs:      tagIDs,
+		Revision:    newDishRevision(dish, ingredients, steps, tags, models.DishRevisionActionCreate, userID, 0),
+	}, nil
+}
+
+// matchImportIngredients 匹配导入菜式的食材并记录匹配结果，同一食材与单位出现多次时合并用量
+func (s *DishService) matchImportIngredients(draft *dishImportDraft, item *models.DishImportItem) ([]models.IngredientInput, error) {
+	knownIDs := make([]string, 0, len(draft.dish.Ingredients))
+	for _, source := range draft.dish.Ingredients {
+		if source != nil && strings.TrimSpace(source.IngredientID) != "" {
+			knownIDs = append(knownIDs, strings.TrimSpace(source.IngredientID))
+		}
+	}
+	known, err := s.ingredientRepo.GetActiveByIDs(uniqueStrings(knownIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ingredients: %w", err)
+	}
+
+	inputs := make([]models.IngredientInput, 0, len(draft.dish.Ingredients))
+	merged := make(map[string]int, len(draft.dish.Ingredients))
+	for idx, source := range draft.dish.Ingredients {
+		if source == nil {
+			continue
+		}
+
+		result := &models.DishImportIngredient{
+			Name:   strings.TrimSpace(source.Name),
+			Amount: math.Max(source.Amount, 0),
+			Unit:   strings.TrimSpace(source.Unit),
+			Notes:  truncateRunes(strings.TrimSpace(source.Notes), 255),
+		}
+		if idx < len(draft.ingredientTexts) {
+			result.Text = draft.ingredientTexts[idx]
+		}
+
+		var defaultUnit string
+		if base, ok := known[strings.TrimSpace(source.IngredientID)]; ok {
+			result.Matched = true
+			result.IngredientID = base.ID
+			result.IngredientName = base.Name
+			defaultUnit = base.DefaultUnit
+		} else if result.Name != "" {
+			match, candidates, err := s.matchImportIngredient(result.Name)
+			if err != nil {
+				return nil, err
+			}
+			if match != nil {
+				result.Matched = true
+				result.IngredientID = match.IngredientID
+				result.IngredientName = match.Name
+				defaultUnit = match.DefaultUnit
+			} else {
+				result.Candidates = candidates
+			}
+		} else {
+			continue
+		}
+
+		item.Ingredients = append(item.Ingredients, result)
+		if !result.Matched {
+			item.UnmatchedCount++
+			continue
+		}
+
+		if result.Unit == "" {
+			result.Unit = defaultUnit
+		}
+		if result.Unit == "" {
+			result.Unit = importDefaultUnit
+		}
+		result.Unit = truncateRunes(result.Unit, 20)
+
+		key := result.IngredientID + "|" + strings.ToLower(result.Unit)
+		if pos, exists := merged[key]; exists {
+			inputs[pos].Amount += result.Amount
+			if result.Notes != "" {
+				inputs[pos].Notes = truncateRunes(strings.Trim(inputs[pos].Notes+"；"+result.Notes, "；"), 255)
+			}
+			continue
+		}
+		merged[key] = len(inputs)
+		inputs = append(inputs, models.IngredientInput{
+			IngredientID: result.IngredientID,
+			Amount:       result.Amount,
+			Unit:         result.Unit,
+			Notes:        result.Notes,
+			SortOrder:    len(inputs) + 1,
+		})
+	}
+
+	for idx := range inputs {
+		inputs[idx].Amount = math.Round(inputs[idx].Amount*100) / 100
+	}
+
+	return inputs, nil
+}
+
+// matchImportIngredient 匹配导入的食材名称
+// 先按名称搜索，未能确定时再查找名称包含在导入名称中的食材（如“新鲜猪肉末”对应“猪肉”），取最长的一个
+func (s *DishService) matchImportIngredient(name string) (*models.IngredientSearchResult, []*models.IngredientSearchResult, error) {
+	match, candidates, err := s.searchIngredient(name, importIngredientCandidates)
+	if err != nil || match != nil {
+		return match, candidates, err
+	}
+
+	contained, err := s.ingredientRepo.FindActiveContainedIn(name, importIngredientCandidates)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to match ingredients: %w", err)
+	}
+	if len(contained) > 0 {
+		return contained[0], nil, nil
+	}
+
+	return nil, candidates, nil
+}
+
+// parseDishTransferBundle 解析本应用导出的菜式包
+func parseDishTransferBundle(data json.RawMessage) ([]*dishImportDraft, error) {
+	var bundle models.DishTransferBundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return nil, ErrInvalidDishImport
+	}
+	if bundle.Kind != "" && bundle.Kind != models.DishTransferKind {
+		return nil, ErrInvalidDishImport
+	}
+
+	drafts := make([]*dishImportDraft, 0, len(bundle.Dishes))
+	for _, dish := range bundle.Dishes {
+		if dish != nil {
+			drafts = append(drafts, &dishImportDraft{dish: dish})
+		}
+	}
+	return drafts, nil
+}
+
+// parseRecipeJSONLD 解析 schema.org Recipe JSON-LD，支持单个对象、数组与 @graph
+func parseRecipeJSONLD(data json.RawMessage) ([]*dishImportDraft, error) {
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, ErrInvalidDishImport
+	}
+
+	var nodes []map[string]interface{}
+	collectRecipeNodes(doc, &nodes)
+
+	drafts := make([]*dishImportDraft, 0, len(nodes))
+	for _, node := range nodes {
+		drafts = append(drafts, buildRecipeDraft(node))
+	}
+	return drafts, nil
+}
+
+// collectRecipeNodes 递归查找 @type 为 Recipe 的节点
+func collectRecipeNodes(node interface{}, nodes *[]map[string]interface{}) {
+	switch value := node.(type) {
+	case []interface{}:
+		for _, child := range value {
+			collectRecipeNodes(child, nodes)
+		}
+	case map[string]interface{}:
+		if isRecipeType(value["@type"]) {
+			*nodes = append(*nodes, value)
+			return
+		}
+		if graph, ok := value["@graph"]; ok {
+			collectRecipeNodes(graph, nodes)
+		}
+	}
+}
+
+func isRecipeType(value interface{}) bool {
+	for _, item := range jsonLDStrings(value) {
+		if item == "Recipe" || strings.HasSuffix(item, "/Recipe") || strings.HasSuffix(item, ":Recipe") {
+			return true
+		}
+	}
+	return false
+}
+
+// buildRecipeDraft 将 Recipe 节点转换为导入菜式
+func buildRecipeDraft(node map[string]interface{}) *dishImportDraft {
+	dish := &models.PortableDish{
+		Name:        jsonLDText(node["name"]),
+		Description: jsonLDText(node["description"]),
+		ImageURL:    jsonLDImage(node["image"]),
+		Category:    jsonLDText(node["recipeCategory"]),
+		Servings:    jsonLDServings(node["recipeYield"]),
+		PrepMinutes: parseISODuration(jsonLDText(node["prepTime"])),
+		CookMinutes: parseISODuration(jsonLDText(node["cookTime"])),
+	}
+	if dish.PrepMinutes == 0 && dish.CookMinutes == 0 {
+		dish.CookMinutes = parseISODuration(jsonLDText(node["totalTime"]))
+	}
+
+	for _, keyword := range jsonLDStrings(node["keywords"]) {
+		dish.Tags = append(dish.Tags, recipeSeparatorPattern.Split(keyword, -1)...)
+	}
+
+	ingredients := node["recipeIngredient"]
+	if ingredients == nil {
+		ingredients = node["ingredients"]
+	}
+	draft := &dishImportDraft{dish: dish}
+	for _, text := range jsonLDStrings(ingredients) {
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+		dish.Ingredients = append(dish.Ingredients, parseRecipeIngredient(text))
+		draft.ingredientTexts = append(draft.ingredientTexts, text)
+	}
+
+	collectRecipeSteps(node["recipeInstructions"], &dish.Steps)
+	return draft
+}
+
+// collectRecipeSteps 展开 recipeInstructions，支持文本、HowToStep 与 HowToSection
+func collectRecipeSteps(value interface{}, steps *[]*models.CookingStepInput) {
+	switch item := value.(type) {
+	case string:
+		for _, line := range strings.Split(item, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				*steps = append(*steps, &models.CookingStepInput{Content: line})
+			}
+		}
+	case []interface{}:
+		for _, child := range item {
+			collectRecipeSteps(child, steps)
+		}
+	case map[string]interface{}:
+		if children, ok := item["itemListElement"]; ok {
+			collectRecipeSteps(children, steps)
+			return
+		}
+		text := jsonLDText(item["text"])
+		if text == "" {
+			text = jsonLDText(item["name"])
+		}
+		if text != "" {
+			*steps = append(*steps, &models.CookingStepInput{
+				Content:  text,
+				ImageURL: jsonLDImage(item["image"]),
+			})
+		}
+	}
+}
+
+// buildImportSteps 整理导入的步骤，去掉空步骤、按顺序重新编号并修正超出范围的字段
+func buildImportSteps(sources []*models.CookingStepInput) []models.CookingStepInput {
+	steps := make([]models.CookingStepInput, 0, len(sources))
+	for _, source := range sources {
+		if source == nil {
+			continue
+		}
+		content := truncateRunes(strings.TrimSpace(source.Content), 2000)
+		if content == "" {
+			continue
+		}
+
+		step := models.CookingStepInput{
+			Order:           len(steps) + 1,
+			Content:         content,
+			ImageURL:        importURL(source.ImageURL),
+			DurationMinutes: clampInt(source.DurationMinutes, 0, maxStepMinutes),
+			Passive:         source.Passive,
+		}
+		switch source.Equipment {
+		case models.EquipmentWok, models.EquipmentOven, models.EquipmentBurner:
+			step.Equipment = source.Equipment
+		}
+		steps = append(steps, step)
+	}
+	return steps
+}
+
+// parseRecipeIngredient 解析 "猪肉 300 g (切片)"、"2 cloves garlic, minced" 这类食材文本
+func parseRecipeIngredient(text string) *models.PortableIngredient {
+	ingredient := &models.PortableIngredient{}
+	notes := make([]string, 0)
+
+	rest := text
+	for _, match := range recipeNotesPattern.FindAllStringSubmatch(rest, -1) {
+		if note := strings.TrimSpace(match[1]); note != "" {
+			notes = append(notes, note)
+		}
+	}
+	rest = recipeNotesPattern.ReplaceAllString(rest, " ")
+
+	if parts := recipeSeparatorPattern.Split(rest, 2); len(parts) == 2 {
+		rest = parts[0]
+		if note := strings.TrimSpace(parts[1]); note != "" {
+			notes = append(notes, note)
+		}
+	}
+
+	for _, word := range recipeVagueAmounts {
+		if idx := strings.Index(strings.ToLower(rest), word); idx >= 0 {
+			rest = rest[:idx] + " " + rest[idx+len(word):]
+			notes = append(notes, word)
+		}
+	}
+
+	for fraction, ascii := range recipeFractions {
+		rest = strings.ReplaceAll(rest, fraction, ascii)
+	}
+
+	if loc := recipeQuantityPattern.FindStringSubmatchIndex(rest); loc != nil {
+		quantity := rest[loc[2]:loc[3]]
+		if loc[4] >= 0 {
+			quantity = rest[loc[4]:loc[5]]
+		}
+		ingredient.Amount = parseRecipeAmount(quantity)
+
+		unit, after := splitRecipeUnit(rest[loc[1]:])
+		ingredient.Unit = unit
+		rest = rest[:loc[0]] + " " + after
+	}
+
+	ingredient.Name = strings.Trim(strings.Join(strings.Fields(rest), " "), " .:：-")
+	ingredient.Notes = strings.Join(notes, "，")
+	return ingredient
+}
+
+// parseRecipeAmount 解析数量，支持 "1.5"、"1/2"、"1 1/2"
+func parseRecipeAmount(value string) float64 {
+	var amount float64
+	for _, field := range strings.Fields(value) {
+		if numerator, denominator, ok := strings.Cut(field, "/"); ok {
+			n, errN := strconv.ParseFloat(numerator, 64)
+			d, errD := strconv.ParseFloat(denominator, 64)
+			if errN == nil && errD == nil && d != 0 {
+				amount += n / d
+			}
+			continue
+		}
+		if number, err := strconv.ParseFloat(field, 64); err == nil {
+			amount += number
+		}
+	}
+	return amount
+}
+
+// splitRecipeUnit 从数量后的文本开头识别单位，返回标准单位名与剩余文本
+// 英文单位需是完整单词（"2 garlic" 中的 g 不是单位），允许复数形式（cups）
+func splitRecipeUnit(text string) (string, string) {
+	text = strings.TrimLeftFunc(text, unicode.IsSpace)
+	runes := []rune(text)
+
+	for n := min(len(runes), 8); n > 0; n-- {
+		prefix := string(runes[:n])
+		if n < len(runes) && isASCIILetter(runes[n-1]) && isASCIILetter(runes[n]) {
+			continue
+		}
+
+		unit, ok := utils.LookupUnit(prefix)
+		if !ok && n > 1 && isASCIILetter(runes[n-1]) {
+			unit, ok = utils.LookupUnit(strings.TrimSuffix(prefix, "s"))
+		}
+		if ok {
+			return unit.Name, strings.TrimPrefix(string(runes[n:]), ".")
+		}
+	}
+
+	return "", text
+}
+
+func isASCIILetter(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+// buildPortableDish 将菜式详情转换为导出格式
+func buildPortableDish(detail *models.DishDetailResponse) *models.PortableDish {
+	dish := &models.PortableDish{
+		Name:        detail.Name,
+		Category:    detail.Category,
+		Description: detail.Description,
+		ImageURL:    detail.ImageURL,
+		PrepMinutes: detail.PrepMinutes,
+		CookMinutes: detail.CookMinutes,
+		Servings:    detail.Servings,
+		Difficulty:  detail.Difficulty,
+		Tags:        make([]string, 0, len(detail.Tags)),
+		Ingredients: make([]*models.PortableIngredient, 0, len(detail.Ingredients)),
+		Steps:       make([]*models.CookingStepInput, 0, len(detail.Steps)),
+	}
+
+	for _, tag := range detail.Tags {
+		dish.Tags = append(dish.Tags, tag.Name)
+	}
+	for _, ingredient := range detail.Ingredients {
+		dish.Ingredients = append(dish.Ingredients, &models.PortableIngredient{
+			IngredientID: ingredient.IngredientID,
+			Name:         ingredient.IngredientName,
+			Amount:       ingredient.Amount,
+			Unit:         ingredient.Unit,
+			Notes:        ingredient.Notes,
+		})
+	}
+	for _, step := range detail.Steps {
+		dish.Steps = append(dish.Steps, &models.CookingStepInput{
+			Order:           step.Order,
+			Content:         step.Content,
+			ImageURL:        step.ImageURL,
+			DurationMinutes: step.DurationMinutes,
+			Passive:         step.Passive,
+			Equipment:       step.Equipment,
+		})
+	}
+
+	return dish
+}
+
+// buildRecipe 将菜式详情转换为 schema.org Recipe
+func buildRecipe(detail *models.DishDetailResponse) *models.Recipe {
+	recipe := &models.Recipe{
+		Type:               "Recipe",
+		Name:               detail.Name,
+		Description:        detail.Description,
+		RecipeCategory:     detail.Category,
+		RecipeYield:        strconv.Itoa(detail.Servings),
+		PrepTime:           formatISODuration(detail.PrepMinutes),
+		CookTime:           formatISODuration(detail.CookMinutes),
+		TotalTime:          formatISODuration(detail.TotalMinutes),
+		RecipeIngredient:   make([]string, 0, len(detail.Ingredients)),
+		RecipeInstructions: make([]*models.RecipeHowToStep, 0, len(detail.Steps)),
+	}
+	if detail.ImageURL != "" {
+		recipe.Image = []string{detail.ImageURL}
+	}
+
+	tagNames := make([]string, 0, len(detail.Tags))
+	for _, tag := range detail.Tags {
+		tagNames = append(tagNames, tag.Name)
+	}
+	recipe.Keywords = strings.Join(tagNames, ", ")
+
+	for _, ingredient := range detail.Ingredients {
+		text := ingredient.IngredientName
+		if ingredient.Amount > 0 {
+			text += " " + strconv.FormatFloat(ingredient.Amount, 'f', -1, 64) + " " + ingredient.Unit
+		}
+		if ingredient.Notes != "" {
+			text += " (" + ingredient.Notes + ")"
+		}
+		recipe.RecipeIngredient = append(recipe.RecipeIngredient, text)
+	}
+
+	for idx, step := range detail.Steps {
+		recipe.RecipeInstructions = append(recipe.RecipeInstructions, &models.RecipeHowToStep{
+			Type:     "HowToStep",
+			Position: idx + 1,
+			Text:     step.Content,
+			Image:    step.ImageURL,
+		})
+	}
+
+	return recipe
+}
+
+// formatISODuration 将分钟数格式化为 ISO 8601 时长，0 返回空字符串
+func formatISODuration(minutes int) string {
+	if minutes <= 0 {
+		return ""
+	}
+
+	duration := "PT"
+	if hours := minutes / 60; hours > 0 {
+		duration += strconv.Itoa(hours) + "H"
+	}
+	if rest := minutes % 60; rest > 0 {
+		duration += strconv.Itoa(rest) + "M"
+	}
+	return duration
+}
+
+// parseISODuration 解析 ISO 8601 时长为分钟数，无法解析时返回 0
+func parseISODuration(value string) int {
+	match := isoDurationPattern.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil {
+		return 0
+	}
+
+	days, _ := strconv.Atoi(match[1])
+	hours, _ := strconv.Atoi(match[2])
+	minutes, _ := strconv.Atoi(match[3])
+	seconds, _ := strconv.ParseFloat(match[4], 64)
+
+	total := days*24*60 + hours*60 + minutes + int(math.Round(seconds/60))
+	return clampInt(total, 0, maxStepMinutes)
+}
+
+// jsonLDStrings 将 JSON-LD 中的文本或文本数组统一为字符串列表
+func jsonLDStrings(value interface{}) []string {
+	switch item := value.(type) {
+	case string:
+		return []string{item}
+	case []interface{}:
+		values := make([]string, 0, len(item))
+		for _, child := range item {
+			if text, ok := child.(string); ok {
+				values = append(values, text)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// jsonLDText 取 JSON-LD 中的第一个文本值
+func jsonLDText(value interface{}) string {
+	for _, text := range jsonLDStrings(value) {
+		if text = strings.TrimSpace(text); text != "" {
+			return text
+		}
+	}
+	return ""
+}
+
+// jsonLDImage 取 JSON-LD 中的第一张图片地址，支持文本、数组与 ImageObject
+func jsonLDImage(value interface{}) string {
+	switch item := value.(type) {
+	case string:
+		return strings.TrimSpace(item)
+	case []interface{}:
+		for _, child := range item {
+			if url := jsonLDImage(child); url != "" {
+				return url
+			}
+		}
+	case map[string]interface{}:
+		return jsonLDText(item["url"])
+	}
+	return ""
+}
+
+// jsonLDServings 从 recipeYield（如 4、"4 servings"、"2人份"）中取份数
+func jsonLDServings(value interface{}) int {
+	switch item := value.(type) {
+	case float64:
+		return int(item)
+	case []interface{}:
+		for _, child := range item {
+			if servings := jsonLDServings(child); servings > 0 {
+				return servings
+			}
+		}
+	case string:
+		if match := recipeQuantityPattern.FindStringSubmatch(item); match != nil {
+			return int(parseRecipeAmount(match[1]))
+		}
+	}
+	return 0
+}
+
+// importURL 导入的图片地址超出长度限制时丢弃
+func importURL(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) > 500 {
+		return ""
+	}
+	return value
+}
+
+// importDifficulty 导入的难度不是 easy、medium、hard 时置空
+func importDifficulty(value string) string {
+	switch value {
+	case models.DishDifficultyEasy, models.DishDifficultyMedium, models.DishDifficultyHard:
+		return value
+	}
+	return ""
+}
+
+func truncateRunes(value string, limit int) string {
+	if utf8.RuneCountInString(value) <= limit {
+		return value
+	}
+	return string([]rune(value)[:limit])
+}
+
+func clampInt(value, lower, upper int) int {
+	if value < lower {
+		return lower
+	}
+	if value > upper {
+		return upper
+	}
+	return value
+}
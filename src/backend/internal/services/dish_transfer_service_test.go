@@ -0,0 +1,84 @@
+package services
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseRecipeIngredient(t *testing.T) {
+	tests := []struct {
+		text       string
+		wantName   string
+		wantAmount float64
+		wantUnit   string
+		wantNotes  string
+	}{
+		{text: "猪肉 300 g (切片)", wantName: "猪肉", wantAmount: 300, wantUnit: "g", wantNotes: "切片"},
+		{text: "300g 猪肉", wantName: "猪肉", wantAmount: 300, wantUnit: "g"},
+		{text: "鸡蛋 2个", wantName: "鸡蛋", wantAmount: 2, wantUnit: "个"},
+		{text: "生抽 2勺", wantName: "生抽", wantAmount: 2, wantUnit: "勺"},
+		{text: "姜 3-4 片", wantName: "姜", wantAmount: 4, wantUnit: "片"},
+		{text: "盐 适量", wantName: "盐", wantNotes: "适量"},
+		{text: "2 garlic, minced", wantName: "garlic", wantAmount: 2, wantNotes: "minced"},
+		{text: "2 garlic", wantName: "garlic", wantAmount: 2}, // g 不是完整单词，不识别为单位
+		{text: "1 1/2 cups sugar", wantName: "sugar", wantAmount: 1.5, wantUnit: "杯"},
+		{text: "½ tsp salt", wantName: "salt", wantAmount: 0.5, wantUnit: "茶匙"},
+		{text: "1 tbsp. olive oil", wantName: "olive oil", wantAmount: 1, wantUnit: "勺"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got := parseRecipeIngredient(tt.text)
+			if got.Name != tt.wantName || math.Abs(got.Amount-tt.wantAmount) > 1e-9 || got.Unit != tt.wantUnit || got.Notes != tt.wantNotes {
+				t.Fatalf("parseRecipeIngredient(%q) = {%q %v %q %q}, want {%q %v %q %q}",
+					tt.text, got.Name, got.Amount, got.Unit, got.Notes,
+					tt.wantName, tt.wantAmount, tt.wantUnit, tt.wantNotes)
+			}
+		})
+	}
+}
+
+func TestParseISODuration(t *testing.T) {
+	tests := []struct {
+		value string
+		want  int
+	}{
+		{value: "PT45M", want: 45},
+		{value: "PT1H30M", want: 90},
+		{value: "P0DT2H", want: 120},
+		{value: "PT90S", want: 2},
+		{value: " PT10M ", want: 10},
+		{value: "P2D", want: maxStepMinutes},
+		{value: "", want: 0},
+		{value: "bad", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := parseISODuration(tt.value); got != tt.want {
+				t.Fatalf("parseISODuration(%q) = %d, want %d", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatISODuration(t *testing.T) {
+	tests := []struct {
+		minutes int
+		want    string
+	}{
+		{minutes: 0, want: ""},
+		{minutes: 45, want: "PT45M"},
+		{minutes: 60, want: "PT1H"},
+		{minutes: 90, want: "PT1H30M"},
+	}
+
+	for _, tt := range tests {
+		if got := formatISODuration(tt.minutes); got != tt.want {
+			t.Fatalf("formatISODuration(%d) = %q, want %q", tt.minutes, got, tt.want)
+		}
+		if tt.minutes > 0 && parseISODuration(tt.want) != tt.minutes {
+			t.Fatalf("parseISODuration(formatISODuration(%d)) did not round-trip", tt.minutes)
+		}
+	}
+}
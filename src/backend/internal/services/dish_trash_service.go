@@ -0,0 +1,172 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"onetaste-family/backend/internal/models"
+	"onetaste-family/backend/internal/repositories"
+)
+
+const (
+	// dishTrashRetention 菜式在回收站中的保留时长，期满后彻底删除
+	dishTrashRetention = 30 * 24 * time.Hour
+	// dishPurgeBatchSize 后台任务单次清理的菜式数量
+	dishPurgeBatchSize = 200
+)
+
+var (
+	// ErrDishInUse 菜式已被菜单引用，删除需要用户确认
+	ErrDishInUse = errors.New("dish is used in menus")
+	// ErrDishNotRestorable 菜式内容已被清除，无法恢复
+	ErrDishNotRestorable = errors.New("dish is not restorable")
+)
+
+// DishInUseError 删除被菜单引用的菜式时返回，携带受影响的菜单
+type DishInUseError struct {
+	Impact *models.DishDeleteImpactResponse
+}
+
+func (e *DishInUseError) Error() string {
+	return fmt.Sprintf("%s: %d menus", ErrDishInUse.Error(), len(e.Impact.FutureMenus)+len(e.Impact.PastMenus))
+}
+
+func (e *DishInUseError) Unwrap() error {
+	return ErrDishInUse
+}
+
+// GetDishDeleteImpact 删除前检查菜式被哪些菜单引用
+func (s *DishService) GetDishDeleteImpact(userID, dishID string) (*models.DishDeleteImpactResponse, error) {
+	family, err := s.getFamilyForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	dish, err := s.dishRepo.GetDishByID(dishID, family.ID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrDishNotFound) {
+			return nil, ErrDishNotFound
+		}
+		return nil, fmt.Errorf("failed to get dish: %w", err)
+	}
+
+	return s.buildDishDeleteImpact(family.ID, dish)
+}
+
+// ListTrashedDishes 获取回收站中的菜式
+func (s *DishService) ListTrashedDishes(userID string, req *models.DishTrashListRequest) (*models.DishTrashListResponse, error) {
+	family, err := s.getFamilyForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	dishes, total, err := s.dishRepo.ListDeletedDishes(family.ID, req.Page, req.PageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deleted dishes: %w", err)
+	}
+
+	for _, dish := range dishes {
+		dish.PurgeAfter = dish.DeletedAt.Add(dishTrashRetention)
+	}
+
+	return &models.DishTrashListResponse{
+		Dishes:   dishes,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, nil
+}
+
+// RestoreDish 从回收站恢复菜式（仅创建者或家庭管理员）
+// 恢复后占用菜式数量额度，名称不能与现有菜式重复；删除时被移出的菜单不会自动恢复
+func (s *DishService) RestoreDish(userID, dishID string) (*models.DishDetailResponse, error) {
+	family, err := s.getFamilyForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	dish, err := s.dishRepo.GetDeletedDishByID(dishID, family.ID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrDishNotFound) {
+			return nil, ErrDishNotFound
+		}
+		return nil, fmt.Errorf("failed to get dish: %w", err)
+	}
+
+	if dish.CreatedBy != userID && family.OwnerID != userID {
+		return nil, ErrDishPermissionDenied
+	}
+
+	// 回收站功能上线前删除的菜式已清除食材与步骤，无法恢复为完整菜式
+	ingredients, err := s.dishRepo.GetIngredients(dish.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ingredients: %w", err)
+	}
+	if len(ingredients) == 0 {
+		return nil, ErrDishNotRestorable
+	}
+
+	tier, _, err := s.membership.FamilyTier(family.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve membership tier: %w", err)
+	}
+
+	count, err := s.dishRepo.CountByFamily(family.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count dishes: %w", err)
+	}
+	if count >= tier.MaxDishes {
+		return nil, ErrDishLimitReached
+	}
+
+	exists, err := s.dishRepo.ExistsByName(family.ID, dish.Name, dish.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check dish name: %w", err)
+	}
+	if exists {
+		return nil, ErrDishNameExists
+	}
+
+	if err := s.dishRepo.RestoreDish(dish.ID, family.ID); err != nil {
+		if errors.Is(err, repositories.ErrDishNotFound) {
+			return nil, ErrDishNotFound
+		}
+		return nil, fmt.Errorf("failed to restore dish: %w", err)
+	}
+
+	return s.GetDishDetail(userID, dish.ID, 0)
+}
+
+// PurgeExpiredDishes 彻底删除回收站中超过保留期限的菜式，返回本次删除的数量
+func (s *DishService) PurgeExpiredDishes() (int64, error) {
+	before := time.Now().Add(-dishTrashRetention)
+	return s.dishRepo.PurgeDeletedDishes(before, dishPurgeBatchSize)
+}
+
+// buildDishDeleteImpact 按今天划分引用菜式的未来菜单与历史菜单
+func (s *DishService) buildDishDeleteImpact(familyID string, dish *models.Dish) (*models.DishDeleteImpactResponse, error) {
+	usages, err := s.menuRepo.ListMenusByDish(familyID, dish.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dish menus: %w", err)
+	}
+
+	impact := &models.DishDeleteImpactResponse{
+		DishID:      dish.ID,
+		Name:        dish.Name,
+		FutureMenus: make([]*models.DishMenuUsage, 0),
+		PastMenus:   make([]*models.DishMenuUsage, 0),
+		InUse:       len(usages) > 0,
+	}
+
+	today := formatDate(time.Now())
+	for _, usage := range usages {
+		if usage.Date >= today {
+			impact.FutureMenus = append(impact.FutureMenus, usage)
+		} else {
+			impact.PastMenus = append(impact.PastMenus, usage)
+		}
+	}
+
+	return impact, nil
+}
@@ -13,4 +13,14 @@ var (
 	ErrInvalidCredentials = errors.New("invalid credentials")
 	// ErrUserNotFound 用户不存在
 	ErrUserNotFound = errors.New("user not found")
+	// ErrCaptchaRequired 登录失败次数过多，需要图形验证码
+	ErrCaptchaRequired = errors.New("captcha required")
+	// ErrLoginTooFrequent 登录失败过多，需等待后重试
+	ErrLoginTooFrequent = errors.New("login too frequent")
+	// ErrAccountLocked 账号或IP已被临时锁定
+	ErrAccountLocked = errors.New("account locked")
+	// ErrAIServiceUnavailable AI服务不可用（未配置、熔断或请求失败）
+	ErrAIServiceUnavailable = errors.New("ai service unavailable")
+	// ErrAIInvalidResult AI服务返回的结果无法使用
+	ErrAIInvalidResult = errors.New("ai service returned invalid result")
 )
@@ -0,0 +1,212 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"onetaste-family/backend/internal/models"
+	"onetaste-family/backend/internal/repositories"
+)
+
+var (
+	// ErrFamilyDissolveNameMismatch 解散确认的家庭名称不一致
+	ErrFamilyDissolveNameMismatch = errors.New("family dissolve confirm name mismatch")
+)
+
+const (
+	// familyDataRetention 解散后数据保留时长，期间owner可导出数据
+	familyDataRetention = 30 * 24 * time.Hour
+	// familyPurgeBatchSize 后台任务单次清理的家庭数量
+	familyPurgeBatchSize = 50
+)
+
+// DissolveFamily 解散家庭（仅owner）
+// 家庭标记为解散，全部成员退出，菜式与菜单软删除，数据保留期满后由后台任务清理
+func (s *FamilyService) DissolveFamily(userID string, req *models.DissolveFamilyRequest) (resp *models.FamilyDissolveResponse, err error) {
+	family, err := s.familyRepo.GetFamilyByUserID(userID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrFamilyNotFound) {
+			return nil, ErrFamilyNotFound
+		}
+		return nil, fmt.Errorf("failed to get family: %w", err)
+	}
+
+	ctx := context.Background()
+	tx, err := s.familyRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction failed: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	family, err = s.familyRepo.LockFamilyTx(ctx, tx, family.ID)
+	if err != nil {
+		return nil, mapFamilyRepoError(err)
+	}
+	if family.OwnerID != userID {
+		return nil, ErrNotFamilyOwner
+	}
+	if strings.TrimSpace(req.ConfirmName) != family.Name {
+		return nil, ErrFamilyDissolveNameMismatch
+	}
+
+	// 数据库 TIMESTAMP 精度为微秒，截断后同一时间点可用于标记批量软删除的数据
+	dissolvedAt := time.Now().UTC().Truncate(time.Microsecond)
+	purgeAfter := dissolvedAt.Add(familyDataRetention)
+
+	if err = s.familyRepo.DissolveFamilyTx(ctx, tx, family.ID, dissolvedAt, purgeAfter); err != nil {
+		return nil, mapFamilyRepoError(err)
+	}
+	if err = s.familyRepo.DeactivateAllMembersTx(ctx, tx, family.ID); err != nil {
+		return nil, err
+	}
+	if err = s.invitationRepo.RevokeAllByFamilyTx(ctx, tx, family.ID); err != nil {
+		return nil, err
+	}
+	if err = s.dishRepo.SoftDeleteByFamilyTx(ctx, tx, family.ID, dissolvedAt); err != nil {
+		return nil, err
+	}
+	if err = s.menuRepo.SoftDeleteByFamilyTx(ctx, tx, family.ID, dissolvedAt); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit transaction failed: %w", err)
+	}
+
+	return &models.FamilyDissolveResponse{
+		FamilyID:    family.ID,
+		DissolvedAt: dissolvedAt,
+		PurgeAfter:  purgeAfter,
+	}, nil
+}
+
+// ExportFamily 导出家庭创建的全部数据（仅owner）
+// familyID 为空时导出当前家庭；已解散的家庭在数据清理前仍可由原owner导出
+func (s *FamilyService) ExportFamily(userID, familyID string) (*models.FamilyExportBundle, error) {
+	if familyID == "" {
+		current, err := s.familyRepo.GetFamilyByUserID(userID)
+		if err != nil {
+			if errors.Is(err, repositories.ErrFamilyNotFound) {
+				return nil, ErrFamilyNotFound
+			}
+			return nil, fmt.Errorf("failed to get family: %w", err)
+		}
+		familyID = current.ID
+	}
+
+	family, err := s.familyRepo.GetFamilyForExport(familyID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrFamilyNotFound) {
+			return nil, ErrFamilyNotFound
+		}
+		return nil, fmt.Errorf("failed to get family: %w", err)
+	}
+	if family.OwnerID != userID {
+		return nil, ErrNotFamilyOwner
+	}
+
+	members, err := s.familyRepo.GetAllFamilyMembers(family.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	dishes, err := s.dishRepo.ListDishesForExport(family.ID, family.DissolvedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	dishIDs := make([]string, 0, len(dishes))
+	for _, dish := range dishes {
+		dishIDs = append(dishIDs, dish.ID)
+	}
+	dishTags, err := s.tagRepo.GetByDishIDs(dishIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	dishDetails := make([]*models.DishDetailResponse, 0, len(dishes))
+	for _, dish := range dishes {
+		ingredients, err := s.dishRepo.GetIngredients(dish.ID)
+		if err != nil {
+			return nil, err
+		}
+		steps, err := s.dishRepo.GetCookingSteps(dish.ID)
+		if err != nil {
+			return nil, err
+		}
+		tags := dishTags[dish.ID]
+		sortDishTags(tags)
+		dishDetails = append(dishDetails, buildDishDetailResponse(dish, ingredients, steps, tags))
+	}
+
+	menus, err := s.menuRepo.ListMenusForExport(family.ID, family.DissolvedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	lists, err := s.shoppingRepo.ListAllByFamily(family.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, list := range lists {
+		items, err := s.shoppingRepo.GetListItems(list.ListID)
+		if err != nil {
+			return nil, err
+		}
+		if items == nil {
+			items = []*models.ShoppingListItem{}
+		}
+		list.Items = items
+	}
+
+	return &models.FamilyExportBundle{
+		Version:    models.FamilyExportVersion,
+		ExportedAt: time.Now().UTC(),
+		Family: &models.FamilyExportInfo{
+			FamilyID:    family.ID,
+			Name:        family.Name,
+			Description: family.Description,
+			OwnerID:     family.OwnerID,
+			Status:      family.Status,
+			CreatedAt:   family.CreatedAt,
+			DissolvedAt: family.DissolvedAt,
+			PurgeAfter:  family.PurgeAfter,
+		},
+		Members:       members,
+		Dishes:        dishDetails,
+		Menus:         menus,
+		ShoppingLists: lists,
+	}, nil
+}
+
+// PurgeExpiredFamilies 清理超过保留期限的已解散家庭数据，返回本次清理的家庭数量
+func (s *FamilyService) PurgeExpiredFamilies() (int, error) {
+	now := time.Now().UTC()
+	familyIDs, err := s.familyRepo.ListFamiliesToPurge(now, familyPurgeBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	var errs []error
+	for _, familyID := range familyIDs {
+		if err := s.familyRepo.PurgeFamilyData(familyID, now); err != nil {
+			if errors.Is(err, repositories.ErrFamilyNotFound) {
+				continue
+			}
+			errs = append(errs, fmt.Errorf("purge family %s: %w", familyID, err))
+			continue
+		}
+		purged++
+	}
+
+	return purged, errors.Join(errs...)
+}
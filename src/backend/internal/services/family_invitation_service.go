@@ -0,0 +1,218 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"onetaste-family/backend/internal/models"
+	"onetaste-family/backend/internal/repositories"
+	"onetaste-family/backend/internal/utils"
+)
+
+var (
+	// ErrInvalidInvitation 邀请令牌无效或邀请不存在
+	ErrInvalidInvitation = errors.New("invalid invitation")
+	// ErrInvitationExpired 邀请已过期
+	ErrInvitationExpired = errors.New("invitation expired")
+	// ErrInvitationRevoked 邀请已撤销
+	ErrInvitationRevoked = errors.New("invitation revoked")
+	// ErrInvitationExhausted 邀请使用次数已用完
+	ErrInvitationExhausted = errors.New("invitation exhausted")
+	// ErrInvitationPhoneMismatch 当前用户手机号与邀请指定手机号不一致
+	ErrInvitationPhoneMismatch = errors.New("invitation phone mismatch")
+)
+
+const (
+	defaultInvitationHours   = 24
+	defaultInvitationMaxUses = 1
+)
+
+// CreateInvitation 创建家庭邀请，家庭成员均可发起
+func (s *FamilyService) CreateInvitation(userID string, req *models.CreateFamilyInvitationRequest) (*models.FamilyInvitationResponse, error) {
+	family, err := s.familyRepo.GetFamilyByUserID(userID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrFamilyNotFound) {
+			return nil, ErrFamilyNotFound
+		}
+		return nil, fmt.Errorf("failed to get family: %w", err)
+	}
+
+	hours := req.ExpiresInHours
+	if hours <= 0 {
+		hours = defaultInvitationHours
+	}
+	maxUses := req.MaxUses
+	if maxUses <= 0 {
+		maxUses = defaultInvitationMaxUses
+	}
+
+	invitation := &models.FamilyInvitation{
+		ID:          utils.GenerateULID(),
+		FamilyID:    family.ID,
+		InviterID:   userID,
+		TargetPhone: strings.TrimSpace(req.TargetPhone),
+		MaxUses:     maxUses,
+		ExpiresAt:   invitationNow().Add(time.Duration(hours) * time.Hour),
+	}
+
+	if err := s.invitationRepo.Create(invitation); err != nil {
+		return nil, fmt.Errorf("failed to create invitation: %w", err)
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	invitation.InviterNickname = user.Nickname
+
+	resp := buildInvitationResponse(invitation)
+	resp.Token = utils.GenerateInviteToken(invitation.ID)
+	return resp, nil
+}
+
+// ListInvitations 获取当前家庭的邀请列表
+func (s *FamilyService) ListInvitations(userID string) ([]*models.FamilyInvitationResponse, error) {
+	family, err := s.familyRepo.GetFamilyByUserID(userID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrFamilyNotFound) {
+			return nil, ErrFamilyNotFound
+		}
+		return nil, fmt.Errorf("failed to get family: %w", err)
+	}
+
+	invitations, err := s.invitationRepo.ListByFamily(family.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invitations: %w", err)
+	}
+
+	result := make([]*models.FamilyInvitationResponse, 0, len(invitations))
+	for _, invitation := range invitations {
+		result = append(result, buildInvitationResponse(invitation))
+	}
+
+	return result, nil
+}
+
+// RevokeInvitation 撤销邀请，仅邀请人本人或owner可操作
+func (s *FamilyService) RevokeInvitation(userID, invitationID string) error {
+	family, err := s.familyRepo.GetFamilyByUserID(userID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrFamilyNotFound) {
+			return ErrFamilyNotFound
+		}
+		return fmt.Errorf("failed to get family: %w", err)
+	}
+
+	invitation, err := s.invitationRepo.GetByID(invitationID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrFamilyInvitationNotFound) {
+			return ErrInvalidInvitation
+		}
+		return fmt.Errorf("failed to get invitation: %w", err)
+	}
+	if invitation.FamilyID != family.ID {
+		return ErrInvalidInvitation
+	}
+	if invitation.InviterID != userID && family.OwnerID != userID {
+		return ErrNotFamilyOwner
+	}
+	if invitation.RevokedAt != nil {
+		return ErrInvitationRevoked
+	}
+
+	if err := s.invitationRepo.Revoke(invitation.ID, family.ID); err != nil {
+		if errors.Is(err, repositories.ErrFamilyInvitationNotFound) {
+			return ErrInvitationRevoked
+		}
+		return fmt.Errorf("failed to revoke invitation: %w", err)
+	}
+
+	return nil
+}
+
+// PreviewInvitation 根据邀请令牌获取落地页展示信息
+func (s *FamilyService) PreviewInvitation(token string) (*models.FamilyInvitationPreview, error) {
+	invitationID, err := utils.ParseInviteToken(token)
+	if err != nil {
+		return nil, ErrInvalidInvitation
+	}
+
+	invitation, err := s.invitationRepo.GetByID(invitationID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrFamilyInvitationNotFound) {
+			return nil, ErrInvalidInvitation
+		}
+		return nil, fmt.Errorf("failed to get invitation: %w", err)
+	}
+	if err := invitationStatusError(invitation); err != nil {
+		return nil, err
+	}
+
+	family, err := s.familyRepo.GetFamilyByID(invitation.FamilyID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrFamilyNotFound) {
+			return nil, ErrFamilyNotFound
+		}
+		return nil, fmt.Errorf("failed to get family: %w", err)
+	}
+
+	inviter, err := s.userRepo.GetByID(invitation.InviterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inviter: %w", err)
+	}
+
+	memberCount, err := s.familyRepo.CountFamilyMembers(family.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count family members: %w", err)
+	}
+
+	return &models.FamilyInvitationPreview{
+		FamilyName:      family.Name,
+		InviterNickname: inviter.Nickname,
+		InviterAvatar:   inviter.Avatar,
+		MemberCount:     memberCount,
+		RequiresPhone:   invitation.TargetPhone != "",
+		ExpiresAt:       invitation.ExpiresAt,
+	}, nil
+}
+
+func invitationStatusError(invitation *models.FamilyInvitation) error {
+	switch invitation.Status(invitationNow()) {
+	case models.FamilyInvitationStatusRevoked:
+		return ErrInvitationRevoked
+	case models.FamilyInvitationStatusExpired:
+		return ErrInvitationExpired
+	case models.FamilyInvitationStatusExhausted:
+		return ErrInvitationExhausted
+	default:
+		return nil
+	}
+}
+
+func buildInvitationResponse(invitation *models.FamilyInvitation) *models.FamilyInvitationResponse {
+	return &models.FamilyInvitationResponse{
+		InvitationID:    invitation.ID,
+		InviterID:       invitation.InviterID,
+		InviterNickname: invitation.InviterNickname,
+		TargetPhone:     maskPhone(invitation.TargetPhone),
+		MaxUses:         invitation.MaxUses,
+		UsedCount:       invitation.UsedCount,
+		Status:          invitation.Status(invitationNow()),
+		ExpiresAt:       invitation.ExpiresAt,
+		CreatedAt:       invitation.CreatedAt,
+	}
+}
+
+// invitationNow 邀请表使用无时区 TIMESTAMP，统一按 UTC 写入与比较
+func invitationNow() time.Time {
+	return time.Now().UTC()
+}
+
+func maskPhone(phone string) string {
+	if len(phone) != 11 {
+		return phone
+	}
+	return phone[:3] + "****" + phone[7:]
+}
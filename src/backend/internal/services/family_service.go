@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"strings"
@@ -27,12 +28,21 @@ var (
 	ErrInviterNotInFamily = errors.New("inviter not in family")
 	// ErrFamilyMemberLimitReached 家庭成员数量已达上限
 	ErrFamilyMemberLimitReached = errors.New("family member limit reached")
-	// ErrFamilyNameMismatch 二维码中的家庭名称不匹配
-	ErrFamilyNameMismatch = errors.New("family name mismatch")
+	// ErrNotFamilyOwner 仅家庭owner可操作
+	ErrNotFamilyOwner = errors.New("not family owner")
+	// ErrFamilyMemberNotFound 成员不存在或已退出
+	ErrFamilyMemberNotFound = errors.New("family member not found")
+	// ErrCannotRemoveSelf owner不能移除自己
+	ErrCannotRemoveSelf = errors.New("cannot remove self")
+	// ErrCannotTransferToSelf 不能将家庭转让给自己
+	ErrCannotTransferToSelf = errors.New("cannot transfer to self")
+	// ErrOwnerMustTransfer owner退出前必须指定新owner
+	ErrOwnerMustTransfer = errors.New("owner must transfer before leaving")
+	// ErrOwnerLastMember owner是家庭唯一成员，无法退出
+	ErrOwnerLastMember = errors.New("owner is the last member")
 )
 
 const (
-	defaultMaxDishes           = 30
 	familyNameMaxLength        = 100
 	familyDescriptionMaxLength = 500
 	maxFamilyMembers           = 10
@@ -40,13 +50,27 @@ const (
 
 // FamilyService 家庭业务逻辑层
 type FamilyService struct {
-	familyRepo *repositories.FamilyRepository
+	familyRepo     *repositories.FamilyRepository
+	invitationRepo *repositories.FamilyInvitationRepository
+	userRepo       *repositories.UserRepository
+	dishRepo       *repositories.DishRepository
+	tagRepo        *repositories.DishTagRepository
+	menuRepo       *repositories.MenuRepository
+	shoppingRepo   *repositories.ShoppingRepository
+	membership     *MembershipService
 }
 
 // NewFamilyService 创建FamilyService
 func NewFamilyService() *FamilyService {
 	return &FamilyService{
-		familyRepo: repositories.NewFamilyRepository(),
+		familyRepo:     repositories.NewFamilyRepository(),
+		invitationRepo: repositories.NewFamilyInvitationRepository(),
+		userRepo:       repositories.NewUserRepository(),
+		dishRepo:       repositories.NewDishRepository(),
+		tagRepo:        repositories.NewDishTagRepository(),
+		menuRepo:       repositories.NewMenuRepository(),
+		shoppingRepo:   repositories.NewShoppingRepository(),
+		membership:     NewMembershipService(),
 	}
 }
 
@@ -76,7 +100,7 @@ func (s *FamilyService) CreateFamily(req *models.CreateFamilyRequest, userID str
 		Name:        name,
 		Description: description,
 		OwnerID:     userID,
-		MaxDishes:   defaultMaxDishes,
+		MaxDishes:   FreeTier().MaxDishes,
 		Status:      models.FamilyStatusActive,
 	}
 
@@ -136,6 +160,11 @@ func (s *FamilyService) GetFamilyInfo(userID string) (*models.FamilyInfoResponse
 		return nil, fmt.Errorf("failed to get family stats: %w", err)
 	}
 
+	tier, _, err := s.membership.FamilyTier(family.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve membership tier: %w", err)
+	}
+
 	return &models.FamilyInfoResponse{
 		FamilyID:    family.ID,
 		Name:        family.Name,
@@ -143,16 +172,21 @@ func (s *FamilyService) GetFamilyInfo(userID string) (*models.FamilyInfoResponse
 		OwnerID:     family.OwnerID,
 		MemberCount: memberCount,
 		DishCount:   dishCount,
-		MaxDishes:   family.MaxDishes,
+		MaxDishes:   tier.MaxDishes,
 	}, nil
 }
 
-// JoinFamilyViaInvite 扫码加入家庭
-func (s *FamilyService) JoinFamilyViaInvite(req *models.FamilyInviteRequest, userID string) (*models.FamilyJoinResponse, error) {
+// JoinFamilyViaInvite 扫码加入家庭，兑换邀请令牌
+func (s *FamilyService) JoinFamilyViaInvite(req *models.FamilyInviteRequest, userID string) (resp *models.FamilyJoinResponse, err error) {
 	if strings.ToLower(req.Action) != "accept" {
 		return nil, ErrInvalidInviteAction
 	}
 
+	invitationID, err := utils.ParseInviteToken(req.Token)
+	if err != nil {
+		return nil, ErrInvalidInvitation
+	}
+
 	inFamily, err := s.familyRepo.IsUserInFamily(userID)
 	if err != nil {
 		return nil, fmt.Errorf("check user family membership failed: %w", err)
@@ -161,29 +195,56 @@ func (s *FamilyService) JoinFamilyViaInvite(req *models.FamilyInviteRequest, use
 		return nil, ErrUserAlreadyInFamily
 	}
 
-	family, err := s.familyRepo.GetFamilyByID(req.FamilyID)
+	ctx := context.Background()
+	tx, err := s.familyRepo.BeginTx(ctx)
 	if err != nil {
-		if errors.Is(err, repositories.ErrFamilyNotFound) {
-			return nil, ErrFamilyNotFound
+		return nil, fmt.Errorf("begin transaction failed: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
 		}
-		return nil, fmt.Errorf("failed to get family: %w", err)
+	}()
+
+	invitation, err := s.invitationRepo.LockByIDTx(ctx, tx, invitationID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrFamilyInvitationNotFound) {
+			return nil, ErrInvalidInvitation
+		}
+		return nil, err
+	}
+	if err = invitationStatusError(invitation); err != nil {
+		return nil, err
 	}
 
-	if req.FamilyName != "" && req.FamilyName != family.Name {
-		return nil, ErrFamilyNameMismatch
+	if invitation.TargetPhone != "" {
+		var user *models.User
+		user, err = s.userRepo.GetByID(userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+		if user.Phone != invitation.TargetPhone {
+			return nil, ErrInvitationPhoneMismatch
+		}
 	}
 
-	inviterValid, err := s.familyRepo.IsUserInSpecificFamily(req.InviterID, req.FamilyID)
+	family, err := s.familyRepo.LockFamilyTx(ctx, tx, invitation.FamilyID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to validate inviter: %w", err)
+		return nil, mapFamilyRepoError(err)
 	}
-	if !inviterValid {
-		return nil, ErrInviterNotInFamily
+
+	// 邀请人已退出家庭时邀请失效
+	if _, err = s.familyRepo.GetFamilyMemberTx(ctx, tx, family.ID, invitation.InviterID); err != nil {
+		if errors.Is(err, repositories.ErrFamilyMemberNotFound) {
+			return nil, ErrInviterNotInFamily
+		}
+		return nil, err
 	}
 
-	memberCount, err := s.familyRepo.CountFamilyMembers(req.FamilyID)
+	memberCount, err := s.familyRepo.CountFamilyMembersTx(ctx, tx, family.ID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to count family members: %w", err)
+		return nil, err
 	}
 	if memberCount >= maxFamilyMembers {
 		return nil, ErrFamilyMemberLimitReached
@@ -191,19 +252,27 @@ func (s *FamilyService) JoinFamilyViaInvite(req *models.FamilyInviteRequest, use
 
 	member := &models.FamilyMember{
 		ID:       utils.GenerateULID(),
-		FamilyID: req.FamilyID,
+		FamilyID: family.ID,
 		UserID:   userID,
 		Role:     models.FamilyRoleMember,
 		Status:   models.FamilyMemberStatusActive,
 	}
 
-	if err := s.familyRepo.AddFamilyMember(member); err != nil {
+	if err = s.familyRepo.AddFamilyMemberTx(ctx, tx, member); err != nil {
 		if errors.Is(err, repositories.ErrFamilyMemberExists) {
 			return nil, ErrUserAlreadyInFamily
 		}
 		return nil, fmt.Errorf("failed to add family member: %w", err)
 	}
 
+	if err = s.invitationRepo.IncrementUsedTx(ctx, tx, invitation.ID); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit transaction failed: %w", err)
+	}
+
 	return &models.FamilyJoinResponse{
 		FamilyID:   member.FamilyID,
 		MemberRole: member.Role,
@@ -228,3 +297,181 @@ func (s *FamilyService) GetFamilyMembers(userID string) ([]*models.FamilyMemberI
 
 	return members, nil
 }
+
+// UpdateMemberRole 修改成员角色（仅owner），设为owner即转让家庭
+func (s *FamilyService) UpdateMemberRole(userID, targetUserID string, req *models.UpdateFamilyMemberRoleRequest) ([]*models.FamilyMemberInfo, error) {
+	if req.Role == models.FamilyRoleOwner {
+		return s.TransferOwnership(userID, &models.TransferFamilyOwnershipRequest{UserID: targetUserID})
+	}
+
+	// owner 只能通过转让家庭降级
+	if targetUserID == userID {
+		return nil, ErrOwnerMustTransfer
+	}
+
+	return s.runOwnerTx(userID, func(ctx context.Context, tx *sql.Tx, family *models.Family) error {
+		if _, err := s.familyRepo.GetFamilyMemberTx(ctx, tx, family.ID, targetUserID); err != nil {
+			return err
+		}
+		return s.familyRepo.UpdateMemberRoleTx(ctx, tx, family.ID, targetUserID, req.Role)
+	})
+}
+
+// RemoveMember 移除家庭成员（仅owner）
+func (s *FamilyService) RemoveMember(userID, targetUserID string) ([]*models.FamilyMemberInfo, error) {
+	if targetUserID == userID {
+		return nil, ErrCannotRemoveSelf
+	}
+
+	return s.runOwnerTx(userID, func(ctx context.Context, tx *sql.Tx, family *models.Family) error {
+		return s.familyRepo.DeactivateMemberTx(ctx, tx, family.ID, targetUserID)
+	})
+}
+
+// TransferOwnership 转让家庭（仅owner），原owner降为普通成员
+func (s *FamilyService) TransferOwnership(userID string, req *models.TransferFamilyOwnershipRequest) ([]*models.FamilyMemberInfo, error) {
+	if req.UserID == userID {
+		return nil, ErrCannotTransferToSelf
+	}
+
+	return s.runOwnerTx(userID, func(ctx context.Context, tx *sql.Tx, family *models.Family) error {
+		return s.transferOwnershipTx(ctx, tx, family, req.UserID)
+	})
+}
+
+// LeaveFamily 成员主动退出家庭，owner退出时需同时转让家庭
+func (s *FamilyService) LeaveFamily(userID string, req *models.LeaveFamilyRequest) (err error) {
+	family, err := s.familyRepo.GetFamilyByUserID(userID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrFamilyNotFound) {
+			return ErrFamilyNotFound
+		}
+		return fmt.Errorf("failed to get family: %w", err)
+	}
+
+	ctx := context.Background()
+	tx, err := s.familyRepo.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction failed: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	family, err = s.familyRepo.LockFamilyTx(ctx, tx, family.ID)
+	if err != nil {
+		return mapFamilyRepoError(err)
+	}
+
+	if family.OwnerID == userID {
+		var memberCount int
+		memberCount, err = s.familyRepo.CountFamilyMembersTx(ctx, tx, family.ID)
+		if err != nil {
+			return err
+		}
+		if memberCount <= 1 {
+			return ErrOwnerLastMember
+		}
+		if req.NewOwnerID == "" {
+			return ErrOwnerMustTransfer
+		}
+		if req.NewOwnerID == userID {
+			return ErrCannotTransferToSelf
+		}
+		if err = s.transferOwnershipTx(ctx, tx, family, req.NewOwnerID); err != nil {
+			return mapFamilyRepoError(err)
+		}
+	}
+
+	if err = s.familyRepo.DeactivateMemberTx(ctx, tx, family.ID, userID); err != nil {
+		return mapFamilyRepoError(err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction failed: %w", err)
+	}
+
+	return nil
+}
+
+// runOwnerTx 在事务内锁定家庭、校验owner身份后执行操作，成功后返回最新成员列表
+func (s *FamilyService) runOwnerTx(userID string, fn func(ctx context.Context, tx *sql.Tx, family *models.Family) error) (members []*models.FamilyMemberInfo, err error) {
+	family, err := s.familyRepo.GetFamilyByUserID(userID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrFamilyNotFound) {
+			return nil, ErrFamilyNotFound
+		}
+		return nil, fmt.Errorf("failed to get family: %w", err)
+	}
+
+	ctx := context.Background()
+	tx, err := s.familyRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction failed: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	family, err = s.familyRepo.LockFamilyTx(ctx, tx, family.ID)
+	if err != nil {
+		return nil, mapFamilyRepoError(err)
+	}
+	if family.OwnerID != userID {
+		return nil, ErrNotFamilyOwner
+	}
+
+	if err = fn(ctx, tx, family); err != nil {
+		return nil, mapFamilyRepoError(err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit transaction failed: %w", err)
+	}
+
+	members, err = s.familyRepo.GetFamilyMembers(family.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get family members: %w", err)
+	}
+
+	return members, nil
+}
+
+// transferOwnershipTx 在事务内转让家庭，保持 families.owner_id 与成员角色一致
+func (s *FamilyService) transferOwnershipTx(ctx context.Context, tx *sql.Tx, family *models.Family, newOwnerID string) error {
+	if _, err := s.familyRepo.GetFamilyMemberTx(ctx, tx, family.ID, newOwnerID); err != nil {
+		return err
+	}
+
+	if err := s.familyRepo.UpdateMemberRoleTx(ctx, tx, family.ID, family.OwnerID, models.FamilyRoleMember); err != nil {
+		return err
+	}
+
+	if err := s.familyRepo.UpdateMemberRoleTx(ctx, tx, family.ID, newOwnerID, models.FamilyRoleOwner); err != nil {
+		return err
+	}
+
+	if err := s.familyRepo.UpdateFamilyOwnerTx(ctx, tx, family.ID, newOwnerID); err != nil {
+		return err
+	}
+
+	family.OwnerID = newOwnerID
+	return nil
+}
+
+func mapFamilyRepoError(err error) error {
+	switch {
+	case errors.Is(err, repositories.ErrFamilyNotFound):
+		return ErrFamilyNotFound
+	case errors.Is(err, repositories.ErrFamilyMemberNotFound):
+		return ErrFamilyMemberNotFound
+	default:
+		return err
+	}
+}
@@ -0,0 +1,272 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"onetaste-family/backend/internal/models"
+	"onetaste-family/backend/internal/repositories"
+	"onetaste-family/backend/internal/utils"
+	"onetaste-family/backend/pkg/aiclient"
+)
+
+var (
+	// ErrHealthRecordNotFound 身体状况记录不存在或无权查看
+	ErrHealthRecordNotFound = errors.New("health record not found")
+	// ErrEmptyHealthRecord 身体状况记录内容为空
+	ErrEmptyHealthRecord = errors.New("empty health record")
+	// ErrHealthRecordAccessDenied 无权查看或分析该成员的身体状况记录
+	ErrHealthRecordAccessDenied = errors.New("health record access denied")
+)
+
+// HealthRecordService 身体状况记录业务逻辑
+// 记录仅本人可见；成员开启公开后，家庭管理员可以查看，但不能新增或分析。
+type HealthRecordService struct {
+	healthRepo *repositories.HealthRecordRepository
+	familyRepo *repositories.FamilyRepository
+	userRepo   *repositories.UserRepository
+}
+
+// NewHealthRecordService 创建 HealthRecordService
+func NewHealthRecordService() *HealthRecordService {
+	return &HealthRecordService{
+		healthRepo: repositories.NewHealthRecordRepository(),
+		familyRepo: repositories.NewFamilyRepository(),
+		userRepo:   repositories.NewUserRepository(),
+	}
+}
+
+// CreateRecord 记录本人当前的身体状况
+func (s *HealthRecordService) CreateRecord(userID string, req *models.CreateHealthRecordRequest) (*models.HealthRecord, error) {
+	family, err := s.getFamilyForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	record := &models.HealthRecord{
+		ID:              utils.GenerateULID(),
+		UserID:          userID,
+		FamilyID:        family.ID,
+		Diseases:        uniqueStrings(trimStrings(req.Diseases)),
+		WorkStatus:      req.WorkStatus,
+		StressLevel:     req.StressLevel,
+		BodyFeelings:    strings.TrimSpace(req.BodyFeelings),
+		Recommendations: []string{},
+		DietSuggestions: []string{},
+	}
+	if len(record.Diseases) == 0 && record.WorkStatus == "" && record.StressLevel == "" && record.BodyFeelings == "" {
+		return nil, ErrEmptyHealthRecord
+	}
+
+	raw, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode health record: %w", err)
+	}
+	record.RawData = string(raw)
+
+	if err := s.healthRepo.Create(record); err != nil {
+		return nil, err
+	}
+
+	record.RawData = ""
+	return record, nil
+}
+
+// ListRecords 分页获取本人或已公开成员的身体状况记录
+func (s *HealthRecordService) ListRecords(userID string, req *models.HealthRecordListRequest) (*models.HealthRecordListResponse, error) {
+	family, err := s.getFamilyForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	targetID := req.UserID
+	if targetID == "" {
+		targetID = userID
+	}
+	if err := s.checkViewPermission(family, userID, targetID); err != nil {
+		return nil, err
+	}
+
+	records, total, err := s.healthRepo.ListByUser(family.ID, targetID, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.HealthRecordListResponse{
+		Records:  records,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, nil
+}
+
+// GetRecord 获取一条身体状况记录，无权查看时按不存在处理
+func (s *HealthRecordService) GetRecord(userID, recordID string) (*models.HealthRecord, error) {
+	family, err := s.getFamilyForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getVisibleRecord(family, userID, recordID)
+}
+
+// AnalyzeRecord 由AI分析本人的一条身体状况记录，并将分析结果保存到该记录
+func (s *HealthRecordService) AnalyzeRecord(ctx context.Context, userID, recordID string) (*models.HealthRecordAnalysisResponse, error) {
+	family, err := s.getFamilyForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := s.getVisibleRecord(family, userID, recordID)
+	if err != nil {
+		return nil, err
+	}
+	if record.UserID != userID {
+		return nil, ErrHealthRecordAccessDenied
+	}
+
+	profile := aiclient.MemberProfile{
+		UserID:       userID,
+		Diseases:     record.Diseases,
+		WorkStatus:   record.WorkStatus,
+		StressLevel:  record.StressLevel,
+		BodyFeelings: record.BodyFeelings,
+	}
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil && !errors.Is(err, repositories.ErrUserNotFound) {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user != nil {
+		profile.Nickname = user.Nickname
+		profile.DietaryPreferences = user.DietaryPreferences
+	}
+
+	result, err := aiclient.GetClient().AnalyzeHealth(ctx, &aiclient.HealthAnalyzeRequest{Member: profile})
+	if err != nil {
+		return nil, aiServiceError(err)
+	}
+
+	analysis := strings.TrimSpace(result.Analysis)
+	if analysis == "" {
+		return nil, fmt.Errorf("%w: empty analysis", ErrAIInvalidResult)
+	}
+
+	analyzedAt := time.Now()
+	record.AnalysisResult = analysis
+	record.Recommendations = trimStrings(result.Recommendations)
+	record.DietSuggestions = trimStrings(result.DietSuggestions)
+	record.AnalyzedAt = &analyzedAt
+
+	if err := s.healthRepo.UpdateAnalysis(record); err != nil {
+		if errors.Is(err, repositories.ErrHealthRecordNotFound) {
+			return nil, ErrHealthRecordNotFound
+		}
+		return nil, err
+	}
+
+	return &models.HealthRecordAnalysisResponse{Record: record}, nil
+}
+
+// GetSharing 获取本人是否向家庭管理员公开身体状况记录
+func (s *HealthRecordService) GetSharing(userID string) (*models.HealthSharingResponse, error) {
+	family, err := s.getFamilyForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	shared, err := s.familyRepo.GetHealthSharing(family.ID, userID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrFamilyMemberNotFound) {
+			return nil, ErrFamilyNotFound
+		}
+		return nil, err
+	}
+
+	return &models.HealthSharingResponse{Shared: shared}, nil
+}
+
+// UpdateSharing 设置本人是否向家庭管理员公开身体状况记录
+func (s *HealthRecordService) UpdateSharing(userID string, shared bool) (*models.HealthSharingResponse, error) {
+	family, err := s.getFamilyForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.familyRepo.UpdateHealthSharing(family.ID, userID, shared); err != nil {
+		if errors.Is(err, repositories.ErrFamilyMemberNotFound) {
+			return nil, ErrFamilyNotFound
+		}
+		return nil, err
+	}
+
+	return &models.HealthSharingResponse{Shared: shared}, nil
+}
+
+// getVisibleRecord 获取调用者可查看的记录
+func (s *HealthRecordService) getVisibleRecord(family *models.Family, userID, recordID string) (*models.HealthRecord, error) {
+	record, err := s.healthRepo.GetByID(recordID, family.ID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrHealthRecordNotFound) {
+			return nil, ErrHealthRecordNotFound
+		}
+		return nil, err
+	}
+
+	if err := s.checkViewPermission(family, userID, record.UserID); err != nil {
+		if errors.Is(err, ErrHealthRecordAccessDenied) || errors.Is(err, ErrFamilyMemberNotFound) {
+			return nil, ErrHealthRecordNotFound
+		}
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// checkViewPermission 本人可查看自己的记录；家庭管理员可查看已开启公开的成员记录
+func (s *HealthRecordService) checkViewPermission(family *models.Family, userID, targetID string) error {
+	if targetID == userID {
+		return nil
+	}
+	if family.OwnerID != userID {
+		return ErrHealthRecordAccessDenied
+	}
+
+	shared, err := s.familyRepo.GetHealthSharing(family.ID, targetID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrFamilyMemberNotFound) {
+			return ErrFamilyMemberNotFound
+		}
+		return err
+	}
+	if !shared {
+		return ErrHealthRecordAccessDenied
+	}
+
+	return nil
+}
+
+func (s *HealthRecordService) getFamilyForUser(userID string) (*models.Family, error) {
+	family, err := s.familyRepo.GetFamilyByUserID(userID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrFamilyNotFound) {
+			return nil, ErrFamilyNotFound
+		}
+		return nil, fmt.Errorf("failed to get family: %w", err)
+	}
+	return family, nil
+}
+
+// trimStrings 去掉空白项
+func trimStrings(values []string) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
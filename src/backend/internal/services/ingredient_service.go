@@ -1,11 +1,22 @@
 package services
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
 	"onetaste-family/backend/internal/models"
 	"onetaste-family/backend/internal/repositories"
+	"onetaste-family/backend/internal/utils"
+)
+
+var (
+	// ErrIngredientNotFound 基础食材不存在或已停用
+	ErrIngredientNotFound = errors.New("ingredient not found")
+	// ErrUnknownUnit 无法识别的单位
+	ErrUnknownUnit = errors.New("unknown unit")
+	// ErrIncompatibleUnits 单位之间无法换算
+	ErrIncompatibleUnits = errors.New("incompatible units")
 )
 
 // IngredientService 食材服务
@@ -47,3 +58,80 @@ func (s *IngredientService) GetIngredientsByCategory(req *models.IngredientCateg
 		Total:    total,
 	}, nil
 }
+
+// ListUnits 获取支持换算的单位
+func (s *IngredientService) ListUnits() *models.UnitListResponse {
+	units := utils.ListUnits()
+	items := make([]*models.UnitInfo, 0, len(units))
+	for _, unit := range units {
+		aliases := unit.Aliases
+		if aliases == nil {
+			aliases = []string{}
+		}
+		items = append(items, &models.UnitInfo{
+			Name:      unit.Name,
+			Dimension: string(unit.Dimension),
+			Factor:    unit.Factor,
+			Aliases:   aliases,
+		})
+	}
+
+	return &models.UnitListResponse{Units: items}
+}
+
+// ConvertUnit 单位换算预览，提供食材时使用其密度与单个重量
+func (s *IngredientService) ConvertUnit(req *models.UnitConvertRequest) (*models.UnitConvertResponse, error) {
+	ingredientID := strings.TrimSpace(req.IngredientID)
+	toUnit := strings.TrimSpace(req.ToUnit)
+	profile := utils.UnitProfile{}
+
+	if ingredientID != "" {
+		ingredients, err := s.ingredientRepo.GetActiveByIDs([]string{ingredientID})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ingredient: %w", err)
+		}
+		ingredient, ok := ingredients[ingredientID]
+		if !ok {
+			return nil, ErrIngredientNotFound
+		}
+		profile = utils.UnitProfile{Density: ingredient.Density, PieceWeight: ingredient.PieceWeight}
+		if toUnit == "" {
+			toUnit = strings.TrimSpace(ingredient.DefaultUnit)
+		}
+	}
+
+	if toUnit == "" {
+		return nil, ErrUnknownUnit
+	}
+
+	result, err := utils.ConvertUnit(req.Amount, req.FromUnit, toUnit, profile)
+	if err != nil {
+		if errors.Is(err, utils.ErrUnknownUnit) {
+			return nil, ErrUnknownUnit
+		}
+		return nil, ErrIncompatibleUnits
+	}
+
+	return &models.UnitConvertResponse{
+		IngredientID: ingredientID,
+		Amount:       req.Amount,
+		FromUnit:     strings.TrimSpace(req.FromUnit),
+		ToUnit:       toUnit,
+		Result:       roundAmount(result),
+	}, nil
+}
+
+// normalizeIngredientAmount 将数量换算为食材推荐单位，无法换算时保留原数量与单位
+func normalizeIngredientAmount(amount float64, unit, defaultUnit string, profile utils.UnitProfile) (float64, string) {
+	unit = strings.TrimSpace(unit)
+	defaultUnit = strings.TrimSpace(defaultUnit)
+	if defaultUnit == "" || unit == "" {
+		return amount, unit
+	}
+
+	converted, err := utils.ConvertUnit(amount, unit, defaultUnit, profile)
+	if err != nil {
+		return amount, unit
+	}
+	return converted, defaultUnit
+}
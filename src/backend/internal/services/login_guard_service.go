@@ -0,0 +1,214 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"onetaste-family/backend/internal/models"
+	"onetaste-family/backend/pkg/cache"
+)
+
+const (
+	loginFailPhoneKeyPrefix  = "login_fail:phone:"
+	loginFailIPKeyPrefix     = "login_fail:ip:"
+	loginBlockPhoneKeyPrefix = "login_block:phone:"
+	loginBlockIPKeyPrefix    = "login_block:ip:"
+
+	// loginFailWindow 失败计数的统计窗口，从最近一次失败开始计算
+	loginFailWindow = time.Hour
+	// loginCaptchaThreshold 同一手机号失败达到该次数后需要图形验证码
+	loginCaptchaThreshold = 3
+	// loginIPCaptchaThreshold 同一IP失败达到该次数后需要图形验证码
+	loginIPCaptchaThreshold = 10
+	// loginBackoffThreshold 同一手机号失败达到该次数后开始指数退避
+	loginBackoffThreshold = 5
+	loginBackoffBase      = 30 * time.Second
+	loginBackoffMax       = 10 * time.Minute
+	// loginMaxFailures 同一手机号失败达到该次数后临时锁定
+	loginMaxFailures = 10
+	// loginIPMaxFailures 同一IP失败达到该次数后临时锁定
+	loginIPMaxFailures = 50
+	loginLockDuration  = 30 * time.Minute
+)
+
+// 限制键中保存的限制类型
+const (
+	loginBlockBackoff = "backoff"
+	loginBlockLocked  = "locked"
+)
+
+// LoginGuardError 登录被防护规则拒绝，携带当前的限制信息
+type LoginGuardError struct {
+	Err    error
+	Status *models.LoginGuardStatus
+}
+
+func (e *LoginGuardError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *LoginGuardError) Unwrap() error {
+	return e.Err
+}
+
+// LoginGuardService 登录防护服务
+// 按手机号与IP分别统计登录失败次数：失败几次后需要图形验证码，
+// 继续失败则按指数退避要求等待，达到上限后临时锁定。
+type LoginGuardService struct {
+	redis *redis.Client
+}
+
+// NewLoginGuardService 创建登录防护服务
+func NewLoginGuardService() *LoginGuardService {
+	return &LoginGuardService{
+		redis: cache.GetRedis(),
+	}
+}
+
+// Check 登录前检查手机号与IP是否处于退避或锁定中
+// 未被限制时返回当前防护状态，由调用方根据 CaptchaRequired 决定是否校验验证码
+func (s *LoginGuardService) Check(ctx context.Context, phone, ip string) (*models.LoginGuardStatus, error) {
+	if s.redis == nil {
+		return nil, errors.New("redis client is not initialized")
+	}
+
+	pipe := s.redis.Pipeline()
+	phoneFailures := pipe.Get(ctx, loginFailPhoneKeyPrefix+phone)
+	phoneBlock := pipe.Get(ctx, loginBlockPhoneKeyPrefix+phone)
+	phoneBlockTTL := pipe.TTL(ctx, loginBlockPhoneKeyPrefix+phone)
+	var ipFailures, ipBlock *redis.StringCmd
+	var ipBlockTTL *redis.DurationCmd
+	if ip != "" {
+		ipFailures = pipe.Get(ctx, loginFailIPKeyPrefix+ip)
+		ipBlock = pipe.Get(ctx, loginBlockIPKeyPrefix+ip)
+		ipBlockTTL = pipe.TTL(ctx, loginBlockIPKeyPrefix+ip)
+	}
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("failed to get login guard state: %w", err)
+	}
+
+	status := buildLoginGuardStatus(parseLoginCounter(phoneFailures), parseLoginCounter(ipFailures))
+
+	if ipBlock != nil && ipBlock.Val() == loginBlockLocked {
+		return nil, newLoginGuardError(ErrAccountLocked, status, ipBlockTTL.Val())
+	}
+	switch phoneBlock.Val() {
+	case loginBlockLocked:
+		return nil, newLoginGuardError(ErrAccountLocked, status, phoneBlockTTL.Val())
+	case loginBlockBackoff:
+		return nil, newLoginGuardError(ErrLoginTooFrequent, status, phoneBlockTTL.Val())
+	}
+
+	return status, nil
+}
+
+// RecordFailure 记录一次登录失败，按失败次数设置退避或锁定，返回最新防护状态
+func (s *LoginGuardService) RecordFailure(ctx context.Context, phone, ip string) (*models.LoginGuardStatus, error) {
+	if s.redis == nil {
+		return nil, errors.New("redis client is not initialized")
+	}
+
+	pipe := s.redis.TxPipeline()
+	phoneFailures := pipe.Incr(ctx, loginFailPhoneKeyPrefix+phone)
+	pipe.Expire(ctx, loginFailPhoneKeyPrefix+phone, loginFailWindow)
+	var ipFailures *redis.IntCmd
+	if ip != "" {
+		ipFailures = pipe.Incr(ctx, loginFailIPKeyPrefix+ip)
+		pipe.Expire(ctx, loginFailIPKeyPrefix+ip, loginFailWindow)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to record login failure: %w", err)
+	}
+
+	phoneCount := int(phoneFailures.Val())
+	ipCount := 0
+	if ipFailures != nil {
+		ipCount = int(ipFailures.Val())
+	}
+	status := buildLoginGuardStatus(phoneCount, ipCount)
+
+	if ipCount >= loginIPMaxFailures {
+		if err := s.redis.Set(ctx, loginBlockIPKeyPrefix+ip, loginBlockLocked, loginLockDuration).Err(); err != nil {
+			return nil, fmt.Errorf("failed to lock ip: %w", err)
+		}
+		status.RetryAfter = int64(loginLockDuration.Seconds())
+	}
+
+	if kind, delay := loginPhoneBlock(phoneCount); delay > 0 {
+		if err := s.redis.Set(ctx, loginBlockPhoneKeyPrefix+phone, kind, delay).Err(); err != nil {
+			return nil, fmt.Errorf("failed to block phone: %w", err)
+		}
+		if seconds := int64(delay.Seconds()); seconds > status.RetryAfter {
+			status.RetryAfter = seconds
+		}
+	}
+
+	return status, nil
+}
+
+// Reset 登录成功后清除手机号的失败记录，IP 计数随窗口自然过期
+func (s *LoginGuardService) Reset(ctx context.Context, phone string) error {
+	if s.redis == nil {
+		return errors.New("redis client is not initialized")
+	}
+
+	if err := s.redis.Del(ctx, loginFailPhoneKeyPrefix+phone, loginBlockPhoneKeyPrefix+phone).Err(); err != nil {
+		return fmt.Errorf("failed to reset login failures: %w", err)
+	}
+
+	return nil
+}
+
+// loginPhoneBlock 根据手机号累计失败次数计算限制类型与时长
+func loginPhoneBlock(failures int) (string, time.Duration) {
+	if failures >= loginMaxFailures {
+		return loginBlockLocked, loginLockDuration
+	}
+	if failures < loginBackoffThreshold {
+		return "", 0
+	}
+
+	delay := loginBackoffBase << (failures - loginBackoffThreshold)
+	if delay > loginBackoffMax {
+		delay = loginBackoffMax
+	}
+	return loginBlockBackoff, delay
+}
+
+func buildLoginGuardStatus(phoneFailures, ipFailures int) *models.LoginGuardStatus {
+	remaining := loginMaxFailures - phoneFailures
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	return &models.LoginGuardStatus{
+		CaptchaRequired:   phoneFailures >= loginCaptchaThreshold || ipFailures >= loginIPCaptchaThreshold,
+		RemainingAttempts: remaining,
+	}
+}
+
+func newLoginGuardError(err error, status *models.LoginGuardStatus, retryAfter time.Duration) *LoginGuardError {
+	// TTL 为负表示键没有过期时间或已过期，至少提示等待1秒
+	seconds := int64((retryAfter + time.Second - 1) / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+	status.RetryAfter = seconds
+	return &LoginGuardError{Err: err, Status: status}
+}
+
+func parseLoginCounter(cmd *redis.StringCmd) int {
+	if cmd == nil {
+		return 0
+	}
+	count, err := strconv.Atoi(cmd.Val())
+	if err != nil {
+		return 0
+	}
+	return count
+}
@@ -0,0 +1,198 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"onetaste-family/backend/internal/models"
+	"onetaste-family/backend/internal/repositories"
+	"onetaste-family/backend/internal/utils"
+)
+
+var (
+	// ErrInvalidMembershipPlan 会员套餐不存在
+	ErrInvalidMembershipPlan = errors.New("invalid membership plan")
+)
+
+// membershipTiers 各会员等级的权益上限
+var membershipTiers = map[string]models.MembershipTier{
+	models.MembershipTierFree: {
+		Type:      models.MembershipTierFree,
+		MaxDishes: 30,
+	},
+	models.MembershipTierPremium: {
+		Type:      models.MembershipTierPremium,
+		MaxDishes: 60,
+	},
+}
+
+// MembershipService 会员业务逻辑层
+// 会员权益挂在家庭上：家庭存在有效会员记录时为付费版，否则为免费版。
+// 权益上限每次按当前时间实时计算，families.max_dishes 仅作为展示用的同步副本。
+type MembershipService struct {
+	membershipRepo *repositories.MembershipRepository
+	familyRepo     *repositories.FamilyRepository
+}
+
+// NewMembershipService 创建MembershipService
+func NewMembershipService() *MembershipService {
+	return &MembershipService{
+		membershipRepo: repositories.NewMembershipRepository(),
+		familyRepo:     repositories.NewFamilyRepository(),
+	}
+}
+
+// FreeTier 免费版权益
+func FreeTier() models.MembershipTier {
+	return membershipTiers[models.MembershipTierFree]
+}
+
+// FamilyTier 计算家庭当前生效的会员等级，返回生效中的会员记录（免费版为nil）
+func (s *MembershipService) FamilyTier(familyID string) (models.MembershipTier, *models.Membership, error) {
+	membership, err := s.membershipRepo.GetActiveByFamily(familyID, membershipNow())
+	if err != nil {
+		if errors.Is(err, repositories.ErrMembershipNotFound) {
+			return FreeTier(), nil, nil
+		}
+		return models.MembershipTier{}, nil, err
+	}
+
+	return membershipTiers[models.MembershipTierPremium], membership, nil
+}
+
+// GetMembership 获取用户当前的会员信息，未加入家庭的用户视为免费版
+func (s *MembershipService) GetMembership(userID string) (*models.MembershipResponse, error) {
+	family, err := s.familyRepo.GetFamilyByUserID(userID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrFamilyNotFound) {
+			tier := FreeTier()
+			return &models.MembershipResponse{Type: tier.Type, Features: tier}, nil
+		}
+		return nil, fmt.Errorf("failed to get family: %w", err)
+	}
+
+	tier, membership, err := s.FamilyTier(family.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve membership tier: %w", err)
+	}
+
+	_, dishCount, err := s.familyRepo.GetFamilyStats(family.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get family stats: %w", err)
+	}
+
+	resp := &models.MembershipResponse{
+		Type:      tier.Type,
+		FamilyID:  family.ID,
+		Features:  tier,
+		DishCount: dishCount,
+	}
+	if membership != nil {
+		expiresAt, err := s.latestExpiry(family.ID, membership)
+		if err != nil {
+			return nil, err
+		}
+		resp.PlanType = membership.PlanType
+		resp.ExpiresAt = &expiresAt
+	}
+
+	return resp, nil
+}
+
+// ActivateMembershipTx 在事务内为家庭开通或续费会员
+// 家庭已有有效会员时从最晚到期时间顺延，开通后同步家庭的菜式数量上限
+func (s *MembershipService) ActivateMembershipTx(ctx context.Context, tx *sql.Tx, userID, familyID, planType string) (*models.Membership, error) {
+	now := membershipNow()
+	startedAt := now
+
+	latest, err := s.membershipRepo.GetLatestActiveByFamilyTx(ctx, tx, familyID, now)
+	if err != nil && !errors.Is(err, repositories.ErrMembershipNotFound) {
+		return nil, err
+	}
+	if latest != nil {
+		startedAt = latest.ExpiresAt
+	}
+
+	expiresAt, err := membershipPlanEnd(planType, startedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	membership := &models.Membership{
+		ID:        utils.GenerateULID(),
+		UserID:    userID,
+		FamilyID:  familyID,
+		PlanType:  planType,
+		Status:    models.MembershipStatusActive,
+		StartedAt: startedAt,
+		ExpiresAt: expiresAt,
+	}
+	if err := s.membershipRepo.CreateTx(ctx, tx, membership); err != nil {
+		return nil, err
+	}
+
+	premium := membershipTiers[models.MembershipTierPremium]
+	if err := s.familyRepo.UpdateMaxDishesTx(ctx, tx, familyID, premium.MaxDishes); err != nil {
+		return nil, err
+	}
+
+	return membership, nil
+}
+
+// ExpireMemberships 将到期的会员标记为过期并重新计算相关家庭的权益上限，返回受影响的家庭数量
+func (s *MembershipService) ExpireMemberships() (int, error) {
+	familyIDs, err := s.membershipRepo.ExpireDue(membershipNow())
+	if err != nil {
+		return 0, err
+	}
+
+	var errs []error
+	for _, familyID := range familyIDs {
+		if err := s.SyncFamilyLimits(familyID); err != nil {
+			errs = append(errs, fmt.Errorf("sync family %s: %w", familyID, err))
+		}
+	}
+
+	return len(familyIDs), errors.Join(errs...)
+}
+
+// SyncFamilyLimits 按家庭当前会员等级刷新 families.max_dishes
+func (s *MembershipService) SyncFamilyLimits(familyID string) error {
+	tier, _, err := s.FamilyTier(familyID)
+	if err != nil {
+		return err
+	}
+	return s.familyRepo.UpdateMaxDishes(familyID, tier.MaxDishes)
+}
+
+// latestExpiry 返回家庭连续续费后的最终到期时间
+func (s *MembershipService) latestExpiry(familyID string, current *models.Membership) (time.Time, error) {
+	latest, err := s.membershipRepo.GetLatestActiveByFamily(familyID, membershipNow())
+	if err != nil {
+		if errors.Is(err, repositories.ErrMembershipNotFound) {
+			return current.ExpiresAt, nil
+		}
+		return time.Time{}, err
+	}
+
+	return latest.ExpiresAt, nil
+}
+
+// membershipPlanEnd 根据套餐计算到期时间
+func membershipPlanEnd(planType string, startedAt time.Time) (time.Time, error) {
+	switch planType {
+	case models.MembershipPlanMonthly:
+		return startedAt.AddDate(0, 1, 0), nil
+	case models.MembershipPlanYearly:
+		return startedAt.AddDate(1, 0, 0), nil
+	default:
+		return time.Time{}, ErrInvalidMembershipPlan
+	}
+}
+
+func membershipNow() time.Time {
+	return time.Now().UTC()
+}
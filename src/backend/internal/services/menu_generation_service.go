@@ -0,0 +1,511 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"onetaste-family/backend/internal/models"
+	"onetaste-family/backend/internal/repositories"
+	"onetaste-family/backend/internal/utils"
+	"onetaste-family/backend/pkg/aiclient"
+)
+
+// maxMenuGenerationDays 单次生成菜单的最大天数
+const maxMenuGenerationDays = 14
+
+var (
+	// ErrInvalidMenuDateRange 生成菜单的日期范围非法
+	ErrInvalidMenuDateRange = errors.New("invalid menu date range")
+	// ErrEmptyDishLibrary 食谱库为空，无法生成菜单
+	ErrEmptyDishLibrary = errors.New("empty dish library")
+	// ErrMenuGenerationNotFound 菜单生成草稿不存在
+	ErrMenuGenerationNotFound = errors.New("menu generation not found")
+	// ErrMenuGenerationNotDraft 草稿已确认或已放弃
+	ErrMenuGenerationNotDraft = errors.New("menu generation is not a draft")
+	// ErrMenuGenerationConflict 草稿会覆盖手动创建的菜单，需要用户确认
+	ErrMenuGenerationConflict = errors.New("menu generation conflicts with manual menus")
+)
+
+// MenuGenerationConflictError 确认草稿时与手动菜单冲突，携带冲突的餐次
+type MenuGenerationConflictError struct {
+	Conflicts []*models.MenuGenerationSlot
+}
+
+func (e *MenuGenerationConflictError) Error() string {
+	return fmt.Sprintf("%s: %d slots", ErrMenuGenerationConflict.Error(), len(e.Conflicts))
+}
+
+func (e *MenuGenerationConflictError) Unwrap() error {
+	return ErrMenuGenerationConflict
+}
+
+// GenerateMenus 调用AI服务生成一段时间的菜单草稿
+// 草稿不会直接写入菜单，需调用 ConfirmGeneration 确认后才生效
+func (s *MenuService) GenerateMenus(ctx context.Context, userID string, req *models.GenerateMenuRequest) (*models.MenuGenerationResponse, error) {
+	family, err := s.getFamilyForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	startDate, endDate, err := parseGenerationRange(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	mealTypes := normalizeMealTypes(req.MealTypes)
+
+	dishes, err := s.dishRepo.ListPlanningDishes(family.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dishes: %w", err)
+	}
+	if len(dishes) == 0 {
+		return nil, ErrEmptyDishLibrary
+	}
+
+	members, err := s.buildMemberProfiles(family.ID, userID, req.MemberIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	aiReq := &aiclient.MenuGenerateRequest{
+		StartDate: formatDate(startDate),
+		EndDate:   formatDate(endDate),
+		MealTypes: mealTypes,
+		Members:   members,
+		Dishes:    make([]aiclient.DishCandidate, 0, len(dishes)),
+	}
+	for _, dish := range dishes {
+		aiReq.Dishes = append(aiReq.Dishes, aiclient.DishCandidate{
+			DishID:      dish.ID,
+			Name:        dish.Name,
+			Category:    dish.Category,
+			Ingredients: dish.Ingredients,
+		})
+	}
+
+	aiResp, err := aiclient.GetClient().GenerateMenu(ctx, aiReq)
+	if err != nil {
+		return nil, aiServiceError(err)
+	}
+
+	generation := &models.MenuGeneration{
+		ID:        utils.GenerateULID(),
+		FamilyID:  family.ID,
+		CreatedBy: userID,
+		Source:    models.MenuSourceAI,
+		StartDate: startDate,
+		EndDate:   endDate,
+		Reason:    aiResp.Reason,
+		Status:    models.MenuGenerationStatusDraft,
+	}
+	generation.Items, err = s.validateGeneratedMenus(family.ID, startDate, endDate, mealTypes, aiResp.Menus)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.generationRepo.CreateWithItems(generation); err != nil {
+		return nil, fmt.Errorf("failed to save menu generation: %w", err)
+	}
+
+	return s.buildGenerationResponse(generation)
+}
+
+// GetGeneration 获取菜单生成草稿
+func (s *MenuService) GetGeneration(userID, generationID string) (*models.MenuGenerationResponse, error) {
+	family, err := s.getFamilyForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	generation, err := s.generationRepo.GetByID(generationID, family.ID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrMenuGenerationNotFound) {
+			return nil, ErrMenuGenerationNotFound
+		}
+		return nil, fmt.Errorf("failed to get menu generation: %w", err)
+	}
+
+	return s.buildGenerationResponse(generation)
+}
+
+// ConfirmGeneration 确认草稿并写入菜单
+// 同一餐次已有手动菜单时，只有 overwrite 为 true 才会覆盖；已有的AI菜单直接替换
+func (s *MenuService) ConfirmGeneration(ctx context.Context, userID, generationID string, req *models.ConfirmMenuGenerationRequest) (resp *models.ConfirmMenuGenerationResponse, err error) {
+	family, err := s.getFamilyForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.generationRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction failed: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	// 菜单表没有唯一约束，与接受周计划一样先锁定家庭记录，再检查餐次是否已有菜单，避免并发写入重复菜单
+	if _, err = s.familyRepo.LockFamilyTx(ctx, tx, family.ID); err != nil {
+		if errors.Is(err, repositories.ErrFamilyNotFound) {
+			return nil, ErrFamilyNotFound
+		}
+		return nil, fmt.Errorf("failed to lock family: %w", err)
+	}
+
+	generation, err := s.generationRepo.GetByIDForUpdateTx(ctx, tx, generationID, family.ID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrMenuGenerationNotFound) {
+			return nil, ErrMenuGenerationNotFound
+		}
+		return nil, fmt.Errorf("failed to get menu generation: %w", err)
+	}
+	if generation.Status != models.MenuGenerationStatusDraft {
+		return nil, ErrMenuGenerationNotDraft
+	}
+
+	// 草稿生成后菜式可能已被删除，确认前重新校验
+	if err = s.validateDishesInFamily(family.ID, generationDishIDs(generation.Items)); err != nil {
+		return nil, err
+	}
+
+	existing := make([]*models.Menu, len(generation.Items))
+	conflicts := make([]*models.MenuGenerationSlot, 0)
+	for i, item := range generation.Items {
+		menu, err := s.menuRepo.GetMenuByDateAndMealTypeTx(ctx, tx, family.ID, item.Date, item.MealType)
+		if err != nil {
+			if errors.Is(err, repositories.ErrMenuNotFound) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to check existing menu: %w", err)
+		}
+		existing[i] = menu
+		if menu.Source != models.MenuSourceAI {
+			conflicts = append(conflicts, &models.MenuGenerationSlot{
+				Date:           formatDate(item.Date),
+				MealType:       item.MealType,
+				Reason:         item.Reason,
+				ExistingMenuID: menu.ID,
+				ExistingSource: menu.Source,
+			})
+		}
+	}
+	if len(conflicts) > 0 && !req.Overwrite {
+		return nil, &MenuGenerationConflictError{Conflicts: conflicts}
+	}
+
+	menus := make([]*models.Menu, 0, len(generation.Items))
+	for i, item := range generation.Items {
+		menu := &models.Menu{
+			ID:        utils.GenerateULID(),
+			FamilyID:  family.ID,
+			Date:      item.Date,
+			MealType:  item.MealType,
+			CreatedBy: userID,
+			Source:    models.MenuSourceAI,
+		}
+
+		if existing[i] != nil {
+			menu.ID = existing[i].ID
+			menu.CreatedBy = existing[i].CreatedBy
+			menu.CreatedAt = existing[i].CreatedAt
+			menu.Servings = existing[i].Servings
+			if err = s.menuRepo.UpdateMenuWithDishesTx(ctx, tx, menu, item.DishIDs); err != nil {
+				return nil, fmt.Errorf("failed to update menu: %w", err)
+			}
+		} else if err = s.menuRepo.CreateMenuWithDishesTx(ctx, tx, menu, item.DishIDs); err != nil {
+			return nil, fmt.Errorf("failed to create menu: %w", err)
+		}
+		menus = append(menus, menu)
+	}
+
+	now := time.Now()
+	generation.Status = models.MenuGenerationStatusConfirmed
+	generation.ConfirmedAt = &now
+	if err = s.generationRepo.UpdateStatusTx(ctx, tx, generation); err != nil {
+		return nil, fmt.Errorf("failed to confirm menu generation: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit transaction failed: %w", err)
+	}
+
+	resp = &models.ConfirmMenuGenerationResponse{
+		GenerationID: generation.ID,
+		Menus:        make([]*models.MenuDetail, 0, len(menus)),
+	}
+	for _, menu := range menus {
+		detail, err := s.buildMenuDetail(menu)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build menu detail: %w", err)
+		}
+		resp.Menus = append(resp.Menus, detail)
+	}
+
+	return resp, nil
+}
+
+// DiscardGeneration 放弃草稿
+func (s *MenuService) DiscardGeneration(userID, generationID string) error {
+	family, err := s.getFamilyForUser(userID)
+	if err != nil {
+		return err
+	}
+
+	err = s.generationRepo.UpdateStatus(generationID, family.ID, models.MenuGenerationStatusDraft, models.MenuGenerationStatusDiscarded)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, repositories.ErrMenuGenerationNotFound) {
+		return fmt.Errorf("failed to discard menu generation: %w", err)
+	}
+
+	// 区分草稿不存在与草稿已不是待确认状态
+	if _, err = s.generationRepo.GetByID(generationID, family.ID); err != nil {
+		if errors.Is(err, repositories.ErrMenuGenerationNotFound) {
+			return ErrMenuGenerationNotFound
+		}
+		return fmt.Errorf("failed to get menu generation: %w", err)
+	}
+	return ErrMenuGenerationNotDraft
+}
+
+// buildMemberProfiles 组装参与生成的成员画像，memberIDs 为空时使用全部成员
+// 身体状况只包含发起人本人及已开启公开的成员，其他成员仅提供饮食偏好
+func (s *MenuService) buildMemberProfiles(familyID, userID string, memberIDs []string) ([]aiclient.MemberProfile, error) {
+	familyMembers, err := s.familyRepo.GetFamilyMembers(familyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get family members: %w", err)
+	}
+
+	nicknames := make(map[string]string, len(familyMembers))
+	for _, member := range familyMembers {
+		nicknames[member.UserID] = member.Nickname
+	}
+
+	selected := make([]string, 0, len(familyMembers))
+	if len(memberIDs) == 0 {
+		for _, member := range familyMembers {
+			selected = append(selected, member.UserID)
+		}
+	} else {
+		seen := make(map[string]bool, len(memberIDs))
+		for _, memberID := range memberIDs {
+			if _, ok := nicknames[memberID]; !ok {
+				return nil, ErrFamilyMemberNotFound
+			}
+			if !seen[memberID] {
+				seen[memberID] = true
+				selected = append(selected, memberID)
+			}
+		}
+	}
+
+	healthVisible := make([]string, 0, len(selected))
+	for _, memberID := range selected {
+		if memberID == userID {
+			healthVisible = append(healthVisible, memberID)
+			continue
+		}
+		shared, err := s.familyRepo.GetHealthSharing(familyID, memberID)
+		if err != nil {
+			if errors.Is(err, repositories.ErrFamilyMemberNotFound) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get health sharing: %w", err)
+		}
+		if shared {
+			healthVisible = append(healthVisible, memberID)
+		}
+	}
+
+	records, err := s.healthRepo.GetLatestByUsers(familyID, healthVisible)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get health records: %w", err)
+	}
+
+	profiles := make([]aiclient.MemberProfile, 0, len(selected))
+	for _, memberID := range selected {
+		profile := aiclient.MemberProfile{UserID: memberID, Nickname: nicknames[memberID]}
+
+		user, err := s.userRepo.GetByID(memberID)
+		if err != nil && !errors.Is(err, repositories.ErrUserNotFound) {
+			return nil, fmt.Errorf("failed to get member: %w", err)
+		}
+		if user != nil {
+			profile.DietaryPreferences = user.DietaryPreferences
+		}
+
+		if record, ok := records[memberID]; ok {
+			profile.Diseases = record.Diseases
+			profile.WorkStatus = record.WorkStatus
+			profile.StressLevel = record.StressLevel
+			profile.BodyFeelings = record.BodyFeelings
+		}
+
+		profiles = append(profiles, profile)
+	}
+
+	return profiles, nil
+}
+
+// validateGeneratedMenus 校验AI返回的菜单：日期与餐次必须在请求范围内且不重复，菜式必须属于该家庭
+func (s *MenuService) validateGeneratedMenus(familyID string, startDate, endDate time.Time, mealTypes []string, menus []aiclient.GeneratedMenu) ([]*models.MenuGenerationItem, error) {
+	if len(menus) == 0 {
+		return nil, fmt.Errorf("%w: no menus returned", ErrAIInvalidResult)
+	}
+
+	allowedMealTypes := make(map[string]bool, len(mealTypes))
+	for _, mealType := range mealTypes {
+		allowedMealTypes[mealType] = true
+	}
+
+	items := make([]*models.MenuGenerationItem, 0, len(menus))
+	slots := make(map[string]bool, len(menus))
+	for _, menu := range menus {
+		date, err := parseDate(menu.Date)
+		if err != nil || date.Before(startDate) || date.After(endDate) {
+			return nil, fmt.Errorf("%w: date %q out of range", ErrAIInvalidResult, menu.Date)
+		}
+		if !allowedMealTypes[menu.MealType] {
+			return nil, fmt.Errorf("%w: unexpected meal type %q", ErrAIInvalidResult, menu.MealType)
+		}
+
+		slot := menu.Date + "/" + menu.MealType
+		if slots[slot] {
+			return nil, fmt.Errorf("%w: duplicate menu %s", ErrAIInvalidResult, slot)
+		}
+		slots[slot] = true
+
+		dishIDs := uniqueStrings(menu.DishIDs)
+		if len(dishIDs) == 0 {
+			return nil, fmt.Errorf("%w: menu %s has no dishes", ErrAIInvalidResult, slot)
+		}
+
+		items = append(items, &models.MenuGenerationItem{
+			ID:       utils.GenerateULID(),
+			Date:     date,
+			MealType: menu.MealType,
+			DishIDs:  dishIDs,
+			Reason:   menu.Reason,
+		})
+	}
+
+	if err := s.validateDishesInFamily(familyID, generationDishIDs(items)); err != nil {
+		if errors.Is(err, ErrDishNotFound) || errors.Is(err, ErrDishNotInFamily) {
+			return nil, fmt.Errorf("%w: %w", ErrAIInvalidResult, err)
+		}
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// buildGenerationResponse 构建草稿响应，并标出每餐已有的菜单
+func (s *MenuService) buildGenerationResponse(generation *models.MenuGeneration) (*models.MenuGenerationResponse, error) {
+	resp := &models.MenuGenerationResponse{
+		GenerationID: generation.ID,
+		StartDate:    formatDate(generation.StartDate),
+		EndDate:      formatDate(generation.EndDate),
+		Source:       generation.Source,
+		Status:       generation.Status,
+		Reason:       generation.Reason,
+		Menus:        make([]*models.MenuGenerationSlot, 0, len(generation.Items)),
+		CreatedAt:    generation.CreatedAt,
+	}
+
+	for _, item := range generation.Items {
+		dishes, err := s.getDishSummaries(item.DishIDs, generation.FamilyID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get dish summaries: %w", err)
+		}
+
+		slot := &models.MenuGenerationSlot{
+			Date:     formatDate(item.Date),
+			MealType: item.MealType,
+			Dishes:   dishes,
+			Reason:   item.Reason,
+		}
+
+		if generation.Status == models.MenuGenerationStatusDraft {
+			menu, err := s.menuRepo.GetMenuByDateAndMealType(generation.FamilyID, item.Date, item.MealType)
+			if err != nil && !errors.Is(err, repositories.ErrMenuNotFound) {
+				return nil, fmt.Errorf("failed to check existing menu: %w", err)
+			}
+			if menu != nil {
+				slot.ExistingMenuID = menu.ID
+				slot.ExistingSource = menu.Source
+			}
+		}
+
+		resp.Menus = append(resp.Menus, slot)
+	}
+
+	return resp, nil
+}
+
+// parseGenerationRange 解析生成范围，结束日期不早于开始日期且不超过 maxMenuGenerationDays 天
+func parseGenerationRange(start, end string) (time.Time, time.Time, error) {
+	startDate, err := parseDate(start)
+	if err != nil {
+		return time.Time{}, time.Time{}, ErrInvalidMenuDate
+	}
+	endDate, err := parseDate(end)
+	if err != nil {
+		return time.Time{}, time.Time{}, ErrInvalidMenuDate
+	}
+	if endDate.Before(startDate) || endDate.After(startDate.AddDate(0, 0, maxMenuGenerationDays-1)) {
+		return time.Time{}, time.Time{}, ErrInvalidMenuDateRange
+	}
+	return startDate, endDate, nil
+}
+
+// normalizeMealTypes 去重并按早、午、晚排序，为空时返回三餐
+func normalizeMealTypes(mealTypes []string) []string {
+	all := []string{models.MealTypeBreakfast, models.MealTypeLunch, models.MealTypeDinner}
+	if len(mealTypes) == 0 {
+		return all
+	}
+
+	requested := make(map[string]bool, len(mealTypes))
+	for _, mealType := range mealTypes {
+		requested[mealType] = true
+	}
+
+	result := make([]string, 0, len(all))
+	for _, mealType := range all {
+		if requested[mealType] {
+			result = append(result, mealType)
+		}
+	}
+	return result
+}
+
+// generationDishIDs 汇总草稿中出现的全部菜式ID
+func generationDishIDs(items []*models.MenuGenerationItem) []string {
+	dishIDs := make([]string, 0)
+	for _, item := range items {
+		dishIDs = append(dishIDs, item.DishIDs...)
+	}
+	return uniqueStrings(dishIDs)
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if value == "" || seen[value] {
+			continue
+		}
+		seen[value] = true
+		result = append(result, value)
+	}
+	return result
+}
+
+// aiServiceError 将AI客户端错误归为服务不可用，保留原始错误便于记录日志
+func aiServiceError(err error) error {
+	return fmt.Errorf("%w: %w", ErrAIServiceUnavailable, err)
+}
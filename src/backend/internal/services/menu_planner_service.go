@@ -0,0 +1,395 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"onetaste-family/backend/internal/models"
+	"onetaste-family/backend/internal/repositories"
+	"onetaste-family/backend/internal/utils"
+)
+
+var (
+	// ErrInvalidMenuPlan 排菜参数或待写入的菜单非法
+	ErrInvalidMenuPlan = errors.New("invalid menu plan")
+	// ErrMenuSlotOccupied 该餐次已有菜单
+	ErrMenuSlotOccupied = errors.New("menu slot occupied")
+)
+
+// plannerDishesPerMeal 规则排菜每餐安排的菜式数量
+var plannerDishesPerMeal = map[string]int{
+	models.MealTypeBreakfast: 1,
+	models.MealTypeLunch:     2,
+	models.MealTypeDinner:    2,
+}
+
+// mealTypeLabels 餐次中文名称，用于提示信息
+var mealTypeLabels = map[string]string{
+	models.MealTypeBreakfast: "早餐",
+	models.MealTypeLunch:     "午餐",
+	models.MealTypeDinner:    "晚餐",
+}
+
+// PlanMenus 按规则为日期范围内的空餐次排菜，返回预览，不写入菜单
+// 不依赖AI服务，相同的食谱库与已有菜单总是得到相同的结果
+func (s *MenuService) PlanMenus(userID string, req *models.PlanMenuRequest) (*models.PlanMenuResponse, error) {
+	family, err := s.getFamilyForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	startDate, endDate, err := parseGenerationRange(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	mealTypes := normalizeMealTypes(req.MealTypes)
+
+	noRepeatDays := models.MenuPlanDefaultNoRepeatDays
+	if req.NoRepeatDays != nil {
+		noRepeatDays = *req.NoRepeatDays
+	}
+
+	pinnedIDs := uniqueStrings(req.PinnedDishIDs)
+	excludedIDs := uniqueStrings(req.ExcludedDishIDs)
+	excluded := make(map[string]bool, len(excludedIDs))
+	for _, dishID := range excludedIDs {
+		excluded[dishID] = true
+	}
+	for _, dishID := range pinnedIDs {
+		if excluded[dishID] {
+			return nil, ErrInvalidMenuPlan
+		}
+	}
+	if err = s.validateDishesInFamily(family.ID, append(append([]string{}, pinnedIDs...), excludedIDs...)); err != nil {
+		return nil, err
+	}
+
+	dishes, err := s.dishRepo.ListPlanningDishes(family.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dishes: %w", err)
+	}
+
+	planner := newMenuPlanner(noRepeatDays, dishes, pinnedIDs, excluded)
+	if len(planner.candidates) == 0 {
+		return nil, ErrEmptyDishLibrary
+	}
+
+	// 范围前后 noRepeatDays-1 天内的已有菜单同样参与不重复判断
+	margin := noRepeatDays - 1
+	slots, err := s.menuRepo.GetMenuSlotsByDateRange(family.ID, startDate.AddDate(0, 0, -margin), endDate.AddDate(0, 0, margin))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get menus: %w", err)
+	}
+
+	occupied := make(map[string]bool, len(slots))
+	for _, slot := range slots {
+		planner.record(daysBetween(startDate, slot.Date), slot.DishIDs)
+		if slot.Date.Before(startDate) || slot.Date.After(endDate) {
+			continue
+		}
+		occupied[formatDate(slot.Date)+"/"+slot.MealType] = true
+		// 范围内已有菜单中的指定菜式视为已安排
+		for _, dishID := range slot.DishIDs {
+			planner.placed[dishID] = true
+		}
+	}
+
+	resp := &models.PlanMenuResponse{
+		StartDate:    formatDate(startDate),
+		EndDate:      formatDate(endDate),
+		NoRepeatDays: noRepeatDays,
+		Menus:        make([]*models.PlannedMenu, 0),
+		Warnings:     make([]string, 0),
+	}
+
+	planned := make([][]string, 0)
+	for date, day := startDate, 0; !date.After(endDate); date, day = date.AddDate(0, 0, 1), day+1 {
+		for _, mealType := range mealTypes {
+			dateStr := formatDate(date)
+			if occupied[dateStr+"/"+mealType] {
+				resp.SkippedSlots++
+				continue
+			}
+
+			count := plannerDishesPerMeal[mealType]
+			dishIDs, relaxed := planner.pick(day, count)
+			switch {
+			case len(dishIDs) == 0:
+				resp.Warnings = append(resp.Warnings, fmt.Sprintf("%s %s：没有可安排的菜式", dateStr, mealTypeLabels[mealType]))
+				continue
+			case len(dishIDs) < count:
+				resp.Warnings = append(resp.Warnings, fmt.Sprintf("%s %s：可选菜式不足，仅安排了%d道", dateStr, mealTypeLabels[mealType], len(dishIDs)))
+			case relaxed:
+				resp.Warnings = append(resp.Warnings, fmt.Sprintf("%s %s：可选菜式不足，已放宽%d天内不重复的限制", dateStr, mealTypeLabels[mealType], noRepeatDays))
+			}
+
+			resp.Menus = append(resp.Menus, &models.PlannedMenu{Date: dateStr, MealType: mealType})
+			planned = append(planned, dishIDs)
+		}
+	}
+
+	for _, dishID := range pinnedIDs {
+		if !planner.placed[dishID] {
+			resp.Warnings = append(resp.Warnings, fmt.Sprintf("指定菜式「%s」无法在不重复规则内安排", planner.names[dishID]))
+		}
+	}
+
+	summaries, err := s.getDishSummaries(flattenDishIDs(planned), family.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dish summaries: %w", err)
+	}
+	summaryByID := make(map[string]*models.DishSummary, len(summaries))
+	for _, summary := range summaries {
+		summaryByID[summary.DishID] = summary
+	}
+	for i, menu := range resp.Menus {
+		menu.Dishes = make([]*models.DishSummary, 0, len(planned[i]))
+		for _, dishID := range planned[i] {
+			if summary, ok := summaryByID[dishID]; ok {
+				menu.Dishes = append(menu.Dishes, summary)
+			}
+		}
+	}
+
+	return resp, nil
+}
+
+// AcceptMenuPlan 将排菜预览写入菜单
+// 预览之后该餐次已被他人填写时整体失败，避免覆盖
+func (s *MenuService) AcceptMenuPlan(ctx context.Context, userID string, req *models.AcceptMenuPlanRequest) (resp *models.AcceptMenuPlanResponse, err error) {
+	family, err := s.getFamilyForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	menus := make([]*models.Menu, 0, len(req.Menus))
+	dishIDs := make([][]string, 0, len(req.Menus))
+	slots := make(map[string]bool, len(req.Menus))
+	for _, item := range req.Menus {
+		date, err := parseDate(item.Date)
+		if err != nil {
+			return nil, ErrInvalidMenuDate
+		}
+		if !isValidMealType(item.MealType) {
+			return nil, ErrInvalidMealType
+		}
+
+		slot := formatDate(date) + "/" + item.MealType
+		if slots[slot] {
+			return nil, ErrInvalidMenuPlan
+		}
+		slots[slot] = true
+
+		ids := uniqueStrings(item.DishIDs)
+		if len(ids) == 0 {
+			return nil, ErrInvalidDishIDs
+		}
+
+		menus = append(menus, &models.Menu{
+			ID:        utils.GenerateULID(),
+			FamilyID:  family.ID,
+			Date:      date,
+			MealType:  item.MealType,
+			CreatedBy: userID,
+			Source:    models.MenuSourceManual,
+		})
+		dishIDs = append(dishIDs, ids)
+	}
+
+	if err = s.validateDishesInFamily(family.ID, flattenDishIDs(dishIDs)); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.menuRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction failed: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	// 菜单表没有唯一约束，锁定家庭记录以串行化同一家庭的写入
+	if _, err = s.familyRepo.LockFamilyTx(ctx, tx, family.ID); err != nil {
+		if errors.Is(err, repositories.ErrFamilyNotFound) {
+			return nil, ErrFamilyNotFound
+		}
+		return nil, fmt.Errorf("failed to lock family: %w", err)
+	}
+
+	for i, menu := range menus {
+		_, err = s.menuRepo.GetMenuByDateAndMealTypeTx(ctx, tx, family.ID, menu.Date, menu.MealType)
+		if err == nil {
+			return nil, ErrMenuSlotOccupied
+		}
+		if !errors.Is(err, repositories.ErrMenuNotFound) {
+			return nil, fmt.Errorf("failed to check existing menu: %w", err)
+		}
+
+		if err = s.menuRepo.CreateMenuWithDishesTx(ctx, tx, menu, dishIDs[i]); err != nil {
+			return nil, fmt.Errorf("failed to create menu: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit transaction failed: %w", err)
+	}
+
+	resp = &models.AcceptMenuPlanResponse{Menus: make([]*models.MenuDetail, 0, len(menus))}
+	for _, menu := range menus {
+		detail, err := s.buildMenuDetail(menu)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build menu detail: %w", err)
+		}
+		resp.Menus = append(resp.Menus, detail)
+	}
+
+	return resp, nil
+}
+
+// menuPlanner 规则排菜的状态
+// 每次选菜按以下顺序比较候选菜式：尚未安排的指定菜式优先，当天已出现次数少的分类优先，
+// 范围内出现次数少的分类优先，距离上次出现更久的菜式优先，最后按食谱库顺序。
+type menuPlanner struct {
+	noRepeatDays int
+	candidates   []*models.PlanningDish
+	pinned       map[string]bool
+	placed       map[string]bool
+	names        map[string]string
+	categories   map[string]string
+
+	usedDays        map[string][]int
+	dayCategories   map[int]map[string]int
+	rangeCategories map[string]int
+}
+
+func newMenuPlanner(noRepeatDays int, dishes []*models.PlanningDish, pinnedIDs []string, excluded map[string]bool) *menuPlanner {
+	p := &menuPlanner{
+		noRepeatDays:    noRepeatDays,
+		candidates:      make([]*models.PlanningDish, 0, len(dishes)),
+		pinned:          make(map[string]bool, len(pinnedIDs)),
+		placed:          make(map[string]bool, len(pinnedIDs)),
+		names:           make(map[string]string, len(dishes)),
+		categories:      make(map[string]string, len(dishes)),
+		usedDays:        make(map[string][]int),
+		dayCategories:   make(map[int]map[string]int),
+		rangeCategories: make(map[string]int),
+	}
+
+	for _, dishID := range pinnedIDs {
+		p.pinned[dishID] = true
+	}
+	for _, dish := range dishes {
+		p.names[dish.ID] = dish.Name
+		p.categories[dish.ID] = dish.Category
+		if !excluded[dish.ID] {
+			p.candidates = append(p.candidates, dish)
+		}
+	}
+
+	return p
+}
+
+// record 记录某天出现的菜式，day 为相对开始日期的天数
+func (p *menuPlanner) record(day int, dishIDs []string) {
+	for _, dishID := range dishIDs {
+		p.usedDays[dishID] = append(p.usedDays[dishID], day)
+
+		category := p.categories[dishID]
+		if p.dayCategories[day] == nil {
+			p.dayCategories[day] = make(map[string]int)
+		}
+		p.dayCategories[day][category]++
+		p.rangeCategories[category]++
+	}
+}
+
+// pick 为某一餐选出 count 道菜；满足不重复规则的菜式不足时放宽为仅当天不重复，relaxed 返回 true
+func (p *menuPlanner) pick(day, count int) (dishIDs []string, relaxed bool) {
+	dishIDs = make([]string, 0, count)
+	for len(dishIDs) < count {
+		best := p.best(day, p.noRepeatDays)
+		if best == nil {
+			best = p.best(day, 1)
+			if best == nil {
+				break
+			}
+			relaxed = true
+		}
+
+		dishIDs = append(dishIDs, best.ID)
+		p.record(day, []string{best.ID})
+		p.placed[best.ID] = true
+	}
+	return dishIDs, relaxed
+}
+
+// best 返回与已安排菜式至少间隔 minGap 天的最优候选
+func (p *menuPlanner) best(day, minGap int) *models.PlanningDish {
+	var best *models.PlanningDish
+	bestGap := 0
+	for _, dish := range p.candidates {
+		gap := p.gap(dish.ID, day)
+		if gap < minGap {
+			continue
+		}
+		if best == nil || p.better(day, dish, gap, best, bestGap) {
+			best = dish
+			bestGap = gap
+		}
+	}
+	return best
+}
+
+func (p *menuPlanner) better(day int, a *models.PlanningDish, aGap int, b *models.PlanningDish, bGap int) bool {
+	aPinned := p.pinned[a.ID] && !p.placed[a.ID]
+	bPinned := p.pinned[b.ID] && !p.placed[b.ID]
+	if aPinned != bPinned {
+		return aPinned
+	}
+
+	aDay, bDay := p.dayCategories[day][a.Category], p.dayCategories[day][b.Category]
+	if aDay != bDay {
+		return aDay < bDay
+	}
+
+	aRange, bRange := p.rangeCategories[a.Category], p.rangeCategories[b.Category]
+	if aRange != bRange {
+		return aRange < bRange
+	}
+
+	return aGap > bGap
+}
+
+// gap 返回菜式与最近一次出现相隔的天数，从未出现时返回最大值
+func (p *menuPlanner) gap(dishID string, day int) int {
+	gap := math.MaxInt
+	for _, used := range p.usedDays[dishID] {
+		diff := day - used
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff < gap {
+			gap = diff
+		}
+	}
+	return gap
+}
+
+// flattenDishIDs 汇总多餐的菜式ID并去重
+func flattenDishIDs(dishIDs [][]string) []string {
+	all := make([]string, 0)
+	for _, ids := range dishIDs {
+		all = append(all, ids...)
+	}
+	return uniqueStrings(all)
+}
+
+// daysBetween 返回 to 相对 from 的天数
+func daysBetween(from, to time.Time) int {
+	return int(math.Round(to.Sub(from).Hours() / 24))
+}
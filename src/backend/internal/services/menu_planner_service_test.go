@@ -0,0 +1,210 @@
+package services
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"onetaste-family/backend/internal/models"
+)
+
+func planningDishes(pairs ...string) []*models.PlanningDish {
+	dishes := make([]*models.PlanningDish, 0, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		dishes = append(dishes, &models.PlanningDish{ID: pairs[i], Name: pairs[i], Category: pairs[i+1]})
+	}
+	return dishes
+}
+
+func TestMenuPlannerPick(t *testing.T) {
+	tests := []struct {
+		name         string
+		noRepeatDays int
+		dishes       []*models.PlanningDish
+		pinned       []string
+		excluded     map[string]bool
+		history      map[int][]string // 已有菜单，day -> 菜式
+		day          int
+		count        int
+		want         []string
+		wantRelaxed  bool
+	}{
+		{
+			name:         "指定菜式优先",
+			noRepeatDays: 3,
+			dishes:       planningDishes("a", "荤菜", "b", "素菜", "c", "汤"),
+			pinned:       []string{"c"},
+			count:        2,
+			want:         []string{"c", "a"},
+		},
+		{
+			name:         "同一天分类错开",
+			noRepeatDays: 3,
+			dishes:       planningDishes("a", "荤菜", "b", "荤菜", "c", "素菜"),
+			count:        2,
+			want:         []string{"a", "c"},
+		},
+		{
+			name:         "范围内出现少的分类优先",
+			noRepeatDays: 1,
+			dishes:       planningDishes("a", "荤菜", "b", "荤菜", "c", "素菜"),
+			history:      map[int][]string{0: {"a"}},
+			day:          1,
+			count:        1,
+			want:         []string{"c"},
+		},
+		{
+			name:         "距离上次出现更久的优先",
+			noRepeatDays: 1,
+			dishes:       planningDishes("a", "荤菜", "b", "荤菜"),
+			history:      map[int][]string{0: {"a"}, 2: {"b"}},
+			day:          5,
+			count:        1,
+			want:         []string{"a"},
+		},
+		{
+			name:         "不重复天数内跳过",
+			noRepeatDays: 3,
+			dishes:       planningDishes("a", "荤菜", "b", "荤菜"),
+			history:      map[int][]string{0: {"a"}},
+			day:          1,
+			count:        1,
+			want:         []string{"b"},
+		},
+		{
+			name:         "菜式不足时放宽为当天不重复",
+			noRepeatDays: 3,
+			dishes:       planningDishes("a", "荤菜", "b", "素菜"),
+			history:      map[int][]string{0: {"a", "b"}},
+			day:          1,
+			count:        1,
+			want:         []string{"a"},
+			wantRelaxed:  true,
+		},
+		{
+			name:         "当天不能重复时少排",
+			noRepeatDays: 3,
+			dishes:       planningDishes("a", "荤菜"),
+			count:        2,
+			want:         []string{"a"},
+		},
+		{
+			name:         "排除的菜式不参与",
+			noRepeatDays: 3,
+			dishes:       planningDishes("a", "荤菜", "b", "素菜"),
+			excluded:     map[string]bool{"a": true},
+			count:        1,
+			want:         []string{"b"},
+		},
+		{
+			name:         "没有候选菜式",
+			noRepeatDays: 3,
+			count:        2,
+			want:         []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			planner := newMenuPlanner(tt.noRepeatDays, tt.dishes, tt.pinned, tt.excluded)
+			for day, dishIDs := range tt.history {
+				planner.record(day, dishIDs)
+			}
+
+			got, relaxed := planner.pick(tt.day, tt.count)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("pick() = %v, want %v", got, tt.want)
+			}
+			if relaxed != tt.wantRelaxed {
+				t.Fatalf("pick() relaxed = %v, want %v", relaxed, tt.wantRelaxed)
+			}
+		})
+	}
+}
+
+func TestMenuPlannerPickPlacesPinnedOnce(t *testing.T) {
+	planner := newMenuPlanner(1, planningDishes("a", "荤菜", "b", "荤菜"), []string{"b"}, nil)
+
+	first, _ := planner.pick(0, 1)
+	second, _ := planner.pick(1, 1)
+	if !reflect.DeepEqual(first, []string{"b"}) || !reflect.DeepEqual(second, []string{"a"}) {
+		t.Fatalf("pick() = %v, %v, want [b], [a]", first, second)
+	}
+}
+
+func TestMenuPlannerBest(t *testing.T) {
+	tests := []struct {
+		name    string
+		history map[int][]string
+		day     int
+		minGap  int
+		want    string // 空字符串表示没有候选
+	}{
+		{name: "均未出现时按食谱库顺序", minGap: 3, want: "a"},
+		{name: "间隔不足的菜式跳过", history: map[int][]string{0: {"a"}}, day: 2, minGap: 3, want: "b"},
+		{name: "恰好满足间隔", history: map[int][]string{0: {"a"}, 1: {"b"}}, day: 3, minGap: 3, want: "a"},
+		{name: "全部间隔不足", history: map[int][]string{0: {"a"}, 1: {"b"}}, day: 2, minGap: 3, want: ""},
+		{name: "之后的日期同样计入间隔", history: map[int][]string{4: {"a"}}, day: 2, minGap: 3, want: "b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			planner := newMenuPlanner(3, planningDishes("a", "荤菜", "b", "荤菜"), nil, nil)
+			for day, dishIDs := range tt.history {
+				planner.record(day, dishIDs)
+			}
+
+			got := ""
+			if best := planner.best(tt.day, tt.minGap); best != nil {
+				got = best.ID
+			}
+			if got != tt.want {
+				t.Fatalf("best() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMenuPlannerBetter(t *testing.T) {
+	dishes := planningDishes("a", "荤菜", "b", "素菜", "c", "荤菜")
+	byID := make(map[string]*models.PlanningDish, len(dishes))
+	for _, dish := range dishes {
+		byID[dish.ID] = dish
+	}
+
+	tests := []struct {
+		name    string
+		pinned  []string
+		placed  []string
+		history map[int][]string
+		day     int
+		a       string
+		aGap    int
+		b       string
+		bGap    int
+		want    bool
+	}{
+		{name: "未安排的指定菜式优先", pinned: []string{"c"}, history: map[int][]string{0: {"a"}}, a: "c", aGap: math.MaxInt, b: "b", bGap: math.MaxInt, want: true},
+		{name: "已安排的指定菜式不再优先", pinned: []string{"c"}, placed: []string{"c"}, history: map[int][]string{0: {"a"}}, a: "c", aGap: math.MaxInt, b: "b", bGap: math.MaxInt, want: false},
+		{name: "当天分类出现少的优先", history: map[int][]string{0: {"a"}, 1: {"b", "b"}}, a: "b", aGap: math.MaxInt, b: "c", bGap: math.MaxInt, want: true},
+		{name: "范围内分类出现少的优先", history: map[int][]string{1: {"a"}}, a: "b", aGap: math.MaxInt, b: "c", bGap: math.MaxInt, want: true},
+		{name: "分类相同时间隔久的优先", a: "c", aGap: 5, b: "a", bGap: 3, want: true},
+		{name: "完全相同时不替换", a: "c", aGap: 3, b: "a", bGap: 3, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			planner := newMenuPlanner(3, dishes, tt.pinned, nil)
+			for day, dishIDs := range tt.history {
+				planner.record(day, dishIDs)
+			}
+			for _, dishID := range tt.placed {
+				planner.placed[dishID] = true
+			}
+
+			if got := planner.better(tt.day, byID[tt.a], tt.aGap, byID[tt.b], tt.bGap); got != tt.want {
+				t.Fatalf("better(%s, %s) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
@@ -22,21 +22,31 @@ var (
 	ErrInvalidDishIDs = errors.New("invalid dish ids")
 	// ErrDishNotInFamily 菜式不属于该家庭
 	ErrDishNotInFamily = errors.New("dish not in family")
+	// ErrInvalidMenuServings 单独设置用餐人数的菜式不在菜单中
+	ErrInvalidMenuServings = errors.New("invalid menu servings")
 )
 
 // MenuService 菜单业务逻辑层
 type MenuService struct {
-	menuRepo   *repositories.MenuRepository
-	dishRepo   *repositories.DishRepository
-	familyRepo *repositories.FamilyRepository
+	menuRepo       *repositories.MenuRepository
+	dishRepo       *repositories.DishRepository
+	familyRepo     *repositories.FamilyRepository
+	generationRepo *repositories.MenuGenerationRepository
+	healthRepo     *repositories.HealthRecordRepository
+	userRepo       *repositories.UserRepository
+	membership     *MembershipService
 }
 
 // NewMenuService 创建MenuService
 func NewMenuService() *MenuService {
 	return &MenuService{
-		menuRepo:   repositories.NewMenuRepository(),
-		dishRepo:   repositories.NewDishRepository(),
-		familyRepo: repositories.NewFamilyRepository(),
+		menuRepo:       repositories.NewMenuRepository(),
+		dishRepo:       repositories.NewDishRepository(),
+		familyRepo:     repositories.NewFamilyRepository(),
+		generationRepo: repositories.NewMenuGenerationRepository(),
+		healthRepo:     repositories.NewHealthRecordRepository(),
+		userRepo:       repositories.NewUserRepository(),
+		membership:     NewMembershipService(),
 	}
 }
 
@@ -68,6 +78,11 @@ func (s *MenuService) CreateMenu(userID string, req *models.CreateMenuRequest) (
 		return nil, err
 	}
 
+	dishServings, err := menuDishServings(req.DishIDs, req.DishServings)
+	if err != nil {
+		return nil, err
+	}
+
 	// 检查是否已存在相同日期和餐次的菜单
 	existingMenu, err := s.menuRepo.GetMenuByDateAndMealType(family.ID, date, req.MealType)
 	if err != nil && !errors.Is(err, repositories.ErrMenuNotFound) {
@@ -75,12 +90,14 @@ func (s *MenuService) CreateMenu(userID string, req *models.CreateMenuRequest) (
 	}
 
 	menu := &models.Menu{
-		ID:        utils.GenerateULID(),
-		FamilyID:  family.ID,
-		Date:      date,
-		MealType:  req.MealType,
-		CreatedBy: userID,
-		Source:    models.MenuSourceManual,
+		ID:           utils.GenerateULID(),
+		FamilyID:     family.ID,
+		Date:         date,
+		MealType:     req.MealType,
+		CreatedBy:    userID,
+		Source:       models.MenuSourceManual,
+		Servings:     req.Servings,
+		DishServings: dishServings,
 	}
 
 	// 如果已存在，则更新；否则创建
@@ -103,11 +120,13 @@ func (s *MenuService) CreateMenu(userID string, req *models.CreateMenuRequest) (
 	if err != nil {
 		return nil, fmt.Errorf("failed to get dish summaries: %w", err)
 	}
+	applyMealServings(dishes, menu.Servings, menu.DishServings)
 
 	return &models.MenuCreateResponse{
 		MenuID:   menu.ID,
 		Date:     formatDate(menu.Date),
 		MealType: menu.MealType,
+		Servings: menu.Servings,
 		Dishes:   dishes,
 	}, nil
 }
@@ -229,35 +248,51 @@ func (s *MenuService) UpdateMenu(userID, menuID string, req *models.UpdateMenuRe
 		menu.MealType = req.MealType
 	}
 
+	// 更新用餐人数（如果提供）
+	if req.Servings != nil {
+		menu.Servings = *req.Servings
+	}
+
 	// 更新菜式列表（如果提供）
-	if len(req.DishIDs) > 0 {
+	dishIDs := req.DishIDs
+	if len(dishIDs) > 0 {
 		// 验证所有菜式都属于该家庭
-		if err = s.validateDishesInFamily(family.ID, req.DishIDs); err != nil {
+		if err = s.validateDishesInFamily(family.ID, dishIDs); err != nil {
 			return nil, err
 		}
-
-		if err = s.menuRepo.UpdateMenuWithDishes(menu, req.DishIDs); err != nil {
-			if errors.Is(err, repositories.ErrMenuNotFound) {
-				return nil, ErrMenuNotFound
-			}
-			return nil, fmt.Errorf("failed to update menu: %w", err)
-		}
 	} else {
 		// 只更新菜单基本信息，保留原有菜式列表
-		// 获取原有菜式ID列表
-		existingDishIDs, err := s.menuRepo.GetMenuDishes(menu.ID)
+		dishIDs, err = s.menuRepo.GetMenuDishes(menu.ID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get existing dishes: %w", err)
 		}
+	}
 
-		if err = s.menuRepo.UpdateMenuWithDishes(menu, existingDishIDs); err != nil {
-			if errors.Is(err, repositories.ErrMenuNotFound) {
-				return nil, ErrMenuNotFound
+	// 更新单个菜式的用餐人数（如果提供），否则保留仍在菜单中的菜式原有设置
+	if req.DishServings != nil {
+		if menu.DishServings, err = menuDishServings(dishIDs, req.DishServings); err != nil {
+			return nil, err
+		}
+	} else {
+		existing, err := s.menuRepo.GetMenuDishServings(menu.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get menu dish servings: %w", err)
+		}
+		menu.DishServings = make(map[string]int, len(existing))
+		for _, dishID := range dishIDs {
+			if value, ok := existing[dishID]; ok {
+				menu.DishServings[dishID] = value
 			}
-			return nil, fmt.Errorf("failed to update menu: %w", err)
 		}
 	}
 
+	if err = s.menuRepo.UpdateMenuWithDishes(menu, dishIDs); err != nil {
+		if errors.Is(err, repositories.ErrMenuNotFound) {
+			return nil, ErrMenuNotFound
+		}
+		return nil, fmt.Errorf("failed to update menu: %w", err)
+	}
+
 	// 获取更新后的菜单详情
 	detail, err := s.buildMenuDetail(menu)
 	if err != nil {
@@ -281,16 +316,30 @@ func (s *MenuService) buildMenuDetail(menu *models.Menu) (*models.MenuDetail, er
 		return nil, fmt.Errorf("failed to get dish summaries: %w", err)
 	}
 
+	dishServings, err := s.menuRepo.GetMenuDishServings(menu.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get menu dish servings: %w", err)
+	}
+	applyMealServings(dishes, menu.Servings, dishServings)
+
+	// 预计用时按一人依次制作各菜式累计
+	totalMinutes := 0
+	for _, dish := range dishes {
+		totalMinutes += dish.TotalMinutes
+	}
+
 	return &models.MenuDetail{
-		MenuID:    menu.ID,
-		FamilyID:  menu.FamilyID,
-		Date:      formatDate(menu.Date),
-		MealType:  menu.MealType,
-		CreatedBy: menu.CreatedBy,
-		Source:    menu.Source,
-		Dishes:    dishes,
-		CreatedAt: menu.CreatedAt,
-		UpdatedAt: menu.UpdatedAt,
+		MenuID:       menu.ID,
+		FamilyID:     menu.FamilyID,
+		Date:         formatDate(menu.Date),
+		MealType:     menu.MealType,
+		CreatedBy:    menu.CreatedBy,
+		Source:       menu.Source,
+		Servings:     menu.Servings,
+		Dishes:       dishes,
+		TotalMinutes: totalMinutes,
+		CreatedAt:    menu.CreatedAt,
+		UpdatedAt:    menu.UpdatedAt,
 	}, nil
 }
 
@@ -328,19 +377,59 @@ func (s *MenuService) getDishSummaries(dishIDs []string, familyID string) ([]*mo
 		}
 
 		dishes = append(dishes, &models.DishSummary{
-			DishID:      dish.ID,
-			Name:        dish.Name,
-			Category:    dish.Category,
-			Description: dish.Description,
-			ImageURL:    dish.ImageURL,
-			CreatedAt:   dish.CreatedAt,
-			UpdatedAt:   dish.UpdatedAt,
+			DishID:       dish.ID,
+			Name:         dish.Name,
+			Category:     dish.Category,
+			Description:  dish.Description,
+			ImageURL:     dish.ImageURL,
+			PrepMinutes:  dish.PrepMinutes,
+			CookMinutes:  dish.CookMinutes,
+			TotalMinutes: dish.TotalMinutes(),
+			Servings:     dish.Servings,
+			Difficulty:   dish.Difficulty,
+			CreatedAt:    dish.CreatedAt,
+			UpdatedAt:    dish.UpdatedAt,
 		})
 	}
 
 	return dishes, nil
 }
 
+// applyMealServings 设置各菜式本餐准备的份数：菜式单独设置优先，其次菜单用餐人数，最后菜式默认份数
+func applyMealServings(dishes []*models.DishSummary, menuServings int, dishServings map[string]int) {
+	for _, dish := range dishes {
+		switch {
+		case dishServings[dish.DishID] > 0:
+			dish.MealServings = dishServings[dish.DishID]
+		case menuServings > 0:
+			dish.MealServings = menuServings
+		default:
+			dish.MealServings = dish.Servings
+		}
+	}
+}
+
+// menuDishServings 校验单独设置用餐人数的菜式都在菜单中
+func menuDishServings(dishIDs []string, servings map[string]int) (map[string]int, error) {
+	result := make(map[string]int, len(servings))
+	if len(servings) == 0 {
+		return result, nil
+	}
+
+	inMenu := make(map[string]struct{}, len(dishIDs))
+	for _, dishID := range dishIDs {
+		inMenu[dishID] = struct{}{}
+	}
+	for dishID, value := range servings {
+		if _, ok := inMenu[dishID]; !ok {
+			return nil, ErrInvalidMenuServings
+		}
+		result[dishID] = value
+	}
+
+	return result, nil
+}
+
 func (s *MenuService) getFamilyForUser(userID string) (*models.Family, error) {
 	family, err := s.familyRepo.GetFamilyByUserID(userID)
 	if err != nil {
@@ -0,0 +1,484 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"onetaste-family/backend/internal/models"
+	"onetaste-family/backend/internal/repositories"
+	"onetaste-family/backend/internal/utils"
+	"onetaste-family/backend/pkg/payment"
+)
+
+const (
+	// paymentOrderTTL 待支付订单的有效期，超时后由定时任务关闭
+	paymentOrderTTL = 30 * time.Minute
+	// paymentRefundWindow 支付后允许申请退款的时间窗口
+	paymentRefundWindow = 7 * 24 * time.Hour
+	// paymentRefundRetryDelay 订单进入退款中超过该时长仍未完成时由定时任务重试退款
+	paymentRefundRetryDelay = 5 * time.Minute
+	// paymentRefundRetryBatch 每次重试退款的最大订单数
+	paymentRefundRetryBatch = 100
+)
+
+// membershipPlans 可购买的会员套餐，价格以分为单位
+var membershipPlans = []models.MembershipPlan{
+	{PlanType: models.MembershipPlanMonthly, Name: "月度会员", AmountCents: 1500},
+	{PlanType: models.MembershipPlanYearly, Name: "年度会员", AmountCents: 12800},
+}
+
+var (
+	// ErrPaymentOrderNotFound 支付订单不存在
+	ErrPaymentOrderNotFound = errors.New("payment order not found")
+	// ErrUnsupportedPaymentMethod 不支持的支付渠道
+	ErrUnsupportedPaymentMethod = errors.New("unsupported payment method")
+	// ErrPaymentAmountMismatch 回调金额与订单金额不一致
+	ErrPaymentAmountMismatch = errors.New("payment amount mismatch")
+	// ErrPaymentOrderNotPayable 订单已关闭或已退款，无法支付
+	ErrPaymentOrderNotPayable = errors.New("payment order not payable")
+	// ErrPaymentOrderNotRefundable 订单状态不允许退款
+	ErrPaymentOrderNotRefundable = errors.New("payment order not refundable")
+	// ErrRefundWindowExpired 已超过退款期限
+	ErrRefundWindowExpired = errors.New("refund window expired")
+	// ErrMembershipRenewed 该订单之后已有续费，需先退款最近一笔订单
+	ErrMembershipRenewed = errors.New("membership renewed after this order")
+	// ErrMockPaymentDisabled 当前未启用模拟支付渠道
+	ErrMockPaymentDisabled = errors.New("mock payment disabled")
+)
+
+// PaymentService 支付订单业务逻辑层
+// 订单状态流转：pending -> paid -> refunding -> refunded，pending 超时 -> expired，渠道通知失败 -> failed。
+// 退款先在事务内将订单标记为 refunding 并提交，再在事务外调用渠道，避免渠道请求期间持有订单行锁。
+// 超时关闭后到达的成功回调仍视为有效支付，保证用户付款后一定能拿到会员。
+type PaymentService struct {
+	orderRepo      *repositories.PaymentOrderRepository
+	familyRepo     *repositories.FamilyRepository
+	membershipRepo *repositories.MembershipRepository
+	membership     *MembershipService
+}
+
+// NewPaymentService 创建PaymentService
+func NewPaymentService() *PaymentService {
+	return &PaymentService{
+		orderRepo:      repositories.NewPaymentOrderRepository(),
+		familyRepo:     repositories.NewFamilyRepository(),
+		membershipRepo: repositories.NewMembershipRepository(),
+		membership:     NewMembershipService(),
+	}
+}
+
+// ListPlans 获取可购买的会员套餐
+func (s *PaymentService) ListPlans() []models.MembershipPlan {
+	plans := make([]models.MembershipPlan, len(membershipPlans))
+	copy(plans, membershipPlans)
+	return plans
+}
+
+// CreateOrder 为用户当前家庭创建会员订单，并向支付渠道下单
+func (s *PaymentService) CreateOrder(ctx context.Context, userID string, req *models.SubscribeRequest) (*models.PaymentOrderResponse, error) {
+	plan, ok := findMembershipPlan(req.Plan)
+	if !ok {
+		return nil, ErrInvalidMembershipPlan
+	}
+
+	gateway := payment.GetGateway()
+	if req.PaymentMethod != "" && req.PaymentMethod != gateway.Name() {
+		return nil, ErrUnsupportedPaymentMethod
+	}
+
+	family, err := s.familyRepo.GetFamilyByUserID(userID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrFamilyNotFound) {
+			return nil, ErrFamilyNotFound
+		}
+		return nil, fmt.Errorf("failed to get family: %w", err)
+	}
+
+	expiresAt := paymentNow().Add(paymentOrderTTL)
+	order := &models.PaymentOrder{
+		ID:            utils.GenerateULID(),
+		OrderNo:       "OT" + utils.GenerateULID(),
+		UserID:        userID,
+		FamilyID:      family.ID,
+		PlanType:      plan.PlanType,
+		AmountCents:   plan.AmountCents,
+		PaymentMethod: gateway.Name(),
+		Status:        models.PaymentOrderStatusPending,
+		ExpiresAt:     &expiresAt,
+	}
+	if err := s.orderRepo.Create(order); err != nil {
+		return nil, err
+	}
+
+	params, err := gateway.CreatePayment(ctx, &payment.PaymentRequest{
+		OrderNo:     order.OrderNo,
+		Description: plan.Name,
+		AmountCents: order.AmountCents,
+		ExpiresAt:   expiresAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create payment: %w", err)
+	}
+
+	resp := buildPaymentOrderResponse(order)
+	resp.Payment = params
+	return resp, nil
+}
+
+// GetOrder 获取用户自己的订单
+func (s *PaymentService) GetOrder(userID, orderNo string) (*models.PaymentOrderResponse, error) {
+	order, err := s.orderRepo.GetByUserAndOrderNo(userID, orderNo)
+	if err != nil {
+		if errors.Is(err, repositories.ErrPaymentOrderNotFound) {
+			return nil, ErrPaymentOrderNotFound
+		}
+		return nil, err
+	}
+
+	return buildPaymentOrderResponse(order), nil
+}
+
+// ListOrders 分页获取用户的订单
+func (s *PaymentService) ListOrders(userID string, page, pageSize int) (*models.PaymentOrderListResponse, error) {
+	orders, total, err := s.orderRepo.ListByUser(userID, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.PaymentOrderListResponse{
+		Orders:   make([]*models.PaymentOrderResponse, 0, len(orders)),
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}
+	for _, order := range orders {
+		resp.Orders = append(resp.Orders, buildPaymentOrderResponse(order))
+	}
+
+	return resp, nil
+}
+
+// HandleNotification 处理支付渠道回调，重复回调直接返回成功
+func (s *PaymentService) HandleNotification(ctx context.Context, provider string, body []byte, header http.Header) error {
+	gateway := payment.GetGateway()
+	if provider != gateway.Name() {
+		return ErrUnsupportedPaymentMethod
+	}
+
+	notification, err := gateway.ParseNotification(ctx, body, header)
+	if err != nil {
+		return err
+	}
+
+	refund, err := s.applyNotification(ctx, notification)
+	if err != nil {
+		return err
+	}
+
+	// 渠道退款失败时订单保持退款中，回调照常确认，由定时任务重试
+	if refund != nil {
+		if err := s.completeRefund(ctx, refund, "family dissolved"); err != nil {
+			log.Printf("failed to refund payment order %s: %v", refund.OrderNo, err)
+		}
+	}
+
+	return nil
+}
+
+// applyNotification 在事务内锁定订单并根据支付结果推进订单状态，返回需要在事务提交后发起退款的订单
+func (s *PaymentService) applyNotification(ctx context.Context, notification *payment.Notification) (refund *models.PaymentOrder, err error) {
+	tx, err := s.orderRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction failed: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	order, err := s.orderRepo.GetByOrderNoForUpdateTx(ctx, tx, notification.OrderNo)
+	if err != nil {
+		if errors.Is(err, repositories.ErrPaymentOrderNotFound) {
+			return nil, ErrPaymentOrderNotFound
+		}
+		return nil, err
+	}
+
+	// 已支付、退款中或已退款的订单说明回调已处理过，直接确认
+	switch order.Status {
+	case models.PaymentOrderStatusPaid, models.PaymentOrderStatusRefunding, models.PaymentOrderStatusRefunded:
+		return nil, tx.Commit()
+	}
+
+	if notification.AmountCents != order.AmountCents {
+		return nil, ErrPaymentAmountMismatch
+	}
+
+	if notification.Status != payment.NotificationStatusSuccess {
+		if err = s.orderRepo.MarkFailedTx(ctx, tx, order.ID); err != nil {
+			return nil, err
+		}
+		return nil, tx.Commit()
+	}
+
+	paidAt := notification.PaidAt.UTC()
+	order.TransactionID = notification.TransactionID
+	order.PaidAt = &paidAt
+
+	_, err = s.familyRepo.LockFamilyTx(ctx, tx, order.FamilyID)
+	if err != nil {
+		if !errors.Is(err, repositories.ErrFamilyNotFound) {
+			return nil, err
+		}
+
+		// 付款期间家庭已解散，会员无处生效，提交后原路退款
+		if err = s.orderRepo.MarkPaidTx(ctx, tx, order); err != nil {
+			return nil, err
+		}
+		if err = s.orderRepo.MarkRefundingTx(ctx, tx, order.ID); err != nil {
+			return nil, err
+		}
+		if err = tx.Commit(); err != nil {
+			return nil, fmt.Errorf("commit transaction failed: %w", err)
+		}
+		order.Status = models.PaymentOrderStatusRefunding
+		return order, nil
+	}
+
+	membership, err := s.membership.ActivateMembershipTx(ctx, tx, order.UserID, order.FamilyID, order.PlanType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to activate membership: %w", err)
+	}
+	order.MembershipID = membership.ID
+
+	if err = s.orderRepo.MarkPaidTx(ctx, tx, order); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit transaction failed: %w", err)
+	}
+
+	return nil, nil
+}
+
+// SimulateMockPayment 使用模拟渠道为用户的订单生成一条已签名的回调并处理，仅在 mock 渠道下可用
+func (s *PaymentService) SimulateMockPayment(ctx context.Context, userID string, req *models.MockPayRequest) (*models.PaymentOrderResponse, error) {
+	gateway, ok := payment.GetGateway().(*payment.MockGateway)
+	if !ok {
+		return nil, ErrMockPaymentDisabled
+	}
+
+	order, err := s.orderRepo.GetByUserAndOrderNo(userID, req.OrderNo)
+	if err != nil {
+		if errors.Is(err, repositories.ErrPaymentOrderNotFound) {
+			return nil, ErrPaymentOrderNotFound
+		}
+		return nil, err
+	}
+	if order.Status == models.PaymentOrderStatusRefunding || order.Status == models.PaymentOrderStatusRefunded {
+		return nil, ErrPaymentOrderNotPayable
+	}
+
+	status := req.Status
+	if status == "" {
+		status = payment.NotificationStatusSuccess
+	}
+
+	body, header, err := gateway.BuildNotification(order.OrderNo, order.AmountCents, status)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.HandleNotification(ctx, gateway.Name(), body, header); err != nil {
+		return nil, err
+	}
+
+	return s.GetOrder(userID, order.OrderNo)
+}
+
+// RefundOrder 为订单发起全额退款并取消对应的会员，仅支持支付后7天内的最近一笔订单
+// 渠道退款失败时订单保持退款中并返回当前状态，由定时任务重试；对退款中的订单再次调用会立即重试
+func (s *PaymentService) RefundOrder(ctx context.Context, userID, orderNo string) (*models.PaymentOrderResponse, error) {
+	order, err := s.startRefundTx(ctx, userID, orderNo)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.membership.SyncFamilyLimits(order.FamilyID); err != nil {
+		log.Printf("failed to sync family %s limits after refund: %v", order.FamilyID, err)
+	}
+
+	if err := s.completeRefund(ctx, order, "user requested"); err != nil {
+		log.Printf("failed to refund payment order %s: %v", order.OrderNo, err)
+	}
+
+	return buildPaymentOrderResponse(order), nil
+}
+
+// startRefundTx 在事务内校验订单、取消对应的会员并将订单标记为退款中
+func (s *PaymentService) startRefundTx(ctx context.Context, userID, orderNo string) (order *models.PaymentOrder, err error) {
+	tx, err := s.orderRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction failed: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	order, err = s.orderRepo.GetByOrderNoForUpdateTx(ctx, tx, orderNo)
+	if err != nil {
+		if errors.Is(err, repositories.ErrPaymentOrderNotFound) {
+			return nil, ErrPaymentOrderNotFound
+		}
+		return nil, err
+	}
+	if order.UserID != userID {
+		return nil, ErrPaymentOrderNotFound
+	}
+
+	// 已在退款中的订单会员已取消，直接重新发起渠道退款
+	if order.Status == models.PaymentOrderStatusRefunding {
+		if err = tx.Commit(); err != nil {
+			return nil, fmt.Errorf("commit transaction failed: %w", err)
+		}
+		return order, nil
+	}
+
+	if order.Status != models.PaymentOrderStatusPaid || order.PaidAt == nil {
+		return nil, ErrPaymentOrderNotRefundable
+	}
+
+	if paymentNow().Sub(*order.PaidAt) > paymentRefundWindow {
+		return nil, ErrRefundWindowExpired
+	}
+
+	if order.MembershipID != "" {
+		if err = s.ensureLatestMembershipTx(ctx, tx, order); err != nil {
+			return nil, err
+		}
+		if err = s.membershipRepo.CancelTx(ctx, tx, order.MembershipID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = s.orderRepo.MarkRefundingTx(ctx, tx, order.ID); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit transaction failed: %w", err)
+	}
+
+	order.Status = models.PaymentOrderStatusRefunding
+	return order, nil
+}
+
+// completeRefund 调用渠道退款并将退款中的订单标记为已退款，渠道按订单号幂等处理重复退款
+func (s *PaymentService) completeRefund(ctx context.Context, order *models.PaymentOrder, reason string) error {
+	if err := payment.GetGateway().Refund(ctx, refundRequest(order, reason)); err != nil {
+		return fmt.Errorf("failed to refund payment: %w", err)
+	}
+
+	now := paymentNow()
+	if err := s.orderRepo.MarkRefunded(order.ID, now); err != nil {
+		return err
+	}
+
+	order.Status = models.PaymentOrderStatusRefunded
+	order.RefundedAt = &now
+	return nil
+}
+
+// RetryPendingRefunds 重试长时间停留在退款中的订单，返回完成退款的数量
+func (s *PaymentService) RetryPendingRefunds(ctx context.Context) (int, error) {
+	orders, err := s.orderRepo.ListRefunding(paymentRefundRetryDelay, paymentRefundRetryBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	refunded := 0
+	for _, order := range orders {
+		if err := s.completeRefund(ctx, order, "refund retry"); err != nil {
+			log.Printf("failed to refund payment order %s: %v", order.OrderNo, err)
+			continue
+		}
+		refunded++
+	}
+
+	return refunded, nil
+}
+
+// ensureLatestMembershipTx 续费记录从上一笔的到期时间顺延，退掉中间一笔会让后续记录留下空档，
+// 因此只允许退款家庭最近一笔会员订单
+func (s *PaymentService) ensureLatestMembershipTx(ctx context.Context, tx *sql.Tx, order *models.PaymentOrder) error {
+	if _, err := s.familyRepo.LockFamilyTx(ctx, tx, order.FamilyID); err != nil {
+		if errors.Is(err, repositories.ErrFamilyNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	latest, err := s.membershipRepo.GetLatestActiveByFamilyTx(ctx, tx, order.FamilyID, paymentNow())
+	if err != nil {
+		if errors.Is(err, repositories.ErrMembershipNotFound) {
+			return nil
+		}
+		return err
+	}
+	if latest.ID != order.MembershipID {
+		return ErrMembershipRenewed
+	}
+
+	return nil
+}
+
+// ExpirePendingOrders 关闭超时未支付的订单，返回关闭的数量
+func (s *PaymentService) ExpirePendingOrders() (int64, error) {
+	return s.orderRepo.ExpirePending(paymentNow())
+}
+
+func findMembershipPlan(planType string) (models.MembershipPlan, bool) {
+	for _, plan := range membershipPlans {
+		if plan.PlanType == planType {
+			return plan, true
+		}
+	}
+	return models.MembershipPlan{}, false
+}
+
+func refundRequest(order *models.PaymentOrder, reason string) *payment.RefundRequest {
+	return &payment.RefundRequest{
+		OrderNo:       order.OrderNo,
+		TransactionID: order.TransactionID,
+		AmountCents:   order.AmountCents,
+		Reason:        reason,
+	}
+}
+
+func buildPaymentOrderResponse(order *models.PaymentOrder) *models.PaymentOrderResponse {
+	return &models.PaymentOrderResponse{
+		OrderNo:       order.OrderNo,
+		PlanType:      order.PlanType,
+		AmountCents:   order.AmountCents,
+		PaymentMethod: order.PaymentMethod,
+		Status:        order.Status,
+		ExpiresAt:     order.ExpiresAt,
+		PaidAt:        order.PaidAt,
+		RefundedAt:    order.RefundedAt,
+		CreatedAt:     order.CreatedAt,
+	}
+}
+
+func paymentNow() time.Time {
+	return time.Now().UTC()
+}
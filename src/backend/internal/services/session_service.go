@@ -0,0 +1,339 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/redis/go-redis/v9"
+
+	"onetaste-family/backend/internal/models"
+	"onetaste-family/backend/internal/utils"
+	"onetaste-family/backend/pkg/cache"
+)
+
+var (
+	// ErrInvalidRefreshToken 刷新令牌无效、已过期或已被使用
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	// ErrSessionNotFound 会话不存在或已失效
+	ErrSessionNotFound = errors.New("session not found")
+)
+
+const (
+	sessionKeyPrefix          = "session:"
+	userSessionsKeyPrefix     = "user_sessions:"
+	refreshTokenKeyPrefix     = "refresh_token:"
+	usedRefreshTokenKeyPrefix = "refresh_token_used:"
+	tokenDenylistKeyPrefix    = "jwt_denylist:"
+
+	sessionUserAgentMaxLength = 255
+)
+
+// 会话在 Redis 中以 Hash 保存的字段
+const (
+	sessionFieldUserID          = "user_id"
+	sessionFieldRefreshHash     = "refresh_hash"
+	sessionFieldAccessJTI       = "access_jti"
+	sessionFieldAccessExpiresAt = "access_expires_at"
+	sessionFieldUserAgent       = "user_agent"
+	sessionFieldIP              = "ip"
+	sessionFieldCreatedAt       = "created_at"
+	sessionFieldLastActiveAt    = "last_active_at"
+)
+
+// SessionService 登录会话服务
+// 每次登录创建一个会话，会话持有当前刷新令牌摘要与最新访问令牌的 jti；
+// 刷新令牌一次性使用并轮换，吊销会话时将最新访问令牌加入黑名单。
+type SessionService struct {
+	redis *redis.Client
+}
+
+// NewSessionService 创建会话服务
+func NewSessionService() *SessionService {
+	return &SessionService{
+		redis: cache.GetRedis(),
+	}
+}
+
+// CreateSession 为用户创建新会话并签发访问令牌与刷新令牌
+func (s *SessionService) CreateSession(ctx context.Context, userID string, device models.SessionDevice) (*models.LoginResponse, error) {
+	if s.redis == nil {
+		return nil, errors.New("redis client is not initialized")
+	}
+
+	return s.issueTokens(ctx, userID, utils.GenerateULID(), device, time.Now())
+}
+
+// Refresh 使用刷新令牌换取新的访问令牌与刷新令牌
+// 已轮换过的刷新令牌被再次使用时视为泄露，直接吊销整个会话
+func (s *SessionService) Refresh(ctx context.Context, refreshToken string, device models.SessionDevice) (*models.LoginResponse, error) {
+	if s.redis == nil {
+		return nil, errors.New("redis client is not initialized")
+	}
+
+	refreshHash := utils.HashRefreshToken(refreshToken)
+	sessionID, err := s.redis.GetDel(ctx, refreshTokenKeyPrefix+refreshHash).Result()
+	if errors.Is(err, redis.Nil) {
+		if err := s.revokeReusedRefreshToken(ctx, refreshHash); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+
+	fields, err := s.redis.HGetAll(ctx, sessionKeyPrefix+sessionID).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if len(fields) == 0 || fields[sessionFieldRefreshHash] != refreshHash {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	_, err = s.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, usedRefreshTokenKeyPrefix+refreshHash, sessionID, refreshTokenTTL())
+		denyAccessToken(ctx, pipe, fields)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+
+	createdAt := parseSessionTime(fields[sessionFieldCreatedAt])
+	return s.issueTokens(ctx, fields[sessionFieldUserID], sessionID, device, createdAt)
+}
+
+// RevokeSession 吊销用户的指定会话
+func (s *SessionService) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	if s.redis == nil {
+		return errors.New("redis client is not initialized")
+	}
+
+	revoked, err := s.revokeSession(ctx, userID, sessionID)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return ErrSessionNotFound
+	}
+
+	return nil
+}
+
+// RevokeOtherSessions 吊销除 keepSessionID 以外的全部会话，返回吊销数量
+func (s *SessionService) RevokeOtherSessions(ctx context.Context, userID, keepSessionID string) (int, error) {
+	if s.redis == nil {
+		return 0, errors.New("redis client is not initialized")
+	}
+
+	sessionIDs, err := s.redis.SMembers(ctx, userSessionsKeyPrefix+userID).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	count := 0
+	for _, sessionID := range sessionIDs {
+		if sessionID == keepSessionID {
+			continue
+		}
+		revoked, err := s.revokeSession(ctx, userID, sessionID)
+		if err != nil {
+			return count, err
+		}
+		if revoked {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// RevokeAllSessions 吊销用户全部会话，返回吊销数量
+func (s *SessionService) RevokeAllSessions(ctx context.Context, userID string) (int, error) {
+	return s.RevokeOtherSessions(ctx, userID, "")
+}
+
+// ListSessions 获取用户的有效会话列表，按最近活跃时间倒序
+func (s *SessionService) ListSessions(ctx context.Context, userID, currentSessionID string) ([]*models.SessionInfo, error) {
+	if s.redis == nil {
+		return nil, errors.New("redis client is not initialized")
+	}
+
+	userSessionsKey := userSessionsKeyPrefix + userID
+	sessionIDs, err := s.redis.SMembers(ctx, userSessionsKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	sessions := make([]*models.SessionInfo, 0, len(sessionIDs))
+	for _, sessionID := range sessionIDs {
+		fields, err := s.redis.HGetAll(ctx, sessionKeyPrefix+sessionID).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get session: %w", err)
+		}
+		if len(fields) == 0 || fields[sessionFieldUserID] != userID {
+			// 会话已过期，顺便清理索引
+			s.redis.SRem(ctx, userSessionsKey, sessionID)
+			continue
+		}
+
+		sessions = append(sessions, &models.SessionInfo{
+			SessionID:    sessionID,
+			UserAgent:    fields[sessionFieldUserAgent],
+			IP:           fields[sessionFieldIP],
+			Current:      sessionID == currentSessionID,
+			CreatedAt:    parseSessionTime(fields[sessionFieldCreatedAt]),
+			LastActiveAt: parseSessionTime(fields[sessionFieldLastActiveAt]),
+		})
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastActiveAt.After(sessions[j].LastActiveAt)
+	})
+
+	return sessions, nil
+}
+
+// IsTokenRevoked 判断访问令牌是否已被加入黑名单
+func (s *SessionService) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	if s.redis == nil {
+		return false, errors.New("redis client is not initialized")
+	}
+
+	count, err := s.redis.Exists(ctx, tokenDenylistKeyPrefix+jti).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to check token denylist: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// issueTokens 签发令牌并写入会话，刷新令牌的有效期从本次签发开始计算
+func (s *SessionService) issueTokens(ctx context.Context, userID, sessionID string, device models.SessionDevice, createdAt time.Time) (*models.LoginResponse, error) {
+	accessToken, claims, err := utils.GenerateToken(userID, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	refreshToken, err := utils.GenerateRefreshToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	refreshHash := utils.HashRefreshToken(refreshToken)
+
+	userAgent := device.UserAgent
+	if utf8.RuneCountInString(userAgent) > sessionUserAgentMaxLength {
+		userAgent = string([]rune(userAgent)[:sessionUserAgentMaxLength])
+	}
+
+	ttl := refreshTokenTTL()
+	sessionKey := sessionKeyPrefix + sessionID
+	userSessionsKey := userSessionsKeyPrefix + userID
+	_, err = s.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, sessionKey, map[string]interface{}{
+			sessionFieldUserID:          userID,
+			sessionFieldRefreshHash:     refreshHash,
+			sessionFieldAccessJTI:       claims.ID,
+			sessionFieldAccessExpiresAt: strconv.FormatInt(claims.ExpiresAt.Unix(), 10),
+			sessionFieldUserAgent:       userAgent,
+			sessionFieldIP:              device.IP,
+			sessionFieldCreatedAt:       strconv.FormatInt(createdAt.Unix(), 10),
+			sessionFieldLastActiveAt:    strconv.FormatInt(time.Now().Unix(), 10),
+		})
+		pipe.Expire(ctx, sessionKey, ttl)
+		pipe.Set(ctx, refreshTokenKeyPrefix+refreshHash, sessionID, ttl)
+		pipe.SAdd(ctx, userSessionsKey, sessionID)
+		pipe.Expire(ctx, userSessionsKey, ttl)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store session: %w", err)
+	}
+
+	return &models.LoginResponse{
+		UserID:           userID,
+		Token:            accessToken,
+		ExpiresIn:        utils.GetTokenExpiration(),
+		RefreshToken:     refreshToken,
+		RefreshExpiresIn: utils.GetRefreshTokenExpiration(),
+	}, nil
+}
+
+// revokeSession 删除会话及其刷新令牌，并将最新访问令牌加入黑名单
+func (s *SessionService) revokeSession(ctx context.Context, userID, sessionID string) (bool, error) {
+	sessionKey := sessionKeyPrefix + sessionID
+	fields, err := s.redis.HGetAll(ctx, sessionKey).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to get session: %w", err)
+	}
+	if len(fields) == 0 || fields[sessionFieldUserID] != userID {
+		s.redis.SRem(ctx, userSessionsKeyPrefix+userID, sessionID)
+		return false, nil
+	}
+
+	_, err = s.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, sessionKey, refreshTokenKeyPrefix+fields[sessionFieldRefreshHash])
+		pipe.SRem(ctx, userSessionsKeyPrefix+userID, sessionID)
+		denyAccessToken(ctx, pipe, fields)
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	return true, nil
+}
+
+// revokeReusedRefreshToken 已轮换的刷新令牌被重复使用时吊销对应会话
+func (s *SessionService) revokeReusedRefreshToken(ctx context.Context, refreshHash string) error {
+	sessionID, err := s.redis.Get(ctx, usedRefreshTokenKeyPrefix+refreshHash).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check used refresh token: %w", err)
+	}
+
+	userID, err := s.redis.HGet(ctx, sessionKeyPrefix+sessionID, sessionFieldUserID).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+
+	_, err = s.revokeSession(ctx, userID, sessionID)
+	return err
+}
+
+// denyAccessToken 将会话当前的访问令牌加入黑名单，保留到令牌自然过期
+func denyAccessToken(ctx context.Context, pipe redis.Pipeliner, fields map[string]string) {
+	jti := fields[sessionFieldAccessJTI]
+	if jti == "" {
+		return
+	}
+
+	ttl := time.Until(parseSessionTime(fields[sessionFieldAccessExpiresAt]))
+	if ttl <= 0 {
+		return
+	}
+
+	pipe.Set(ctx, tokenDenylistKeyPrefix+jti, "1", ttl)
+}
+
+func refreshTokenTTL() time.Duration {
+	return time.Duration(utils.GetRefreshTokenExpiration()) * time.Second
+}
+
+func parseSessionTime(value string) time.Time {
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(seconds, 0).UTC()
+}
@@ -0,0 +1,629 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"onetaste-family/backend/internal/models"
+	"onetaste-family/backend/internal/repositories"
+	"onetaste-family/backend/internal/utils"
+)
+
+var (
+	// ErrShoppingListNotFound 购物清单不存在
+	ErrShoppingListNotFound = errors.New("shopping list not found")
+	// ErrInvalidShoppingRange 购物清单日期范围非法
+	ErrInvalidShoppingRange = errors.New("invalid shopping date range")
+	// ErrNoMenusInRange 所选范围内没有菜单
+	ErrNoMenusInRange = errors.New("no menus in range")
+	// ErrShoppingItemNotFound 购物清单项不存在
+	ErrShoppingItemNotFound = errors.New("shopping list item not found")
+	// ErrShoppingIngredientNotFound 添加的基础食材不存在或已停用
+	ErrShoppingIngredientNotFound = errors.New("shopping ingredient not found")
+)
+
+const (
+	// maxShoppingRangeDays 单个购物清单最多覆盖的天数
+	maxShoppingRangeDays = 31
+)
+
+// ShoppingService 购物清单业务逻辑层
+type ShoppingService struct {
+	shoppingRepo   *repositories.ShoppingRepository
+	menuRepo       *repositories.MenuRepository
+	familyRepo     *repositories.FamilyRepository
+	ingredientRepo *repositories.IngredientRepository
+}
+
+// NewShoppingService 创建ShoppingService
+func NewShoppingService() *ShoppingService {
+	return &ShoppingService{
+		shoppingRepo:   repositories.NewShoppingRepository(),
+		menuRepo:       repositories.NewMenuRepository(),
+		familyRepo:     repositories.NewFamilyRepository(),
+		ingredientRepo: repositories.NewIngredientRepository(),
+	}
+}
+
+// GenerateShoppingList 根据日期范围或指定菜单生成购物清单
+func (s *ShoppingService) GenerateShoppingList(userID string, req *models.GenerateShoppingListRequest) (*models.ShoppingListDetail, error) {
+	family, err := s.getFamilyForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	menus, err := s.resolveMenus(family.ID, req)
+	if err != nil {
+		return nil, err
+	}
+	if len(menus) == 0 {
+		return nil, ErrNoMenusInRange
+	}
+
+	menuIDs := make([]string, 0, len(menus))
+	startDate, endDate := menus[0].Date, menus[0].Date
+	for _, menu := range menus {
+		menuIDs = append(menuIDs, menu.ID)
+		if menu.Date.Before(startDate) {
+			startDate = menu.Date
+		}
+		if menu.Date.After(endDate) {
+			endDate = menu.Date
+		}
+	}
+
+	menuIngredients, err := s.menuRepo.GetIngredientsByMenuIDs(menuIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get menu ingredients: %w", err)
+	}
+
+	source := models.ShoppingListSourceDateRange
+	if len(req.MenuIDs) > 0 {
+		source = models.ShoppingListSourceMenus
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		name = fmt.Sprintf("购物清单 %s ~ %s", formatDate(startDate), formatDate(endDate))
+	}
+
+	list := &models.ShoppingList{
+		ID:        utils.GenerateULID(),
+		FamilyID:  family.ID,
+		Name:      name,
+		StartDate: startDate,
+		EndDate:   endDate,
+		Status:    models.ShoppingListStatusPending,
+		Source:    source,
+		CreatedBy: userID,
+	}
+
+	items := aggregateShoppingItems(menuIngredients)
+	if err := s.shoppingRepo.CreateListWithItems(list, items, menuIDs); err != nil {
+		return nil, fmt.Errorf("failed to create shopping list: %w", err)
+	}
+
+	return buildShoppingListDetail(list, items), nil
+}
+
+// GetShoppingList 获取购物清单详情
+func (s *ShoppingService) GetShoppingList(userID, listID string) (*models.ShoppingListDetail, error) {
+	family, err := s.getFamilyForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := s.shoppingRepo.GetListByID(listID, family.ID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrShoppingListNotFound) {
+			return nil, ErrShoppingListNotFound
+		}
+		return nil, fmt.Errorf("failed to get shopping list: %w", err)
+	}
+
+	items, err := s.shoppingRepo.GetListItems(list.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shopping list items: %w", err)
+	}
+
+	return buildShoppingListDetail(list, items), nil
+}
+
+// GetShoppingLists 分页获取家庭购物清单
+func (s *ShoppingService) GetShoppingLists(userID string, req *models.ShoppingListQuery) (*models.ShoppingListListResponse, error) {
+	family, err := s.getFamilyForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	lists, total, err := s.shoppingRepo.GetListsByFamily(family.ID, req.Page, req.PageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query shopping lists: %w", err)
+	}
+	if lists == nil {
+		lists = []*models.ShoppingListSummary{}
+	}
+
+	return &models.ShoppingListListResponse{
+		Lists:    lists,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, nil
+}
+
+// AddShoppingItem 手动添加清单项，与已有的同食材同单位清单项合并数量
+func (s *ShoppingService) AddShoppingItem(userID, listID string, req *models.AddShoppingItemRequest) (*models.ShoppingListDetail, error) {
+	list, items, err := s.getListWithItems(userID, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	ingredientID := strings.TrimSpace(req.IngredientID)
+	ingredients, err := s.ingredientRepo.GetActiveByIDs([]string{ingredientID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ingredient: %w", err)
+	}
+	ingredient, exists := ingredients[ingredientID]
+	if !exists {
+		return nil, ErrShoppingIngredientNotFound
+	}
+
+	profile := utils.UnitProfile{Density: ingredient.Density, PieceWeight: ingredient.PieceWeight}
+	amount, unit := normalizeIngredientAmount(req.TotalAmount, req.Unit, ingredient.DefaultUnit, profile)
+	if existing := findShoppingItem(items, shoppingItemKey(ingredientID, unit), ""); existing != nil {
+		existing.TotalAmount = roundAmount(existing.TotalAmount + amount)
+		existing.Status = models.ShoppingItemStatusPending
+		existing.IsManual = true
+		if err := s.shoppingRepo.UpdateItem(existing); err != nil {
+			return nil, s.mapItemError(err, "failed to update shopping list item")
+		}
+		return s.GetShoppingList(userID, list.ID)
+	}
+
+	item := &models.ShoppingListItem{
+		ID:             utils.GenerateULID(),
+		ListID:         list.ID,
+		IngredientID:   ingredient.ID,
+		IngredientName: ingredient.Name,
+		Category:       ingredient.Category,
+		TotalAmount:    roundAmount(amount),
+		Unit:           unit,
+		StorageDays:    ingredient.StorageDays,
+		Status:         models.ShoppingItemStatusPending,
+		IsManual:       true,
+		SortOrder:      len(items) + 1,
+	}
+	for _, existing := range items {
+		if existing.SortOrder >= item.SortOrder {
+			item.SortOrder = existing.SortOrder + 1
+		}
+	}
+
+	if err := s.shoppingRepo.AddItem(item); err != nil {
+		return nil, fmt.Errorf("failed to add shopping list item: %w", err)
+	}
+
+	return s.GetShoppingList(userID, list.ID)
+}
+
+// UpdateShoppingItem 修改清单项数量或单位，修改后的清单项在重新生成时保留
+func (s *ShoppingService) UpdateShoppingItem(userID, listID, itemID string, req *models.UpdateShoppingItemRequest) (*models.ShoppingListDetail, error) {
+	list, items, err := s.getListWithItems(userID, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	item := findShoppingItemByID(items, itemID)
+	if item == nil {
+		return nil, ErrShoppingItemNotFound
+	}
+
+	unit := strings.TrimSpace(req.Unit)
+	if unit == "" {
+		unit = item.Unit
+	}
+
+	// 与添加清单项、生成清单一致，可换算的单位统一为食材推荐单位；食材已停用时按填写的单位保存
+	ingredients, err := s.ingredientRepo.GetActiveByIDs([]string{item.IngredientID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ingredient: %w", err)
+	}
+	defaultUnit := ""
+	profile := utils.UnitProfile{}
+	if ingredient, exists := ingredients[item.IngredientID]; exists {
+		defaultUnit = ingredient.DefaultUnit
+		profile = utils.UnitProfile{Density: ingredient.Density, PieceWeight: ingredient.PieceWeight}
+	}
+
+	if target := applyShoppingItemUpdate(items, item, req.TotalAmount, unit, defaultUnit, profile); target != item {
+		if err := s.shoppingRepo.MergeItem(target, item.ID); err != nil {
+			return nil, s.mapItemError(err, "failed to merge shopping list item")
+		}
+		return s.GetShoppingList(userID, list.ID)
+	}
+
+	if err := s.shoppingRepo.UpdateItem(item); err != nil {
+		return nil, s.mapItemError(err, "failed to update shopping list item")
+	}
+
+	return s.GetShoppingList(userID, list.ID)
+}
+
+// applyShoppingItemUpdate 将修改后的数量与单位换算为食材推荐单位后应用到清单项
+// 换算后与同食材同单位的其他清单项重复时合并到该清单项并返回它，否则修改 item 本身并返回 item
+func applyShoppingItemUpdate(items []*models.ShoppingListItem, item *models.ShoppingListItem, amount float64, unit, defaultUnit string, profile utils.UnitProfile) *models.ShoppingListItem {
+	amount, unit = normalizeIngredientAmount(amount, unit, defaultUnit, profile)
+
+	if other := findShoppingItem(items, shoppingItemKey(item.IngredientID, unit), item.ID); other != nil {
+		other.TotalAmount = roundAmount(other.TotalAmount + amount)
+		other.Status = models.ShoppingItemStatusPending
+		other.IsManual = true
+		return other
+	}
+
+	item.TotalAmount = roundAmount(amount)
+	item.Unit = unit
+	item.IsManual = true
+	return item
+}
+
+// RemoveShoppingItem 删除清单项
+func (s *ShoppingService) RemoveShoppingItem(userID, listID, itemID string) (*models.ShoppingListDetail, error) {
+	list, err := s.getList(userID, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.shoppingRepo.DeleteItem(itemID, list.ID); err != nil {
+		return nil, s.mapItemError(err, "failed to delete shopping list item")
+	}
+
+	return s.GetShoppingList(userID, list.ID)
+}
+
+// ToggleShoppingItem 切换清单项的购买状态，全部购买后清单自动完成
+func (s *ShoppingService) ToggleShoppingItem(userID, listID, itemID string) (*models.ShoppingListDetail, error) {
+	list, items, err := s.getListWithItems(userID, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	item := findShoppingItemByID(items, itemID)
+	if item == nil {
+		return nil, ErrShoppingItemNotFound
+	}
+
+	if item.Status == models.ShoppingItemStatusPurchased {
+		item.Status = models.ShoppingItemStatusPending
+	} else {
+		item.Status = models.ShoppingItemStatusPurchased
+	}
+
+	if err := s.shoppingRepo.UpdateItem(item); err != nil {
+		return nil, s.mapItemError(err, "failed to update shopping list item")
+	}
+
+	return s.GetShoppingList(userID, list.ID)
+}
+
+// RegenerateShoppingList 按原来源重新汇总菜单食材，保留手动添加或修改的清单项及已购买状态
+func (s *ShoppingService) RegenerateShoppingList(userID, listID string) (*models.ShoppingListDetail, error) {
+	list, items, err := s.getListWithItems(userID, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	var menuIDs []string
+	if list.Source == models.ShoppingListSourceMenus {
+		menuIDs, err = s.shoppingRepo.GetListMenuIDs(list.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get shopping list menus: %w", err)
+		}
+	} else {
+		menus, err := s.menuRepo.GetMenusByDateRange(list.FamilyID, list.StartDate, list.EndDate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get menus: %w", err)
+		}
+		for _, menu := range menus {
+			menuIDs = append(menuIDs, menu.ID)
+		}
+	}
+
+	menuIngredients, err := s.menuRepo.GetIngredientsByMenuIDs(menuIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get menu ingredients: %w", err)
+	}
+
+	manual := make([]*models.ShoppingListItem, 0)
+	manualKeys := make(map[string]struct{})
+	purchasedKeys := make(map[string]struct{})
+	for _, item := range items {
+		key := shoppingItemKey(item.IngredientID, item.Unit)
+		if item.IsManual {
+			manual = append(manual, item)
+			manualKeys[key] = struct{}{}
+			continue
+		}
+		if item.Status == models.ShoppingItemStatusPurchased {
+			purchasedKeys[key] = struct{}{}
+		}
+	}
+
+	generated := make([]*models.ShoppingListItem, 0)
+	for _, item := range aggregateShoppingItems(menuIngredients) {
+		key := shoppingItemKey(item.IngredientID, item.Unit)
+		if _, exists := manualKeys[key]; exists {
+			continue
+		}
+		if _, exists := purchasedKeys[key]; exists {
+			item.Status = models.ShoppingItemStatusPurchased
+		}
+		generated = append(generated, item)
+	}
+
+	all := make([]*models.ShoppingListItem, 0, len(generated)+len(manual))
+	all = append(all, generated...)
+	all = append(all, manual...)
+	sortShoppingItems(all)
+
+	if err := s.shoppingRepo.ReplaceGeneratedItems(list, generated, manual, menuIDs); err != nil {
+		if errors.Is(err, repositories.ErrShoppingListNotFound) {
+			return nil, ErrShoppingListNotFound
+		}
+		return nil, fmt.Errorf("failed to regenerate shopping list: %w", err)
+	}
+
+	return s.GetShoppingList(userID, list.ID)
+}
+
+func (s *ShoppingService) getList(userID, listID string) (*models.ShoppingList, error) {
+	family, err := s.getFamilyForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := s.shoppingRepo.GetListByID(listID, family.ID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrShoppingListNotFound) {
+			return nil, ErrShoppingListNotFound
+		}
+		return nil, fmt.Errorf("failed to get shopping list: %w", err)
+	}
+
+	return list, nil
+}
+
+func (s *ShoppingService) getListWithItems(userID, listID string) (*models.ShoppingList, []*models.ShoppingListItem, error) {
+	list, err := s.getList(userID, listID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	items, err := s.shoppingRepo.GetListItems(list.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get shopping list items: %w", err)
+	}
+
+	return list, items, nil
+}
+
+func (s *ShoppingService) mapItemError(err error, message string) error {
+	if errors.Is(err, repositories.ErrShoppingItemNotFound) {
+		return ErrShoppingItemNotFound
+	}
+	return fmt.Errorf("%s: %w", message, err)
+}
+
+// resolveMenus 根据请求解析出参与汇总的菜单
+func (s *ShoppingService) resolveMenus(familyID string, req *models.GenerateShoppingListRequest) ([]*models.Menu, error) {
+	if len(req.MenuIDs) > 0 {
+		menus := make([]*models.Menu, 0, len(req.MenuIDs))
+		seen := make(map[string]struct{}, len(req.MenuIDs))
+		for _, menuID := range req.MenuIDs {
+			if _, exists := seen[menuID]; exists {
+				continue
+			}
+			seen[menuID] = struct{}{}
+
+			menu, err := s.menuRepo.GetMenuByID(menuID, familyID)
+			if err != nil {
+				if errors.Is(err, repositories.ErrMenuNotFound) {
+					return nil, ErrMenuNotFound
+				}
+				return nil, fmt.Errorf("failed to get menu: %w", err)
+			}
+			menus = append(menus, menu)
+		}
+		return menus, nil
+	}
+
+	if req.StartDate == "" || req.EndDate == "" {
+		return nil, ErrInvalidShoppingRange
+	}
+
+	startDate, err := parseDate(req.StartDate)
+	if err != nil {
+		return nil, ErrInvalidMenuDate
+	}
+	endDate, err := parseDate(req.EndDate)
+	if err != nil {
+		return nil, ErrInvalidMenuDate
+	}
+	if endDate.Before(startDate) || endDate.Sub(startDate).Hours()/24 >= maxShoppingRangeDays {
+		return nil, ErrInvalidShoppingRange
+	}
+
+	menus, err := s.menuRepo.GetMenusByDateRange(familyID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get menus: %w", err)
+	}
+
+	return menus, nil
+}
+
+func (s *ShoppingService) getFamilyForUser(userID string) (*models.Family, error) {
+	family, err := s.familyRepo.GetFamilyByUserID(userID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrFamilyNotFound) {
+			return nil, ErrFamilyNotFound
+		}
+		return nil, fmt.Errorf("failed to get family: %w", err)
+	}
+	return family, nil
+}
+
+// aggregateShoppingItems 按 ingredient_id + unit 汇总菜单食材数量
+// 各菜式用量先按本餐份数缩放，可换算的单位再统一为食材推荐单位；
+// 缩放后的用量累加完成后才按单位步长取整，避免每道菜各自取整（含至少一个步长）后放大总量
+func aggregateShoppingItems(rows []*models.MenuIngredient) []*models.ShoppingListItem {
+	items := make([]*models.ShoppingListItem, 0, len(rows))
+	index := make(map[string]*models.ShoppingListItem, len(rows))
+	// scaledKeys 记录按份数缩放过的汇总项，这些项在累加完成后统一按单位步长取整
+	scaledKeys := make(map[string]bool, len(rows))
+
+	for _, row := range rows {
+		profile := utils.UnitProfile{Density: row.Density, PieceWeight: row.PieceWeight}
+		scaled, isScaled := utils.ScaleServings(row.Amount, row.BaseServings, row.Servings)
+		amount, unit := normalizeIngredientAmount(scaled, row.Unit, row.DefaultUnit, profile)
+		key := shoppingItemKey(row.IngredientID, unit)
+		if isScaled {
+			scaledKeys[key] = true
+		}
+		if item, exists := index[key]; exists {
+			item.TotalAmount += amount
+			continue
+		}
+
+		item := &models.ShoppingListItem{
+			ID:             utils.GenerateULID(),
+			IngredientID:   row.IngredientID,
+			IngredientName: row.IngredientName,
+			Category:       row.Category,
+			TotalAmount:    amount,
+			Unit:           unit,
+			Status:         models.ShoppingItemStatusPending,
+		}
+		if row.StorageDays != nil {
+			value := *row.StorageDays
+			item.StorageDays = &value
+		}
+
+		index[key] = item
+		items = append(items, item)
+	}
+
+	for key, item := range index {
+		if scaledKeys[key] {
+			item.TotalAmount = utils.RoundToUnitStep(item.TotalAmount, item.Unit)
+		}
+		item.TotalAmount = roundAmount(item.TotalAmount)
+	}
+
+	sortShoppingItems(items)
+	return items
+}
+
+// sortShoppingItems 按分类、名称排序并重排 sort_order
+func sortShoppingItems(items []*models.ShoppingListItem) {
+	sort.SliceStable(items, func(i, j int) bool {
+		ci, cj := shoppingCategory(items[i].Category), shoppingCategory(items[j].Category)
+		if ci != cj {
+			if ci == models.ShoppingCategoryOther {
+				return false
+			}
+			if cj == models.ShoppingCategoryOther {
+				return true
+			}
+			return ci < cj
+		}
+		return items[i].IngredientName < items[j].IngredientName
+	})
+
+	for idx, item := range items {
+		item.SortOrder = idx + 1
+	}
+}
+
+func buildShoppingListDetail(list *models.ShoppingList, items []*models.ShoppingListItem) *models.ShoppingListDetail {
+	groups := make([]*models.ShoppingListGroup, 0)
+	groupIndex := make(map[string]*models.ShoppingListGroup)
+	purchased := 0
+
+	for _, item := range items {
+		if item.Status == models.ShoppingItemStatusPurchased {
+			purchased++
+		}
+
+		category := shoppingCategory(item.Category)
+		group, exists := groupIndex[category]
+		if !exists {
+			group = &models.ShoppingListGroup{
+				Category: category,
+				Items:    []*models.ShoppingListItem{},
+			}
+			groupIndex[category] = group
+			groups = append(groups, group)
+		}
+		group.Items = append(group.Items, item)
+	}
+
+	return &models.ShoppingListDetail{
+		ListID:         list.ID,
+		FamilyID:       list.FamilyID,
+		Name:           list.Name,
+		StartDate:      formatDate(list.StartDate),
+		EndDate:        formatDate(list.EndDate),
+		Status:         list.Status,
+		Source:         list.Source,
+		CreatedBy:      list.CreatedBy,
+		Groups:         groups,
+		TotalItems:     len(items),
+		PurchasedItems: purchased,
+		CreatedAt:      list.CreatedAt,
+		UpdatedAt:      list.UpdatedAt,
+	}
+}
+
+func findShoppingItemByID(items []*models.ShoppingListItem, itemID string) *models.ShoppingListItem {
+	for _, item := range items {
+		if item.ID == itemID {
+			return item
+		}
+	}
+	return nil
+}
+
+// findShoppingItem 按合并键查找清单项，excludeID 用于排除自身
+func findShoppingItem(items []*models.ShoppingListItem, key, excludeID string) *models.ShoppingListItem {
+	for _, item := range items {
+		if item.ID != excludeID && shoppingItemKey(item.IngredientID, item.Unit) == key {
+			return item
+		}
+	}
+	return nil
+}
+
+// shoppingItemKey 合并键，单位别名（如“克”与“g”）视为同一单位
+func shoppingItemKey(ingredientID, unit string) string {
+	if known, ok := utils.LookupUnit(unit); ok {
+		return ingredientID + "|" + known.Name
+	}
+	return ingredientID + "|" + strings.ToLower(strings.TrimSpace(unit))
+}
+
+func shoppingCategory(category string) string {
+	category = strings.TrimSpace(category)
+	if category == "" {
+		return models.ShoppingCategoryOther
+	}
+	return category
+}
+
+// roundAmount 保留两位小数，与 DECIMAL(10,2) 一致
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
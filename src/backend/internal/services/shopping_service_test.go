@@ -0,0 +1,140 @@
+package services
+
+import (
+	"math"
+	"testing"
+
+	"onetaste-family/backend/internal/models"
+	"onetaste-family/backend/internal/utils"
+)
+
+func TestAggregateShoppingItems(t *testing.T) {
+	type want struct {
+		unit   string
+		amount float64
+	}
+
+	tests := []struct {
+		name string
+		rows []*models.MenuIngredient
+		want []want
+	}{
+		{
+			name: "缩放后先累加再取整",
+			rows: []*models.MenuIngredient{
+				{IngredientID: "egg", Amount: 1, Unit: "个", BaseServings: 4, Servings: 1},
+				{IngredientID: "egg", Amount: 1, Unit: "个", BaseServings: 4, Servings: 1},
+			},
+			want: []want{{unit: "个", amount: 1}},
+		},
+		{
+			name: "克的缩放误差不累积",
+			rows: []*models.MenuIngredient{
+				{IngredientID: "pork", Amount: 100, Unit: "g", BaseServings: 3, Servings: 2},
+				{IngredientID: "pork", Amount: 100, Unit: "g", BaseServings: 3, Servings: 2},
+			},
+			want: []want{{unit: "g", amount: 133}},
+		},
+		{
+			name: "未缩放的用量不按步长取整",
+			rows: []*models.MenuIngredient{
+				{IngredientID: "onion", Amount: 0.5, Unit: "个", BaseServings: 2, Servings: 2},
+			},
+			want: []want{{unit: "个", amount: 0.5}},
+		},
+		{
+			name: "有缩放的汇总项整体取整",
+			rows: []*models.MenuIngredient{
+				{IngredientID: "onion", Amount: 0.5, Unit: "个", BaseServings: 2, Servings: 2},
+				{IngredientID: "onion", Amount: 1, Unit: "个", BaseServings: 2, Servings: 3},
+			},
+			want: []want{{unit: "个", amount: 2}},
+		},
+		{
+			name: "缩放后的总量至少保留一个步长",
+			rows: []*models.MenuIngredient{
+				{IngredientID: "salt", Amount: 0.5, Unit: "茶匙", BaseServings: 4, Servings: 1},
+			},
+			want: []want{{unit: "茶匙", amount: 0.5}},
+		},
+		{
+			name: "不同单位分开汇总",
+			rows: []*models.MenuIngredient{
+				{IngredientID: "egg", Amount: 1, Unit: "个", BaseServings: 2, Servings: 2},
+				{IngredientID: "egg", Amount: 50, Unit: "g", BaseServings: 2, Servings: 2},
+			},
+			want: []want{{unit: "个", amount: 1}, {unit: "g", amount: 50}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := aggregateShoppingItems(tt.rows)
+			if len(items) != len(tt.want) {
+				t.Fatalf("got %d items, want %d", len(items), len(tt.want))
+			}
+
+			got := make(map[string]float64, len(items))
+			for _, item := range items {
+				got[item.Unit] = item.TotalAmount
+			}
+			for _, w := range tt.want {
+				amount, ok := got[w.unit]
+				if !ok {
+					t.Fatalf("missing item with unit %q", w.unit)
+				}
+				if math.Abs(amount-w.amount) > 1e-9 {
+					t.Fatalf("unit %q total = %v, want %v", w.unit, amount, w.amount)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyShoppingItemUpdate(t *testing.T) {
+	pieceWeight := 50.0
+
+	tests := []struct {
+		name        string
+		amount      float64
+		unit        string
+		defaultUnit string
+		profile     utils.UnitProfile
+		wantTarget  string // 合并到的清单项，等于被修改项时表示未合并
+		wantAmount  float64
+		wantUnit    string
+	}{
+		{name: "换算后与已有清单项重复时合并", amount: 0.5, unit: "kg", defaultUnit: "g", wantTarget: "grams", wantAmount: 800, wantUnit: "g"},
+		{name: "单位别名视为同一单位", amount: 200, unit: "克", defaultUnit: "g", wantTarget: "grams", wantAmount: 500, wantUnit: "g"},
+		{name: "按单个重量换算后合并", amount: 2, unit: "个", defaultUnit: "g", profile: utils.UnitProfile{PieceWeight: &pieceWeight}, wantTarget: "grams", wantAmount: 400, wantUnit: "g"},
+		{name: "无法换算时保留填写的单位", amount: 2, unit: "个", defaultUnit: "g", wantTarget: "edited", wantAmount: 2, wantUnit: "个"},
+		{name: "换算为推荐单位且不重复", amount: 1, unit: "斤", defaultUnit: "kg", wantTarget: "edited", wantAmount: 0.5, wantUnit: "kg"},
+		{name: "食材没有推荐单位时按填写保存", amount: 0.5, unit: "kg", wantTarget: "edited", wantAmount: 0.5, wantUnit: "kg"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grams := &models.ShoppingListItem{ID: "grams", IngredientID: "pork", TotalAmount: 300, Unit: "g", Status: models.ShoppingItemStatusPurchased}
+			edited := &models.ShoppingListItem{ID: "edited", IngredientID: "pork", TotalAmount: 1, Unit: "块"}
+			other := &models.ShoppingListItem{ID: "other", IngredientID: "beef", TotalAmount: 100, Unit: "g"}
+			items := []*models.ShoppingListItem{grams, edited, other}
+
+			target := applyShoppingItemUpdate(items, edited, tt.amount, tt.unit, tt.defaultUnit, tt.profile)
+			if target.ID != tt.wantTarget {
+				t.Fatalf("target = %s, want %s", target.ID, tt.wantTarget)
+			}
+			if math.Abs(target.TotalAmount-tt.wantAmount) > 1e-9 || target.Unit != tt.wantUnit {
+				t.Fatalf("target = %v %s, want %v %s", target.TotalAmount, target.Unit, tt.wantAmount, tt.wantUnit)
+			}
+			if !target.IsManual {
+				t.Fatal("updated item should be marked manual")
+			}
+			if target == grams && grams.Status != models.ShoppingItemStatusPending {
+				t.Fatalf("merged item status = %s, want pending", grams.Status)
+			}
+			if other.TotalAmount != 100 {
+				t.Fatal("item of another ingredient should not change")
+			}
+		})
+	}
+}
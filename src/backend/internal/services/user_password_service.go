@@ -0,0 +1,232 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"onetaste-family/backend/internal/models"
+	"onetaste-family/backend/internal/repositories"
+	"onetaste-family/backend/internal/utils"
+)
+
+var (
+	// ErrOldPasswordMismatch 当前密码不正确
+	ErrOldPasswordMismatch = errors.New("old password mismatch")
+	// ErrInvalidResetCode 短信验证码错误或已过期
+	ErrInvalidResetCode = errors.New("invalid reset code")
+	// ErrResetCodeTooFrequent 短信验证码发送过于频繁
+	ErrResetCodeTooFrequent = errors.New("reset code too frequent")
+	// ErrInvalidPassword 新密码不符合要求
+	ErrInvalidPassword = errors.New("password must be at least 6 characters")
+)
+
+const (
+	resetCodeKeyPrefix         = "password_reset_code:"
+	resetCodeAttemptsKeyPrefix = "password_reset_attempts:"
+	resetCodeCooldownKeyPrefix = "password_reset_cooldown:"
+
+	resetCodeLength      = 6
+	resetCodeTTL         = 10 * time.Minute
+	resetCodeCooldown    = time.Minute
+	resetCodeMaxAttempts = 5
+)
+
+// ChangePassword 已登录用户修改密码，成功后吊销除当前会话以外的全部会话
+func (s *UserService) ChangePassword(ctx context.Context, userID, sessionID string, req *models.ChangePasswordRequest) (*models.ChangePasswordResponse, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		if err == repositories.ErrUserNotFound {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if !utils.CheckPassword(req.OldPassword, user.Password) {
+		return nil, ErrOldPasswordMismatch
+	}
+
+	if err := s.updatePassword(user.ID, req.NewPassword); err != nil {
+		return nil, err
+	}
+
+	count, err := s.sessionService.RevokeOtherSessions(ctx, user.ID, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	return &models.ChangePasswordResponse{RevokedSessions: count}, nil
+}
+
+// SendPasswordResetCode 校验图形验证码后向手机号发送重置密码短信验证码
+// 手机号未注册时同样返回成功，避免被用来探测账号
+func (s *UserService) SendPasswordResetCode(ctx context.Context, req *models.SendResetCodeRequest) (*models.SendResetCodeResponse, error) {
+	if !utils.ValidatePhone(req.Phone) {
+		return nil, fmt.Errorf("invalid phone format")
+	}
+
+	if err := s.captchaService.ValidateCaptcha(ctx, req.CaptchaKey, req.VerifyCode); err != nil {
+		switch {
+		case errors.Is(err, ErrCaptchaExpired):
+			return nil, ErrCaptchaExpired
+		case errors.Is(err, ErrInvalidVerifyCode):
+			return nil, ErrInvalidVerifyCode
+		default:
+			return nil, fmt.Errorf("failed to validate captcha: %w", err)
+		}
+	}
+
+	if s.redis == nil {
+		return nil, errors.New("redis client is not initialized")
+	}
+
+	acquired, err := s.redis.SetNX(ctx, resetCodeCooldownKeyPrefix+req.Phone, "1", resetCodeCooldown).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to check reset code cooldown: %w", err)
+	}
+	if !acquired {
+		return nil, ErrResetCodeTooFrequent
+	}
+
+	resp := &models.SendResetCodeResponse{
+		ExpireIn: int64(resetCodeTTL.Seconds()),
+		ResendIn: int64(resetCodeCooldown.Seconds()),
+	}
+
+	exists, err := s.userRepo.ExistsByPhone(req.Phone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check phone exists: %w", err)
+	}
+	if !exists {
+		return resp, nil
+	}
+
+	code, err := generateResetCode()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate reset code: %w", err)
+	}
+
+	_, err = s.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, resetCodeKeyPrefix+req.Phone, code, resetCodeTTL)
+		pipe.Del(ctx, resetCodeAttemptsKeyPrefix+req.Phone)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store reset code: %w", err)
+	}
+
+	message := fmt.Sprintf("【一味家】您正在重置密码，验证码 %s，%d分钟内有效。如非本人操作请忽略。", code, int(resetCodeTTL.Minutes()))
+	if err := s.smsSender.Send(ctx, req.Phone, message); err != nil {
+		s.redis.Del(ctx, resetCodeKeyPrefix+req.Phone, resetCodeCooldownKeyPrefix+req.Phone)
+		return nil, fmt.Errorf("failed to send reset code: %w", err)
+	}
+
+	return resp, nil
+}
+
+// ResetPassword 使用短信验证码重置密码，成功后吊销该用户全部会话
+// 验证码连续输错达到上限后作废，需重新获取
+func (s *UserService) ResetPassword(ctx context.Context, req *models.ResetPasswordRequest) error {
+	if !utils.ValidatePhone(req.Phone) {
+		return fmt.Errorf("invalid phone format")
+	}
+	// 先校验新密码，避免密码不合规时白白消耗验证码
+	if !utils.ValidatePassword(req.NewPassword) {
+		return ErrInvalidPassword
+	}
+
+	if s.redis == nil {
+		return errors.New("redis client is not initialized")
+	}
+
+	codeKey := resetCodeKeyPrefix + req.Phone
+	stored, err := s.redis.Get(ctx, codeKey).Result()
+	if errors.Is(err, redis.Nil) {
+		return ErrInvalidResetCode
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get reset code: %w", err)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(stored), []byte(req.Code)) != 1 {
+		attemptsKey := resetCodeAttemptsKeyPrefix + req.Phone
+		attempts, err := s.redis.Incr(ctx, attemptsKey).Result()
+		if err != nil {
+			return fmt.Errorf("failed to record reset code attempt: %w", err)
+		}
+		s.redis.Expire(ctx, attemptsKey, resetCodeTTL)
+		if attempts >= resetCodeMaxAttempts {
+			s.redis.Del(ctx, codeKey, attemptsKey)
+		}
+		return ErrInvalidResetCode
+	}
+
+	// 验证码只能使用一次，删除失败说明已被并发请求使用
+	deleted, err := s.redis.Del(ctx, codeKey, resetCodeAttemptsKeyPrefix+req.Phone).Result()
+	if err != nil {
+		return fmt.Errorf("failed to consume reset code: %w", err)
+	}
+	if deleted == 0 {
+		return ErrInvalidResetCode
+	}
+
+	user, err := s.userRepo.GetByPhone(req.Phone)
+	if err != nil {
+		if err == repositories.ErrUserNotFound {
+			return ErrInvalidResetCode
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if err := s.updatePassword(user.ID, req.NewPassword); err != nil {
+		return err
+	}
+
+	if _, err := s.sessionService.RevokeAllSessions(ctx, user.ID); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	// 重置成功后解除该手机号的登录限制
+	return s.loginGuard.Reset(ctx, req.Phone)
+}
+
+func (s *UserService) updatePassword(userID, password string) error {
+	if !utils.ValidatePassword(password) {
+		return ErrInvalidPassword
+	}
+
+	hashedPassword, err := utils.HashPassword(password)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	if err := s.userRepo.UpdatePassword(userID, hashedPassword); err != nil {
+		if err == repositories.ErrUserNotFound {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+
+	return nil
+}
+
+// generateResetCode 生成6位数字短信验证码
+func generateResetCode() (string, error) {
+	max := big.NewInt(1)
+	for i := 0; i < resetCodeLength; i++ {
+		max.Mul(max, big.NewInt(10))
+	}
+
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%0*d", resetCodeLength, n.Int64()), nil
+}
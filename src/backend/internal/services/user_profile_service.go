@@ -0,0 +1,163 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+
+	"onetaste-family/backend/internal/models"
+	"onetaste-family/backend/internal/repositories"
+	"onetaste-family/backend/pkg/storage"
+)
+
+var (
+	// ErrInvalidAvatar 头像不是当前用户上传的文件或文件不存在
+	ErrInvalidAvatar = errors.New("invalid avatar")
+)
+
+// avatarDirectory 用户头像在对象存储中的目录
+func avatarDirectory(userID string) string {
+	return "user/head/" + userID
+}
+
+// UpdateProfile 更新昵称、头像与饮食偏好，未传的字段保持不变
+// 头像必须是当前用户上传到自己头像目录下的文件，替换后删除旧头像文件
+func (s *UserService) UpdateProfile(ctx context.Context, userID string, req *models.UpdateProfileRequest) (*models.UserInfoResponse, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		if err == repositories.ErrUserNotFound {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if nickname := strings.TrimSpace(req.Nickname); nickname != "" {
+		user.Nickname = nickname
+	}
+
+	if req.DietaryPreferences != nil {
+		user.DietaryPreferences = normalizeDietaryPreferences(req.DietaryPreferences)
+	}
+
+	oldAvatar := user.Avatar
+	if avatar := strings.TrimSpace(req.Avatar); avatar != "" && avatar != oldAvatar {
+		if err := s.verifyAvatar(ctx, userID, avatar); err != nil {
+			return nil, err
+		}
+		user.Avatar = avatar
+	}
+
+	if err := s.userRepo.UpdateProfile(user); err != nil {
+		if err == repositories.ErrUserNotFound {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to update profile: %w", err)
+	}
+
+	if user.Avatar != oldAvatar {
+		s.removeAvatar(ctx, userID, oldAvatar)
+	}
+
+	return s.GetUserInfo(userID)
+}
+
+// UploadAvatar 上传头像到当前用户的头像目录并设为新头像，替换后删除旧头像文件
+func (s *UserService) UploadAvatar(ctx context.Context, userID string, reader io.Reader, input *models.MediaUploadInput) (*models.UserInfoResponse, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		if err == repositories.ErrUserNotFound {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	input.Directory = avatarDirectory(userID)
+	upload, err := s.mediaService.UploadMedia(ctx, reader, input)
+	if err != nil {
+		return nil, err
+	}
+
+	oldAvatar := user.Avatar
+	user.Avatar = upload.URL
+	if err := s.userRepo.UpdateProfile(user); err != nil {
+		s.removeAvatar(ctx, userID, upload.URL)
+		if err == repositories.ErrUserNotFound {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to update avatar: %w", err)
+	}
+
+	s.removeAvatar(ctx, userID, oldAvatar)
+
+	return s.GetUserInfo(userID)
+}
+
+// verifyAvatar 校验头像地址指向当前用户头像目录下已存在的文件
+func (s *UserService) verifyAvatar(ctx context.Context, userID, avatar string) error {
+	objectKey, ok := ownAvatarObjectKey(userID, avatar)
+	if !ok {
+		return ErrInvalidAvatar
+	}
+
+	exists, err := storage.Exists(ctx, objectKey)
+	if err != nil {
+		return fmt.Errorf("failed to check avatar object: %w", err)
+	}
+	if !exists {
+		return ErrInvalidAvatar
+	}
+
+	return nil
+}
+
+// removeAvatar 删除不再使用的头像文件，仅处理当前用户头像目录下的文件，失败只记录日志
+func (s *UserService) removeAvatar(ctx context.Context, userID, avatar string) {
+	objectKey, ok := ownAvatarObjectKey(userID, avatar)
+	if !ok {
+		return
+	}
+
+	if err := storage.Remove(ctx, objectKey); err != nil {
+		log.Printf("failed to remove avatar %s: %v", objectKey, err)
+	}
+}
+
+func ownAvatarObjectKey(userID, avatar string) (string, bool) {
+	if avatar == "" {
+		return "", false
+	}
+
+	objectKey, ok := storage.ObjectKeyFromURL(avatar)
+	if !ok {
+		return "", false
+	}
+
+	prefix := avatarDirectory(userID) + "/"
+	rest := strings.TrimPrefix(objectKey, prefix)
+	if rest == objectKey || rest == "" || strings.Contains(rest, "/") {
+		return "", false
+	}
+
+	return objectKey, true
+}
+
+// normalizeDietaryPreferences 去除空白与重复的偏好标签，保持原有顺序
+func normalizeDietaryPreferences(preferences []string) []string {
+	seen := make(map[string]struct{}, len(preferences))
+	result := make([]string, 0, len(preferences))
+	for _, preference := range preferences {
+		preference = strings.TrimSpace(preference)
+		if preference == "" {
+			continue
+		}
+		if _, ok := seen[preference]; ok {
+			continue
+		}
+		seen[preference] = struct{}{}
+		result = append(result, preference)
+	}
+	return result
+}
@@ -4,28 +4,45 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
 
 	"onetaste-family/backend/internal/models"
 	"onetaste-family/backend/internal/repositories"
 	"onetaste-family/backend/internal/utils"
+	"onetaste-family/backend/pkg/cache"
+	"onetaste-family/backend/pkg/sms"
 )
 
 // UserService 用户业务逻辑层
 type UserService struct {
+	redis          *redis.Client
 	userRepo       *repositories.UserRepository
 	captchaService *CaptchaService
+	sessionService *SessionService
+	loginGuard     *LoginGuardService
+	smsSender      sms.SMSSender
+	mediaService   *MediaService
+	membership     *MembershipService
 }
 
 // NewUserService 创建用户Service
 func NewUserService() *UserService {
 	return &UserService{
+		redis:          cache.GetRedis(),
 		userRepo:       repositories.NewUserRepository(),
 		captchaService: NewCaptchaService(),
+		sessionService: NewSessionService(),
+		loginGuard:     NewLoginGuardService(),
+		smsSender:      sms.GetSender(),
+		mediaService:   NewMediaService(),
+		membership:     NewMembershipService(),
 	}
 }
 
 // Register 用户注册
-func (s *UserService) Register(ctx context.Context, req *models.RegisterRequest) (*models.RegisterResponse, error) {
+func (s *UserService) Register(ctx context.Context, req *models.RegisterRequest, device models.SessionDevice) (*models.RegisterResponse, error) {
 	// 验证手机号格式
 	if !utils.ValidatePhone(req.Phone) {
 		return nil, fmt.Errorf("invalid phone format")
@@ -79,50 +96,86 @@ func (s *UserService) Register(ctx context.Context, req *models.RegisterRequest)
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 
-	// 生成JWT Token
-	token, err := utils.GenerateToken(user.ID)
+	// 创建登录会话并签发令牌
+	tokens, err := s.sessionService.CreateSession(ctx, user.ID, device)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate token: %w", err)
+		return nil, fmt.Errorf("failed to create session: %w", err)
 	}
 
 	return &models.RegisterResponse{
-		UserID: user.ID,
-		Token:  token,
+		UserID:           user.ID,
+		Token:            tokens.Token,
+		ExpiresIn:        tokens.ExpiresIn,
+		RefreshToken:     tokens.RefreshToken,
+		RefreshExpiresIn: tokens.RefreshExpiresIn,
 	}, nil
 }
 
 // Login 用户登录
-func (s *UserService) Login(req *models.LoginRequest) (*models.LoginResponse, error) {
+// 同一手机号或IP连续失败后需要图形验证码，继续失败将被要求等待或临时锁定
+func (s *UserService) Login(ctx context.Context, req *models.LoginRequest, device models.SessionDevice) (*models.LoginResponse, error) {
 	// 验证手机号格式
 	if !utils.ValidatePhone(req.Phone) {
 		return nil, fmt.Errorf("invalid phone format")
 	}
 
-	// 获取用户
+	// 检查登录防护状态
+	guard, err := s.loginGuard.Check(ctx, req.Phone, device.IP)
+	if err != nil {
+		return nil, err
+	}
+
+	// 失败次数过多时需要图形验证码
+	if guard.CaptchaRequired {
+		if strings.TrimSpace(req.CaptchaKey) == "" {
+			return nil, &LoginGuardError{Err: ErrCaptchaRequired, Status: guard}
+		}
+		if err := s.captchaService.ValidateCaptcha(ctx, req.CaptchaKey, req.VerifyCode); err != nil {
+			switch {
+			case errors.Is(err, ErrCaptchaExpired):
+				return nil, &LoginGuardError{Err: ErrCaptchaExpired, Status: guard}
+			case errors.Is(err, ErrInvalidVerifyCode):
+				return nil, &LoginGuardError{Err: ErrInvalidVerifyCode, Status: guard}
+			default:
+				return nil, fmt.Errorf("failed to validate captcha: %w", err)
+			}
+		}
+	}
+
+	// 获取用户，手机号不存在同样计入失败次数，避免被用来探测账号
 	user, err := s.userRepo.GetByPhone(req.Phone)
 	if err != nil {
 		if err == repositories.ErrUserNotFound {
-			return nil, ErrInvalidCredentials
+			return nil, s.loginFailed(ctx, req.Phone, device.IP)
 		}
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
 	// 验证密码
 	if !utils.CheckPassword(req.Password, user.Password) {
-		return nil, ErrInvalidCredentials
+		return nil, s.loginFailed(ctx, req.Phone, device.IP)
+	}
+
+	if err := s.loginGuard.Reset(ctx, req.Phone); err != nil {
+		return nil, err
 	}
 
-	// 生成JWT Token
-	token, err := utils.GenerateToken(user.ID)
+	// 创建登录会话并签发令牌
+	tokens, err := s.sessionService.CreateSession(ctx, user.ID, device)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate token: %w", err)
+		return nil, fmt.Errorf("failed to create session: %w", err)
 	}
 
-	return &models.LoginResponse{
-		UserID:    user.ID,
-		Token:     token,
-		ExpiresIn: utils.GetTokenExpiration(),
-	}, nil
+	return tokens, nil
+}
+
+// loginFailed 记录登录失败并返回携带最新防护状态的凭证错误
+func (s *UserService) loginFailed(ctx context.Context, phone, ip string) error {
+	guard, err := s.loginGuard.RecordFailure(ctx, phone, ip)
+	if err != nil {
+		return err
+	}
+	return &LoginGuardError{Err: ErrInvalidCredentials, Status: guard}
 }
 
 // GetUserInfo 获取用户信息
@@ -135,17 +188,26 @@ func (s *UserService) GetUserInfo(userID string) (*models.UserInfoResponse, erro
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
-	// TODO: 获取会员信息（后续实现）
+	membershipResp, err := s.membership.GetMembership(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get membership: %w", err)
+	}
 	membership := models.MembershipInfo{
-		Type:      "free",
-		ExpiresAt: nil,
+		Type:      membershipResp.Type,
+		ExpiresAt: membershipResp.ExpiresAt,
+	}
+
+	preferences := user.DietaryPreferences
+	if preferences == nil {
+		preferences = []string{}
 	}
 
 	return &models.UserInfoResponse{
-		UserID:     user.ID,
-		Phone:      user.Phone,
-		Nickname:   user.Nickname,
-		Avatar:     user.Avatar,
-		Membership: membership,
+		UserID:             user.ID,
+		Phone:              user.Phone,
+		Nickname:           user.Nickname,
+		Avatar:             user.Avatar,
+		Membership:         membership,
+		DietaryPreferences: preferences,
 	}, nil
 }
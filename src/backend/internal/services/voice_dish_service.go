@@ -0,0 +1,208 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"path"
+	"sort"
+	"strings"
+
+	"onetaste-family/backend/internal/models"
+	"onetaste-family/backend/internal/utils"
+	"onetaste-family/backend/pkg/aiclient"
+	"onetaste-family/backend/pkg/storage"
+)
+
+const (
+	// MaxVoiceAudioSize 语音录入音频最大 10MB
+	MaxVoiceAudioSize int64 = 10 * 1024 * 1024
+	// voiceIngredientCandidates 未匹配食材返回的候选数量
+	voiceIngredientCandidates = 5
+	// maxStepMinutes 单个步骤时长上限，与创建菜式的校验一致
+	maxStepMinutes = 1440
+)
+
+// DishTranscriber 将菜式语音转写并整理为结构化草稿
+type DishTranscriber interface {
+	TranscribeDish(ctx context.Context, audioURL, format string) (*aiclient.VoiceToDishResponse, error)
+}
+
+// aiDishTranscriber 由AI服务完成转写与整理，开启 ai_service.stub 时由本地替身服务返回固定结果
+type aiDishTranscriber struct{}
+
+func (aiDishTranscriber) TranscribeDish(ctx context.Context, audioURL, format string) (*aiclient.VoiceToDishResponse, error) {
+	return aiclient.GetClient().VoiceToDish(ctx, &aiclient.VoiceToDishRequest{
+		AudioURL:    audioURL,
+		AudioFormat: format,
+	})
+}
+
+// DraftDishFromVoice 上传菜式语音并整理为菜式草稿，音频转写完成后即删除
+// 识别出的食材按名称匹配食材库，未匹配的食材单独标出；草稿不会保存，用户确认后再调用创建菜式接口。
+func (s *DishService) DraftDishFromVoice(ctx context.Context, userID string, reader io.Reader, input *models.VoiceDishInput) (*models.VoiceDishDraftResponse, error) {
+	if reader == nil {
+		return nil, fmt.Errorf("invalid reader")
+	}
+
+	if input.Size <= 0 || input.Size > MaxVoiceAudioSize {
+		return nil, ErrInvalidMediaSize
+	}
+
+	format, ok := models.VoiceAudioFormat(input.ContentType)
+	if !ok {
+		return nil, ErrUnsupportedMediaType
+	}
+
+	family, err := s.getFamilyForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	objectKey := path.Join("family", family.ID, "voice", utils.GenerateULID()+"."+format)
+	audioURL, err := storage.Upload(ctx, objectKey, reader, input.Size, input.ContentType)
+	if err != nil {
+		return nil, fmt.Errorf("upload voice to storage failed: %w", err)
+	}
+	// 音频只用于本次转写，无论成功失败都在返回前删除；请求被取消时也要删除，因此不继承取消信号
+	defer func() {
+		if removeErr := storage.Remove(context.WithoutCancel(ctx), objectKey); removeErr != nil {
+			log.Printf("failed to remove voice object %s: %v", objectKey, removeErr)
+		}
+	}()
+
+	result, err := s.transcriber.TranscribeDish(ctx, audioURL, format)
+	if err != nil {
+		return nil, aiServiceError(err)
+	}
+
+	name := strings.TrimSpace(result.Dish.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: dish name is empty", ErrAIInvalidResult)
+	}
+
+	draft := &models.CreateDishRequest{
+		Name:        name,
+		Category:    strings.TrimSpace(result.Dish.Category),
+		Description: strings.TrimSpace(result.Dish.Description),
+		Ingredients: make([]models.IngredientInput, 0, len(result.Dish.Ingredients)),
+		Steps:       buildVoiceDishSteps(result.Dish.Steps),
+		Servings:    models.DefaultDishServings,
+	}
+
+	ingredients := make([]*models.VoiceDishIngredient, 0, len(result.Dish.Ingredients))
+	unmatched := 0
+	for _, item := range result.Dish.Ingredients {
+		ingredient, err := s.matchVoiceIngredient(item)
+		if err != nil {
+			return nil, err
+		}
+		if ingredient == nil {
+			continue
+		}
+		ingredients = append(ingredients, ingredient)
+
+		if !ingredient.Matched {
+			unmatched++
+			continue
+		}
+		draft.Ingredients = append(draft.Ingredients, models.IngredientInput{
+			IngredientID: ingredient.IngredientID,
+			Amount:       ingredient.Amount,
+			Unit:         ingredient.Unit,
+			Notes:        ingredient.Notes,
+			SortOrder:    len(draft.Ingredients),
+		})
+	}
+
+	return &models.VoiceDishDraftResponse{
+		Transcript:     strings.TrimSpace(result.Transcript),
+		Draft:          draft,
+		Ingredients:    ingredients,
+		UnmatchedCount: unmatched,
+	}, nil
+}
+
+// matchVoiceIngredient 用食材搜索匹配识别出的食材名称
+// 名称完全一致或搜索结果唯一时视为匹配，否则返回候选供用户选择。名称为空时返回 nil。
+func (s *DishService) matchVoiceIngredient(item aiclient.DraftIngredient) (*models.VoiceDishIngredient, error) {
+	name := strings.TrimSpace(item.Name)
+	if name == "" {
+		return nil, nil
+	}
+
+	ingredient := &models.VoiceDishIngredient{
+		Name:   name,
+		Amount: item.Amount,
+		Unit:   strings.TrimSpace(item.Unit),
+		Notes:  strings.TrimSpace(item.Notes),
+	}
+
+	match, candidates, err := s.searchIngredient(name, voiceIngredientCandidates)
+	if err != nil {
+		return nil, err
+	}
+	if match == nil {
+		ingredient.Candidates = candidates
+		return ingredient, nil
+	}
+
+	ingredient.Matched = true
+	ingredient.IngredientID = match.IngredientID
+	ingredient.IngredientName = match.Name
+	if ingredient.Unit == "" {
+		ingredient.Unit = match.DefaultUnit
+	}
+	return ingredient, nil
+}
+
+// searchIngredient 按名称搜索食材库，名称完全一致或搜索结果唯一时返回匹配项，否则返回候选
+func (s *DishService) searchIngredient(name string, limit int) (*models.IngredientSearchResult, []*models.IngredientSearchResult, error) {
+	results, err := s.ingredientRepo.SearchActiveIngredients(name, limit)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to search ingredients: %w", err)
+	}
+
+	for _, result := range results {
+		if strings.EqualFold(result.Name, name) {
+			return result, nil, nil
+		}
+	}
+	if len(results) == 1 {
+		return results[0], nil, nil
+	}
+
+	return nil, results, nil
+}
+
+// buildVoiceDishSteps 按识别出的顺序整理步骤，去掉空步骤并重新编号
+func buildVoiceDishSteps(drafts []aiclient.DraftStep) []models.CookingStepInput {
+	sorted := make([]aiclient.DraftStep, 0, len(drafts))
+	for _, draft := range drafts {
+		if strings.TrimSpace(draft.Content) != "" {
+			sorted = append(sorted, draft)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].StepOrder < sorted[j].StepOrder
+	})
+
+	steps := make([]models.CookingStepInput, 0, len(sorted))
+	for idx, draft := range sorted {
+		minutes := draft.DurationMinutes
+		if minutes < 0 {
+			minutes = 0
+		}
+		if minutes > maxStepMinutes {
+			minutes = maxStepMinutes
+		}
+
+		steps = append(steps, models.CookingStepInput{
+			Order:           idx + 1,
+			Content:         strings.TrimSpace(draft.Content),
+			DurationMinutes: minutes,
+		})
+	}
+	return steps
+}
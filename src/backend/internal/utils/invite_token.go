@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+
+	"onetaste-family/backend/internal/config"
+)
+
+const inviteTokenPurpose = "family-invitation:"
+
+var (
+	// ErrInvalidInviteToken 邀请令牌无效
+	ErrInvalidInviteToken = errors.New("invalid invite token")
+)
+
+// GenerateInviteToken 为邀请ID生成签名令牌，格式：<邀请ID>.<签名>
+func GenerateInviteToken(invitationID string) string {
+	return invitationID + "." + signInvitation(invitationID)
+}
+
+// ParseInviteToken 校验邀请令牌签名并返回邀请ID
+func ParseInviteToken(token string) (string, error) {
+	invitationID, signature, found := strings.Cut(strings.TrimSpace(token), ".")
+	if !found || len(invitationID) != 26 || signature == "" {
+		return "", ErrInvalidInviteToken
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(signInvitation(invitationID))) {
+		return "", ErrInvalidInviteToken
+	}
+
+	return invitationID, nil
+}
+
+func signInvitation(invitationID string) string {
+	mac := hmac.New(sha256.New, []byte(config.AppConfig.Invite.Secret))
+	mac.Write([]byte(inviteTokenPurpose + invitationID))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -1,6 +1,10 @@
 package utils
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"time"
 
@@ -15,45 +19,49 @@ var (
 
 // Claims JWT Claims
 type Claims struct {
-	UserID string `json:"user_id"`
+	UserID    string `json:"user_id"`
+	SessionID string `json:"sid"` // 登录会话ID，用于会话吊销
 	jwt.RegisteredClaims
 }
 
-// GenerateToken 生成JWT Token
-func GenerateToken(userID string) (string, error) {
-	expirationTime := time.Now().Add(config.AppConfig.JWT.Expiration)
-	
+// GenerateToken 生成访问令牌，每个令牌带唯一 jti 以便加入黑名单
+func GenerateToken(userID, sessionID string) (string, *Claims, error) {
+	now := time.Now()
+	expirationTime := now.Add(config.AppConfig.JWT.Expiration)
+
 	claims := &Claims{
-		UserID: userID,
+		UserID:    userID,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        GenerateULID(),
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			NotBefore: jwt.NewNumericDate(time.Now()),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
 		},
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	tokenString, err := token.SignedString([]byte(config.AppConfig.JWT.Secret))
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
-	return tokenString, nil
+	return tokenString, claims, nil
 }
 
 // ParseToken 解析JWT Token
 func ParseToken(tokenString string) (*Claims, error) {
 	claims := &Claims{}
-	
+
 	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
 		return []byte(config.AppConfig.JWT.Secret), nil
-	})
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
 
 	if err != nil {
 		return nil, err
 	}
 
-	if !token.Valid {
+	if !token.Valid || claims.ID == "" || claims.SessionID == "" {
 		return nil, ErrInvalidToken
 	}
 
@@ -64,3 +72,23 @@ func ParseToken(tokenString string) (*Claims, error) {
 func GetTokenExpiration() int64 {
 	return int64(config.AppConfig.JWT.Expiration.Seconds())
 }
+
+// GenerateRefreshToken 生成不透明的随机刷新令牌
+func GenerateRefreshToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// HashRefreshToken 计算刷新令牌摘要，服务端只保存摘要
+func HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// GetRefreshTokenExpiration 获取刷新令牌过期时间（秒）
+func GetRefreshTokenExpiration() int64 {
+	return int64(config.AppConfig.JWT.RefreshExpiration.Seconds())
+}
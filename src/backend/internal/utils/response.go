@@ -16,6 +16,16 @@ type FieldError struct {
 	Message string `json:"message" example:"手机号格式不正确"`            // 错误消息
 }
 
+// 业务错误码，用于区分同一HTTP状态下需要前端特殊处理的情况
+const (
+	// CodeCaptchaRequired 需要先完成图形验证码
+	CodeCaptchaRequired = 40001
+	// CodeAccountLocked 账号已被临时锁定
+	CodeAccountLocked = 42301
+	// CodeTooManyRequests 操作过于频繁，需等待后重试
+	CodeTooManyRequests = 42901
+)
+
 // Success 成功响应
 func Success(data interface{}) *Response {
 	return &Response{
@@ -42,6 +52,15 @@ func Error(code int, message string) *Response {
 	}
 }
 
+// ErrorWithData 带数据的错误响应
+func ErrorWithData(code int, message string, data interface{}) *Response {
+	return &Response{
+		Code:    code,
+		Message: message,
+		Data:    data,
+	}
+}
+
 // ErrorWithFields 带字段错误的响应
 func ErrorWithFields(code int, message string, errors []FieldError) *Response {
 	return &Response{
@@ -0,0 +1,203 @@
+package utils
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strings"
+)
+
+// UnitDimension 单位维度
+type UnitDimension string
+
+const (
+	// UnitDimensionMass 质量，基准单位为克
+	UnitDimensionMass UnitDimension = "mass"
+	// UnitDimensionVolume 体积，基准单位为毫升
+	UnitDimensionVolume UnitDimension = "volume"
+	// UnitDimensionCount 计数，基准为单个
+	UnitDimensionCount UnitDimension = "count"
+)
+
+var (
+	// ErrUnknownUnit 无法识别的单位
+	ErrUnknownUnit = errors.New("unknown unit")
+	// ErrIncompatibleUnits 单位之间无法换算
+	ErrIncompatibleUnits = errors.New("incompatible units")
+)
+
+// Unit 单位定义
+type Unit struct {
+	Name      string        // 标准名称
+	Dimension UnitDimension // 维度
+	Factor    float64       // 换算到基准单位（克、毫升、个）的系数
+	Aliases   []string      // 别名
+	Step      float64       // 按份数缩放后的取整步长，0表示保留两位小数
+}
+
+// UnitProfile 食材级别的换算参数
+type UnitProfile struct {
+	Density     *float64 // 密度（克/毫升）
+	PieceWeight *float64 // 单个重量（克）
+}
+
+var unitDefinitions = []*Unit{
+	{Name: "g", Dimension: UnitDimensionMass, Factor: 1, Aliases: []string{"克", "gram", "grams"}, Step: 1},
+	{Name: "kg", Dimension: UnitDimensionMass, Factor: 1000, Aliases: []string{"千克", "公斤", "kilogram"}},
+	{Name: "mg", Dimension: UnitDimensionMass, Factor: 0.001, Aliases: []string{"毫克"}},
+	{Name: "斤", Dimension: UnitDimensionMass, Factor: 500, Aliases: []string{"市斤"}},
+	{Name: "两", Dimension: UnitDimensionMass, Factor: 50, Aliases: []string{"市两"}},
+	{Name: "钱", Dimension: UnitDimensionMass, Factor: 5},
+	{Name: "lb", Dimension: UnitDimensionMass, Factor: 453.592, Aliases: []string{"磅", "lbs"}},
+	{Name: "oz", Dimension: UnitDimensionMass, Factor: 28.3495, Aliases: []string{"盎司"}},
+	{Name: "ml", Dimension: UnitDimensionVolume, Factor: 1, Aliases: []string{"毫升", "cc"}, Step: 1},
+	{Name: "l", Dimension: UnitDimensionVolume, Factor: 1000, Aliases: []string{"升", "公升", "litre", "liter"}},
+	{Name: "勺", Dimension: UnitDimensionVolume, Factor: 15, Aliases: []string{"汤匙", "大勺", "汤勺", "tbsp"}, Step: 0.5},
+	{Name: "茶匙", Dimension: UnitDimensionVolume, Factor: 5, Aliases: []string{"小勺", "小匙", "tsp"}, Step: 0.5},
+	{Name: "杯", Dimension: UnitDimensionVolume, Factor: 240, Aliases: []string{"cup"}, Step: 0.25},
+	{Name: "个", Dimension: UnitDimensionCount, Factor: 1, Step: 1},
+	{Name: "只", Dimension: UnitDimensionCount, Factor: 1, Step: 1},
+	{Name: "颗", Dimension: UnitDimensionCount, Factor: 1, Step: 1},
+	{Name: "根", Dimension: UnitDimensionCount, Factor: 1, Step: 1},
+	{Name: "块", Dimension: UnitDimensionCount, Factor: 1, Step: 1},
+	{Name: "片", Dimension: UnitDimensionCount, Factor: 1, Step: 1},
+	{Name: "条", Dimension: UnitDimensionCount, Factor: 1, Step: 1},
+	{Name: "瓣", Dimension: UnitDimensionCount, Factor: 1, Step: 1},
+	{Name: "把", Dimension: UnitDimensionCount, Factor: 1, Step: 1},
+	{Name: "节", Dimension: UnitDimensionCount, Factor: 1, Step: 1},
+	{Name: "张", Dimension: UnitDimensionCount, Factor: 1, Step: 1},
+}
+
+var unitIndex = buildUnitIndex()
+
+func buildUnitIndex() map[string]*Unit {
+	index := make(map[string]*Unit)
+	for _, unit := range unitDefinitions {
+		index[strings.ToLower(unit.Name)] = unit
+		for _, alias := range unit.Aliases {
+			index[strings.ToLower(alias)] = unit
+		}
+	}
+	return index
+}
+
+// LookupUnit 根据名称或别名查找单位（忽略大小写与首尾空白）
+func LookupUnit(name string) (*Unit, bool) {
+	unit, ok := unitIndex[strings.ToLower(strings.TrimSpace(name))]
+	return unit, ok
+}
+
+// ListUnits 返回全部支持的单位，按维度与系数排序
+func ListUnits() []*Unit {
+	units := make([]*Unit, len(unitDefinitions))
+	copy(units, unitDefinitions)
+	sort.SliceStable(units, func(i, j int) bool {
+		if units[i].Dimension != units[j].Dimension {
+			return units[i].Dimension < units[j].Dimension
+		}
+		return units[i].Factor < units[j].Factor
+	})
+	return units
+}
+
+// ConvertUnit 将数量从 from 单位换算到 to 单位
+// 同维度直接换算；体积与质量之间需要密度，计数与质量/体积之间需要单个重量。
+// 不同的计数单位（如“根”与“个”）含义不同，不互相换算。
+func ConvertUnit(amount float64, from, to string, profile UnitProfile) (float64, error) {
+	fromUnit, ok := LookupUnit(from)
+	if !ok {
+		return 0, ErrUnknownUnit
+	}
+	toUnit, ok := LookupUnit(to)
+	if !ok {
+		return 0, ErrUnknownUnit
+	}
+
+	if fromUnit == toUnit {
+		return amount, nil
+	}
+
+	if fromUnit.Dimension == toUnit.Dimension {
+		if fromUnit.Dimension == UnitDimensionCount {
+			return 0, ErrIncompatibleUnits
+		}
+		return amount * fromUnit.Factor / toUnit.Factor, nil
+	}
+
+	grams, err := toGrams(amount, fromUnit, profile)
+	if err != nil {
+		return 0, err
+	}
+	return fromGrams(grams, toUnit, profile)
+}
+
+func toGrams(amount float64, unit *Unit, profile UnitProfile) (float64, error) {
+	switch unit.Dimension {
+	case UnitDimensionMass:
+		return amount * unit.Factor, nil
+	case UnitDimensionVolume:
+		if profile.Density == nil || *profile.Density <= 0 {
+			return 0, ErrIncompatibleUnits
+		}
+		return amount * unit.Factor * *profile.Density, nil
+	default:
+		if profile.PieceWeight == nil || *profile.PieceWeight <= 0 {
+			return 0, ErrIncompatibleUnits
+		}
+		return amount * *profile.PieceWeight, nil
+	}
+}
+
+func fromGrams(grams float64, unit *Unit, profile UnitProfile) (float64, error) {
+	switch unit.Dimension {
+	case UnitDimensionMass:
+		return grams / unit.Factor, nil
+	case UnitDimensionVolume:
+		if profile.Density == nil || *profile.Density <= 0 {
+			return 0, ErrIncompatibleUnits
+		}
+		return grams / *profile.Density / unit.Factor, nil
+	default:
+		if profile.PieceWeight == nil || *profile.PieceWeight <= 0 {
+			return 0, ErrIncompatibleUnits
+		}
+		return grams / *profile.PieceWeight, nil
+	}
+}
+
+// ScaleAmount 按份数缩放数量，并按单位的取整步长修整
+// 例如鸡蛋（个）取整数、勺按半勺取整；数量大于0时至少保留一个步长，未知单位保留两位小数。
+// 份数不变时原样返回。
+func ScaleAmount(amount float64, unit string, baseServings, servings int) float64 {
+	scaled, ok := ScaleServings(amount, baseServings, servings)
+	if !ok {
+		return amount
+	}
+	return RoundToUnitStep(scaled, unit)
+}
+
+// ScaleServings 按份数缩放数量，不做取整；份数无效或不变时返回原数量与 false
+// 需要先累加再取整的场景（如汇总购物清单）使用此函数，避免逐项取整放大误差。
+func ScaleServings(amount float64, baseServings, servings int) (float64, bool) {
+	if baseServings <= 0 || servings <= 0 || baseServings == servings {
+		return amount, false
+	}
+	return amount * float64(servings) / float64(baseServings), true
+}
+
+// RoundToUnitStep 按单位的取整步长修整数量，数量大于0时至少保留一个步长，未知单位保留两位小数
+func RoundToUnitStep(amount float64, unit string) float64 {
+	step := 0.0
+	if u, ok := LookupUnit(unit); ok {
+		step = u.Step
+	}
+	if step <= 0 {
+		return math.Round(amount*100) / 100
+	}
+
+	rounded := math.Round(amount/step) * step
+	if rounded <= 0 && amount > 0 {
+		return step
+	}
+	return rounded
+}
@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"math"
+	"testing"
+)
+
+func TestScaleAmount(t *testing.T) {
+	tests := []struct {
+		name         string
+		amount       float64
+		unit         string
+		baseServings int
+		servings     int
+		want         float64
+	}{
+		{name: "份数不变原样返回", amount: 1.3, unit: "个", baseServings: 2, servings: 2, want: 1.3},
+		{name: "默认份数无效原样返回", amount: 1.3, unit: "个", baseServings: 0, servings: 4, want: 1.3},
+		{name: "目标份数无效原样返回", amount: 1.3, unit: "个", baseServings: 2, servings: 0, want: 1.3},
+		{name: "计数单位取整", amount: 3, unit: "个", baseServings: 4, servings: 2, want: 2},
+		{name: "计数单位放大", amount: 3, unit: "个", baseServings: 2, servings: 4, want: 6},
+		{name: "计数单位至少保留一个", amount: 1, unit: "个", baseServings: 4, servings: 1, want: 1},
+		{name: "勺按半勺取整", amount: 1, unit: "勺", baseServings: 2, servings: 3, want: 1.5},
+		{name: "勺至少保留半勺", amount: 1, unit: "勺", baseServings: 4, servings: 1, want: 0.5},
+		{name: "别名使用同一步长", amount: 1, unit: "tbsp", baseServings: 2, servings: 1, want: 0.5},
+		{name: "杯按四分之一取整", amount: 1, unit: "杯", baseServings: 3, servings: 1, want: 0.25},
+		{name: "克取整数", amount: 100, unit: "g", baseServings: 3, servings: 2, want: 67},
+		{name: "无步长单位保留两位小数", amount: 1, unit: "kg", baseServings: 3, servings: 2, want: 0.67},
+		{name: "未知单位保留两位小数", amount: 1, unit: "撮", baseServings: 3, servings: 2, want: 0.67},
+		{name: "数量为0不补步长", amount: 0, unit: "个", baseServings: 2, servings: 4, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ScaleAmount(tt.amount, tt.unit, tt.baseServings, tt.servings)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Fatalf("ScaleAmount(%v, %q, %d, %d) = %v, want %v", tt.amount, tt.unit, tt.baseServings, tt.servings, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScaleServings(t *testing.T) {
+	tests := []struct {
+		name         string
+		amount       float64
+		baseServings int
+		servings     int
+		want         float64
+		wantScaled   bool
+	}{
+		{name: "按比例缩放不取整", amount: 1, baseServings: 4, servings: 1, want: 0.25, wantScaled: true},
+		{name: "份数不变", amount: 1, baseServings: 2, servings: 2, want: 1},
+		{name: "份数无效", amount: 1, baseServings: 0, servings: 2, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, scaled := ScaleServings(tt.amount, tt.baseServings, tt.servings)
+			if math.Abs(got-tt.want) > 1e-9 || scaled != tt.wantScaled {
+				t.Fatalf("ScaleServings(%v, %d, %d) = %v, %v, want %v, %v", tt.amount, tt.baseServings, tt.servings, got, scaled, tt.want, tt.wantScaled)
+			}
+		})
+	}
+}
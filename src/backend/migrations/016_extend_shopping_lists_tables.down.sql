@@ -0,0 +1,10 @@
+-- 删除购物清单来源菜单关联表
+ALTER TABLE shopping_list_menus DROP CONSTRAINT IF EXISTS fk_shopping_list_menus_menu_id;
+ALTER TABLE shopping_list_menus DROP CONSTRAINT IF EXISTS fk_shopping_list_menus_list_id;
+DROP TABLE IF EXISTS shopping_list_menus;
+
+-- 回滚购物清单项的基础食材字段
+ALTER TABLE shopping_list_items DROP CONSTRAINT IF EXISTS fk_shopping_list_items_ingredient_id;
+DROP INDEX IF EXISTS idx_shopping_list_items_ingredient_id;
+ALTER TABLE shopping_list_items DROP COLUMN IF EXISTS storage_days;
+ALTER TABLE shopping_list_items DROP COLUMN IF EXISTS ingredient_id;
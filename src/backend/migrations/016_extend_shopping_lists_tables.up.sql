@@ -0,0 +1,33 @@
+-- 购物清单项关联基础食材
+ALTER TABLE shopping_list_items ADD COLUMN ingredient_id CHAR(26);
+ALTER TABLE shopping_list_items ADD COLUMN storage_days INT;
+
+COMMENT ON COLUMN shopping_list_items.ingredient_id IS '基础食材ID';
+COMMENT ON COLUMN shopping_list_items.storage_days IS '建议存放天数';
+
+CREATE INDEX IF NOT EXISTS idx_shopping_list_items_ingredient_id ON shopping_list_items(ingredient_id);
+
+ALTER TABLE shopping_list_items ADD CONSTRAINT fk_shopping_list_items_ingredient_id
+    FOREIGN KEY (ingredient_id) REFERENCES ingredients(id) ON DELETE RESTRICT;
+
+-- 创建购物清单来源菜单关联表
+CREATE TABLE shopping_list_menus (
+    id CHAR(26) PRIMARY KEY,
+    list_id CHAR(26) NOT NULL,
+    menu_id CHAR(26) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (list_id, menu_id)
+);
+
+COMMENT ON TABLE shopping_list_menus IS '购物清单来源菜单关联表';
+COMMENT ON COLUMN shopping_list_menus.list_id IS '清单ID';
+COMMENT ON COLUMN shopping_list_menus.menu_id IS '菜单ID';
+
+CREATE INDEX IF NOT EXISTS idx_shopping_list_menus_list_id ON shopping_list_menus(list_id);
+CREATE INDEX IF NOT EXISTS idx_shopping_list_menus_menu_id ON shopping_list_menus(menu_id);
+
+ALTER TABLE shopping_list_menus ADD CONSTRAINT fk_shopping_list_menus_list_id
+    FOREIGN KEY (list_id) REFERENCES shopping_lists(id) ON DELETE CASCADE;
+
+ALTER TABLE shopping_list_menus ADD CONSTRAINT fk_shopping_list_menus_menu_id
+    FOREIGN KEY (menu_id) REFERENCES menus(id) ON DELETE CASCADE;
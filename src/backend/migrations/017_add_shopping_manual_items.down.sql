@@ -0,0 +1,3 @@
+-- 回滚购物清单手动编辑标记
+ALTER TABLE shopping_list_items DROP COLUMN IF EXISTS is_manual;
+ALTER TABLE shopping_lists DROP COLUMN IF EXISTS source;
@@ -0,0 +1,6 @@
+-- 购物清单记录生成方式，清单项区分手动编辑
+ALTER TABLE shopping_lists ADD COLUMN source VARCHAR(20) DEFAULT 'date_range';
+ALTER TABLE shopping_list_items ADD COLUMN is_manual BOOLEAN DEFAULT FALSE;
+
+COMMENT ON COLUMN shopping_lists.source IS '生成方式：date_range-按日期范围，menus-按指定菜单';
+COMMENT ON COLUMN shopping_list_items.is_manual IS '是否手动添加或编辑，重新生成时保留';
@@ -0,0 +1,3 @@
+-- 回滚基础食材单位换算参数
+ALTER TABLE ingredients DROP COLUMN IF EXISTS piece_weight;
+ALTER TABLE ingredients DROP COLUMN IF EXISTS density;
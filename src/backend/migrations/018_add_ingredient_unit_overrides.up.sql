@@ -0,0 +1,26 @@
+-- 基础食材单位换算参数
+ALTER TABLE ingredients ADD COLUMN density DECIMAL(10,4);
+ALTER TABLE ingredients ADD COLUMN piece_weight DECIMAL(10,2);
+
+COMMENT ON COLUMN ingredients.density IS '密度（克/毫升），用于体积与质量换算，为空时不换算';
+COMMENT ON COLUMN ingredients.piece_weight IS '单个重量（克），用于个、根、块等计数单位与质量换算，为空时不换算';
+
+-- 常见食材的换算参数
+UPDATE ingredients SET piece_weight = 50 WHERE id = '01HFBASEING000000000001100';   -- 鸡蛋
+UPDATE ingredients SET piece_weight = 10 WHERE id = '01HFBASEING000000000001200';   -- 鹌鹑蛋
+UPDATE ingredients SET piece_weight = 150 WHERE id = '01HFBASEING000000000002100';  -- 西红柿
+UPDATE ingredients SET piece_weight = 200 WHERE id = '01HFBASEING000000000002200';  -- 黄瓜
+UPDATE ingredients SET piece_weight = 200 WHERE id = '01HFBASEING000000000002300';  -- 土豆
+UPDATE ingredients SET piece_weight = 250 WHERE id = '01HFBASEING000000000002400';  -- 红薯
+UPDATE ingredients SET piece_weight = 150 WHERE id = '01HFBASEING000000000002500';  -- 胡萝卜
+UPDATE ingredients SET piece_weight = 200 WHERE id = '01HFBASEING000000000003100';  -- 洋葱
+UPDATE ingredients SET piece_weight = 5 WHERE id = '01HFBASEING000000000003400';    -- 蒜
+UPDATE ingredients SET piece_weight = 150 WHERE id = '01HFBASEING000000000003700';  -- 青椒
+UPDATE ingredients SET density = 1.03 WHERE id = '01HFBASEING000000000007200';      -- 牛奶
+UPDATE ingredients SET density = 1.05 WHERE id = '01HFBASEING000000000007300';      -- 酸奶
+UPDATE ingredients SET density = 1.15 WHERE id = '01HFBASEING000000000008500';      -- 生抽
+UPDATE ingredients SET density = 1.2 WHERE id = '01HFBASEING000000000008600';       -- 老抽
+UPDATE ingredients SET density = 1.2 WHERE id = '01HFBASEING000000000008700';       -- 蚝油
+UPDATE ingredients SET density = 0.98 WHERE id = '01HFBASEING000000000008800';      -- 料酒
+UPDATE ingredients SET density = 1.05 WHERE id = '01HFBASEING000000000008900';      -- 陈醋
+UPDATE ingredients SET density = 0.92 WHERE id = '01HFBASEING000000000009000';      -- 香油
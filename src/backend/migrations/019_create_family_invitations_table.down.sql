@@ -0,0 +1,5 @@
+-- 删除家庭邀请表
+DROP TRIGGER IF EXISTS update_family_invitations_updated_at ON family_invitations;
+ALTER TABLE family_invitations DROP CONSTRAINT IF EXISTS fk_family_invitations_inviter_id;
+ALTER TABLE family_invitations DROP CONSTRAINT IF EXISTS fk_family_invitations_family_id;
+DROP TABLE IF EXISTS family_invitations;
@@ -0,0 +1,34 @@
+-- 创建家庭邀请表
+CREATE TABLE family_invitations (
+    id CHAR(26) PRIMARY KEY,
+    family_id CHAR(26) NOT NULL,
+    inviter_id CHAR(26) NOT NULL,
+    target_phone VARCHAR(20),
+    max_uses INT NOT NULL DEFAULT 1,
+    used_count INT NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMENT ON TABLE family_invitations IS '家庭邀请表';
+COMMENT ON COLUMN family_invitations.family_id IS '家庭ID';
+COMMENT ON COLUMN family_invitations.inviter_id IS '邀请人ID';
+COMMENT ON COLUMN family_invitations.target_phone IS '指定被邀请人手机号，为空时不限制';
+COMMENT ON COLUMN family_invitations.max_uses IS '最大使用次数';
+COMMENT ON COLUMN family_invitations.used_count IS '已使用次数';
+COMMENT ON COLUMN family_invitations.expires_at IS '过期时间';
+COMMENT ON COLUMN family_invitations.revoked_at IS '撤销时间';
+
+CREATE INDEX IF NOT EXISTS idx_family_invitations_family_id ON family_invitations(family_id);
+CREATE INDEX IF NOT EXISTS idx_family_invitations_expires_at ON family_invitations(expires_at);
+
+CREATE TRIGGER update_family_invitations_updated_at BEFORE UPDATE ON family_invitations
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+ALTER TABLE family_invitations ADD CONSTRAINT fk_family_invitations_family_id
+    FOREIGN KEY (family_id) REFERENCES families(id) ON DELETE CASCADE;
+
+ALTER TABLE family_invitations ADD CONSTRAINT fk_family_invitations_inviter_id
+    FOREIGN KEY (inviter_id) REFERENCES users(id) ON DELETE CASCADE;
@@ -0,0 +1,7 @@
+-- 回滚家庭解散相关字段
+DROP INDEX IF EXISTS idx_menus_deleted_at;
+DROP INDEX IF EXISTS idx_families_purge_after;
+ALTER TABLE menus DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE families DROP COLUMN IF EXISTS purged_at;
+ALTER TABLE families DROP COLUMN IF EXISTS purge_after;
+ALTER TABLE families DROP COLUMN IF EXISTS dissolved_at;
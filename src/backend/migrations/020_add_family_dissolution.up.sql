@@ -0,0 +1,13 @@
+-- 家庭解散：记录解散时间与数据清理期限，菜单支持软删除
+ALTER TABLE families ADD COLUMN dissolved_at TIMESTAMP;
+ALTER TABLE families ADD COLUMN purge_after TIMESTAMP;
+ALTER TABLE families ADD COLUMN purged_at TIMESTAMP;
+ALTER TABLE menus ADD COLUMN deleted_at TIMESTAMP;
+
+COMMENT ON COLUMN families.dissolved_at IS '解散时间';
+COMMENT ON COLUMN families.purge_after IS '数据保留截止时间，之后由后台任务清理';
+COMMENT ON COLUMN families.purged_at IS '数据清理完成时间';
+COMMENT ON COLUMN menus.deleted_at IS '删除时间（软删除）';
+
+CREATE INDEX IF NOT EXISTS idx_families_purge_after ON families(purge_after);
+CREATE INDEX IF NOT EXISTS idx_menus_deleted_at ON menus(deleted_at);
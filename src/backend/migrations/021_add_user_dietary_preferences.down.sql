@@ -0,0 +1,2 @@
+-- 回滚用户饮食偏好字段
+ALTER TABLE users DROP COLUMN IF EXISTS dietary_preferences;
@@ -0,0 +1,4 @@
+-- 用户资料：增加饮食偏好
+ALTER TABLE users ADD COLUMN dietary_preferences TEXT[] NOT NULL DEFAULT '{}';
+
+COMMENT ON COLUMN users.dietary_preferences IS '饮食偏好标签，例如：少辣、素食、低糖';
@@ -0,0 +1,6 @@
+-- 回滚支付订单扩展字段
+DROP INDEX IF EXISTS idx_payment_orders_expires_at;
+ALTER TABLE payment_orders DROP COLUMN IF EXISTS membership_id;
+ALTER TABLE payment_orders DROP COLUMN IF EXISTS refunded_at;
+ALTER TABLE payment_orders DROP COLUMN IF EXISTS expires_at;
+ALTER TABLE payment_orders DROP COLUMN IF EXISTS transaction_id;
@@ -0,0 +1,14 @@
+-- 支付订单：记录渠道交易号、订单过期与退款时间，并关联开通的会员记录
+ALTER TABLE payment_orders ADD COLUMN transaction_id VARCHAR(64);
+ALTER TABLE payment_orders ADD COLUMN expires_at TIMESTAMP;
+ALTER TABLE payment_orders ADD COLUMN refunded_at TIMESTAMP;
+ALTER TABLE payment_orders ADD COLUMN membership_id CHAR(26);
+
+COMMENT ON COLUMN payment_orders.payment_method IS '支付渠道：mock, wechat, alipay';
+COMMENT ON COLUMN payment_orders.status IS '状态：pending-待支付，paid-已支付，expired-已过期，refunded-已退款，failed-失败';
+COMMENT ON COLUMN payment_orders.transaction_id IS '支付渠道交易号';
+COMMENT ON COLUMN payment_orders.expires_at IS '待支付订单过期时间';
+COMMENT ON COLUMN payment_orders.refunded_at IS '退款时间';
+COMMENT ON COLUMN payment_orders.membership_id IS '支付成功后开通的会员记录ID';
+
+CREATE INDEX IF NOT EXISTS idx_payment_orders_expires_at ON payment_orders(expires_at) WHERE status = 'pending';
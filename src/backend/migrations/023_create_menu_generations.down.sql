@@ -0,0 +1,4 @@
+-- 删除菜单生成草稿表
+DROP TABLE IF EXISTS menu_generation_items;
+DROP TRIGGER IF EXISTS update_menu_generations_updated_at ON menu_generations;
+DROP TABLE IF EXISTS menu_generations;
@@ -0,0 +1,57 @@
+-- 自动生成的菜单草稿：用户确认后才写入 menus，避免覆盖手动维护的菜单
+CREATE TABLE menu_generations (
+    id CHAR(26) PRIMARY KEY,
+    family_id CHAR(26) NOT NULL,
+    created_by CHAR(26) NOT NULL,
+    source VARCHAR(20) NOT NULL DEFAULT 'ai',
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    reason TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'draft',
+    confirmed_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMENT ON TABLE menu_generations IS '菜单生成草稿表';
+COMMENT ON COLUMN menu_generations.family_id IS '家庭ID';
+COMMENT ON COLUMN menu_generations.created_by IS '发起人ID';
+COMMENT ON COLUMN menu_generations.source IS '来源：ai-AI生成';
+COMMENT ON COLUMN menu_generations.start_date IS '开始日期';
+COMMENT ON COLUMN menu_generations.end_date IS '结束日期';
+COMMENT ON COLUMN menu_generations.reason IS '整体推荐理由';
+COMMENT ON COLUMN menu_generations.status IS '状态：draft-草稿，confirmed-已确认，discarded-已放弃';
+COMMENT ON COLUMN menu_generations.confirmed_at IS '确认时间';
+
+CREATE INDEX IF NOT EXISTS idx_menu_generations_family_id ON menu_generations(family_id, created_at);
+
+CREATE TRIGGER update_menu_generations_updated_at BEFORE UPDATE ON menu_generations
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+ALTER TABLE menu_generations ADD CONSTRAINT fk_menu_generations_family_id
+    FOREIGN KEY (family_id) REFERENCES families(id) ON DELETE CASCADE;
+
+ALTER TABLE menu_generations ADD CONSTRAINT fk_menu_generations_created_by
+    FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE CASCADE;
+
+-- 草稿中的单餐菜单
+CREATE TABLE menu_generation_items (
+    id CHAR(26) PRIMARY KEY,
+    generation_id CHAR(26) NOT NULL,
+    date DATE NOT NULL,
+    meal_type VARCHAR(20) NOT NULL,
+    dish_ids TEXT[] NOT NULL DEFAULT '{}',
+    reason TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (generation_id, date, meal_type)
+);
+
+COMMENT ON TABLE menu_generation_items IS '菜单生成草稿明细表';
+COMMENT ON COLUMN menu_generation_items.generation_id IS '草稿ID';
+COMMENT ON COLUMN menu_generation_items.date IS '日期';
+COMMENT ON COLUMN menu_generation_items.meal_type IS '餐次：breakfast-早餐，lunch-午餐，dinner-晚餐';
+COMMENT ON COLUMN menu_generation_items.dish_ids IS '菜式ID列表';
+COMMENT ON COLUMN menu_generation_items.reason IS '该餐推荐理由';
+
+ALTER TABLE menu_generation_items ADD CONSTRAINT fk_menu_generation_items_generation_id
+    FOREIGN KEY (generation_id) REFERENCES menu_generations(id) ON DELETE CASCADE;
@@ -0,0 +1,6 @@
+-- 回滚烹饪步骤排程字段
+ALTER TABLE cooking_steps DROP CONSTRAINT IF EXISTS chk_cooking_steps_equipment;
+ALTER TABLE cooking_steps DROP CONSTRAINT IF EXISTS chk_cooking_steps_duration_minutes;
+ALTER TABLE cooking_steps DROP COLUMN IF EXISTS equipment;
+ALTER TABLE cooking_steps DROP COLUMN IF EXISTS is_passive;
+ALTER TABLE cooking_steps DROP COLUMN IF EXISTS duration_minutes;
@@ -0,0 +1,13 @@
+-- 烹饪步骤增加时长、是否需要人看管与所需厨具，用于多道菜的烹饪排程
+ALTER TABLE cooking_steps ADD COLUMN duration_minutes INT NOT NULL DEFAULT 0;
+ALTER TABLE cooking_steps ADD COLUMN is_passive BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE cooking_steps ADD COLUMN equipment VARCHAR(20);
+
+COMMENT ON COLUMN cooking_steps.duration_minutes IS '步骤时长（分钟），0表示未设置';
+COMMENT ON COLUMN cooking_steps.is_passive IS '是否为无需看管的步骤（炖煮、烤制、腌制等），可与其他步骤并行';
+COMMENT ON COLUMN cooking_steps.equipment IS '所需厨具：wok-炒锅，oven-烤箱，burner-灶眼，为空表示不占用厨具';
+
+ALTER TABLE cooking_steps ADD CONSTRAINT chk_cooking_steps_duration_minutes
+    CHECK (duration_minutes >= 0);
+ALTER TABLE cooking_steps ADD CONSTRAINT chk_cooking_steps_equipment
+    CHECK (equipment IS NULL OR equipment IN ('wok', 'oven', 'burner'));
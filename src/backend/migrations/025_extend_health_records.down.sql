@@ -0,0 +1,5 @@
+-- 回滚身体状况记录扩展字段
+ALTER TABLE family_members DROP COLUMN IF EXISTS share_health_records;
+DROP INDEX IF EXISTS idx_health_records_family_user_created;
+ALTER TABLE health_records DROP COLUMN IF EXISTS analyzed_at;
+ALTER TABLE health_records DROP COLUMN IF EXISTS diet_suggestions;
@@ -0,0 +1,13 @@
+-- 身体状况记录增加AI饮食建议与分析时间，家庭成员增加是否向家庭管理员公开身体状况记录
+ALTER TABLE health_records ADD COLUMN diet_suggestions TEXT;
+ALTER TABLE health_records ADD COLUMN analyzed_at TIMESTAMP;
+
+COMMENT ON COLUMN health_records.diet_suggestions IS 'AI饮食建议（JSON数组）';
+COMMENT ON COLUMN health_records.analyzed_at IS 'AI分析时间，为空表示尚未分析';
+
+CREATE INDEX IF NOT EXISTS idx_health_records_family_user_created
+    ON health_records(family_id, user_id, created_at DESC);
+
+ALTER TABLE family_members ADD COLUMN share_health_records BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN family_members.share_health_records IS '是否允许家庭管理员查看本人的身体状况记录，退出家庭时重置';
@@ -0,0 +1,3 @@
+-- 删除菜式标签相关表
+DROP TABLE IF EXISTS dish_tag_relations;
+DROP TABLE IF EXISTS dish_tags;
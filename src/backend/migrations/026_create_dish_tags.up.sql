@@ -0,0 +1,90 @@
+-- 菜式标签：系统预置的主料、口味、难度分组，以及家庭自定义标签
+CREATE TABLE dish_tags (
+    id CHAR(26) PRIMARY KEY,
+    family_id CHAR(26),
+    group_code VARCHAR(20) NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    sort_order INT NOT NULL DEFAULT 0,
+    created_by CHAR(26),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMENT ON TABLE dish_tags IS '菜式标签表';
+COMMENT ON COLUMN dish_tags.family_id IS '家庭ID，为空表示系统标签';
+COMMENT ON COLUMN dish_tags.group_code IS '分组：main_ingredient-主料，flavor-口味，difficulty-难度，custom-自定义';
+COMMENT ON COLUMN dish_tags.name IS '标签名称';
+COMMENT ON COLUMN dish_tags.sort_order IS '组内排序';
+COMMENT ON COLUMN dish_tags.created_by IS '创建人ID，系统标签为空';
+
+ALTER TABLE dish_tags ADD CONSTRAINT chk_dish_tags_group_code
+    CHECK (group_code IN ('main_ingredient', 'flavor', 'difficulty', 'custom'));
+-- 系统标签不属于任何家庭，自定义标签必须属于家庭
+ALTER TABLE dish_tags ADD CONSTRAINT chk_dish_tags_scope
+    CHECK ((family_id IS NULL) = (group_code <> 'custom'));
+
+CREATE UNIQUE INDEX IF NOT EXISTS uk_dish_tags_system_name ON dish_tags(group_code, name) WHERE family_id IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS uk_dish_tags_family_name ON dish_tags(family_id, LOWER(name)) WHERE family_id IS NOT NULL;
+
+ALTER TABLE dish_tags ADD CONSTRAINT fk_dish_tags_family_id
+    FOREIGN KEY (family_id) REFERENCES families(id) ON DELETE CASCADE;
+
+ALTER TABLE dish_tags ADD CONSTRAINT fk_dish_tags_created_by
+    FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL;
+
+-- 菜式与标签的多对多关联
+CREATE TABLE dish_tag_relations (
+    dish_id CHAR(26) NOT NULL,
+    tag_id CHAR(26) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (dish_id, tag_id)
+);
+
+COMMENT ON TABLE dish_tag_relations IS '菜式标签关联表';
+
+CREATE INDEX IF NOT EXISTS idx_dish_tag_relations_tag_id ON dish_tag_relations(tag_id);
+
+ALTER TABLE dish_tag_relations ADD CONSTRAINT fk_dish_tag_relations_dish_id
+    FOREIGN KEY (dish_id) REFERENCES dishes(id) ON DELETE CASCADE;
+
+ALTER TABLE dish_tag_relations ADD CONSTRAINT fk_dish_tag_relations_tag_id
+    FOREIGN KEY (tag_id) REFERENCES dish_tags(id) ON DELETE CASCADE;
+
+-- 预置系统标签
+INSERT INTO dish_tags (id, family_id, group_code, name, sort_order)
+VALUES
+    ('01HFDISHTAG000000000000100', NULL, 'main_ingredient', '猪肉', 1),
+    ('01HFDISHTAG000000000000200', NULL, 'main_ingredient', '牛羊肉', 2),
+    ('01HFDISHTAG000000000000300', NULL, 'main_ingredient', '禽肉', 3),
+    ('01HFDISHTAG000000000000400', NULL, 'main_ingredient', '水产', 4),
+    ('01HFDISHTAG000000000000500', NULL, 'main_ingredient', '蛋类', 5),
+    ('01HFDISHTAG000000000000600', NULL, 'main_ingredient', '豆制品', 6),
+    ('01HFDISHTAG000000000000700', NULL, 'main_ingredient', '蔬菜', 7),
+    ('01HFDISHTAG000000000000800', NULL, 'main_ingredient', '菌菇', 8),
+    ('01HFDISHTAG000000000000900', NULL, 'main_ingredient', '主食', 9),
+    ('01HFDISHTAG000000000010100', NULL, 'flavor', '清淡', 1),
+    ('01HFDISHTAG000000000010200', NULL, 'flavor', '咸鲜', 2),
+    ('01HFDISHTAG000000000010300', NULL, 'flavor', '辣', 3),
+    ('01HFDISHTAG000000000010400', NULL, 'flavor', '麻辣', 4),
+    ('01HFDISHTAG000000000010500', NULL, 'flavor', '甜', 5),
+    ('01HFDISHTAG000000000010600', NULL, 'flavor', '酸甜', 6),
+    ('01HFDISHTAG000000000010700', NULL, 'flavor', '酸辣', 7),
+    ('01HFDISHTAG000000000020100', NULL, 'difficulty', '简单', 1),
+    ('01HFDISHTAG000000000020200', NULL, 'difficulty', '中等', 2),
+    ('01HFDISHTAG000000000020300', NULL, 'difficulty', '困难', 3);
+
+-- 已有菜式的分类转为家庭自定义标签，分类筛选由标签筛选替代
+INSERT INTO dish_tags (id, family_id, group_code, name, sort_order)
+SELECT UPPER(SUBSTR(MD5(family_id || ':' || category), 1, 26)), family_id, 'custom', category, 0
+FROM (
+    SELECT DISTINCT family_id, TRIM(category) AS category
+    FROM dishes
+    WHERE deleted_at IS NULL AND TRIM(COALESCE(category, '')) <> ''
+) categories
+ON CONFLICT DO NOTHING;
+
+INSERT INTO dish_tag_relations (dish_id, tag_id)
+SELECT d.id, t.id
+FROM dishes d
+INNER JOIN dish_tags t ON t.family_id = d.family_id AND t.group_code = 'custom' AND LOWER(t.name) = LOWER(TRIM(d.category))
+WHERE d.deleted_at IS NULL
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,10 @@
+-- 删除菜式耗时、份数与难度
+ALTER TABLE dishes DROP CONSTRAINT IF EXISTS chk_dishes_difficulty;
+ALTER TABLE dishes DROP CONSTRAINT IF EXISTS chk_dishes_servings;
+ALTER TABLE dishes DROP CONSTRAINT IF EXISTS chk_dishes_cook_minutes;
+ALTER TABLE dishes DROP CONSTRAINT IF EXISTS chk_dishes_prep_minutes;
+
+ALTER TABLE dishes DROP COLUMN IF EXISTS difficulty;
+ALTER TABLE dishes DROP COLUMN IF EXISTS servings;
+ALTER TABLE dishes DROP COLUMN IF EXISTS cook_minutes;
+ALTER TABLE dishes DROP COLUMN IF EXISTS prep_minutes;
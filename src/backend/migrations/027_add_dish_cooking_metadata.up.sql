@@ -0,0 +1,25 @@
+-- 菜式增加备料时间、烹饪时间、默认份数与难度，用于菜单展示预计用时
+ALTER TABLE dishes ADD COLUMN prep_minutes INT NOT NULL DEFAULT 0;
+ALTER TABLE dishes ADD COLUMN cook_minutes INT NOT NULL DEFAULT 0;
+ALTER TABLE dishes ADD COLUMN servings INT NOT NULL DEFAULT 2;
+ALTER TABLE dishes ADD COLUMN difficulty VARCHAR(20);
+
+ALTER TABLE dishes ADD CONSTRAINT chk_dishes_prep_minutes CHECK (prep_minutes BETWEEN 0 AND 1440);
+ALTER TABLE dishes ADD CONSTRAINT chk_dishes_cook_minutes CHECK (cook_minutes BETWEEN 0 AND 1440);
+ALTER TABLE dishes ADD CONSTRAINT chk_dishes_servings CHECK (servings BETWEEN 1 AND 50);
+ALTER TABLE dishes ADD CONSTRAINT chk_dishes_difficulty CHECK (difficulty IS NULL OR difficulty IN ('easy', 'medium', 'hard'));
+
+COMMENT ON COLUMN dishes.prep_minutes IS '备料时间（分钟）';
+COMMENT ON COLUMN dishes.cook_minutes IS '烹饪时间（分钟）';
+COMMENT ON COLUMN dishes.servings IS '默认份数';
+COMMENT ON COLUMN dishes.difficulty IS '难度：easy、medium、hard，为空表示未设置';
+
+-- 已有菜式按步骤时长估算烹饪时间
+UPDATE dishes d
+SET cook_minutes = LEAST(s.total, 1440)
+FROM (
+    SELECT dish_id, SUM(duration_minutes) AS total
+    FROM cooking_steps
+    GROUP BY dish_id
+) s
+WHERE s.dish_id = d.id AND s.total > 0;
@@ -0,0 +1,6 @@
+-- 删除菜单与菜单菜式的用餐人数
+ALTER TABLE menu_dishes DROP CONSTRAINT IF EXISTS chk_menu_dishes_servings;
+ALTER TABLE menus DROP CONSTRAINT IF EXISTS chk_menus_servings;
+
+ALTER TABLE menu_dishes DROP COLUMN IF EXISTS servings;
+ALTER TABLE menus DROP COLUMN IF EXISTS servings;
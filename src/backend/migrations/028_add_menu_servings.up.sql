@@ -0,0 +1,9 @@
+-- 菜单与菜单菜式增加用餐人数，食材用量按菜式默认份数等比缩放
+ALTER TABLE menus ADD COLUMN servings INT;
+ALTER TABLE menu_dishes ADD COLUMN servings INT;
+
+ALTER TABLE menus ADD CONSTRAINT chk_menus_servings CHECK (servings IS NULL OR servings BETWEEN 1 AND 50);
+ALTER TABLE menu_dishes ADD CONSTRAINT chk_menu_dishes_servings CHECK (servings IS NULL OR servings BETWEEN 1 AND 50);
+
+COMMENT ON COLUMN menus.servings IS '用餐人数，为空表示按各菜式默认份数';
+COMMENT ON COLUMN menu_dishes.servings IS '该菜式的用餐人数，为空表示沿用菜单的用餐人数';
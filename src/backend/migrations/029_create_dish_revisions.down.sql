@@ -0,0 +1,2 @@
+-- 删除菜式修订记录表
+DROP TABLE IF EXISTS dish_revisions;
@@ -0,0 +1,33 @@
+-- 菜式修订记录：每次创建、编辑、恢复菜式都保存一份不可修改的完整快照
+CREATE TABLE dish_revisions (
+    id CHAR(26) PRIMARY KEY,
+    dish_id CHAR(26) NOT NULL,
+    family_id CHAR(26) NOT NULL,
+    revision_no INT NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    restored_from INT,
+    snapshot TEXT NOT NULL,
+    edited_by CHAR(26),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMENT ON TABLE dish_revisions IS '菜式修订记录表';
+COMMENT ON COLUMN dish_revisions.dish_id IS '菜式ID';
+COMMENT ON COLUMN dish_revisions.family_id IS '家庭ID';
+COMMENT ON COLUMN dish_revisions.revision_no IS '版本号，同一菜式从1开始递增';
+COMMENT ON COLUMN dish_revisions.action IS '操作：create-创建，update-编辑，restore-恢复历史版本';
+COMMENT ON COLUMN dish_revisions.restored_from IS '恢复自哪个版本号，仅 restore 时有值';
+COMMENT ON COLUMN dish_revisions.snapshot IS '菜式基本信息、食材、步骤、标签的完整快照（JSON）';
+COMMENT ON COLUMN dish_revisions.edited_by IS '操作人ID';
+
+ALTER TABLE dish_revisions ADD CONSTRAINT chk_dish_revisions_action
+    CHECK (action IN ('create', 'update', 'restore'));
+
+CREATE UNIQUE INDEX IF NOT EXISTS uk_dish_revisions_dish_no ON dish_revisions(dish_id, revision_no);
+
+ALTER TABLE dish_revisions ADD CONSTRAINT fk_dish_revisions_dish_id
+    FOREIGN KEY (dish_id) REFERENCES dishes(id) ON DELETE CASCADE;
+ALTER TABLE dish_revisions ADD CONSTRAINT fk_dish_revisions_family_id
+    FOREIGN KEY (family_id) REFERENCES families(id) ON DELETE CASCADE;
+ALTER TABLE dish_revisions ADD CONSTRAINT fk_dish_revisions_edited_by
+    FOREIGN KEY (edited_by) REFERENCES users(id) ON DELETE SET NULL;
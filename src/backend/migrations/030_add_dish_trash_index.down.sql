@@ -0,0 +1,2 @@
+-- 删除回收站索引
+DROP INDEX IF EXISTS idx_dishes_deleted_at;
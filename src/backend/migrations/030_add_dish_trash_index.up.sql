@@ -0,0 +1,2 @@
+-- 回收站列表与定期清理按删除时间查询已删除菜式
+CREATE INDEX IF NOT EXISTS idx_dishes_deleted_at ON dishes(deleted_at) WHERE deleted_at IS NOT NULL;
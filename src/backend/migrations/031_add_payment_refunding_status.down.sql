@@ -0,0 +1,4 @@
+-- 回滚退款中状态：未完成的退款回退为已支付，需人工核对渠道退款结果
+DROP INDEX IF EXISTS idx_payment_orders_refunding;
+UPDATE payment_orders SET status = 'paid' WHERE status = 'refunding';
+COMMENT ON COLUMN payment_orders.status IS '状态：pending-待支付，paid-已支付，expired-已过期，refunded-已退款，failed-失败';
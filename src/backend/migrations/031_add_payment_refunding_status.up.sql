@@ -0,0 +1,4 @@
+-- 支付订单新增退款中状态：先提交 refunding 再调用渠道退款，未完成的退款由定时任务重试
+COMMENT ON COLUMN payment_orders.status IS '状态：pending-待支付，paid-已支付，expired-已过期，refunding-退款中，refunded-已退款，failed-失败';
+
+CREATE INDEX IF NOT EXISTS idx_payment_orders_refunding ON payment_orders(updated_at) WHERE status = 'refunding';
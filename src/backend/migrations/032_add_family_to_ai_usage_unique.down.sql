@@ -0,0 +1,24 @@
+-- 回滚为按用户计数：同一用户同一周期在多个家庭下的记录合并为一条
+ALTER TABLE ai_usage_logs DROP CONSTRAINT IF EXISTS uk_ai_usage_logs_user_family_period;
+
+WITH merged AS (
+    SELECT user_id, feature_type, period_type, period_date, MIN(id) AS keep_id, SUM(usage_count) AS total
+    FROM ai_usage_logs
+    GROUP BY user_id, feature_type, period_type, period_date
+    HAVING COUNT(*) > 1
+)
+UPDATE ai_usage_logs l
+SET usage_count = merged.total
+FROM merged
+WHERE l.id = merged.keep_id;
+
+DELETE FROM ai_usage_logs l
+USING ai_usage_logs k
+WHERE l.user_id = k.user_id
+  AND l.feature_type = k.feature_type
+  AND l.period_type = k.period_type
+  AND l.period_date = k.period_date
+  AND l.id > k.id;
+
+ALTER TABLE ai_usage_logs ADD CONSTRAINT ai_usage_logs_user_id_feature_type_period_type_period_date_key
+    UNIQUE (user_id, feature_type, period_type, period_date);
@@ -0,0 +1,4 @@
+-- AI调用记录按用户+家庭计数：用户切换家庭后在新家庭产生新的记录，不再改写旧记录的 family_id，避免已用额度被计入新家庭或从原家庭中消失
+ALTER TABLE ai_usage_logs DROP CONSTRAINT IF EXISTS ai_usage_logs_user_id_feature_type_period_type_period_date_key;
+ALTER TABLE ai_usage_logs ADD CONSTRAINT uk_ai_usage_logs_user_family_period
+    UNIQUE (user_id, family_id, feature_type, period_type, period_date);
@@ -0,0 +1,24 @@
+-- 恢复难度标签分组，并按 dishes.difficulty 重新关联
+ALTER TABLE dish_tags DROP CONSTRAINT IF EXISTS chk_dish_tags_group_code;
+ALTER TABLE dish_tags ADD CONSTRAINT chk_dish_tags_group_code
+    CHECK (group_code IN ('main_ingredient', 'flavor', 'difficulty', 'custom'));
+
+COMMENT ON COLUMN dish_tags.group_code IS '分组：main_ingredient-主料，flavor-口味，difficulty-难度，custom-自定义';
+
+INSERT INTO dish_tags (id, family_id, group_code, name, sort_order)
+VALUES
+    ('01HFDISHTAG000000000020100', NULL, 'difficulty', '简单', 1),
+    ('01HFDISHTAG000000000020200', NULL, 'difficulty', '中等', 2),
+    ('01HFDISHTAG000000000020300', NULL, 'difficulty', '困难', 3)
+ON CONFLICT DO NOTHING;
+
+INSERT INTO dish_tag_relations (dish_id, tag_id)
+SELECT id,
+    CASE difficulty
+        WHEN 'easy' THEN '01HFDISHTAG000000000020100'
+        WHEN 'medium' THEN '01HFDISHTAG000000000020200'
+        ELSE '01HFDISHTAG000000000020300'
+    END
+FROM dishes
+WHERE difficulty IS NOT NULL
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,21 @@
+-- 菜式难度只保存在 dishes.difficulty 一处：移除难度标签分组，已打难度标签但未设置难度的菜式按标签补齐
+UPDATE dishes d
+SET difficulty = t.difficulty
+FROM (
+    SELECT DISTINCT ON (r.dish_id) r.dish_id,
+        CASE tag.name WHEN '简单' THEN 'easy' WHEN '中等' THEN 'medium' ELSE 'hard' END AS difficulty
+    FROM dish_tag_relations r
+    INNER JOIN dish_tags tag ON tag.id = r.tag_id
+    WHERE tag.group_code = 'difficulty'
+    ORDER BY r.dish_id, tag.sort_order
+) t
+WHERE t.dish_id = d.id AND d.difficulty IS NULL;
+
+-- 关联记录随标签级联删除
+DELETE FROM dish_tags WHERE group_code = 'difficulty';
+
+ALTER TABLE dish_tags DROP CONSTRAINT IF EXISTS chk_dish_tags_group_code;
+ALTER TABLE dish_tags ADD CONSTRAINT chk_dish_tags_group_code
+    CHECK (group_code IN ('main_ingredient', 'flavor', 'custom'));
+
+COMMENT ON COLUMN dish_tags.group_code IS '分组：main_ingredient-主料，flavor-口味，custom-自定义';
@@ -0,0 +1,21 @@
+package aiclient
+
+var client *Client
+
+// InitAIClient 根据配置初始化全局AI服务客户端
+func InitAIClient(cfg Config) {
+	client = NewClient(cfg)
+}
+
+// GetClient 获取AI服务客户端，未初始化时返回未配置地址的客户端，调用会返回 ErrNotConfigured
+func GetClient() *Client {
+	if client == nil {
+		return NewClient(Config{})
+	}
+	return client
+}
+
+// SetClient 替换AI服务客户端
+func SetClient(c *Client) {
+	client = c
+}
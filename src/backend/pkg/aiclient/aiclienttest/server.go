@@ -0,0 +1,235 @@
+// Package aiclienttest 提供进程内的 ai-service 替身，返回确定性的结果，
+// 用于测试以及在没有 Python AI 服务时本地联调。
+package aiclienttest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"onetaste-family/backend/pkg/aiclient"
+)
+
+// Server 进程内的 ai-service 替身
+type Server struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	failures   int
+	failStatus int
+	delay      time.Duration
+	requests   map[string]int
+}
+
+// NewServer 启动替身服务，使用完毕后调用 Close
+func NewServer() *Server {
+	s := &Server{requests: make(map[string]int)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]string{"status": "healthy"})
+	})
+	mux.HandleFunc("/v1/menus/generate", handle(s, generateMenu))
+	mux.HandleFunc("/v1/menus/analyze", handle(s, analyzeMenu))
+	mux.HandleFunc("/v1/health/analyze", handle(s, analyzeHealth))
+	mux.HandleFunc("/v1/dishes/voice", handle(s, voiceToDish))
+	mux.HandleFunc("/v1/cooking/optimize", handle(s, optimizeSteps))
+
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// Client 返回指向替身服务的客户端，不重试、不熔断
+func (s *Server) Client() *aiclient.Client {
+	return aiclient.NewClient(aiclient.Config{BaseURL: s.URL, Timeout: 5 * time.Second})
+}
+
+// FailNext 让接下来的 n 个请求返回指定状态码，用于模拟AI服务故障
+func (s *Server) FailNext(n, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = n
+	s.failStatus = status
+}
+
+// SetDelay 让之后的每个请求延迟指定时间后再响应，用于模拟AI服务超时
+func (s *Server) SetDelay(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.delay = d
+}
+
+// Requests 返回某个接口收到的请求数
+func (s *Server) Requests(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[path]
+}
+
+// handle 统一处理请求计数、故障注入与请求解码
+func handle[Req any, Resp any](s *Server, fn func(*Req) *Resp) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+
+		s.mu.Lock()
+		s.requests[r.URL.Path]++
+		fail := s.failures > 0
+		status := s.failStatus
+		delay := s.delay
+		if fail {
+			s.failures--
+		}
+		s.mu.Unlock()
+
+		if delay > 0 {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(delay):
+			}
+		}
+
+		if fail {
+			writeError(w, status, "injected failure")
+			return
+		}
+
+		req := new(Req)
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+
+		writeJSON(w, fn(req))
+	}
+}
+
+// generateMenu 按日期与餐次轮流选取菜式：早餐1道，午餐、晚餐各2道
+func generateMenu(req *aiclient.MenuGenerateRequest) *aiclient.MenuGenerateResponse {
+	resp := &aiclient.MenuGenerateResponse{Menus: []aiclient.GeneratedMenu{}}
+	if len(req.Dishes) == 0 {
+		resp.Reason = "食谱库为空，无法生成菜单"
+		return resp
+	}
+
+	start, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return resp
+	}
+	end, err := time.Parse("2006-01-02", req.EndDate)
+	if err != nil || end.Before(start) {
+		return resp
+	}
+
+	mealTypes := req.MealTypes
+	if len(mealTypes) == 0 {
+		mealTypes = []string{"breakfast", "lunch", "dinner"}
+	}
+
+	next := 0
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		for _, mealType := range mealTypes {
+			count := 2
+			if mealType == "breakfast" {
+				count = 1
+			}
+			if count > len(req.Dishes) {
+				count = len(req.Dishes)
+			}
+
+			menu := aiclient.GeneratedMenu{Date: day.Format("2006-01-02"), MealType: mealType}
+			for i := 0; i < count; i++ {
+				menu.DishIDs = append(menu.DishIDs, req.Dishes[next%len(req.Dishes)].DishID)
+				next++
+			}
+			menu.Reason = "荤素搭配，避免连续重复"
+			resp.Menus = append(resp.Menus, menu)
+		}
+	}
+
+	resp.Reason = fmt.Sprintf("根据%d位家庭成员的情况，从%d道菜中轮换搭配", len(req.Members), len(req.Dishes))
+	return resp
+}
+
+func analyzeMenu(req *aiclient.MenuAnalyzeRequest) *aiclient.MenuAnalyzeResponse {
+	dishCount := 0
+	for _, menu := range req.Menus {
+		dishCount += len(menu.Dishes)
+	}
+
+	return &aiclient.MenuAnalyzeResponse{
+		Analysis:    fmt.Sprintf("共%d餐、%d道菜，整体搭配较为均衡", len(req.Menus), dishCount),
+		Suggestions: []string{"适当增加绿叶蔬菜", "减少油炸类菜式"},
+	}
+}
+
+func analyzeHealth(req *aiclient.HealthAnalyzeRequest) *aiclient.HealthAnalyzeResponse {
+	analysis := "身体状况良好"
+	if len(req.Member.Diseases) > 0 {
+		analysis = "需关注：" + strings.Join(req.Member.Diseases, "、")
+	}
+
+	return &aiclient.HealthAnalyzeResponse{
+		Analysis:        analysis,
+		Recommendations: []string{"保证充足睡眠", "适量运动"},
+		DietSuggestions: []string{"清淡饮食", "少盐少油"},
+	}
+}
+
+func voiceToDish(req *aiclient.VoiceToDishRequest) *aiclient.VoiceToDishResponse {
+	return &aiclient.VoiceToDishResponse{
+		Transcript: "番茄炒蛋，嗯，要两个番茄三个鸡蛋，然后放一点盐。先把鸡蛋炒散盛出来，再炒番茄，最后一起翻炒。",
+		Dish: aiclient.DishDraft{
+			Name:     "番茄炒蛋",
+			Category: "家常菜",
+			Ingredients: []aiclient.DraftIngredient{
+				{Name: "番茄", Amount: 2, Unit: "个"},
+				{Name: "鸡蛋", Amount: 3, Unit: "个"},
+				{Name: "盐", Amount: 2, Unit: "g"},
+			},
+			Steps: []aiclient.DraftStep{
+				{StepOrder: 1, Content: "鸡蛋打散，热锅炒至凝固后盛出", DurationMinutes: 3},
+				{StepOrder: 2, Content: "番茄切块下锅翻炒出汁", DurationMinutes: 4},
+				{StepOrder: 3, Content: "倒入鸡蛋加盐翻炒均匀", DurationMinutes: 1},
+			},
+		},
+	}
+}
+
+// optimizeSteps 按菜式顺序依次排列步骤，不做并行
+func optimizeSteps(req *aiclient.StepOptimizeRequest) *aiclient.StepOptimizeResponse {
+	resp := &aiclient.StepOptimizeResponse{Steps: []aiclient.OptimizedStep{}}
+	minute := 0
+	for _, dish := range req.Dishes {
+		for _, step := range dish.Steps {
+			resp.Steps = append(resp.Steps, aiclient.OptimizedStep{
+				Order:           len(resp.Steps) + 1,
+				DishID:          dish.DishID,
+				Content:         step.Content,
+				StartMinute:     minute,
+				DurationMinutes: step.DurationMinutes,
+			})
+			minute += step.DurationMinutes
+		}
+	}
+	resp.TotalMinutes = minute
+	return resp
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, detail string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"detail": detail})
+}
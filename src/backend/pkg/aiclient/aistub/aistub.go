@@ -0,0 +1,16 @@
+//go:build aistub
+
+// Package aistub 在进程内启动 ai-service 替身，仅在使用 aistub 构建标签编译时可用，
+// 正式构建不包含替身代码。
+package aistub
+
+import "onetaste-family/backend/pkg/aiclient/aiclienttest"
+
+// Enabled 当前构建是否包含替身
+const Enabled = true
+
+// Start 启动替身服务，返回服务地址与停止函数
+func Start() (url string, stop func(), err error) {
+	server := aiclienttest.NewServer()
+	return server.URL, server.Close, nil
+}
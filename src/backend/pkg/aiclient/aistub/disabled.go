@@ -0,0 +1,13 @@
+//go:build !aistub
+
+package aistub
+
+import "errors"
+
+// Enabled 当前构建是否包含替身
+const Enabled = false
+
+// Start 未使用 aistub 构建标签时不提供替身
+func Start() (url string, stop func(), err error) {
+	return "", nil, errors.New("ai service stub is not compiled in, build with -tags aistub")
+}
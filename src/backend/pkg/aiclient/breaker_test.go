@@ -0,0 +1,45 @@
+package aiclient
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBreakerHalfOpenAllowsSingleProbe(t *testing.T) {
+	now := time.Now()
+	b := newBreaker(2, time.Minute)
+
+	b.failure(now)
+	if !b.allow(now) {
+		t.Fatal("breaker should stay closed below threshold")
+	}
+	b.failure(now)
+	if b.allow(now.Add(59 * time.Second)) {
+		t.Fatal("breaker should be open during cooldown")
+	}
+
+	afterCooldown := now.Add(time.Minute)
+	if !b.allow(afterCooldown) {
+		t.Fatal("breaker should allow a probe after cooldown")
+	}
+	if b.allow(afterCooldown) {
+		t.Fatal("breaker should allow only one probe at a time")
+	}
+
+	b.success()
+	if !b.allow(afterCooldown) || !b.allow(afterCooldown) {
+		t.Fatal("breaker should close after a successful probe")
+	}
+}
+
+func TestBreakerDisabled(t *testing.T) {
+	now := time.Now()
+	b := newBreaker(0, time.Minute)
+
+	for i := 0; i < 5; i++ {
+		b.failure(now)
+	}
+	if !b.allow(now) {
+		t.Fatal("breaker with threshold 0 should never open")
+	}
+}
@@ -0,0 +1,260 @@
+package aiclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	pathMenuGenerate  = "/v1/menus/generate"
+	pathMenuAnalyze   = "/v1/menus/analyze"
+	pathHealthAnalyze = "/v1/health/analyze"
+	pathVoiceToDish   = "/v1/dishes/voice"
+	pathStepOptimize  = "/v1/cooking/optimize"
+
+	apiKeyHeader = "X-API-Key"
+	// maxResponseSize AI服务响应体大小上限
+	maxResponseSize = 4 << 20
+)
+
+var (
+	// ErrNotConfigured 未配置AI服务地址
+	ErrNotConfigured = errors.New("ai service is not configured")
+	// ErrCircuitOpen AI服务连续失败，熔断中
+	ErrCircuitOpen = errors.New("ai service circuit open")
+
+	// errTransport 网络层错误（连接失败、超时等），可以重试
+	errTransport = errors.New("ai request failed")
+)
+
+// APIError AI服务返回的错误响应
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("ai service returned %d: %s", e.StatusCode, e.Message)
+}
+
+// retryable 限流与网关类错误可以重试，其余错误重试也不会成功
+func (e *APIError) retryable() bool {
+	switch e.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// Config AI服务客户端配置
+type Config struct {
+	BaseURL          string
+	APIKey           string
+	Timeout          time.Duration // 单次请求超时
+	MaxRetries       int           // 失败后的最大重试次数
+	RetryBackoff     time.Duration // 首次重试等待时间，之后按倍数递增
+	BreakerThreshold int           // 连续失败多少次后熔断，<=0 表示不熔断
+	BreakerCooldown  time.Duration // 熔断持续时间
+}
+
+// Client 调用 Python ai-service 的客户端
+type Client struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+	maxRetries int
+	backoff    time.Duration
+	breaker    *breaker
+}
+
+// NewClient 创建AI服务客户端，未设置的参数使用默认值
+func NewClient(cfg Config) *Client {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 30 * time.Second
+	}
+	if cfg.MaxRetries < 0 {
+		cfg.MaxRetries = 0
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = 200 * time.Millisecond
+	}
+	if cfg.BreakerCooldown <= 0 {
+		cfg.BreakerCooldown = 30 * time.Second
+	}
+
+	return &Client{
+		baseURL:    strings.TrimRight(cfg.BaseURL, "/"),
+		apiKey:     cfg.APIKey,
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+		maxRetries: cfg.MaxRetries,
+		backoff:    cfg.RetryBackoff,
+		breaker:    newBreaker(cfg.BreakerThreshold, cfg.BreakerCooldown),
+	}
+}
+
+// GenerateMenu 根据家庭成员画像与食谱库生成菜单
+func (c *Client) GenerateMenu(ctx context.Context, req *MenuGenerateRequest) (*MenuGenerateResponse, error) {
+	resp := &MenuGenerateResponse{}
+	if err := c.post(ctx, pathMenuGenerate, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// AnalyzeMenu 分析菜单的营养搭配
+func (c *Client) AnalyzeMenu(ctx context.Context, req *MenuAnalyzeRequest) (*MenuAnalyzeResponse, error) {
+	resp := &MenuAnalyzeResponse{}
+	if err := c.post(ctx, pathMenuAnalyze, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// AnalyzeHealth 分析成员身体状况并给出饮食建议
+func (c *Client) AnalyzeHealth(ctx context.Context, req *HealthAnalyzeRequest) (*HealthAnalyzeResponse, error) {
+	resp := &HealthAnalyzeResponse{}
+	if err := c.post(ctx, pathHealthAnalyze, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// VoiceToDish 将语音转写并整理为结构化菜式
+func (c *Client) VoiceToDish(ctx context.Context, req *VoiceToDishRequest) (*VoiceToDishResponse, error) {
+	resp := &VoiceToDishResponse{}
+	if err := c.post(ctx, pathVoiceToDish, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// OptimizeSteps 合并多道菜的烹饪步骤
+func (c *Client) OptimizeSteps(ctx context.Context, req *StepOptimizeRequest) (*StepOptimizeResponse, error) {
+	resp := &StepOptimizeResponse{}
+	if err := c.post(ctx, pathStepOptimize, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// post 发送请求，对网络错误与可重试的状态码按指数退避重试，并记录熔断状态
+func (c *Client) post(ctx context.Context, path string, req, resp interface{}) error {
+	if c.baseURL == "" {
+		return ErrNotConfigured
+	}
+	if !c.breaker.allow(time.Now()) {
+		return ErrCircuitOpen
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to encode ai request: %w", err)
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		if attempt > 0 {
+			wait := c.backoff << (attempt - 1)
+			select {
+			case <-ctx.Done():
+				c.breaker.failure(time.Now())
+				return ctx.Err()
+			case <-time.After(wait):
+			}
+		}
+
+		lastErr = c.do(ctx, path, body, resp)
+		if lastErr == nil {
+			c.breaker.success()
+			return nil
+		}
+		if !shouldRetry(ctx, lastErr) {
+			break
+		}
+	}
+
+	// 调用方参数错误不代表服务异常，不计入熔断
+	var apiErr *APIError
+	if errors.As(lastErr, &apiErr) && apiErr.StatusCode < http.StatusInternalServerError && !apiErr.retryable() {
+		c.breaker.success()
+	} else {
+		c.breaker.failure(time.Now())
+	}
+
+	return lastErr
+}
+
+func (c *Client) do(ctx context.Context, path string, body []byte, resp interface{}) error {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build ai request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if c.apiKey != "" {
+		httpReq.Header.Set(apiKeyHeader, c.apiKey)
+	}
+
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errTransport, err)
+	}
+	defer httpResp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(httpResp.Body, maxResponseSize))
+	if err != nil {
+		return fmt.Errorf("failed to read ai response: %w", err)
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		return &APIError{StatusCode: httpResp.StatusCode, Message: errorMessage(data)}
+	}
+
+	if err := json.Unmarshal(data, resp); err != nil {
+		return fmt.Errorf("failed to decode ai response: %w", err)
+	}
+
+	return nil
+}
+
+// shouldRetry 调用方取消时不再重试；网络错误与限流、网关错误可以重试
+func shouldRetry(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.retryable()
+	}
+
+	return errors.Is(err, errTransport)
+}
+
+// errorMessage 提取 FastAPI 风格的 {"detail": "..."} 错误信息
+func errorMessage(data []byte) string {
+	var payload struct {
+		Detail interface{} `json:"detail"`
+	}
+	if err := json.Unmarshal(data, &payload); err == nil && payload.Detail != nil {
+		if detail, ok := payload.Detail.(string); ok {
+			return detail
+		}
+		if encoded, err := json.Marshal(payload.Detail); err == nil {
+			return string(encoded)
+		}
+	}
+
+	message := strings.TrimSpace(string(data))
+	if len(message) > 200 {
+		message = message[:200]
+	}
+	return message
+}
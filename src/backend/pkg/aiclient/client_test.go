@@ -0,0 +1,177 @@
+package aiclient_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"onetaste-family/backend/pkg/aiclient"
+	"onetaste-family/backend/pkg/aiclient/aiclienttest"
+)
+
+const analyzePath = "/v1/menus/analyze"
+
+func newClient(server *aiclienttest.Server, cfg aiclient.Config) *aiclient.Client {
+	cfg.BaseURL = server.URL
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 5 * time.Second
+	}
+	cfg.RetryBackoff = time.Millisecond
+	return aiclient.NewClient(cfg)
+}
+
+func analyze(client *aiclient.Client) error {
+	_, err := client.AnalyzeMenu(context.Background(), &aiclient.MenuAnalyzeRequest{})
+	return err
+}
+
+func statusCode(err error) int {
+	var apiErr *aiclient.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+func TestClientRetry(t *testing.T) {
+	tests := []struct {
+		name         string
+		failures     int
+		failStatus   int
+		maxRetries   int
+		wantStatus   int // 0 表示期望成功
+		wantRequests int
+	}{
+		{name: "503 重试后成功", failures: 2, failStatus: http.StatusServiceUnavailable, maxRetries: 2, wantRequests: 3},
+		{name: "502 重试后成功", failures: 1, failStatus: http.StatusBadGateway, maxRetries: 2, wantRequests: 2},
+		{name: "504 重试耗尽", failures: 5, failStatus: http.StatusGatewayTimeout, maxRetries: 2, wantStatus: http.StatusGatewayTimeout, wantRequests: 3},
+		{name: "429 重试后成功", failures: 1, failStatus: http.StatusTooManyRequests, maxRetries: 1, wantRequests: 2},
+		{name: "400 不重试", failures: 1, failStatus: http.StatusBadRequest, maxRetries: 2, wantStatus: http.StatusBadRequest, wantRequests: 1},
+		{name: "422 不重试", failures: 1, failStatus: http.StatusUnprocessableEntity, maxRetries: 2, wantStatus: http.StatusUnprocessableEntity, wantRequests: 1},
+		{name: "不配置重试", failures: 1, failStatus: http.StatusServiceUnavailable, maxRetries: 0, wantStatus: http.StatusServiceUnavailable, wantRequests: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := aiclienttest.NewServer()
+			defer server.Close()
+			server.FailNext(tt.failures, tt.failStatus)
+
+			err := analyze(newClient(server, aiclient.Config{MaxRetries: tt.maxRetries}))
+
+			if tt.wantStatus == 0 && err != nil {
+				t.Fatalf("expected success, got %v", err)
+			}
+			if tt.wantStatus != 0 && statusCode(err) != tt.wantStatus {
+				t.Fatalf("expected status %d, got %v", tt.wantStatus, err)
+			}
+			if got := server.Requests(analyzePath); got != tt.wantRequests {
+				t.Fatalf("expected %d requests, got %d", tt.wantRequests, got)
+			}
+		})
+	}
+}
+
+func TestClientTimeout(t *testing.T) {
+	server := aiclienttest.NewServer()
+	defer server.Close()
+	server.SetDelay(time.Second)
+
+	client := newClient(server, aiclient.Config{Timeout: 50 * time.Millisecond, MaxRetries: 1})
+
+	start := time.Now()
+	err := analyze(client)
+	if err == nil {
+		t.Fatal("expected timeout error")
+	}
+	if statusCode(err) != 0 {
+		t.Fatalf("expected transport error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed >= time.Second {
+		t.Fatalf("request was not cut off by timeout, took %s", elapsed)
+	}
+	// 超时属于网络错误，会重试
+	if got := server.Requests(analyzePath); got != 2 {
+		t.Fatalf("expected 2 requests, got %d", got)
+	}
+}
+
+func TestClientContextCanceled(t *testing.T) {
+	server := aiclienttest.NewServer()
+	defer server.Close()
+	server.SetDelay(time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	client := newClient(server, aiclient.Config{MaxRetries: 2})
+	if _, err := client.AnalyzeMenu(ctx, &aiclient.MenuAnalyzeRequest{}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context deadline exceeded, got %v", err)
+	}
+	// 调用方取消后不再重试
+	if got := server.Requests(analyzePath); got != 1 {
+		t.Fatalf("expected 1 request, got %d", got)
+	}
+}
+
+func TestClientBreaker(t *testing.T) {
+	const cooldown = 100 * time.Millisecond
+
+	server := aiclienttest.NewServer()
+	defer server.Close()
+	client := newClient(server, aiclient.Config{BreakerThreshold: 2, BreakerCooldown: cooldown})
+
+	// 连续失败达到阈值后熔断，不再请求AI服务
+	server.FailNext(2, http.StatusServiceUnavailable)
+	for i := 0; i < 2; i++ {
+		if err := analyze(client); statusCode(err) != http.StatusServiceUnavailable {
+			t.Fatalf("call %d: expected 503, got %v", i+1, err)
+		}
+	}
+	if err := analyze(client); !errors.Is(err, aiclient.ErrCircuitOpen) {
+		t.Fatalf("expected circuit open, got %v", err)
+	}
+	if got := server.Requests(analyzePath); got != 2 {
+		t.Fatalf("expected 2 requests while open, got %d", got)
+	}
+
+	// 冷却结束后放行一个探测请求，失败则重新熔断
+	time.Sleep(cooldown)
+	server.FailNext(1, http.StatusServiceUnavailable)
+	if err := analyze(client); statusCode(err) != http.StatusServiceUnavailable {
+		t.Fatalf("expected failed probe, got %v", err)
+	}
+	if err := analyze(client); !errors.Is(err, aiclient.ErrCircuitOpen) {
+		t.Fatalf("expected circuit reopened, got %v", err)
+	}
+
+	// 探测成功后关闭熔断
+	time.Sleep(cooldown)
+	if err := analyze(client); err != nil {
+		t.Fatalf("expected successful probe, got %v", err)
+	}
+	if err := analyze(client); err != nil {
+		t.Fatalf("expected circuit closed, got %v", err)
+	}
+	if got := server.Requests(analyzePath); got != 5 {
+		t.Fatalf("expected 5 requests, got %d", got)
+	}
+}
+
+func TestClientBreakerIgnoresClientErrors(t *testing.T) {
+	server := aiclienttest.NewServer()
+	defer server.Close()
+	client := newClient(server, aiclient.Config{BreakerThreshold: 1, BreakerCooldown: time.Minute})
+
+	server.FailNext(3, http.StatusBadRequest)
+	for i := 0; i < 3; i++ {
+		if err := analyze(client); statusCode(err) != http.StatusBadRequest {
+			t.Fatalf("call %d: expected 400, got %v", i+1, err)
+		}
+	}
+	if err := analyze(client); err != nil {
+		t.Fatalf("client errors should not open the circuit, got %v", err)
+	}
+}
@@ -0,0 +1,148 @@
+package aiclient
+
+// MemberProfile 参与分析的家庭成员画像
+type MemberProfile struct {
+	UserID             string   `json:"user_id"`
+	Nickname           string   `json:"nickname,omitempty"`
+	DietaryPreferences []string `json:"dietary_preferences,omitempty"`
+	Diseases           []string `json:"diseases,omitempty"`
+	WorkStatus         string   `json:"work_status,omitempty"`
+	StressLevel        string   `json:"stress_level,omitempty"`
+	BodyFeelings       string   `json:"body_feelings,omitempty"`
+}
+
+// DishCandidate 家庭食谱库中可供选择的菜式
+type DishCandidate struct {
+	DishID      string   `json:"dish_id"`
+	Name        string   `json:"name"`
+	Category    string   `json:"category,omitempty"`
+	Ingredients []string `json:"ingredients,omitempty"`
+}
+
+// MenuGenerateRequest 菜单生成请求
+type MenuGenerateRequest struct {
+	StartDate string          `json:"start_date"` // YYYY-MM-DD
+	EndDate   string          `json:"end_date"`   // YYYY-MM-DD
+	MealTypes []string        `json:"meal_types"` // breakfast, lunch, dinner
+	Members   []MemberProfile `json:"members"`
+	Dishes    []DishCandidate `json:"dishes"`
+}
+
+// GeneratedMenu 生成的单餐菜单
+type GeneratedMenu struct {
+	Date     string   `json:"date"`
+	MealType string   `json:"meal_type"`
+	DishIDs  []string `json:"dish_ids"`
+	Reason   string   `json:"reason,omitempty"`
+}
+
+// MenuGenerateResponse 菜单生成结果
+type MenuGenerateResponse struct {
+	Menus  []GeneratedMenu `json:"menus"`
+	Reason string          `json:"reason"`
+}
+
+// MenuSnapshot 待分析的单餐菜单
+type MenuSnapshot struct {
+	Date     string          `json:"date"`
+	MealType string          `json:"meal_type"`
+	Dishes   []DishCandidate `json:"dishes"`
+}
+
+// MenuAnalyzeRequest 菜单分析请求
+type MenuAnalyzeRequest struct {
+	Menus   []MenuSnapshot  `json:"menus"`
+	Members []MemberProfile `json:"members,omitempty"`
+}
+
+// MenuAnalyzeResponse 菜单分析结果
+type MenuAnalyzeResponse struct {
+	Analysis    string   `json:"analysis"`
+	Suggestions []string `json:"suggestions"`
+}
+
+// HealthAnalyzeRequest 身体状况分析请求
+type HealthAnalyzeRequest struct {
+	Member MemberProfile `json:"member"`
+}
+
+// HealthAnalyzeResponse 身体状况分析结果
+type HealthAnalyzeResponse struct {
+	Analysis        string   `json:"analysis"`
+	Recommendations []string `json:"recommendations"`
+	DietSuggestions []string `json:"diet_suggestions"`
+}
+
+// VoiceToDishRequest 语音整理菜式请求
+type VoiceToDishRequest struct {
+	AudioURL    string `json:"audio_url"`
+	AudioFormat string `json:"audio_format"`
+}
+
+// DraftIngredient 语音整理出的食材，名称为自由文本
+type DraftIngredient struct {
+	Name   string  `json:"name"`
+	Amount float64 `json:"amount"`
+	Unit   string  `json:"unit"`
+	Notes  string  `json:"notes,omitempty"`
+}
+
+// DraftStep 语音整理出的烹饪步骤
+type DraftStep struct {
+	StepOrder       int    `json:"step_order"`
+	Content         string `json:"content"`
+	DurationMinutes int    `json:"duration_minutes,omitempty"`
+}
+
+// DishDraft 语音整理出的菜式草稿
+type DishDraft struct {
+	Name        string            `json:"name"`
+	Category    string            `json:"category,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Ingredients []DraftIngredient `json:"ingredients"`
+	Steps       []DraftStep       `json:"steps"`
+}
+
+// VoiceToDishResponse 语音整理菜式结果
+type VoiceToDishResponse struct {
+	Transcript string    `json:"transcript"`
+	Dish       DishDraft `json:"dish"`
+}
+
+// StepInput 待优化的烹饪步骤
+type StepInput struct {
+	StepOrder       int    `json:"step_order"`
+	Content         string `json:"content"`
+	DurationMinutes int    `json:"duration_minutes,omitempty"`
+	Passive         bool   `json:"passive,omitempty"`
+	Equipment       string `json:"equipment,omitempty"`
+}
+
+// DishSteps 一道菜的全部步骤
+type DishSteps struct {
+	DishID string      `json:"dish_id"`
+	Name   string      `json:"name"`
+	Steps  []StepInput `json:"steps"`
+}
+
+// StepOptimizeRequest 多道菜烹饪步骤优化请求
+type StepOptimizeRequest struct {
+	MealType string      `json:"meal_type,omitempty"`
+	Dishes   []DishSteps `json:"dishes"`
+}
+
+// OptimizedStep 优化后时间线上的一个步骤
+type OptimizedStep struct {
+	Order           int    `json:"order"`
+	DishID          string `json:"dish_id"`
+	Content         string `json:"content"`
+	StartMinute     int    `json:"start_minute"`
+	DurationMinutes int    `json:"duration_minutes"`
+}
+
+// StepOptimizeResponse 烹饪步骤优化结果
+type StepOptimizeResponse struct {
+	Steps        []OptimizedStep `json:"steps"`
+	TotalMinutes int             `json:"total_minutes"`
+	Tips         []string        `json:"tips,omitempty"`
+}
@@ -0,0 +1,118 @@
+package payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	mockSignatureHeader = "X-Mock-Signature"
+	mockTimestampHeader = "X-Mock-Timestamp"
+	// mockNotificationMaxAge 回调时间戳允许的最大偏差，防止重放
+	mockNotificationMaxAge = 5 * time.Minute
+)
+
+// MockGateway 本地模拟支付渠道
+// 下单直接返回订单号，由 BuildNotification 生成与真实渠道同样需要验签的回调
+type MockGateway struct {
+	secret []byte
+}
+
+type mockNotificationBody struct {
+	OrderNo       string `json:"order_no"`
+	TransactionID string `json:"transaction_id"`
+	AmountCents   int64  `json:"amount_cents"`
+	Status        string `json:"status"`
+	PaidAt        int64  `json:"paid_at"`
+}
+
+// NewMockGateway 创建模拟支付渠道
+func NewMockGateway(secret string) *MockGateway {
+	return &MockGateway{secret: []byte(secret)}
+}
+
+// Name 渠道名称
+func (g *MockGateway) Name() string {
+	return ProviderMock
+}
+
+// CreatePayment 模拟下单
+func (g *MockGateway) CreatePayment(ctx context.Context, req *PaymentRequest) (map[string]string, error) {
+	return map[string]string{
+		"provider":     ProviderMock,
+		"order_no":     req.OrderNo,
+		"amount_cents": strconv.FormatInt(req.AmountCents, 10),
+	}, nil
+}
+
+// ParseNotification 校验签名与时间戳并解析回调
+func (g *MockGateway) ParseNotification(ctx context.Context, body []byte, header http.Header) (*Notification, error) {
+	timestamp := header.Get(mockTimestampHeader)
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+	age := time.Since(time.Unix(seconds, 0))
+	if age > mockNotificationMaxAge || age < -mockNotificationMaxAge {
+		return nil, ErrInvalidSignature
+	}
+
+	expected := g.sign(timestamp, body)
+	if !hmac.Equal([]byte(expected), []byte(header.Get(mockSignatureHeader))) {
+		return nil, ErrInvalidSignature
+	}
+
+	var payload mockNotificationBody
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("failed to decode mock notification: %w", err)
+	}
+
+	return &Notification{
+		OrderNo:       payload.OrderNo,
+		TransactionID: payload.TransactionID,
+		AmountCents:   payload.AmountCents,
+		Status:        payload.Status,
+		PaidAt:        time.Unix(payload.PaidAt, 0).UTC(),
+	}, nil
+}
+
+// Refund 模拟退款，总是成功
+func (g *MockGateway) Refund(ctx context.Context, req *RefundRequest) error {
+	return nil
+}
+
+// BuildNotification 生成一条已签名的支付结果回调，模拟渠道异步通知
+func (g *MockGateway) BuildNotification(orderNo string, amountCents int64, status string) ([]byte, http.Header, error) {
+	now := time.Now()
+	body, err := json.Marshal(mockNotificationBody{
+		OrderNo:       orderNo,
+		TransactionID: "MOCK" + strconv.FormatInt(now.UnixNano(), 10),
+		AmountCents:   amountCents,
+		Status:        status,
+		PaidAt:        now.Unix(),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode mock notification: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	header := http.Header{}
+	header.Set(mockTimestampHeader, timestamp)
+	header.Set(mockSignatureHeader, g.sign(timestamp, body))
+	return body, header, nil
+}
+
+func (g *MockGateway) sign(timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, g.secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
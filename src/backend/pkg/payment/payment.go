@@ -0,0 +1,96 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ProviderMock 本地模拟支付渠道，仅用于开发与测试
+const ProviderMock = "mock"
+
+const (
+	// NotificationStatusSuccess 支付成功
+	NotificationStatusSuccess = "success"
+	// NotificationStatusFailed 支付失败
+	NotificationStatusFailed = "failed"
+)
+
+var (
+	// ErrInvalidSignature 回调签名校验失败
+	ErrInvalidSignature = errors.New("invalid payment signature")
+)
+
+// Config 支付渠道配置
+type Config struct {
+	Provider   string
+	MockSecret string // mock 渠道回调签名密钥
+}
+
+// PaymentRequest 向支付渠道下单的参数
+type PaymentRequest struct {
+	OrderNo     string
+	Description string
+	AmountCents int64
+	ExpiresAt   time.Time
+}
+
+// Notification 支付渠道回调的支付结果
+type Notification struct {
+	OrderNo       string
+	TransactionID string
+	AmountCents   int64
+	Status        string
+	PaidAt        time.Time
+}
+
+// RefundRequest 退款参数
+type RefundRequest struct {
+	OrderNo       string
+	TransactionID string
+	AmountCents   int64
+	Reason        string
+}
+
+// PaymentGateway 支付渠道接口，接入微信支付等渠道时实现该接口并在 InitPayment 中注册
+type PaymentGateway interface {
+	// Name 渠道名称，与回调地址中的 provider 对应
+	Name() string
+	// CreatePayment 向渠道下单，返回客户端拉起支付所需的参数
+	CreatePayment(ctx context.Context, req *PaymentRequest) (map[string]string, error)
+	// ParseNotification 校验回调签名并解析支付结果，签名错误返回 ErrInvalidSignature
+	ParseNotification(ctx context.Context, body []byte, header http.Header) (*Notification, error)
+	// Refund 发起全额退款
+	Refund(ctx context.Context, req *RefundRequest) error
+}
+
+var gateway PaymentGateway
+
+// InitPayment 根据配置初始化支付渠道
+func InitPayment(cfg Config) error {
+	switch strings.ToLower(cfg.Provider) {
+	case "":
+		return errors.New("payment provider is required")
+	case ProviderMock:
+		if cfg.MockSecret == "" {
+			return errors.New("mock payment secret is required")
+		}
+		gateway = NewMockGateway(cfg.MockSecret)
+	default:
+		return fmt.Errorf("unsupported payment provider: %s", cfg.Provider)
+	}
+	return nil
+}
+
+// GetGateway 获取当前支付渠道
+func GetGateway() PaymentGateway {
+	return gateway
+}
+
+// SetGateway 替换支付渠道
+func SetGateway(g PaymentGateway) {
+	gateway = g
+}
@@ -0,0 +1,148 @@
+package sms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// ProviderLog 本地日志发送方式，仅用于开发与测试
+	ProviderLog = "log"
+	// ProviderHTTP 通过 HTTP 短信网关发送
+	ProviderHTTP = "http"
+)
+
+// Config 短信发送配置
+type Config struct {
+	Provider string
+	LogFile  string // log 方式下短信写入的文件，为空时输出到标准日志
+	URL      string // http 方式下的短信网关地址
+	APIKey   string // http 方式下调用短信网关的密钥
+}
+
+// SMSSender 短信发送接口，接入短信服务商时实现该接口并在 InitSMS 中注册
+type SMSSender interface {
+	Send(ctx context.Context, phone, message string) error
+}
+
+var sender SMSSender
+
+// InitSMS 根据配置初始化短信发送器
+func InitSMS(cfg Config) error {
+	switch strings.ToLower(cfg.Provider) {
+	case "", ProviderLog:
+		sender = NewLogSender(cfg.LogFile)
+	case ProviderHTTP:
+		if cfg.URL == "" {
+			return fmt.Errorf("sms gateway url is required for http provider")
+		}
+		sender = NewHTTPSender(cfg.URL, cfg.APIKey)
+	default:
+		return fmt.Errorf("unsupported sms provider: %s", cfg.Provider)
+	}
+	return nil
+}
+
+// GetSender 获取短信发送器，未初始化时退回到标准日志输出
+func GetSender() SMSSender {
+	if sender == nil {
+		return NewLogSender("")
+	}
+	return sender
+}
+
+// SetSender 替换短信发送器
+func SetSender(s SMSSender) {
+	sender = s
+}
+
+// LogSender 将短信内容写入日志或文件，不真正发送
+type LogSender struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewLogSender 创建本地日志短信发送器
+func NewLogSender(path string) *LogSender {
+	return &LogSender{path: path}
+}
+
+// Send 记录一条短信
+func (s *LogSender) Send(ctx context.Context, phone, message string) error {
+	if s.path == "" {
+		log.Printf("[sms] to=%s message=%s", phone, message)
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open sms log file: %w", err)
+	}
+	defer file.Close()
+
+	line := fmt.Sprintf("%s\t%s\t%s\n", time.Now().Format(time.RFC3339), phone, message)
+	if _, err := file.WriteString(line); err != nil {
+		return fmt.Errorf("failed to write sms log file: %w", err)
+	}
+
+	return nil
+}
+
+// HTTPSender 通过 HTTP 短信网关发送短信
+// 以 JSON 提交 {"phone": "...", "message": "..."}，网关返回 2xx 视为发送成功
+type HTTPSender struct {
+	url        string
+	apiKey     string
+	httpClient *http.Client
+}
+
+// NewHTTPSender 创建 HTTP 短信网关发送器
+func NewHTTPSender(url, apiKey string) *HTTPSender {
+	return &HTTPSender{
+		url:        url,
+		apiKey:     apiKey,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Send 发送一条短信
+func (s *HTTPSender) Send(ctx context.Context, phone, message string) error {
+	body, err := json.Marshal(map[string]string{"phone": phone, "message": message})
+	if err != nil {
+		return fmt.Errorf("failed to encode sms request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build sms request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.apiKey != "" {
+		req.Header.Set("X-API-Key", s.apiKey)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send sms: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("sms gateway returned %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+
+	return nil
+}
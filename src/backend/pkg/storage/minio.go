@@ -85,6 +85,36 @@ func Upload(ctx context.Context, objectName string, reader io.Reader, objectSize
 	return buildFileURL(objectName), nil
 }
 
+// Remove 删除 MinIO 中的对象
+func Remove(ctx context.Context, objectName string) error {
+	if minioClient == nil {
+		return errors.New("minio client is not initialized")
+	}
+
+	if err := minioClient.RemoveObject(ctx, minioCfg.Bucket, objectName, minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("failed to remove object: %w", err)
+	}
+
+	return nil
+}
+
+// Exists 判断对象是否存在
+func Exists(ctx context.Context, objectName string) (bool, error) {
+	if minioClient == nil {
+		return false, errors.New("minio client is not initialized")
+	}
+
+	_, err := minioClient.StatObject(ctx, minioCfg.Bucket, objectName, minio.StatObjectOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to stat object: %w", err)
+	}
+
+	return true, nil
+}
+
 // BucketName 返回默认桶名称
 func BucketName() string {
 	return minioCfg.Bucket
@@ -95,6 +125,20 @@ func ObjectURL(objectName string) string {
 	return buildFileURL(objectName)
 }
 
+// ObjectKeyFromURL 从 ObjectURL 生成的访问地址中解析出 objectName，非本桶地址返回 false
+func ObjectKeyFromURL(fileURL string) (string, bool) {
+	prefix := buildFileURL("")
+	if !strings.HasPrefix(fileURL, prefix) {
+		return "", false
+	}
+
+	objectName := strings.TrimPrefix(fileURL, prefix)
+	if objectName == "" {
+		return "", false
+	}
+	return objectName, true
+}
+
 func buildFileURL(objectName string) string {
 	baseURL := strings.TrimRight(minioCfg.BaseURL, "/")
 	if baseURL == "" {
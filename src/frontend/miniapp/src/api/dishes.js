@@ -12,10 +12,14 @@ export function updateDish(id, payload) {
   return request.put(`/dishes/${id}`, payload)
 }
 
-export function deleteDish(id) {
-  return request.delete(`/dishes/${id}`)
+export function deleteDish(id, force = false) {
+  return request.delete(`/dishes/${id}`, { params: force ? { force: true } : {} })
 }
 
 export function getDishDetail(id) {
   return request.get(`/dishes/${id}`)
 }
+
+export function fetchDishTags() {
+  return request.get('/dishes/tags')
+}
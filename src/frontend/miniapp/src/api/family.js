@@ -29,10 +29,30 @@ export function getFamilyMembers() {
   return request.get('/family/members')
 }
 
+/**
+ * 创建家庭邀请，返回的 token 用于生成邀请二维码
+ * @param {object} [data]
+ * @param {number} [data.expires_in_hours] 有效时长（小时），默认24
+ * @param {number} [data.max_uses] 最大使用次数，默认1
+ * @param {string} [data.target_phone] 指定被邀请人手机号
+ */
+export function createInvitation(data = {}) {
+  return request.post('/family/invitations', data)
+}
+
+/**
+ * 根据邀请令牌获取落地页展示信息，无需登录
+ * @param {string} token 二维码中的邀请令牌
+ */
+export function previewInvitation(token) {
+  return request.get('/family/invitations/preview', { params: { token } })
+}
+
 /**
  * 接受家庭邀请
  * @param {object} data
- * action 在当前阶段默认 accept
+ * @param {string} data.token 二维码中的邀请令牌
+ * @param {string} data.action 当前仅支持 accept
  */
 export function acceptInvite(data) {
   return request.post('/family/member/invite', data)
@@ -102,10 +102,10 @@ export const useFamilyStore = defineStore('family', {
       }
     },
 
-    async acceptInvite(params) {
+    async acceptInvite(token) {
       this.error = ''
       try {
-        const res = await acceptInvite({ ...params, action: 'accept' })
+        const res = await acceptInvite({ token, action: 'accept' })
         if (res.data) {
           await this.fetchFamilyInfo(true)
           await this.fetchMembers(true)
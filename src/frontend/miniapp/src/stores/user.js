@@ -58,7 +58,7 @@ export const useUserStore = defineStore('user', {
             user_id: res.data.user_id,
             phone: data.phone,
             nickname: data.nickname
-          })
+          }, res.data.refresh_token)
           return res
         }
         throw new Error(res.message || '注册失败')
@@ -81,7 +81,7 @@ export const useUserStore = defineStore('user', {
           this.setAuth(res.data.token, {
             user_id: res.data.user_id,
             phone: data.phone
-          })
+          }, res.data.refresh_token)
           // 获取完整用户信息
           await this.fetchUserInfo()
           return res
@@ -117,12 +117,13 @@ export const useUserStore = defineStore('user', {
      * 设置认证信息
      * @param {string} token Token
      * @param {object} userInfo 用户信息
+     * @param {string} [refreshToken] 刷新令牌
      */
-    setAuth(token, userInfo) {
+    setAuth(token, userInfo, refreshToken) {
       this.token = token
       this.userInfo = userInfo
       this.isLoggedIn = true
-      setAuth(token, userInfo)
+      setAuth(token, userInfo, refreshToken)
     },
 
     /**
@@ -9,19 +9,33 @@ import { tokenStorage, userInfoStorage } from './storage'
  * 设置认证信息
  * @param {string} token Token
  * @param {object} userInfo 用户信息
+ * @param {string} [refreshToken] 刷新令牌
  */
-export function setAuth(token, userInfo) {
-  tokenStorage.setToken(token)
+export function setAuth(token, userInfo, refreshToken) {
+  setTokens(token, refreshToken)
   if (userInfo) {
     userInfoStorage.setUserInfo(userInfo)
   }
 }
 
+/**
+ * 更新访问令牌与刷新令牌，刷新令牌每次刷新后轮换
+ * @param {string} token Token
+ * @param {string} [refreshToken] 刷新令牌
+ */
+export function setTokens(token, refreshToken) {
+  tokenStorage.setToken(token)
+  if (refreshToken) {
+    tokenStorage.setRefreshToken(refreshToken)
+  }
+}
+
 /**
  * 清除认证信息
  */
 export function clearAuth() {
   tokenStorage.removeToken()
+  tokenStorage.removeRefreshToken()
   userInfoStorage.removeUserInfo()
 }
 
@@ -33,6 +47,14 @@ export function getToken() {
   return tokenStorage.getToken()
 }
 
+/**
+ * 获取刷新令牌
+ * @returns {string|null}
+ */
+export function getRefreshToken() {
+  return tokenStorage.getRefreshToken()
+}
+
 /**
  * 获取用户信息
  * @returns {object|null}
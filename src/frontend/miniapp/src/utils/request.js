@@ -4,7 +4,12 @@
  */
 
 import axios from 'axios'
-import { getToken, clearAuth } from './auth'
+import { getToken, getRefreshToken, setTokens, clearAuth } from './auth'
+
+const baseURL = import.meta.env.VITE_API_BASE_URL || 'http://localhost:8080/api/v1'
+
+// 不触发刷新重试的接口：刷新接口本身以及登录注册
+const NO_REFRESH_URLS = ['/auth/refresh', '/auth/login', '/auth/register']
 
 function createRequestError(message, code, data) {
   const error = new Error(message || '请求失败')
@@ -15,13 +20,97 @@ function createRequestError(message, code, data) {
 
 // 创建 axios 实例
 const request = axios.create({
-  baseURL: import.meta.env.VITE_API_BASE_URL || 'http://localhost:8080/api/v1',
+  baseURL,
   timeout: 10000,
   headers: {
     'Content-Type': 'application/json'
   }
 })
 
+// 清除登录状态并跳转登录页
+function redirectToLogin() {
+  clearAuth()
+  if (typeof window !== 'undefined') {
+    window.location.href = '/login'
+  }
+}
+
+// 进行中的刷新请求，多个请求同时遇到 401 时共用同一次刷新
+let refreshPromise = null
+
+/**
+ * 使用刷新令牌换取新的访问令牌
+ * 刷新令牌每次使用后轮换，因此同一时间只允许发起一次刷新
+ * @returns {Promise<string>} 新的访问令牌
+ */
+function refreshAccessToken() {
+  if (!refreshPromise) {
+    const refreshToken = getRefreshToken()
+    if (!refreshToken) {
+      return Promise.reject(createRequestError('登录已过期', 401))
+    }
+
+    // 直接使用 axios，避免刷新请求再次进入拦截器
+    refreshPromise = axios
+      .post(`${baseURL}/auth/refresh`, { refresh_token: refreshToken })
+      .then((response) => {
+        const data = response.data?.data
+        if (response.data?.code !== 200 || !data?.token) {
+          throw createRequestError(response.data?.message || '登录已过期', 401)
+        }
+        setTokens(data.token, data.refresh_token)
+        return data.token
+      }, (error) => {
+        // 刷新令牌无效或已过期时需要重新登录，网络错误原样返回
+        const status = error?.response?.status
+        if (status === 400 || status === 401) {
+          throw createRequestError(error.response.data?.message || '登录已过期', 401)
+        }
+        throw createRequestError('网络错误，请稍后重试', status)
+      })
+      .finally(() => {
+        refreshPromise = null
+      })
+  }
+  return refreshPromise
+}
+
+/**
+ * 访问令牌过期时刷新后重试原请求，每个请求只重试一次
+ * @param {object} config 原请求配置
+ * @param {Error} error 刷新失败时返回的错误
+ */
+function retryWithRefresh(config, error) {
+  const url = config?.url || ''
+  if (!config || config._retried || NO_REFRESH_URLS.some(path => url.includes(path))) {
+    redirectToLogin()
+    return Promise.reject(error)
+  }
+
+  config._retried = true
+
+  // 请求发出后令牌已被其他请求刷新，直接使用新令牌重试
+  const currentToken = getToken()
+  if (currentToken && config.headers?.Authorization !== `Bearer ${currentToken}`) {
+    return request(config)
+  }
+
+  return refreshAccessToken().then(
+    (token) => {
+      config.headers = config.headers || {}
+      config.headers.Authorization = `Bearer ${token}`
+      return request(config)
+    },
+    (refreshError) => {
+      if (refreshError.code === 401) {
+        redirectToLogin()
+        return Promise.reject(error)
+      }
+      return Promise.reject(refreshError)
+    }
+  )
+}
+
 // 请求拦截器
 request.interceptors.request.use(
   (config) => {
@@ -45,14 +134,12 @@ request.interceptors.response.use(
       return res
     }
 
+    const error = createRequestError(res?.message, res?.code, res)
     if (res?.code === 401) {
-      clearAuth()
-      if (typeof window !== 'undefined') {
-        window.location.href = '/login'
-      }
+      return retryWithRefresh(response.config, error)
     }
 
-    return Promise.reject(createRequestError(res?.message, res?.code, res))
+    return Promise.reject(error)
   },
   (error) => {
     let message = '网络错误，请稍后重试'
@@ -62,14 +149,12 @@ request.interceptors.response.use(
       const data = error.response.data || {}
       message = data.message || message
 
+      const requestError = createRequestError(message, data.code || code, data)
       if (code === 401) {
-        clearAuth()
-        if (typeof window !== 'undefined') {
-          window.location.href = '/login'
-        }
+        return retryWithRefresh(error.config, requestError)
       }
 
-      return Promise.reject(createRequestError(message, data.code || code, data))
+      return Promise.reject(requestError)
     }
 
     return Promise.reject(createRequestError(message, code))
@@ -4,6 +4,7 @@
  */
 
 const TOKEN_KEY = 'token'
+const REFRESH_TOKEN_KEY = 'refresh_token'
 const USER_INFO_KEY = 'user_info'
 
 /**
@@ -89,6 +90,29 @@ export const tokenStorage = {
    */
   removeToken() {
     storage.remove(TOKEN_KEY)
+  },
+
+  /**
+   * 设置刷新令牌
+   * @param {string} refreshToken
+   */
+  setRefreshToken(refreshToken) {
+    storage.set(REFRESH_TOKEN_KEY, refreshToken)
+  },
+
+  /**
+   * 获取刷新令牌
+   * @returns {string|null}
+   */
+  getRefreshToken() {
+    return storage.get(REFRESH_TOKEN_KEY)
+  },
+
+  /**
+   * 删除刷新令牌
+   */
+  removeRefreshToken() {
+    storage.remove(REFRESH_TOKEN_KEY)
   }
 }
 